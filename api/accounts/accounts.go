@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"math/big"
 	"net/http"
+	"strconv"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
@@ -18,17 +19,23 @@ import (
 	"github.com/vechain/thor/v2/api/utils"
 	"github.com/vechain/thor/v2/bft"
 	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/builtin"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/runtime"
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/txpool"
 	"github.com/vechain/thor/v2/xenv"
 )
 
+// maxStorageBatchKeys is the max count of keys read in a storage batch.
+const maxStorageBatchKeys = 200
+
 type Accounts struct {
 	repo              *chain.Repository
 	stater            *state.Stater
+	txPool            *txpool.TxPool
 	callGasLimit      uint64
 	forkConfig        thor.ForkConfig
 	bft               bft.Committer
@@ -38,6 +45,7 @@ type Accounts struct {
 func New(
 	repo *chain.Repository,
 	stater *state.Stater,
+	txPool *txpool.TxPool,
 	callGasLimit uint64,
 	forkConfig thor.ForkConfig,
 	bft bft.Committer,
@@ -46,6 +54,7 @@ func New(
 	return &Accounts{
 		repo,
 		stater,
+		txPool,
 		callGasLimit,
 		forkConfig,
 		bft,
@@ -67,14 +76,14 @@ func (a *Accounts) handleGetCode(w http.ResponseWriter, req *http.Request) error
 	if err != nil {
 		return utils.BadRequest(errors.WithMessage(err, "address"))
 	}
-	revision, err := utils.ParseRevision(req.URL.Query().Get("revision"), false)
+	revision, err := utils.ParseRevision(req.Context(), req.URL.Query().Get("revision"), false)
 	if err != nil {
 		return utils.BadRequest(errors.WithMessage(err, "revision"))
 	}
 
 	_, st, err := utils.GetSummaryAndState(revision, a.repo, a.bft, a.stater)
 	if err != nil {
-		if a.repo.IsNotFound(err) {
+		if utils.IsRevisionError(err) {
 			return utils.BadRequest(errors.WithMessage(err, "revision"))
 		}
 		return err
@@ -108,6 +117,33 @@ func (a *Accounts) getAccount(addr thor.Address, header *block.Header, state *st
 	}, nil
 }
 
+// getEnergyProjection projects the energy of the account to the given timestamp, using the same
+// calculation as the energy builtin.
+func (a *Accounts) getEnergyProjection(addr thor.Address, header *block.Header, state *state.State, timestamp uint64) (*EnergyProjection, error) {
+	balance, err := state.GetBalance(addr)
+	if err != nil {
+		return nil, err
+	}
+	energy, err := state.GetEnergy(addr, header.Timestamp())
+	if err != nil {
+		return nil, err
+	}
+	projected, err := state.GetEnergy(addr, timestamp)
+	if err != nil {
+		return nil, err
+	}
+	rate := new(big.Int).Mul(balance, thor.EnergyGrowthRate)
+	rate.Div(rate, big.NewInt(1e18))
+
+	return &EnergyProjection{
+		Energy:          math.HexOrDecimal256(*energy),
+		GenerationRate:  math.HexOrDecimal256(*rate),
+		RuleSet:         EnergyRuleSetGrowth,
+		Timestamp:       timestamp,
+		ProjectedEnergy: math.HexOrDecimal256(*projected),
+	}, nil
+}
+
 func (a *Accounts) getStorage(addr thor.Address, key thor.Bytes32, state *state.State) (thor.Bytes32, error) {
 	storage, err := state.GetStorage(addr, key)
 	if err != nil {
@@ -116,19 +152,29 @@ func (a *Accounts) getStorage(addr thor.Address, key thor.Bytes32, state *state.
 	return storage, nil
 }
 
+// getMultipleStorage reads the storage values of the keys from the same state.
+// Values are loaded from the storage trie in parallel.
+func (a *Accounts) getMultipleStorage(addr thor.Address, keys []thor.Bytes32, state *state.State) (map[thor.Bytes32]thor.Bytes32, error) {
+	acc, err := state.GetAccountWithStorage(addr, keys)
+	if err != nil {
+		return nil, err
+	}
+	return acc.Storage, nil
+}
+
 func (a *Accounts) handleGetAccount(w http.ResponseWriter, req *http.Request) error {
 	addr, err := thor.ParseAddress(mux.Vars(req)["address"])
 	if err != nil {
 		return utils.BadRequest(errors.WithMessage(err, "address"))
 	}
-	revision, err := utils.ParseRevision(req.URL.Query().Get("revision"), false)
+	revision, err := utils.ParseRevision(req.Context(), req.URL.Query().Get("revision"), false)
 	if err != nil {
 		return utils.BadRequest(errors.WithMessage(err, "revision"))
 	}
 
 	summary, st, err := utils.GetSummaryAndState(revision, a.repo, a.bft, a.stater)
 	if err != nil {
-		if a.repo.IsNotFound(err) {
+		if utils.IsRevisionError(err) {
 			return utils.BadRequest(errors.WithMessage(err, "revision"))
 		}
 		return err
@@ -141,6 +187,142 @@ func (a *Accounts) handleGetAccount(w http.ResponseWriter, req *http.Request) er
 	return utils.WriteJSON(w, acc)
 }
 
+func (a *Accounts) handleGetTxPoolSummary(w http.ResponseWriter, req *http.Request) error {
+	addr, err := thor.ParseAddress(mux.Vars(req)["address"])
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "address"))
+	}
+
+	summary, err := a.txPool.AccountSummary(addr)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, &TxPoolSummary{
+		PendingCount:      summary.PendingCount,
+		ExecutableCount:   summary.ExecutableCount,
+		PendingEnergyCost: math.HexOrDecimal256(*summary.PendingEnergyCost),
+		OldestTxAge:       uint64(summary.OldestTxAge.Seconds()),
+	})
+}
+
+// getProposerStatus picks the proposers the same way as the scheduler, i.e. the endorsed candidates of
+// the authority up to the max block proposers, and locates the address in them.
+func (a *Accounts) getProposerStatus(addr thor.Address, state *state.State) (*ProposerStatus, error) {
+	params := builtin.Params.Native(state)
+	endorsement, err := params.Get(thor.KeyProposerEndorsement)
+	if err != nil {
+		return nil, err
+	}
+	mbp, err := params.Get(thor.KeyMaxBlockProposers)
+	if err != nil {
+		return nil, err
+	}
+	maxBlockProposers := mbp.Uint64()
+	if maxBlockProposers == 0 || maxBlockProposers > thor.InitialMaxBlockProposers {
+		maxBlockProposers = thor.InitialMaxBlockProposers
+	}
+
+	candidates, err := builtin.Authority.Native(state).Candidates(endorsement, maxBlockProposers)
+	if err != nil {
+		return nil, err
+	}
+	status := &ProposerStatus{Proposers: uint64(len(candidates))}
+	for i, c := range candidates {
+		if c.NodeMaster == addr {
+			pos := uint64(i)
+			status.Proposer = true
+			status.Active = c.Active
+			status.Position = &pos
+			break
+		}
+	}
+	return status, nil
+}
+
+func (a *Accounts) handleGetProposerStatus(w http.ResponseWriter, req *http.Request) error {
+	addr, err := thor.ParseAddress(mux.Vars(req)["address"])
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "address"))
+	}
+	revision, err := utils.ParseRevision(req.Context(), req.URL.Query().Get("revision"), false)
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "revision"))
+	}
+
+	_, st, err := utils.GetSummaryAndState(revision, a.repo, a.bft, a.stater)
+	if err != nil {
+		if utils.IsRevisionError(err) {
+			return utils.BadRequest(errors.WithMessage(err, "revision"))
+		}
+		return err
+	}
+
+	status, err := a.getProposerStatus(addr, st)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, status)
+}
+
+func (a *Accounts) handleGetEnergyProjection(w http.ResponseWriter, req *http.Request) error {
+	addr, err := thor.ParseAddress(mux.Vars(req)["address"])
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "address"))
+	}
+	query := req.URL.Query()
+	revision, err := utils.ParseRevision(req.Context(), query.Get("revision"), false)
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "revision"))
+	}
+	var timestamp, blockNumber *uint64
+	if s := query.Get("timestamp"); s != "" {
+		ts, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return utils.BadRequest(errors.WithMessage(err, "timestamp"))
+		}
+		timestamp = &ts
+	}
+	if s := query.Get("blockNumber"); s != "" {
+		num, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return utils.BadRequest(errors.WithMessage(err, "blockNumber"))
+		}
+		blockNumber = &num
+	}
+	if timestamp != nil && blockNumber != nil {
+		return utils.BadRequest(errors.New("timestamp and blockNumber: only one can be specified"))
+	}
+
+	summary, st, err := utils.GetSummaryAndState(revision, a.repo, a.bft, a.stater)
+	if err != nil {
+		if utils.IsRevisionError(err) {
+			return utils.BadRequest(errors.WithMessage(err, "revision"))
+		}
+		return err
+	}
+
+	header := summary.Header
+	target := header.Timestamp()
+	if timestamp != nil {
+		if *timestamp < header.Timestamp() {
+			return utils.BadRequest(errors.New("timestamp: earlier than the block of revision"))
+		}
+		target = *timestamp
+	}
+	if blockNumber != nil {
+		if *blockNumber < uint64(header.Number()) {
+			return utils.BadRequest(errors.New("blockNumber: earlier than the block of revision"))
+		}
+		target += (*blockNumber - uint64(header.Number())) * thor.BlockInterval
+	}
+
+	projection, err := a.getEnergyProjection(addr, header, st, target)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, projection)
+}
+
 func (a *Accounts) handleGetStorage(w http.ResponseWriter, req *http.Request) error {
 	addr, err := thor.ParseAddress(mux.Vars(req)["address"])
 	if err != nil {
@@ -150,14 +332,14 @@ func (a *Accounts) handleGetStorage(w http.ResponseWriter, req *http.Request) er
 	if err != nil {
 		return utils.BadRequest(errors.WithMessage(err, "key"))
 	}
-	revision, err := utils.ParseRevision(req.URL.Query().Get("revision"), false)
+	revision, err := utils.ParseRevision(req.Context(), req.URL.Query().Get("revision"), false)
 	if err != nil {
 		return utils.BadRequest(errors.WithMessage(err, "revision"))
 	}
 
 	_, st, err := utils.GetSummaryAndState(revision, a.repo, a.bft, a.stater)
 	if err != nil {
-		if a.repo.IsNotFound(err) {
+		if utils.IsRevisionError(err) {
 			return utils.BadRequest(errors.WithMessage(err, "revision"))
 		}
 		return err
@@ -170,18 +352,57 @@ func (a *Accounts) handleGetStorage(w http.ResponseWriter, req *http.Request) er
 	return utils.WriteJSON(w, &GetStorageResult{Value: storage.String()})
 }
 
+func (a *Accounts) handleGetStorageBatch(w http.ResponseWriter, req *http.Request) error {
+	addr, err := thor.ParseAddress(mux.Vars(req)["address"])
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "address"))
+	}
+	var batch *StorageBatch
+	if err := utils.ParseJSON(req.Body, &batch); err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "body"))
+	}
+	if batch == nil || len(batch.Keys) == 0 {
+		return utils.BadRequest(errors.New("keys: empty keys"))
+	}
+	if len(batch.Keys) > maxStorageBatchKeys {
+		return utils.BadRequest(fmt.Errorf("keys: exceeds the limit of %d", maxStorageBatchKeys))
+	}
+	revision, err := utils.ParseRevision(req.Context(), req.URL.Query().Get("revision"), false)
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "revision"))
+	}
+
+	_, st, err := utils.GetSummaryAndState(revision, a.repo, a.bft, a.stater)
+	if err != nil {
+		if utils.IsRevisionError(err) {
+			return utils.BadRequest(errors.WithMessage(err, "revision"))
+		}
+		return err
+	}
+
+	storage, err := a.getMultipleStorage(addr, batch.Keys, st)
+	if err != nil {
+		return err
+	}
+	result := &StorageBatchResult{Storage: make(map[string]string, len(storage))}
+	for key, value := range storage {
+		result.Storage[key.String()] = value.String()
+	}
+	return utils.WriteJSON(w, result)
+}
+
 func (a *Accounts) handleCallContract(w http.ResponseWriter, req *http.Request) error {
 	callData := &CallData{}
 	if err := utils.ParseJSON(req.Body, &callData); err != nil {
 		return utils.BadRequest(errors.WithMessage(err, "body"))
 	}
-	revision, err := utils.ParseRevision(req.URL.Query().Get("revision"), true)
+	revision, err := utils.ParseRevision(req.Context(), req.URL.Query().Get("revision"), true)
 	if err != nil {
 		return utils.BadRequest(errors.WithMessage(err, "revision"))
 	}
 	summary, st, err := utils.GetSummaryAndState(revision, a.repo, a.bft, a.stater)
 	if err != nil {
-		if a.repo.IsNotFound(err) {
+		if utils.IsRevisionError(err) {
 			return utils.BadRequest(errors.WithMessage(err, "revision"))
 		}
 		return err
@@ -218,17 +439,35 @@ func (a *Accounts) handleCallBatchCode(w http.ResponseWriter, req *http.Request)
 	if err := utils.ParseJSON(req.Body, &batchCallData); err != nil {
 		return utils.BadRequest(errors.WithMessage(err, "body"))
 	}
-	revision, err := utils.ParseRevision(req.URL.Query().Get("revision"), true)
+	query := req.URL.Query()
+	pending, err := utils.StringToBoolean(query.Get("pending"), false)
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "pending"))
+	}
+	revisionStr := query.Get("revision")
+	if pending {
+		// pending txs are applied as if they are packed into the next block
+		if revisionStr != "" && revisionStr != "next" {
+			return utils.BadRequest(errors.New("pending: only available on revision next"))
+		}
+		revisionStr = "next"
+	}
+	revision, err := utils.ParseRevision(req.Context(), revisionStr, true)
 	if err != nil {
 		return utils.BadRequest(errors.WithMessage(err, "revision"))
 	}
 	summary, st, err := utils.GetSummaryAndState(revision, a.repo, a.bft, a.stater)
 	if err != nil {
-		if a.repo.IsNotFound(err) {
+		if utils.IsRevisionError(err) {
 			return utils.BadRequest(errors.WithMessage(err, "revision"))
 		}
 		return err
 	}
+	if pending {
+		if err := a.applyPendingTxs(req.Context(), summary.Header, st); err != nil {
+			return err
+		}
+	}
 	results, err := a.batchCall(req.Context(), batchCallData, summary.Header, st)
 	if err != nil {
 		return err
@@ -236,6 +475,20 @@ func (a *Accounts) handleCallBatchCode(w http.ResponseWriter, req *http.Request)
 	return utils.WriteJSON(w, results)
 }
 
+func (a *Accounts) newRuntime(header *block.Header, st *state.State) *runtime.Runtime {
+	signer, _ := header.Signer()
+	return runtime.New(a.repo.NewChain(header.ParentID()), st,
+		&xenv.BlockContext{
+			Beneficiary: header.Beneficiary(),
+			Signer:      signer,
+			Number:      header.Number(),
+			Time:        header.Timestamp(),
+			GasLimit:    header.GasLimit(),
+			TotalScore:  header.TotalScore(),
+		},
+		a.forkConfig)
+}
+
 func (a *Accounts) batchCall(
 	ctx context.Context,
 	batchCallData *BatchCallData,
@@ -247,17 +500,7 @@ func (a *Accounts) batchCall(
 		return nil, err
 	}
 
-	signer, _ := header.Signer()
-	rt := runtime.New(a.repo.NewChain(header.ParentID()), st,
-		&xenv.BlockContext{
-			Beneficiary: header.Beneficiary(),
-			Signer:      signer,
-			Number:      header.Number(),
-			Time:        header.Timestamp(),
-			GasLimit:    header.GasLimit(),
-			TotalScore:  header.TotalScore(),
-		},
-		a.forkConfig)
+	rt := a.newRuntime(header, st)
 	results = make(BatchCallResults, 0)
 	resultCh := make(chan interface{}, 1)
 	for i, clause := range clauses {
@@ -371,6 +614,22 @@ func (a *Accounts) Mount(root *mux.Router, pathPrefix string) {
 		Methods(http.MethodGet).
 		Name("GET /accounts/{address}/code").
 		HandlerFunc(utils.WrapHandlerFunc(a.handleGetCode))
+	sub.Path("/{address}/energy-projection").
+		Methods(http.MethodGet).
+		Name("GET /accounts/{address}/energy-projection").
+		HandlerFunc(utils.WrapHandlerFunc(a.handleGetEnergyProjection))
+	sub.Path("/{address}/txpool-summary").
+		Methods(http.MethodGet).
+		Name("GET /accounts/{address}/txpool-summary").
+		HandlerFunc(utils.WrapHandlerFunc(a.handleGetTxPoolSummary))
+	sub.Path("/{address}/proposer").
+		Methods(http.MethodGet).
+		Name("GET /accounts/{address}/proposer").
+		HandlerFunc(utils.WrapHandlerFunc(a.handleGetProposerStatus))
+	sub.Path("/{address}/storage/batch").
+		Methods(http.MethodPost).
+		Name("POST /accounts/{address}/storage/batch").
+		HandlerFunc(utils.WrapHandlerFunc(a.handleGetStorageBatch))
 	sub.Path("/{address}/storage/{key}").
 		Methods("GET").
 		Name("GET /accounts/{address}/storage").
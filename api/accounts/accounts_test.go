@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -21,11 +22,17 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/vechain/thor/v2/api/accounts"
 	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/cmd/thor/solo"
 	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/test/testchain"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/thorclient"
 	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/txpool"
 
 	ABI "github.com/vechain/thor/v2/abi"
 	tccommon "github.com/vechain/thor/v2/thorclient/common"
@@ -95,11 +102,14 @@ var (
 	value           = big.NewInt(10000)
 	storageKey      = thor.Bytes32{}
 	genesisBlock    *block.Block
+	bestHeader      *block.Header
 	contractAddr    thor.Address
 	bytecode        = common.Hex2Bytes("608060405234801561001057600080fd5b50610125806100206000396000f3006080604052600436106049576000357c0100000000000000000000000000000000000000000000000000000000900463ffffffff16806324b8ba5f14604e578063bb4e3f4d14607b575b600080fd5b348015605957600080fd5b506079600480360381019080803560ff16906020019092919050505060cf565b005b348015608657600080fd5b5060b3600480360381019080803560ff169060200190929190803560ff16906020019092919050505060ec565b604051808260ff1660ff16815260200191505060405180910390f35b806000806101000a81548160ff021916908360ff16021790555050565b60008183019050929150505600a165627a7a723058201584add23e31d36c569b468097fe01033525686b59bbb263fb3ab82e9553dae50029")
 	runtimeBytecode = common.Hex2Bytes("6080604052600436106049576000357c0100000000000000000000000000000000000000000000000000000000900463ffffffff16806324b8ba5f14604e578063bb4e3f4d14607b575b600080fd5b348015605957600080fd5b506079600480360381019080803560ff16906020019092919050505060cf565b005b348015608657600080fd5b5060b3600480360381019080803560ff169060200190929190803560ff16906020019092919050505060ec565b604051808260ff1660ff16815260200191505060405180910390f35b806000806101000a81548160ff021916908360ff16021790555050565b60008183019050929150505600a165627a7a723058201584add23e31d36c569b468097fe01033525686b59bbb263fb3ab82e9553dae50029")
 	ts              *httptest.Server
 	tclient         *thorclient.Client
+	txPool          *txpool.TxPool
+	chainTag        byte
 )
 
 func TestAccount(t *testing.T) {
@@ -116,6 +126,13 @@ func TestAccount(t *testing.T) {
 		"getCodeWithNonExistingRevision":      getCodeWithNonExistingRevision,
 		"getStorage":                          getStorage,
 		"getStorageWithNonExistingRevision":   getStorageWithNonExistingRevision,
+		"getStorageBatch":                     getStorageBatch,
+		"getEnergyProjection":                 getEnergyProjection,
+		"getEnergyProjectionOfZeroVETAccount": getEnergyProjectionOfZeroVETAccount,
+		"getEnergyProjectionWithBadParams":    getEnergyProjectionWithBadParams,
+		"getEnergyMatchesContract":            getEnergyMatchesContract,
+		"getTxPoolSummary":                    getTxPoolSummary,
+		"getProposerStatus":                   getProposerStatus,
 		"deployContractWithCall":              deployContractWithCall,
 		"callContract":                        callContract,
 		"callContractWithNonExistingRevision": callContractWithNonExistingRevision,
@@ -141,6 +158,104 @@ func TestDeprecated(t *testing.T) {
 	assert.Equal(t, http.StatusGone, statusCode, "invalid address")
 }
 
+func TestStoragePruned(t *testing.T) {
+	thorChain, err := testchain.NewIntegrationTestChain()
+	require.NoError(t, err)
+
+	// a block whose state trie nodes are missing, as if they were pruned
+	best := thorChain.Repo().BestBlockSummary().Header
+	blk := new(block.Builder).
+		ParentID(best.ID()).
+		Timestamp(best.Timestamp() + thor.BlockInterval).
+		TotalScore(best.TotalScore() + 1).
+		GasLimit(best.GasLimit()).
+		StateRoot(thor.BytesToBytes32([]byte("pruned"))).
+		Build()
+	require.NoError(t, thorChain.Repo().AddBlock(blk, nil, 0))
+
+	router := mux.NewRouter()
+	accounts.New(thorChain.Repo(), thorChain.Stater(), nil, uint64(gasLimit), thor.NoFork, thorChain.Engine(), false).
+		Mount(router, "/accounts")
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	res, statusCode, err := thorclient.New(server.URL).RawHTTPClient().RawHTTPGet(
+		"/accounts/" + addr.String() + "/storage/" + storageKey.String() + "?revision=" + blk.Header().ID().String())
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusGone, statusCode)
+	assert.Contains(t, string(res), "is pruned")
+}
+
+func TestInspectClausesPending(t *testing.T) {
+	// the pool only promotes executable txs while the chain is synced, so launch a fresh chain
+	db := muxdb.NewMem()
+	stater := state.NewStater(db)
+	now := uint64(time.Now().Unix())
+	gene, _, _, err := new(genesis.Builder).
+		GasLimit(thor.InitialGasLimit).
+		Timestamp(now).
+		State(func(st *state.State) error {
+			if err := st.SetCode(builtin.Energy.Address, builtin.Energy.RuntimeBytecodes()); err != nil {
+				return err
+			}
+			bal, _ := new(big.Int).SetString("1000000000000000000000000000", 10)
+			for _, acc := range genesis.DevAccounts() {
+				st.SetBalance(acc.Address, bal)
+				st.SetEnergy(acc.Address, bal, now)
+			}
+			return nil
+		}).
+		Build(stater)
+	require.NoError(t, err)
+	repo, err := chain.NewRepository(db, gene)
+	require.NoError(t, err)
+
+	pool := txpool.New(repo, stater, txpool.Options{
+		Limit:           100,
+		LimitPerAccount: 16,
+		MaxLifetime:     time.Hour,
+	})
+	defer pool.Close()
+
+	router := mux.NewRouter()
+	accounts.New(repo, stater, pool, uint64(gasLimit), thor.NoFork, solo.NewBFTEngine(repo), true).
+		Mount(router, "/accounts")
+	server := httptest.NewServer(router)
+	defer server.Close()
+	client := thorclient.New(server.URL)
+
+	recipient := thor.BytesToAddress([]byte("recipient"))
+	transfer, ok := builtin.Energy.ABI.MethodByName("transfer")
+	require.True(t, ok)
+	input, err := transfer.EncodeInput(recipient, big.NewInt(1000))
+	require.NoError(t, err)
+	require.NoError(t, pool.AddLocal(buildTxWithClauses(repo.ChainTag(), tx.NewClause(&builtin.Energy.Address).WithData(input))))
+	assert.Eventually(t, func() bool { return len(pool.Executables()) == 1 }, 5*time.Second, 100*time.Millisecond)
+
+	balanceOf, ok := builtin.Energy.ABI.MethodByName("balanceOf")
+	require.True(t, ok)
+	input, err = balanceOf.EncodeInput(recipient)
+	require.NoError(t, err)
+	calldata := &accounts.BatchCallData{Clauses: accounts.Clauses{{To: &builtin.Energy.Address, Data: hexutil.Encode(input)}}}
+
+	balance := func(opts ...thorclient.Option) *big.Int {
+		results, err := client.InspectClauses(calldata, opts...)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.False(t, results[0].Reverted, results[0].VMError)
+		return new(big.Int).SetBytes(hexutil.MustDecode(results[0].Data))
+	}
+	assert.Equal(t, big.NewInt(1000), balance(thorclient.Pending()))
+	assert.Equal(t, big.NewInt(1000), balance(thorclient.Revision("next"), thorclient.Pending()))
+	assert.Zero(t, balance().Sign())
+
+	for _, query := range []string{"?pending=true&revision=best", "?pending=abc"} {
+		_, statusCode, err := client.RawHTTPClient().RawHTTPPost("/accounts/*"+query, calldata)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, statusCode, query)
+	}
+}
+
 func getAccount(t *testing.T) {
 	_, statusCode, err := tclient.RawHTTPClient().RawHTTPGet("/accounts/" + invalidAddr)
 	require.NoError(t, err)
@@ -168,7 +283,7 @@ func getAccountWithNonExistingRevision(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Equal(t, http.StatusBadRequest, statusCode, "bad revision")
-	assert.Equal(t, "revision: leveldb: not found\n", string(res), "revision not found")
+	assert.Equal(t, "revision: not found\n", string(res), "revision not found")
 }
 
 func getAccountWithGenesisRevision(t *testing.T) {
@@ -238,7 +353,7 @@ func getCodeWithNonExistingRevision(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Equal(t, http.StatusBadRequest, statusCode, "bad revision")
-	assert.Equal(t, "revision: leveldb: not found\n", string(res), "revision not found")
+	assert.Equal(t, "revision: not found\n", string(res), "revision not found")
 }
 
 func getStorage(t *testing.T) {
@@ -276,7 +391,215 @@ func getStorageWithNonExistingRevision(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Equal(t, http.StatusBadRequest, statusCode, "bad revision")
-	assert.Equal(t, "revision: leveldb: not found\n", string(res), "revision not found")
+	assert.Equal(t, "revision: not found\n", string(res), "revision not found")
+}
+
+func getStorageBatch(t *testing.T) {
+	keys := make([]*thor.Bytes32, 0, 10)
+	for i := range 10 {
+		key := thor.BytesToBytes32([]byte{byte(i)})
+		keys = append(keys, &key)
+	}
+
+	storage, err := tclient.AccountStorageBatch(&contractAddr, keys)
+	require.NoError(t, err)
+	require.Len(t, storage, len(keys))
+	for _, key := range keys {
+		single, err := tclient.AccountStorage(&contractAddr, key)
+		require.NoError(t, err)
+		assert.Equal(t, single.Value, storage[*key].String(), "should match the single read")
+	}
+	assert.Equal(t, thor.BytesToBytes32([]byte{storageValue}), storage[storageKey])
+
+	// the value before the contract is deployed
+	storage, err = tclient.AccountStorageBatch(&contractAddr, keys, thorclient.Revision("0"))
+	require.NoError(t, err)
+	assert.Equal(t, thor.Bytes32{}, storage[storageKey])
+
+	tooManyKeys := make([]thor.Bytes32, 201)
+	for i := range tooManyKeys {
+		tooManyKeys[i] = thor.BytesToBytes32([]byte{byte(i)})
+	}
+	for _, tc := range []struct {
+		name string
+		path string
+		body interface{}
+	}{
+		{"bad address", "/accounts/" + invalidAddr + "/storage/batch", &accounts.StorageBatch{Keys: []thor.Bytes32{storageKey}}},
+		{"bad key", "/accounts/" + contractAddr.String() + "/storage/batch", map[string][]string{"keys": {invalidBytes32}}},
+		{"empty keys", "/accounts/" + contractAddr.String() + "/storage/batch", &accounts.StorageBatch{}},
+		{"too many keys", "/accounts/" + contractAddr.String() + "/storage/batch", &accounts.StorageBatch{Keys: tooManyKeys}},
+		{"bad revision", "/accounts/" + contractAddr.String() + "/storage/batch?revision=" + invalidNumberRevision, &accounts.StorageBatch{Keys: []thor.Bytes32{storageKey}}},
+	} {
+		_, statusCode, err := tclient.RawHTTPClient().RawHTTPPost(tc.path, tc.body)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, statusCode, tc.name)
+	}
+}
+
+func getEnergyMatchesContract(t *testing.T) {
+	balanceOf, _ := builtin.Energy.ABI.MethodByName("balanceOf")
+	for _, revision := range []string{"0", tccommon.BestRevision} {
+		// the account changed in the best block, and the one untouched since genesis
+		for _, acc := range []thor.Address{genesis.DevAccounts()[0].Address, genesis.DevAccounts()[1].Address} {
+			account, err := tclient.Account(&acc, thorclient.Revision(revision))
+			require.NoError(t, err)
+
+			input, err := balanceOf.EncodeInput(acc)
+			require.NoError(t, err)
+			results, err := tclient.InspectClauses(&accounts.BatchCallData{
+				Clauses: accounts.Clauses{{To: &builtin.Energy.Address, Data: hexutil.Encode(input)}},
+			}, thorclient.Revision(revision))
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+			require.False(t, results[0].Reverted)
+
+			var energy *big.Int
+			require.NoError(t, balanceOf.DecodeOutput(hexutil.MustDecode(results[0].Data), &energy))
+			assert.Equal(t, energy, (*big.Int)(&account.Energy), "revision %v, account %v", revision, acc)
+		}
+	}
+}
+
+func getEnergyProjection(t *testing.T) {
+	dev := genesis.DevAccounts()[0].Address
+	acc, err := tclient.Account(&dev)
+	require.NoError(t, err)
+	best := bestHeader
+
+	projection, err := tclient.EnergyProjection(&dev, best.Timestamp()+1000)
+	require.NoError(t, err)
+	assert.Equal(t, accounts.EnergyRuleSetGrowth, projection.RuleSet)
+	assert.Equal(t, best.Timestamp()+1000, projection.Timestamp)
+	assert.Equal(t, acc.Energy, projection.Energy)
+
+	rate := new(big.Int).Mul((*big.Int)(&acc.Balance), thor.EnergyGrowthRate)
+	rate.Div(rate, big.NewInt(1e18))
+	assert.Equal(t, rate, (*big.Int)(&projection.GenerationRate))
+
+	// the growth is calculated as a whole since the account last changed, allow a rounding difference
+	growth := new(big.Int).Sub((*big.Int)(&projection.ProjectedEnergy), (*big.Int)(&projection.Energy))
+	expected := new(big.Int).Mul((*big.Int)(&acc.Balance), thor.EnergyGrowthRate)
+	expected.Mul(expected, big.NewInt(1000))
+	expected.Div(expected, big.NewInt(1e18))
+	assert.True(t, new(big.Int).Sub(growth, expected).CmpAbs(big.NewInt(1)) <= 0)
+
+	// projected to the block number is the same as to the block time
+	res, statusCode, err := tclient.RawHTTPClient().RawHTTPGet(fmt.Sprintf("/accounts/%v/energy-projection?blockNumber=%d", dev, best.Number()+100))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, statusCode)
+	var byNumber accounts.EnergyProjection
+	require.NoError(t, json.Unmarshal(res, &byNumber))
+	assert.Equal(t, projection, &byNumber)
+
+	// no target projects to the block of revision
+	res, statusCode, err = tclient.RawHTTPClient().RawHTTPGet("/accounts/" + dev.String() + "/energy-projection")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, statusCode)
+	var current accounts.EnergyProjection
+	require.NoError(t, json.Unmarshal(res, &current))
+	assert.Equal(t, best.Timestamp(), current.Timestamp)
+	assert.Equal(t, current.Energy, current.ProjectedEnergy)
+}
+
+func getEnergyProjectionOfZeroVETAccount(t *testing.T) {
+	best := bestHeader
+
+	projection, err := tclient.EnergyProjection(&contractAddr, best.Timestamp()+1000)
+	require.NoError(t, err)
+	assert.Equal(t, accounts.EnergyRuleSetGrowth, projection.RuleSet)
+	assert.Equal(t, 0, (*big.Int)(&projection.GenerationRate).Sign())
+	assert.Equal(t, projection.Energy, projection.ProjectedEnergy)
+}
+
+func getEnergyProjectionWithBadParams(t *testing.T) {
+	best := bestHeader
+
+	for _, tc := range []struct {
+		path string
+		msg  string
+	}{
+		{"/accounts/" + invalidAddr + "/energy-projection", ""},
+		{"/accounts/" + addr.String() + "/energy-projection?revision=" + invalidNumberRevision, ""},
+		{"/accounts/" + addr.String() + "/energy-projection?timestamp=abc", ""},
+		{"/accounts/" + addr.String() + "/energy-projection?blockNumber=-1", ""},
+		{"/accounts/" + addr.String() + "/energy-projection?timestamp=1&blockNumber=1", "timestamp and blockNumber: only one can be specified\n"},
+		{fmt.Sprintf("/accounts/%v/energy-projection?timestamp=%d", addr, best.Timestamp()-1), "timestamp: earlier than the block of revision\n"},
+		{"/accounts/" + addr.String() + "/energy-projection?blockNumber=0", "blockNumber: earlier than the block of revision\n"},
+	} {
+		res, statusCode, err := tclient.RawHTTPClient().RawHTTPGet(tc.path)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, statusCode, tc.path)
+		if tc.msg != "" {
+			assert.Equal(t, tc.msg, string(res))
+		}
+	}
+}
+
+func getTxPoolSummary(t *testing.T) {
+	sender := genesis.DevAccounts()[5]
+	for i, g := range []uint64{21000, 30000, 50000} {
+		trx := tx.MustSign(new(tx.Builder).
+			ChainTag(chainTag).
+			Expiration(100).
+			Gas(g).
+			Nonce(uint64(i)).
+			Clause(tx.NewClause(&addr)).
+			Build(), sender.PrivateKey)
+		require.NoError(t, txPool.Add(trx))
+	}
+
+	res, statusCode, err := tclient.RawHTTPClient().RawHTTPGet("/accounts/" + sender.Address.String() + "/txpool-summary")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, statusCode)
+
+	var summary accounts.TxPoolSummary
+	require.NoError(t, json.Unmarshal(res, &summary))
+	assert.Equal(t, 3, summary.PendingCount)
+	// the test chain is not synced, the txs are not washed
+	assert.Zero(t, summary.ExecutableCount)
+	// all the pending txs are summed up, at the base gas price
+	assert.Equal(t, new(big.Int).Mul(big.NewInt(21000+30000+50000), thor.InitialBaseGasPrice), (*big.Int)(&summary.PendingEnergyCost))
+
+	// no pending txs
+	res, statusCode, err = tclient.RawHTTPClient().RawHTTPGet("/accounts/" + addr.String() + "/txpool-summary")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, statusCode)
+	summary = accounts.TxPoolSummary{}
+	require.NoError(t, json.Unmarshal(res, &summary))
+	assert.Zero(t, summary.PendingCount)
+	assert.Zero(t, summary.ExecutableCount)
+	assert.Zero(t, (*big.Int)(&summary.PendingEnergyCost).Sign())
+
+	_, statusCode, err = tclient.RawHTTPClient().RawHTTPGet("/accounts/" + invalidAddr + "/txpool-summary")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+}
+
+func getProposerStatus(t *testing.T) {
+	// the solo block signer is the only authority node of devnet
+	status, err := tclient.ProposerStatus(&genesis.DevAccounts()[0].Address)
+	require.NoError(t, err)
+	assert.True(t, status.Proposer)
+	assert.True(t, status.Active)
+	require.NotNil(t, status.Position)
+	assert.Equal(t, uint64(0), *status.Position)
+	assert.Equal(t, uint64(1), status.Proposers)
+
+	status, err = tclient.ProposerStatus(&addr, thorclient.Revision("0"))
+	require.NoError(t, err)
+	assert.False(t, status.Proposer)
+	assert.False(t, status.Active)
+	assert.Nil(t, status.Position)
+	assert.Equal(t, uint64(1), status.Proposers)
+
+	_, statusCode, err := tclient.RawHTTPClient().RawHTTPGet("/accounts/" + invalidAddr + "/proposer")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, statusCode, "bad address")
+
+	_, statusCode, err = tclient.RawHTTPClient().RawHTTPGet("/accounts/" + addr.String() + "/proposer?revision=" + invalidNumberRevision)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, statusCode, "bad revision")
 }
 
 func initAccountServer(t *testing.T, enabledDeprecated bool) {
@@ -284,6 +607,7 @@ func initAccountServer(t *testing.T, enabledDeprecated bool) {
 	require.NoError(t, err)
 
 	genesisBlock = thorChain.GenesisBlock()
+	chainTag = thorChain.Repo().ChainTag()
 	claTransfer := tx.NewClause(&addr).WithValue(value)
 	claDeploy := tx.NewClause(nil).WithData(bytecode)
 	transaction := buildTxWithClauses(thorChain.Repo().ChainTag(), claTransfer, claDeploy)
@@ -305,8 +629,17 @@ func initAccountServer(t *testing.T, enabledDeprecated bool) {
 		),
 	)
 
+	bestHeader = thorChain.Repo().BestBlockSummary().Header
+
+	txPool = txpool.New(thorChain.Repo(), thorChain.Stater(), txpool.Options{
+		Limit:           100,
+		LimitPerAccount: 16,
+		MaxLifetime:     time.Hour,
+	})
+	t.Cleanup(txPool.Close)
+
 	router := mux.NewRouter()
-	accounts.New(thorChain.Repo(), thorChain.Stater(), uint64(gasLimit), thor.NoFork, thorChain.Engine(), enabledDeprecated).
+	accounts.New(thorChain.Repo(), thorChain.Stater(), txPool, uint64(gasLimit), thor.NoFork, thorChain.Engine(), enabledDeprecated).
 		Mount(router, "/accounts")
 
 	ts = httptest.NewServer(router)
@@ -423,7 +756,7 @@ func callContractWithNonExistingRevision(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Equal(t, http.StatusBadRequest, statusCode, "bad revision")
-	assert.Equal(t, "revision: leveldb: not found\n", string(res), "revision not found")
+	assert.Equal(t, "revision: not found\n", string(res), "revision not found")
 }
 
 func batchCall(t *testing.T) {
@@ -557,5 +890,5 @@ func batchCallWithNonExistingRevision(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Equal(t, http.StatusBadRequest, statusCode, "bad revision")
-	assert.Equal(t, "revision: leveldb: not found\n", string(res), "revision not found")
+	assert.Equal(t, "revision: not found\n", string(res), "revision not found")
 }
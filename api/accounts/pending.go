@@ -0,0 +1,123 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package accounts
+
+import (
+	"context"
+
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/runtime"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// maxPendingTxs is the max count of pending txs applied for a call.
+const maxPendingTxs = 1000
+
+// applyPendingTxs executes a snapshot of the executable txs in the pool on the state, as if they are packed
+// into the block of the header. Txs the packer won't adopt into the block, or failing to execute, are skipped.
+// At most maxPendingTxs txs are applied, within the gas limit of the block and the call gas limit.
+func (a *Accounts) applyPendingTxs(ctx context.Context, header *block.Header, st *state.State) error {
+	var (
+		rt       = a.newRuntime(header, st)
+		chain    = rt.Chain()
+		gasLimit = min(header.GasLimit(), a.callGasLimit)
+		gasUsed  uint64
+		applied  = make(map[thor.Bytes32]bool) // tx id => reverted
+	)
+	for _, trx := range a.txPool.Executables() {
+		if len(applied) == maxPendingTxs {
+			break
+		}
+		if gasUsed+trx.Gas() > gasLimit {
+			continue
+		}
+		adoptable, err := isAdoptable(trx, header, chain, applied)
+		if err != nil {
+			return err
+		}
+		if !adoptable {
+			continue
+		}
+
+		checkpoint := st.NewCheckpoint()
+		receipt, err := executeTx(ctx, rt, trx)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			st.RevertTo(checkpoint)
+			continue
+		}
+		applied[trx.ID()] = receipt.Reverted
+		gasUsed += receipt.GasUsed
+	}
+	return nil
+}
+
+// isAdoptable checks the tx the way the packer does before executing it into the block of the header,
+// with the txs applied so far in the block.
+func isAdoptable(trx *tx.Transaction, header *block.Header, chain *chain.Chain, applied map[thor.Bytes32]bool) (bool, error) {
+	origin, _ := trx.Origin()
+	switch {
+	case thor.IsOriginBlocked(origin),
+		trx.TestFeatures(header.TxsFeatures()) != nil,
+		header.Number() < trx.BlockRef().Number(),
+		trx.IsExpired(header.Number()):
+		return false, nil
+	}
+	if _, found := applied[trx.ID()]; found {
+		return false, nil
+	}
+	if found, err := chain.HasTransaction(trx.ID(), trx.BlockRef().Number()); err != nil || found {
+		return false, err
+	}
+
+	dependsOn := trx.DependsOn()
+	if dependsOn == nil {
+		return true, nil
+	}
+	if reverted, found := applied[*dependsOn]; found {
+		return !reverted, nil
+	}
+	meta, err := chain.GetTransactionMeta(*dependsOn)
+	if err != nil {
+		if chain.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return !meta.Reverted, nil
+}
+
+// executeTx executes the tx on the runtime, interrupting the execution once ctx is done.
+func executeTx(ctx context.Context, rt *runtime.Runtime, trx *tx.Transaction) (*tx.Receipt, error) {
+	executor, err := rt.PrepareTransaction(trx)
+	if err != nil {
+		return nil, err
+	}
+	for executor.HasNextClause() {
+		exec, interrupt := executor.PrepareNext()
+		errCh := make(chan error, 1)
+		go func() {
+			_, _, err := exec()
+			errCh <- err
+		}()
+		select {
+		case <-ctx.Done():
+			interrupt()
+			<-errCh
+			return nil, ctx.Err()
+		case err := <-errCh:
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return executor.Finalize()
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package accounts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/runtime"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/vm"
+	"github.com/vechain/thor/v2/xenv"
+)
+
+func TestIsAdoptable(t *testing.T) {
+	thorChain, err := testchain.NewIntegrationTestChain()
+	require.NoError(t, err)
+
+	sender := genesis.DevAccounts()[0]
+	to := thor.BytesToAddress([]byte("to"))
+	nonce := uint64(0)
+	newTx := func(blockRef uint32, expiration uint32, dependsOn *thor.Bytes32) *tx.Transaction {
+		nonce++
+		return tx.MustSign(new(tx.Builder).
+			ChainTag(thorChain.Repo().ChainTag()).
+			Clause(tx.NewClause(&to)).
+			Gas(21000).
+			BlockRef(tx.NewBlockRef(blockRef)).
+			Expiration(expiration).
+			DependsOn(dependsOn).
+			Nonce(nonce).
+			Build(), sender.PrivateKey)
+	}
+
+	included := newTx(0, 100, nil)
+	require.NoError(t, thorChain.MintTransactions(sender, included))
+	includedID := included.ID()
+
+	// the header of the block after the best one
+	best := thorChain.Repo().BestBlockSummary().Header
+	header := new(block.Builder).
+		ParentID(best.ID()).
+		Timestamp(best.Timestamp() + thor.BlockInterval).
+		GasLimit(best.GasLimit()).
+		Build().Header()
+	chain := thorChain.Repo().NewChain(best.ID())
+
+	pendingID := newTx(0, 100, nil).ID()
+	unknownID := thor.BytesToBytes32([]byte("unknown"))
+	applied := map[thor.Bytes32]bool{pendingID: false, unknownID: true}
+
+	for _, tt := range []struct {
+		name      string
+		trx       *tx.Transaction
+		adoptable bool
+	}{
+		{"plain", newTx(0, 100, nil), true},
+		{"already included", included, false},
+		{"referring to a later block", newTx(header.Number()+1, 100, nil), false},
+		{"expired", newTx(0, header.Number()-1, nil), false},
+		{"depending on an included tx", newTx(0, 100, &includedID), true},
+		{"depending on an applied tx", newTx(0, 100, &pendingID), true},
+		{"depending on a reverted applied tx", newTx(0, 100, &unknownID), false},
+		{"depending on an unknown tx", newTx(0, 100, new(thor.Bytes32)), false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			adoptable, err := isAdoptable(tt.trx, header, chain, applied)
+			require.NoError(t, err)
+			assert.Equal(t, tt.adoptable, adoptable)
+		})
+	}
+}
+
+func TestExecuteTxInterrupted(t *testing.T) {
+	thorChain, err := testchain.NewIntegrationTestChain()
+	require.NoError(t, err)
+
+	best := thorChain.Repo().BestBlockSummary()
+	st := thorChain.Stater().NewState(best.Header.StateRoot(), best.Header.Number(), best.Conflicts, best.SteadyNum)
+	rt := runtime.New(thorChain.Repo().NewChain(best.Header.ID()), st, &xenv.BlockContext{
+		Number:   best.Header.Number() + 1,
+		Time:     best.Header.Timestamp() + thor.BlockInterval,
+		GasLimit: best.Header.GasLimit(),
+	}, thorChain.GetForkConfig())
+
+	// a contract creation looping until out of gas
+	loop := []byte{byte(vm.JUMPDEST), byte(vm.PUSH1), 0, byte(vm.JUMP)}
+	trx := tx.MustSign(new(tx.Builder).
+		ChainTag(thorChain.Repo().ChainTag()).
+		Clause(tx.NewClause(nil).WithData(loop)).
+		Gas(best.Header.GasLimit()).
+		Expiration(100).
+		Build(), genesis.DevAccounts()[0].PrivateKey)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = executeTx(ctx, rt, trx)
+	assert.Equal(t, context.Canceled, err)
+}
@@ -20,6 +20,35 @@ type Account struct {
 	HasCode bool                 `json:"hasCode"`
 }
 
+// EnergyRuleSetGrowth is the rule set where energy grows proportionally to the VET balance,
+// at the rate of thor.EnergyGrowthRate.
+const EnergyRuleSetGrowth = "growth"
+
+// EnergyProjection is the energy of an account projected to a later time.
+type EnergyProjection struct {
+	Energy          math.HexOrDecimal256 `json:"energy"`
+	GenerationRate  math.HexOrDecimal256 `json:"generationRate"` // energy generated per second
+	RuleSet         string               `json:"ruleSet"`
+	Timestamp       uint64               `json:"timestamp"`
+	ProjectedEnergy math.HexOrDecimal256 `json:"projectedEnergy"`
+}
+
+// ProposerStatus is whether an address is an eligible block proposer on top of a block.
+type ProposerStatus struct {
+	Proposer  bool    `json:"proposer"`
+	Active    bool    `json:"active"`    // false if the proposer is considered offline
+	Position  *uint64 `json:"position"`  // index in the proposers list, null if not a proposer
+	Proposers uint64  `json:"proposers"` // count of proposers
+}
+
+// TxPoolSummary summarizes the pending txs of an account in the tx pool.
+type TxPoolSummary struct {
+	PendingCount      int                  `json:"pendingCount"`
+	ExecutableCount   int                  `json:"executableCount"`
+	PendingEnergyCost math.HexOrDecimal256 `json:"pendingEnergyCost"` // of the pending txs, whoever pays it
+	OldestTxAge       uint64               `json:"oldestTxAge"`       // in seconds
+}
+
 // CallData represents contract-call body
 type CallData struct {
 	Value    *math.HexOrDecimal256 `json:"value"`
@@ -37,6 +66,16 @@ type GetStorageResult struct {
 	Value string `json:"value"`
 }
 
+// StorageBatch is the keys of storage to be read in a batch.
+type StorageBatch struct {
+	Keys []thor.Bytes32 `json:"keys"`
+}
+
+// StorageBatchResult maps the keys of storage to their values.
+type StorageBatchResult struct {
+	Storage map[string]string `json:"storage"`
+}
+
 type CallResult struct {
 	Data      string                   `json:"data"`
 	Events    []*transactions.Event    `json:"events"`
@@ -14,17 +14,43 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/vechain/thor/v2/api/admin/apilogs"
 	"github.com/vechain/thor/v2/api/admin/loglevel"
-
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/comm"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/txpool"
+
+	chainAPI "github.com/vechain/thor/v2/api/admin/chain"
 	healthAPI "github.com/vechain/thor/v2/api/admin/health"
+	logdbAPI "github.com/vechain/thor/v2/api/admin/logdb"
+	p2pAPI "github.com/vechain/thor/v2/api/admin/p2p"
+	prunerAPI "github.com/vechain/thor/v2/api/admin/pruner"
+	txpoolAPI "github.com/vechain/thor/v2/api/admin/txpool"
 )
 
-func New(logLevel *slog.LevelVar, health *healthAPI.Health, apiLogsToggle *atomic.Bool) http.HandlerFunc {
+func New(
+	logLevel *slog.LevelVar,
+	health *healthAPI.Health,
+	apiLogsToggle *atomic.Bool,
+	pool *txpool.TxPool,
+	p2p *comm.Communicator,
+	repo *chain.Repository,
+	bft bft.Committer,
+	db *muxdb.MuxDB,
+	logDB *logdb.LogDB,
+) http.HandlerFunc {
 	router := mux.NewRouter()
 	subRouter := router.PathPrefix("/admin").Subrouter()
 
 	loglevel.New(logLevel).Mount(subRouter, "/loglevel")
 	healthAPI.NewAPI(health).Mount(subRouter, "/health")
 	apilogs.New(apiLogsToggle).Mount(subRouter, "/apilogs")
+	txpoolAPI.New(pool).Mount(subRouter, "/txpool")
+	p2pAPI.New(p2p).Mount(subRouter, "/p2p")
+	chainAPI.New(repo, bft, p2p).Mount(subRouter, "/chain")
+	prunerAPI.New(db).Mount(subRouter, "/pruner")
+	logdbAPI.New(logDB, p2p).Mount(subRouter, "/logdb")
 
 	handler := handlers.CompressHandler(router)
 
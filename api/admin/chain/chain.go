@@ -0,0 +1,135 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package chain
+
+import (
+	"net/http"
+	"runtime"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/vechain/thor/v2/api/utils"
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/comm"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// recentBlocks is the count of recent blocks to compute the average block time and gas usage.
+const recentBlocks = 10
+
+type Chain struct {
+	repo *chain.Repository
+	bft  bft.Committer
+	comm *comm.Communicator
+}
+
+// Block identifies a block.
+type Block struct {
+	ID     thor.Bytes32 `json:"id"`
+	Number uint32       `json:"number"`
+}
+
+// Health contains indicators of the chain health.
+type Health struct {
+	BestBlock        *Block  `json:"bestBlock"`
+	FinalizedBlock   *Block  `json:"finalizedBlock"`
+	JustifiedBlock   *Block  `json:"justifiedBlock"`
+	TimeSinceBest    uint64  `json:"timeSinceBest"`    // in seconds
+	IsSyncing        bool    `json:"isSyncing"`        // always false in solo mode
+	AverageBlockTime float64 `json:"averageBlockTime"` // in seconds, of the recent blocks
+	GasUsagePercent  float64 `json:"gasUsagePercent"`  // of the recent blocks
+	MemoryUsageMB    uint64  `json:"memoryUsageMB"`    // memory obtained from the OS
+}
+
+func New(repo *chain.Repository, bft bft.Committer, comm *comm.Communicator) *Chain {
+	return &Chain{
+		repo: repo,
+		bft:  bft,
+		comm: comm,
+	}
+}
+
+func (c *Chain) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+	sub.Path("/health").
+		Methods(http.MethodGet).
+		Name("get-chain-health").
+		HandlerFunc(utils.WrapHandlerFunc(c.handleGetHealth))
+}
+
+func (c *Chain) handleGetHealth(w http.ResponseWriter, _ *http.Request) error {
+	health, err := c.health(time.Now())
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, health)
+}
+
+func (c *Chain) health(now time.Time) (*Health, error) {
+	best := c.repo.BestBlockSummary().Header
+	justified, err := c.bft.Justified()
+	if err != nil {
+		return nil, err
+	}
+
+	var timeSinceBest uint64
+	if ts := uint64(now.Unix()); ts > best.Timestamp() {
+		timeSinceBest = ts - best.Timestamp()
+	}
+
+	var isSyncing bool
+	// no syncing in solo mode
+	if c.comm != nil {
+		select {
+		case <-c.comm.Synced():
+		default:
+			isSyncing = true
+		}
+	}
+
+	// stats of the recent blocks
+	var (
+		averageBlockTime float64
+		gasUsagePercent  float64
+		gasUsed          uint64
+		gasLimit         uint64
+	)
+	n := min(best.Number(), recentBlocks)
+	if n > 0 {
+		bestChain := c.repo.NewChain(best.ID())
+		header := best
+		for i := uint32(0); i < n; i++ {
+			gasUsed += header.GasUsed()
+			gasLimit += header.GasLimit()
+			if header, err = bestChain.GetBlockHeader(header.Number() - 1); err != nil {
+				return nil, err
+			}
+		}
+		// header is the parent of the recent blocks now
+		averageBlockTime = float64(best.Timestamp()-header.Timestamp()) / float64(n)
+		gasUsagePercent = float64(gasUsed) * 100 / float64(gasLimit)
+	}
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	return &Health{
+		BestBlock:        newBlock(best.ID()),
+		FinalizedBlock:   newBlock(c.bft.Finalized()),
+		JustifiedBlock:   newBlock(justified),
+		TimeSinceBest:    timeSinceBest,
+		IsSyncing:        isSyncing,
+		AverageBlockTime: averageBlockTime,
+		GasUsagePercent:  gasUsagePercent,
+		MemoryUsageMB:    mem.Sys / 1024 / 1024,
+	}, nil
+}
+
+func newBlock(id thor.Bytes32) *Block {
+	return &Block{ID: id, Number: block.Number(id)}
+}
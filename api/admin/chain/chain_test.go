@@ -0,0 +1,94 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package chain
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/comm"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/txpool"
+)
+
+func TestHealth(t *testing.T) {
+	thorChain, err := testchain.NewIntegrationTestChain()
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, thorChain.MintTransactions(genesis.DevAccounts()[0]))
+	}
+	best := thorChain.Repo().BestBlockSummary().Header
+
+	// solo chain
+	c := New(thorChain.Repo(), thorChain.Engine(), nil)
+	health, err := c.health(time.Unix(int64(best.Timestamp())+1, 0))
+	require.NoError(t, err)
+	assert.Equal(t, &Block{ID: best.ID(), Number: 3}, health.BestBlock)
+	assert.Equal(t, thorChain.Engine().Finalized(), health.FinalizedBlock.ID)
+	assert.NotNil(t, health.JustifiedBlock)
+	assert.Less(t, health.TimeSinceBest, uint64(5))
+	assert.False(t, health.IsSyncing)
+	assert.Equal(t, float64(thor.BlockInterval), health.AverageBlockTime)
+	assert.Zero(t, health.GasUsagePercent)
+	assert.NotZero(t, health.MemoryUsageMB)
+
+	// best block in the future
+	health, err = c.health(time.Unix(int64(best.Timestamp())-1, 0))
+	require.NoError(t, err)
+	assert.Zero(t, health.TimeSinceBest)
+
+	// not synced yet
+	c = New(thorChain.Repo(), thorChain.Engine(), comm.New(thorChain.Repo(), txpool.New(thorChain.Repo(), nil, txpool.Options{})))
+	health, err = c.health(time.Now())
+	require.NoError(t, err)
+	assert.True(t, health.IsSyncing)
+}
+
+func TestHealthAPI(t *testing.T) {
+	thorChain, err := testchain.NewIntegrationTestChain()
+	require.NoError(t, err)
+	require.NoError(t, thorChain.MintTransactions(genesis.DevAccounts()[0]))
+
+	router := mux.NewRouter()
+	New(thorChain.Repo(), thorChain.Engine(), nil).Mount(router, "/chain")
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	res, err := http.Get(ts.URL + "/chain/health")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(body, &fields))
+	for _, name := range []string{
+		"bestBlock",
+		"finalizedBlock",
+		"justifiedBlock",
+		"timeSinceBest",
+		"isSyncing",
+		"averageBlockTime",
+		"gasUsagePercent",
+		"memoryUsageMB",
+	} {
+		assert.Contains(t, fields, name)
+	}
+
+	var health Health
+	require.NoError(t, json.Unmarshal(body, &health))
+	assert.Equal(t, uint32(1), health.BestBlock.Number)
+}
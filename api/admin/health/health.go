@@ -6,6 +6,7 @@
 package health
 
 import (
+	"sync/atomic"
 	"time"
 
 	"github.com/vechain/thor/v2/chain"
@@ -23,11 +24,13 @@ type Status struct {
 	BestBlockTime        *time.Time `json:"bestBlockTime"`
 	PeerCount            int        `json:"peerCount"`
 	IsNetworkProgressing bool       `json:"isNetworkProgressing"`
+	HaltReason           string     `json:"haltReason,omitempty"`
 }
 
 type Health struct {
-	repo *chain.Repository
-	p2p  *comm.Communicator
+	repo       *chain.Repository
+	p2p        *comm.Communicator
+	haltReason atomic.Pointer[string]
 }
 
 const (
@@ -42,6 +45,12 @@ func New(repo *chain.Repository, p2p *comm.Communicator) *Health {
 	}
 }
 
+// ReportHalted marks the node unhealthy for good, since block processing is halted.
+func (h *Health) ReportHalted(err error) {
+	reason := err.Error()
+	h.haltReason.Store(&reason)
+}
+
 // isNetworkProgressing checks if the network is producing new blocks within the allowed interval.
 func (h *Health) isNetworkProgressing(now time.Time, bestBlockTimestamp time.Time, blockTolerance time.Duration) bool {
 	return now.Sub(bestBlockTimestamp) <= blockTolerance
@@ -75,10 +84,15 @@ func (h *Health) Status(blockTolerance time.Duration, minPeerCount int) (*Status
 	healthy := networkProgressing && nodeConnected
 
 	// Return the current status
-	return &Status{
+	status := &Status{
 		Healthy:              healthy,
 		BestBlockTime:        &bestBlockTimestamp,
 		IsNetworkProgressing: networkProgressing,
 		PeerCount:            connectedPeerCount,
-	}, nil
+	}
+	if reason := h.haltReason.Load(); reason != nil {
+		status.Healthy = false
+		status.HaltReason = *reason
+	}
+	return status, nil
 }
@@ -6,10 +6,13 @@
 package health
 
 import (
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/test/testchain"
 )
 
 func TestHealth_isNetworkProgressing(t *testing.T) {
@@ -69,3 +72,21 @@ func TestHealth_isNodeConnectedP2P(t *testing.T) {
 		})
 	}
 }
+
+func TestHealth_ReportHalted(t *testing.T) {
+	thorChain, err := testchain.NewIntegrationTestChain()
+	require.NoError(t, err)
+	h := New(thorChain.Repo(), nil)
+
+	// the genesis of the test chain is old, don't care about progressing
+	status, err := h.Status(time.Duration(1<<62), defaultMinPeerCount)
+	require.NoError(t, err)
+	assert.True(t, status.Healthy)
+	assert.Empty(t, status.HaltReason)
+
+	h.ReportHalted(errors.New("deep reorg"))
+	status, err = h.Status(time.Duration(1<<62), defaultMinPeerCount)
+	require.NoError(t, err)
+	assert.False(t, status.Healthy)
+	assert.Equal(t, "deep reorg", status.HaltReason)
+}
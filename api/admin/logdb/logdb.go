@@ -0,0 +1,98 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logdb
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/vechain/thor/v2/api/utils"
+	"github.com/vechain/thor/v2/comm"
+	"github.com/vechain/thor/v2/logdb"
+)
+
+type LogDB struct {
+	db   *logdb.LogDB
+	comm *comm.Communicator
+}
+
+// Stats contains the counts of events and transfers, and the range of blocks they are in.
+type Stats struct {
+	TotalEvents       uint64  `json:"totalEvents"`
+	TotalTransfers    uint64  `json:"totalTransfers"`
+	EventsPerBlock    float64 `json:"eventsPerBlock"`    // averaged over the range of blocks
+	TransfersPerBlock float64 `json:"transfersPerBlock"` // averaged over the range of blocks
+	OldestBlock       uint32  `json:"oldestBlock"`
+	NewestBlock       uint32  `json:"newestBlock"`
+}
+
+// Compaction contains the sizes of the logdb in bytes before and after a compaction, and the bytes reclaimed.
+type Compaction struct {
+	SizeBefore uint64 `json:"sizeBefore"`
+	SizeAfter  uint64 `json:"sizeAfter"`
+	Reclaimed  uint64 `json:"reclaimed"`
+}
+
+// New creates the logdb admin API. The comm is nil in solo mode, where blocks are never imported.
+func New(db *logdb.LogDB, comm *comm.Communicator) *LogDB {
+	return &LogDB{
+		db:   db,
+		comm: comm,
+	}
+}
+
+func (l *LogDB) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+	sub.Path("/stats").
+		Methods(http.MethodGet).
+		Name("get-logdb-stats").
+		HandlerFunc(utils.WrapHandlerFunc(l.getStats))
+
+	sub.Path("/compact").
+		Methods(http.MethodPost).
+		Name("post-logdb-compact").
+		HandlerFunc(utils.WrapHandlerFunc(l.compact))
+}
+
+func (l *LogDB) getStats(w http.ResponseWriter, _ *http.Request) error {
+	stats, err := l.db.Statistics()
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, &Stats{
+		TotalEvents:       stats.TotalEvents,
+		TotalTransfers:    stats.TotalTransfers,
+		EventsPerBlock:    stats.EventsPerBlock,
+		TransfersPerBlock: stats.TransfersPerBlock,
+		OldestBlock:       stats.OldestBlock,
+		NewestBlock:       stats.NewestBlock,
+	})
+}
+
+func (l *LogDB) compact(w http.ResponseWriter, r *http.Request) error {
+	// the logs of the imported blocks would wait for the compaction, and stall the import
+	if l.comm != nil {
+		select {
+		case <-l.comm.Synced():
+		default:
+			return utils.HTTPError(errors.New("the node is importing blocks, retry once synced"), http.StatusServiceUnavailable)
+		}
+	}
+	compaction, err := l.db.Compact(r.Context())
+	if err != nil {
+		return err
+	}
+	var reclaimed uint64
+	if compaction.SizeAfter < compaction.SizeBefore {
+		reclaimed = compaction.SizeBefore - compaction.SizeAfter
+	}
+	return utils.WriteJSON(w, &Compaction{
+		SizeBefore: compaction.SizeBefore,
+		SizeAfter:  compaction.SizeAfter,
+		Reclaimed:  reclaimed,
+	})
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logdb
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/comm"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/txpool"
+)
+
+func TestStats(t *testing.T) {
+	db, err := logdb.NewMem()
+	require.NoError(t, err)
+	defer db.Close()
+
+	to := thor.BytesToAddress([]byte("to"))
+	receipt := &tx.Receipt{Outputs: []*tx.Output{{
+		Events:    tx.Events{{Address: to}},
+		Transfers: tx.Transfers{{Recipient: to, Amount: big.NewInt(1)}},
+	}}}
+	w := db.NewWriter()
+	var parentID thor.Bytes32
+	for range 4 {
+		trx := tx.MustSign(new(tx.Builder).Build(), genesis.DevAccounts()[0].PrivateKey)
+		blk := new(block.Builder).ParentID(parentID).Transaction(trx).Build()
+		require.NoError(t, w.Write(blk, tx.Receipts{receipt}))
+		parentID = blk.Header().ID()
+	}
+	require.NoError(t, w.Commit())
+
+	router := mux.NewRouter()
+	New(db, nil).Mount(router, "/admin/logdb")
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	res, err := http.Get(ts.URL + "/admin/logdb/stats")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var stats Stats
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&stats))
+	assert.Equal(t, Stats{
+		TotalEvents:       4,
+		TotalTransfers:    4,
+		EventsPerBlock:    1,
+		TransfersPerBlock: 1,
+		OldestBlock:       1,
+		NewestBlock:       4,
+	}, stats)
+}
+
+func TestCompact(t *testing.T) {
+	db, err := logdb.NewMem()
+	require.NoError(t, err)
+	defer db.Close()
+
+	to := thor.BytesToAddress([]byte("to"))
+	receipt := &tx.Receipt{Outputs: []*tx.Output{{
+		Events: tx.Events{{Address: to, Data: make([]byte, 1024)}},
+	}}}
+	w := db.NewWriter()
+	var parentID thor.Bytes32
+	for range 100 {
+		trx := tx.MustSign(new(tx.Builder).Build(), genesis.DevAccounts()[0].PrivateKey)
+		blk := new(block.Builder).ParentID(parentID).Transaction(trx).Build()
+		require.NoError(t, w.Write(blk, tx.Receipts{receipt}))
+		parentID = blk.Header().ID()
+	}
+	require.NoError(t, w.Truncate(1))
+	require.NoError(t, w.Commit())
+
+	router := mux.NewRouter()
+	New(db, nil).Mount(router, "/admin/logdb")
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	res, err := http.Post(ts.URL+"/admin/logdb/compact", "application/json", nil)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var compaction Compaction
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&compaction))
+	assert.Less(t, compaction.SizeAfter, compaction.SizeBefore)
+	assert.Equal(t, compaction.SizeBefore-compaction.SizeAfter, compaction.Reclaimed)
+	assert.Greater(t, compaction.Reclaimed, uint64(100*1024))
+}
+
+func TestCompactWhileImporting(t *testing.T) {
+	db, err := logdb.NewMem()
+	require.NoError(t, err)
+	defer db.Close()
+
+	thorChain, err := testchain.NewIntegrationTestChain()
+	require.NoError(t, err)
+	communicator := comm.New(thorChain.Repo(), txpool.New(thorChain.Repo(), thorChain.Stater(), txpool.Options{}))
+	defer communicator.Stop()
+
+	router := mux.NewRouter()
+	New(db, communicator).Mount(router, "/admin/logdb")
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	res, err := http.Post(ts.URL+"/admin/logdb/compact", "application/json", nil)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+}
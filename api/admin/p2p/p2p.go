@@ -0,0 +1,78 @@
+// Copyright (c) 2025 The VeChainThor developers
+//
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package p2p
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/api/utils"
+	"github.com/vechain/thor/v2/comm"
+)
+
+const defaultPeersLimit = 20
+
+type P2P struct {
+	comm *comm.Communicator
+}
+
+type PeerInfo struct {
+	ID              string     `json:"id"`
+	Enode           string     `json:"enode"`
+	AvgRTT          float64    `json:"avgRTT"` // in milliseconds
+	LastSeen        *time.Time `json:"lastSeen"`
+	BlocksExchanged uint64     `json:"blocksExchanged"`
+}
+
+func New(comm *comm.Communicator) *P2P {
+	return &P2P{
+		comm: comm,
+	}
+}
+
+func (p *P2P) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+	sub.Path("/peers-by-latency").
+		Methods(http.MethodGet).
+		Name("get-peers-by-latency").
+		HandlerFunc(utils.WrapHandlerFunc(p.handleGetPeersByLatency))
+}
+
+func (p *P2P) handleGetPeersByLatency(w http.ResponseWriter, req *http.Request) error {
+	limit := uint64(defaultPeersLimit)
+	if s := req.URL.Query().Get("limit"); s != "" {
+		parsed, err := strconv.ParseUint(s, 10, 32)
+		if err != nil || parsed == 0 {
+			return utils.BadRequest(errors.New("limit: should be a positive integer"))
+		}
+		limit = parsed
+	}
+
+	peers := make([]*PeerInfo, 0)
+	// no peers in solo mode
+	if p.comm != nil {
+		for _, info := range p.comm.PeersByLatency() {
+			if uint64(len(peers)) >= limit {
+				break
+			}
+			peer := &PeerInfo{
+				ID:              info.ID.String(),
+				Enode:           info.Enode,
+				AvgRTT:          float64(info.AvgRTT) / float64(time.Millisecond),
+				BlocksExchanged: info.BlocksExchanged,
+			}
+			if !info.LastSeen.IsZero() {
+				lastSeen := info.LastSeen
+				peer.LastSeen = &lastSeen
+			}
+			peers = append(peers, peer)
+		}
+	}
+	return utils.WriteJSON(w, peers)
+}
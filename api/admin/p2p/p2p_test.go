@@ -0,0 +1,58 @@
+// Copyright (c) 2025 The VeChainThor developers
+//
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package p2p
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/comm"
+	"github.com/vechain/thor/v2/test/testchain"
+)
+
+func TestPeersByLatency(t *testing.T) {
+	thorChain, err := testchain.NewIntegrationTestChain()
+	require.NoError(t, err)
+
+	communicator := comm.New(thorChain.Repo(), nil)
+	defer communicator.Stop()
+
+	for _, c := range []*comm.Communicator{communicator, nil} {
+		router := mux.NewRouter()
+		New(c).Mount(router, "/admin/p2p")
+		ts := httptest.NewServer(router)
+
+		body, code := httpGet(t, ts.URL+"/admin/p2p/peers-by-latency")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "[]\n", string(body))
+
+		body, code = httpGet(t, ts.URL+"/admin/p2p/peers-by-latency?limit=5")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "[]\n", string(body))
+
+		for _, limit := range []string{"0", "-1", "abc"} {
+			body, code = httpGet(t, ts.URL+"/admin/p2p/peers-by-latency?limit="+limit)
+			assert.Equal(t, http.StatusBadRequest, code)
+			assert.Equal(t, "limit: should be a positive integer\n", string(body))
+		}
+		ts.Close()
+	}
+}
+
+func httpGet(t *testing.T, url string) ([]byte, int) {
+	res, err := http.Get(url) //#nosec G107
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	return body, res.StatusCode
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2025 The VeChainThor developers
+//
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package pruner
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/api/utils"
+	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/muxdb"
+)
+
+const mb = 1024 * 1024
+
+type Pruner struct {
+	db *muxdb.MuxDB
+}
+
+// IOLimit is the max megabytes per second deleted by the pruner, 0 means no limit.
+type IOLimit struct {
+	IOLimitMB int64 `json:"ioLimitMB"`
+}
+
+func New(db *muxdb.MuxDB) *Pruner {
+	return &Pruner{
+		db: db,
+	}
+}
+
+func (p *Pruner) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+	sub.Path("/io-limit").
+		Methods(http.MethodGet).
+		Name("get-pruner-io-limit").
+		HandlerFunc(utils.WrapHandlerFunc(p.getIOLimit))
+
+	sub.Path("/io-limit").
+		Methods(http.MethodPost).
+		Name("post-pruner-io-limit").
+		HandlerFunc(utils.WrapHandlerFunc(p.setIOLimit))
+}
+
+func (p *Pruner) getIOLimit(w http.ResponseWriter, _ *http.Request) error {
+	return utils.WriteJSON(w, IOLimit{
+		IOLimitMB: p.db.TrieCleanIOLimit() / mb,
+	})
+}
+
+func (p *Pruner) setIOLimit(w http.ResponseWriter, r *http.Request) error {
+	var req IOLimit
+	if err := utils.ParseJSON(r.Body, &req); err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "body"))
+	}
+	if req.IOLimitMB < 0 {
+		return utils.BadRequest(errors.New("ioLimitMB: must not be negative"))
+	}
+	p.db.SetTrieCleanIOLimit(req.IOLimitMB * mb)
+
+	log.Info("pruner io limit updated", "pkg", "pruner", "limitMB", req.IOLimitMB)
+
+	return utils.WriteJSON(w, IOLimit{
+		IOLimitMB: p.db.TrieCleanIOLimit() / mb,
+	})
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2025 The VeChainThor developers
+//
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package pruner
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/muxdb"
+)
+
+func TestIOLimit(t *testing.T) {
+	db := muxdb.NewMem()
+	router := mux.NewRouter()
+	New(db).Mount(router, "/admin/pruner")
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	do := func(method, body string) (IOLimit, int) {
+		req, err := http.NewRequest(method, ts.URL+"/admin/pruner/io-limit", strings.NewReader(body))
+		require.NoError(t, err)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		var limit IOLimit
+		if res.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&limit))
+		} else {
+			_, err = io.ReadAll(res.Body)
+			require.NoError(t, err)
+		}
+		return limit, res.StatusCode
+	}
+
+	limit, code := do(http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int64(0), limit.IOLimitMB)
+
+	limit, code = do(http.MethodPost, `{"ioLimitMB":8}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int64(8), limit.IOLimitMB)
+	assert.Equal(t, int64(8*1024*1024), db.TrieCleanIOLimit())
+
+	limit, code = do(http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int64(8), limit.IOLimitMB)
+
+	_, code = do(http.MethodPost, `{"ioLimitMB":-1}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	_, code = do(http.MethodPost, `invalid`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, int64(8*1024*1024), db.TrieCleanIOLimit())
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2025 The VeChainThor developers
+//
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package txpool
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/api/utils"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/txpool"
+)
+
+type TxPool struct {
+	pool *txpool.TxPool
+}
+
+type EvictResult struct {
+	Removed int `json:"removed"`
+}
+
+func New(pool *txpool.TxPool) *TxPool {
+	return &TxPool{
+		pool: pool,
+	}
+}
+
+func (t *TxPool) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+	sub.Path("/by-address/{address}").
+		Methods(http.MethodDelete).
+		Name("delete-txpool-by-address").
+		HandlerFunc(utils.WrapHandlerFunc(t.handleEvictByAddress))
+}
+
+func (t *TxPool) handleEvictByAddress(w http.ResponseWriter, req *http.Request) error {
+	addr, err := thor.ParseAddress(mux.Vars(req)["address"])
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "address"))
+	}
+
+	return utils.WriteJSON(w, &EvictResult{
+		Removed: t.pool.EvictByAddress(addr),
+	})
+}
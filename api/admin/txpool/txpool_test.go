@@ -0,0 +1,83 @@
+// Copyright (c) 2025 The VeChainThor developers
+//
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package txpool
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/txpool"
+)
+
+func TestEvictByAddress(t *testing.T) {
+	thorChain, err := testchain.NewIntegrationTestChain()
+	require.NoError(t, err)
+
+	pool := txpool.New(thorChain.Repo(), thorChain.Stater(), txpool.Options{
+		Limit:           10,
+		LimitPerAccount: 16,
+		MaxLifetime:     time.Hour,
+	})
+	defer pool.Close()
+
+	acc := genesis.DevAccounts()[0]
+	for i := 0; i < 3; i++ {
+		trx := tx.MustSign(
+			new(tx.Builder).
+				ChainTag(thorChain.Repo().ChainTag()).
+				Expiration(100).
+				Gas(21000).
+				Nonce(uint64(i)).
+				Build(),
+			acc.PrivateKey,
+		)
+		require.NoError(t, pool.Add(trx))
+	}
+
+	router := mux.NewRouter()
+	New(pool).Mount(router, "/admin/txpool")
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	body, code := httpDelete(t, ts.URL+"/admin/txpool/by-address/"+acc.Address.String())
+	assert.Equal(t, http.StatusOK, code)
+	var res EvictResult
+	require.NoError(t, json.Unmarshal(body, &res))
+	assert.Equal(t, 3, res.Removed)
+	assert.Empty(t, pool.Dump())
+
+	body, code = httpDelete(t, ts.URL+"/admin/txpool/by-address/"+thor.Address{}.String())
+	assert.Equal(t, http.StatusOK, code)
+	require.NoError(t, json.Unmarshal(body, &res))
+	assert.Equal(t, 0, res.Removed)
+
+	_, code = httpDelete(t, ts.URL+"/admin/txpool/by-address/0xinvalid")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func httpDelete(t *testing.T, url string) ([]byte, int) {
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	require.NoError(t, err)
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	r, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	return r, res.StatusCode
+}
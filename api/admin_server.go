@@ -15,24 +15,33 @@ import (
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/v2/api/admin"
 	"github.com/vechain/thor/v2/api/admin/health"
+	"github.com/vechain/thor/v2/bft"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/co"
 	"github.com/vechain/thor/v2/comm"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/txpool"
 )
 
 func StartAdminServer(
 	addr string,
 	logLevel *slog.LevelVar,
 	repo *chain.Repository,
+	bft bft.Committer,
 	p2p *comm.Communicator,
+	healthStatus *health.Health,
 	apiLogs *atomic.Bool,
+	txPool *txpool.TxPool,
+	db *muxdb.MuxDB,
+	logDB *logdb.LogDB,
 ) (string, func(), error) {
 	listener, err := net.Listen("tcp", addr)
 	if err != nil {
 		return "", nil, errors.Wrapf(err, "listen admin API addr [%v]", addr)
 	}
 
-	adminHandler := admin.New(logLevel, health.New(repo, p2p), apiLogs)
+	adminHandler := admin.New(logLevel, healthStatus, apiLogs, txPool, p2p, repo, bft, db, logDB)
 
 	srv := &http.Server{Handler: adminHandler, ReadHeaderTimeout: time.Second, ReadTimeout: 5 * time.Second}
 	var goes co.Goes
@@ -16,12 +16,14 @@ import (
 	"github.com/vechain/thor/v2/api/accounts"
 	"github.com/vechain/thor/v2/api/blocks"
 	"github.com/vechain/thor/v2/api/debug"
+	"github.com/vechain/thor/v2/api/dev"
 	"github.com/vechain/thor/v2/api/doc"
 	"github.com/vechain/thor/v2/api/events"
 	"github.com/vechain/thor/v2/api/node"
 	"github.com/vechain/thor/v2/api/subscriptions"
 	"github.com/vechain/thor/v2/api/transactions"
 	"github.com/vechain/thor/v2/api/transfers"
+	"github.com/vechain/thor/v2/api/utils"
 	"github.com/vechain/thor/v2/bft"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/log"
@@ -34,18 +36,28 @@ import (
 var logger = log.WithContext("pkg", "api")
 
 type Config struct {
-	AllowedOrigins    string
-	BacktraceLimit    uint32
-	CallGasLimit      uint64
-	PprofOn           bool
-	SkipLogs          bool
-	AllowCustomTracer bool
-	EnableReqLogger   *atomic.Bool
-	EnableMetrics     bool
-	LogsLimit         uint64
-	AllowedTracers    []string
-	SoloMode          bool
-	EnableDeprecated  bool
+	AllowedOrigins     string
+	BacktraceLimit     uint32
+	CallGasLimit       uint64
+	PprofOn            bool
+	SkipLogs           bool
+	AllowCustomTracer  bool
+	EnableReqLogger    *atomic.Bool
+	EnableMetrics      bool
+	LogsLimit          uint64
+	AllowedTracers     []string
+	SoloMode           bool
+	EnableDeprecated   bool
+	EnableTxPool       bool
+	ChecksumAddresses  bool
+	Subscriptions      subscriptions.Options
+	TraceJobs          debug.JobOptions
+	TracerMetrics      bool // exports the cost of tracers, if metrics enabled
+	DisableCompression bool
+	Compression        []string      // compression modes allowed for the responses, all if empty, see ParseCompression
+	DevSigner          bool          // serves the dev accounts signer, solo mode on devnet only
+	NodeMaster         *thor.Address // the node master address to mark its slots in the schedule, nil in solo mode
+	DefaultFinality    string        // finality of the requests without an explicit revision, see ValidateDefaultFinality
 }
 
 // New return api router
@@ -64,6 +76,9 @@ func New(
 		origins[i] = strings.ToLower(strings.TrimSpace(o))
 	}
 
+	// addresses are encoded by their JSON marshaler, the option applies process-wide
+	thor.SetChecksumAddressJSON(config.ChecksumAddresses)
+
 	router := mux.NewRouter()
 
 	// to serve stoplight, swagger and api docs
@@ -77,26 +92,38 @@ func New(
 			http.Redirect(w, req, "doc/stoplight-ui/", http.StatusTemporaryRedirect)
 		})
 
-	accounts.New(repo, stater, config.CallGasLimit, forkConfig, bft, config.EnableDeprecated).
+	accounts.New(repo, stater, txPool, config.CallGasLimit, forkConfig, bft, config.EnableDeprecated).
 		Mount(router, "/accounts")
 
 	if !config.SkipLogs {
-		events.New(repo, logDB, config.LogsLimit).
+		events.New(repo, logDB, config.LogsLimit, bft).
 			Mount(router, "/logs/event")
-		transfers.New(repo, logDB, config.LogsLimit).
+		transfers.New(repo, logDB, config.LogsLimit, bft).
 			Mount(router, "/logs/transfer")
 	}
-	blocks.New(repo, bft).
+	blocks.New(repo, bft, forkConfig).
 		Mount(router, "/blocks")
-	transactions.New(repo, txPool).
+	transactions.New(repo, txPool, bft).
 		Mount(router, "/transactions")
-	debug.New(repo, stater, forkConfig, config.CallGasLimit, config.AllowCustomTracer, bft, config.AllowedTracers, config.SoloMode).
-		Mount(router, "/debug")
-	node.New(nw).
+	debugAPI := debug.New(repo, stater, forkConfig, config.CallGasLimit, config.AllowCustomTracer, bft, config.AllowedTracers, config.SoloMode, config.TraceJobs, config.EnableMetrics && config.TracerMetrics)
+	debugAPI.Mount(router, "/debug")
+	node.New(nw, txPool, config.EnableTxPool, repo, stater, forkConfig, config.NodeMaster).
 		Mount(router, "/node")
-	subs := subscriptions.New(repo, origins, config.BacktraceLimit, txPool, config.EnableDeprecated)
+	subsLogDB := logDB
+	if config.SkipLogs {
+		subsLogDB = nil
+	}
+	subs := subscriptions.New(repo, stater, subsLogDB, origins, config.BacktraceLimit, txPool, config.EnableDeprecated, config.Subscriptions)
 	subs.Mount(router, "/subscriptions")
 
+	if config.SoloMode && config.DevSigner {
+		if devAPI, err := dev.New(repo, txPool); err != nil {
+			logger.Warn("dev signer disabled", "err", err)
+		} else {
+			devAPI.Mount(router, "/dev")
+		}
+	}
+
 	if config.PprofOn {
 		router.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
 		router.HandleFunc("/debug/pprof/profile", pprof.Profile)
@@ -109,14 +136,22 @@ func New(
 		router.Use(metricsMiddleware)
 	}
 
-	handler := handlers.CompressHandler(router)
+	// the finalities are named as the revisions
+	handler := utils.RevisionHandler(router, config.DefaultFinality)
+	if encodings := allowedEncodings(config.Compression); !config.DisableCompression && len(encodings) > 0 {
+		// subscriptions are websocket streams and not compressed
+		handler = compressHandler(handler, encodings, "/subscriptions")
+	}
 	handler = handlers.CORS(
 		handlers.AllowedOrigins(origins),
 		handlers.AllowedHeaders([]string{"content-type", "x-genesis-id"}),
-		handlers.ExposedHeaders([]string{"x-genesis-id", "x-thorest-ver"}),
+		handlers.ExposedHeaders([]string{"x-genesis-id", "x-thorest-ver", strings.ToLower(utils.ResolvedRevisionHeader)}),
 	)(handler)
 
 	handler = RequestLoggerHandler(handler, logger, config.EnableReqLogger)
 
-	return handler.ServeHTTP, subs.Close // subscriptions handles hijacked conns, which need to be closed
+	return handler.ServeHTTP, func() {
+		subs.Close() // subscriptions handles hijacked conns, which need to be closed
+		debugAPI.Close()
+	}
 }
@@ -0,0 +1,378 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/api/blocks"
+	"github.com/vechain/thor/v2/api/transactions"
+	"github.com/vechain/thor/v2/api/utils"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
+	_ "github.com/vechain/thor/v2/tracers/logger"
+	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/txpool"
+)
+
+func TestChecksumAddresses(t *testing.T) {
+	thorChain, err := testchain.NewIntegrationTestChain()
+	require.NoError(t, err)
+
+	sender, recipient := genesis.DevAccounts()[0], genesis.DevAccounts()[1]
+	trx := tx.MustSign(new(tx.Builder).
+		ChainTag(thorChain.Repo().ChainTag()).
+		Expiration(100).
+		Gas(21000).
+		Nonce(1).
+		Clause(tx.NewClause(&recipient.Address)).
+		Build(), sender.PrivateKey)
+	require.NoError(t, thorChain.MintTransactions(sender, trx))
+
+	txPool := txpool.New(thorChain.Repo(), thorChain.Stater(), txpool.Options{
+		Limit:           100,
+		LimitPerAccount: 16,
+		MaxLifetime:     time.Hour,
+	})
+	defer txPool.Close()
+
+	handler, closer := New(thorChain.Repo(), thorChain.Stater(), txPool, thorChain.LogDB(), thorChain.Engine(), nil, thorChain.GetForkConfig(), Config{
+		BacktraceLimit:    10,
+		CallGasLimit:      10_000_000,
+		LogsLimit:         100,
+		ChecksumAddresses: true,
+		EnableReqLogger:   &atomic.Bool{},
+	})
+	defer thor.SetChecksumAddressJSON(false)
+	defer closer()
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	t.Run("responses", func(t *testing.T) {
+		body, code := httpGet(t, ts.URL+"/blocks/best")
+		require.Equal(t, http.StatusOK, code)
+		assert.Contains(t, string(body), `"signer":"`+sender.Address.Checksumed()+`"`)
+		assert.Contains(t, string(body), `"beneficiary":"`+sender.Address.Checksumed()+`"`)
+
+		var blk blocks.JSONCollapsedBlock
+		require.NoError(t, json.Unmarshal(body, &blk))
+		assert.Equal(t, sender.Address, blk.Signer)
+
+		body, code = httpGet(t, ts.URL+"/transactions/"+trx.ID().String())
+		require.Equal(t, http.StatusOK, code)
+		assert.Contains(t, string(body), `"origin":"`+sender.Address.Checksumed()+`"`)
+		assert.Contains(t, string(body), `"to":"`+recipient.Address.Checksumed()+`"`)
+
+		var txn transactions.Transaction
+		require.NoError(t, json.Unmarshal(body, &txn))
+		assert.Equal(t, sender.Address, txn.Origin)
+		assert.Equal(t, recipient.Address, *txn.Clauses[0].To)
+	})
+
+	t.Run("requests", func(t *testing.T) {
+		inspect := func(to string) (string, int) {
+			res, err := http.Post(ts.URL+"/accounts/*", "application/json",
+				strings.NewReader(`{"clauses":[{"to":"`+to+`","value":"0x0","data":"0x"}]}`))
+			require.NoError(t, err)
+			defer res.Body.Close()
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			return string(body), res.StatusCode
+		}
+
+		checksumed := recipient.Address.Checksumed()
+		_, code := inspect(checksumed)
+		assert.Equal(t, http.StatusOK, code)
+		_, code = inspect(strings.ToLower(checksumed))
+		assert.Equal(t, http.StatusOK, code)
+
+		// flip the case of the last letter
+		invalid := []byte(checksumed)
+		for i := len(invalid) - 1; i >= 2; i-- {
+			if c := invalid[i]; c >= 'a' && c <= 'f' {
+				invalid[i] = c - 'a' + 'A'
+				break
+			} else if c >= 'A' && c <= 'F' {
+				invalid[i] = c - 'A' + 'a'
+				break
+			}
+		}
+		body, code := inspect(string(invalid))
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Contains(t, body, "invalid checksum")
+	})
+}
+
+func TestRevisionResolution(t *testing.T) {
+	thorChain, err := testchain.NewIntegrationTestChain()
+	require.NoError(t, err)
+
+	sender := genesis.DevAccounts()[0]
+	trx := tx.MustSign(new(tx.Builder).
+		ChainTag(thorChain.Repo().ChainTag()).
+		Expiration(100).
+		Gas(21000).
+		Nonce(1).
+		Clause(tx.NewClause(&genesis.DevAccounts()[1].Address)).
+		Build(), sender.PrivateKey)
+	require.NoError(t, thorChain.MintTransactions(sender, trx))
+	best := thorChain.Repo().BestBlockSummary().Header
+
+	txPool := txpool.New(thorChain.Repo(), thorChain.Stater(), txpool.Options{
+		Limit:           100,
+		LimitPerAccount: 16,
+		MaxLifetime:     time.Hour,
+	})
+	defer txPool.Close()
+
+	handler, closer := New(thorChain.Repo(), thorChain.Stater(), txPool, thorChain.LogDB(), thorChain.Engine(), nil, thorChain.GetForkConfig(), Config{
+		BacktraceLimit:  10,
+		CallGasLimit:    10_000_000,
+		LogsLimit:       100,
+		AllowedTracers:  []string{"all"},
+		EnableReqLogger: &atomic.Bool{},
+	})
+	defer closer()
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	type endpoint struct {
+		param          string // the name of the param in error messages
+		allowNext      bool
+		nullIfNotFound bool
+		request        func(rev string) (method, path, body string)
+	}
+	get := func(path string) func(string) (string, string, string) {
+		return func(rev string) (string, string, string) { return http.MethodGet, path + rev, "" }
+	}
+	post := func(path, body string) func(string) (string, string, string) {
+		return func(rev string) (string, string, string) { return http.MethodPost, path + rev, body }
+	}
+	addr := sender.Address.String()
+	endpoints := map[string]endpoint{
+		"GET /accounts/{address}":               {param: "revision", request: get("/accounts/" + addr + "?revision=")},
+		"GET /accounts/{address}/code":          {param: "revision", request: get("/accounts/" + addr + "/code?revision=")},
+		"GET /accounts/{address}/storage/{key}": {param: "revision", request: get("/accounts/" + addr + "/storage/" + thor.Bytes32{}.String() + "?revision=")},
+		"POST /accounts/*":                      {param: "revision", allowNext: true, request: post("/accounts/*?revision=", `{"clauses":[]}`)},
+		"GET /blocks/{revision}":                {param: "revision", nullIfNotFound: true, request: get("/blocks/")},
+		"GET /blocks/{revision}/forks":          {param: "revision", nullIfNotFound: true, request: func(rev string) (string, string, string) { return http.MethodGet, "/blocks/" + rev + "/forks", "" }},
+		"GET /transactions/{id}":                {param: "head", request: get("/transactions/" + trx.ID().String() + "?head=")},
+		"GET /transactions/{id}/receipt":        {param: "head", request: get("/transactions/" + trx.ID().String() + "/receipt?head=")},
+		"POST /debug/tracers/call":              {param: "revision", allowNext: true, request: post("/debug/tracers/call?revision=", `{"name":"structLogger"}`)},
+		"POST /debug/account-range": {param: "revision", request: func(rev string) (string, string, string) {
+			return http.MethodPost, "/debug/account-range", `{"threshold":"0x1","acknowledgeFullScan":true,"revision":"` + rev + `"}`
+		}},
+	}
+
+	do := func(method, path, body string) (string, int) {
+		req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		data, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		return strings.TrimSpace(string(data)), res.StatusCode
+	}
+
+	for name, ep := range endpoints {
+		t.Run(name, func(t *testing.T) {
+			for _, rev := range []string{"best", "1", best.ID().String(), "justified", "finalized"} {
+				body, code := do(ep.request(rev))
+				assert.Equal(t, http.StatusOK, code, "%s: %s", rev, body)
+			}
+
+			body, code := do(ep.request("next"))
+			if ep.allowNext {
+				assert.Equal(t, http.StatusOK, code, body)
+			} else {
+				assert.Equal(t, http.StatusBadRequest, code)
+				assert.Equal(t, ep.param+": invalid revision: next is not allowed", body)
+			}
+
+			body, code = do(ep.request("abc"))
+			assert.Equal(t, http.StatusBadRequest, code)
+			assert.Equal(t, ep.param+`: strconv.ParseUint: parsing "abc": invalid syntax`, body)
+
+			for _, rev := range []string{"100", thor.Bytes32{0xff}.String()} {
+				body, code = do(ep.request(rev))
+				if ep.nullIfNotFound {
+					assert.Equal(t, http.StatusOK, code, rev)
+					assert.Equal(t, "null", body, rev)
+				} else {
+					assert.Equal(t, http.StatusBadRequest, code, rev)
+					assert.Equal(t, ep.param+": not found", body, rev)
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultFinality(t *testing.T) {
+	assert.NoError(t, ValidateDefaultFinality(""))
+	assert.NoError(t, ValidateDefaultFinality(FinalityBest))
+	assert.NoError(t, ValidateDefaultFinality(FinalityFinalized))
+	assert.Error(t, ValidateDefaultFinality("justified"))
+
+	thorChain, err := testchain.NewIntegrationTestChain()
+	require.NoError(t, err)
+
+	sender, recipient := genesis.DevAccounts()[0], thor.BytesToAddress([]byte("recipient"))
+	trx := tx.MustSign(new(tx.Builder).
+		ChainTag(thorChain.Repo().ChainTag()).
+		Expiration(100).
+		Gas(21000).
+		Nonce(1).
+		Clause(tx.NewClause(&recipient).WithValue(big.NewInt(1))).
+		Build(), sender.PrivateKey)
+	require.NoError(t, thorChain.MintTransactions(sender, trx))
+	best := thorChain.Repo().BestBlockSummary().Header
+	finalized := thorChain.Engine().Finalized()
+	require.NotEqual(t, best.ID(), finalized)
+
+	// the test chain doesn't write logs
+	blk, err := thorChain.BestBlock()
+	require.NoError(t, err)
+	receipts, err := thorChain.Repo().GetBlockReceipts(blk.Header().ID())
+	require.NoError(t, err)
+	w := thorChain.LogDB().NewWriter()
+	require.NoError(t, w.Write(blk, receipts))
+	require.NoError(t, w.Commit())
+
+	txPool := txpool.New(thorChain.Repo(), thorChain.Stater(), txpool.Options{
+		Limit:           100,
+		LimitPerAccount: 16,
+		MaxLifetime:     time.Hour,
+	})
+	defer txPool.Close()
+
+	serve := func(finality string) *httptest.Server {
+		handler, closer := New(thorChain.Repo(), thorChain.Stater(), txPool, thorChain.LogDB(), thorChain.Engine(), nil, thorChain.GetForkConfig(), Config{
+			BacktraceLimit:  10,
+			CallGasLimit:    10_000_000,
+			LogsLimit:       100,
+			EnableReqLogger: &atomic.Bool{},
+			DefaultFinality: finality,
+		})
+		t.Cleanup(closer)
+		ts := httptest.NewServer(handler)
+		t.Cleanup(ts.Close)
+		return ts
+	}
+	do := func(t *testing.T, ts *httptest.Server, method, path, body string) (string, string) {
+		req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		data, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode, string(data))
+		return strings.TrimSpace(string(data)), res.Header.Get(utils.ResolvedRevisionHeader)
+	}
+	balance := func(t *testing.T, body string) string {
+		var acc struct{ Balance string }
+		require.NoError(t, json.Unmarshal([]byte(body), &acc))
+		return acc.Balance
+	}
+	transfers := func(t *testing.T, body string) int {
+		var logs []json.RawMessage
+		require.NoError(t, json.Unmarshal([]byte(body), &logs))
+		return len(logs)
+	}
+	accountPath := "/accounts/" + recipient.String()
+	transferFilter := `{"criteriaSet":[{"recipient":"` + recipient.String() + `"}]}`
+
+	t.Run("best", func(t *testing.T) {
+		ts := serve(FinalityBest)
+
+		body, resolved := do(t, ts, http.MethodGet, accountPath, "")
+		assert.Equal(t, "0x1", balance(t, body))
+		assert.Equal(t, best.ID().String(), resolved)
+
+		body, resolved = do(t, ts, http.MethodPost, "/logs/transfer", transferFilter)
+		assert.Equal(t, 1, transfers(t, body))
+		assert.Equal(t, best.ID().String(), resolved)
+
+		body, resolved = do(t, ts, http.MethodGet, "/transactions/"+trx.ID().String(), "")
+		assert.Contains(t, body, trx.ID().String())
+		assert.Equal(t, best.ID().String(), resolved)
+	})
+
+	t.Run("finalized", func(t *testing.T) {
+		ts := serve(FinalityFinalized)
+
+		body, resolved := do(t, ts, http.MethodGet, accountPath, "")
+		assert.Equal(t, "0x0", balance(t, body))
+		assert.Equal(t, finalized.String(), resolved)
+
+		body, resolved = do(t, ts, http.MethodPost, "/logs/transfer", transferFilter)
+		assert.Equal(t, 0, transfers(t, body))
+		assert.Equal(t, finalized.String(), resolved)
+
+		body, resolved = do(t, ts, http.MethodGet, "/transactions/"+trx.ID().String(), "")
+		assert.Equal(t, "null", body)
+		assert.Equal(t, finalized.String(), resolved)
+
+		body, resolved = do(t, ts, http.MethodPost, "/accounts/*", `{"clauses":[]}`)
+		assert.Equal(t, "[]", body)
+		assert.Equal(t, finalized.String(), resolved)
+
+		// opt into best
+		body, resolved = do(t, ts, http.MethodGet, accountPath+"?revision=best", "")
+		assert.Equal(t, "0x1", balance(t, body))
+		assert.Equal(t, best.ID().String(), resolved)
+
+		body, resolved = do(t, ts, http.MethodPost, "/logs/transfer?revision=best", transferFilter)
+		assert.Equal(t, 1, transfers(t, body))
+		assert.Equal(t, best.ID().String(), resolved)
+
+		// tip-based requests are not affected
+		body, resolved = do(t, ts, http.MethodGet, "/transactions/"+trx.ID().String()+"?pending=true", "")
+		assert.Contains(t, body, trx.ID().String())
+		assert.Equal(t, best.ID().String(), resolved)
+
+		_, resolved = do(t, ts, http.MethodGet, accountPath+"/txpool-summary", "")
+		assert.Empty(t, resolved)
+	})
+
+	// blocks are requested by explicit revisions, and respond the block resolved to
+	for _, finality := range []string{FinalityBest, FinalityFinalized} {
+		t.Run("blocks "+finality, func(t *testing.T) {
+			ts := serve(finality)
+
+			for _, tt := range []struct {
+				revision string
+				id       thor.Bytes32
+			}{
+				{"best", best.ID()},
+				{"finalized", finalized},
+				{"1", best.ID()},
+				{best.ID().String(), best.ID()},
+			} {
+				body, resolved := do(t, ts, http.MethodGet, "/blocks/"+tt.revision, "")
+				assert.Contains(t, body, tt.id.String())
+				assert.Equal(t, tt.id.String(), resolved)
+			}
+
+			// no block resolved
+			body, resolved := do(t, ts, http.MethodGet, "/blocks/100", "")
+			assert.Equal(t, "null", body)
+			assert.Empty(t, resolved)
+		})
+	}
+}
@@ -9,7 +9,9 @@ import (
 	"encoding/hex"
 	"fmt"
 	"net/http"
+	"strconv"
 
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
@@ -20,44 +22,89 @@ import (
 	"github.com/vechain/thor/v2/thor"
 )
 
+// maxGasHistoryBlocks is the max count of blocks of a gas history query.
+const maxGasHistoryBlocks = 1024
+
 type Blocks struct {
-	repo *chain.Repository
-	bft  bft.Committer
+	repo       *chain.Repository
+	bft        bft.Committer
+	forkConfig thor.ForkConfig
 }
 
-func New(repo *chain.Repository, bft bft.Committer) *Blocks {
+func New(repo *chain.Repository, bft bft.Committer, forkConfig thor.ForkConfig) *Blocks {
 	return &Blocks{
 		repo,
 		bft,
+		forkConfig,
 	}
 }
 
-func (b *Blocks) handleGetBlock(w http.ResponseWriter, req *http.Request) error {
-	revision, err := utils.ParseRevision(mux.Vars(req)["revision"], false)
-	if err != nil {
-		return utils.BadRequest(errors.WithMessage(err, "revision"))
-	}
-	raw, err := utils.StringToBoolean(req.URL.Query().Get("raw"), false)
+// parseBlockFormat parses the query params deciding the format of the block in responses.
+func parseBlockFormat(req *http.Request) (raw bool, expanded bool, err error) {
+	raw, err = utils.StringToBoolean(req.URL.Query().Get("raw"), false)
 	if err != nil {
-		return utils.BadRequest(errors.WithMessage(err, "raw"))
+		return false, false, utils.BadRequest(errors.WithMessage(err, "raw"))
 	}
-	expanded, err := utils.StringToBoolean(req.URL.Query().Get("expanded"), false)
+	expanded, err = utils.StringToBoolean(req.URL.Query().Get("expanded"), false)
 	if err != nil {
-		return utils.BadRequest(errors.WithMessage(err, "expanded"))
+		return false, false, utils.BadRequest(errors.WithMessage(err, "expanded"))
 	}
 
 	if raw && expanded {
-		return utils.BadRequest(errors.WithMessage(errors.New("Raw and Expanded are mutually exclusive"), "raw&expanded"))
+		return false, false, utils.BadRequest(errors.WithMessage(errors.New("Raw and Expanded are mutually exclusive"), "raw&expanded"))
+	}
+	return
+}
+
+func (b *Blocks) handleGetBlock(w http.ResponseWriter, req *http.Request) error {
+	revision, err := utils.ParseRevision(req.Context(), mux.Vars(req)["revision"], false)
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "revision"))
+	}
+	raw, expanded, err := parseBlockFormat(req)
+	if err != nil {
+		return err
 	}
 
 	summary, err := utils.GetSummary(revision, b.repo, b.bft)
 	if err != nil {
-		if b.repo.IsNotFound(err) {
+		if utils.IsRevisionNotFound(err) {
 			return utils.WriteJSON(w, nil)
 		}
 		return err
 	}
+	return b.writeBlock(w, summary, raw, expanded)
+}
 
+// handleGetBlockByTimestamp responds the block of the best chain at or just before the timestamp,
+// or null if the timestamp is before the genesis block.
+func (b *Blocks) handleGetBlockByTimestamp(w http.ResponseWriter, req *http.Request) error {
+	timestamp, err := strconv.ParseUint(mux.Vars(req)["timestamp"], 10, 64)
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "timestamp"))
+	}
+	raw, expanded, err := parseBlockFormat(req)
+	if err != nil {
+		return err
+	}
+
+	// block timestamps are monotonic along the chain
+	header, err := b.repo.NewBestChain().FindBlockHeaderByTimestamp(timestamp, -1)
+	if err != nil {
+		return err
+	}
+	if header.Timestamp() > timestamp {
+		return utils.WriteJSON(w, nil)
+	}
+
+	summary, err := b.repo.GetBlockSummary(header.ID())
+	if err != nil {
+		return err
+	}
+	return b.writeBlock(w, summary, raw, expanded)
+}
+
+func (b *Blocks) writeBlock(w http.ResponseWriter, summary *chain.BlockSummary, raw, expanded bool) error {
 	if raw {
 		rlpEncoded, err := rlp.EncodeToBytes(summary.Header)
 		if err != nil {
@@ -87,10 +134,12 @@ func (b *Blocks) handleGetBlock(w http.ResponseWriter, req *http.Request) error
 		if err != nil {
 			return err
 		}
+		// the receipts are read for the expanded txs anyway, a collapsed block doesn't pay for them
 		receipts, err := b.repo.GetBlockReceipts(summary.Header.ID())
 		if err != nil {
 			return err
 		}
+		jSummary.BurnedFee = (*math.HexOrDecimal256)(receipts.BurnedFee())
 
 		return utils.WriteJSON(w, &JSONExpandedBlock{
 			jSummary,
@@ -104,6 +153,66 @@ func (b *Blocks) handleGetBlock(w http.ResponseWriter, req *http.Request) error
 	})
 }
 
+func (b *Blocks) handleGetBlockForks(w http.ResponseWriter, req *http.Request) error {
+	revision, err := utils.ParseRevision(req.Context(), mux.Vars(req)["revision"], false)
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "revision"))
+	}
+
+	summary, err := utils.GetSummary(revision, b.repo, b.bft)
+	if err != nil {
+		if utils.IsRevisionNotFound(err) {
+			return utils.WriteJSON(w, nil)
+		}
+		return err
+	}
+
+	return utils.WriteJSON(w, buildJSONBlockForks(summary.Header, b.forkConfig))
+}
+
+func (b *Blocks) handleGetGasHistory(w http.ResponseWriter, req *http.Request) error {
+	revision, err := utils.ParseRevision(req.Context(), mux.Vars(req)["revision"], false)
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "revision"))
+	}
+	blockCount, err := strconv.ParseUint(req.URL.Query().Get("blockCount"), 10, 32)
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "blockCount"))
+	}
+	if blockCount == 0 || blockCount > maxGasHistoryBlocks {
+		return utils.BadRequest(errors.WithMessage(fmt.Errorf("should be in range [1, %d]", maxGasHistoryBlocks), "blockCount"))
+	}
+
+	summary, err := utils.GetSummary(revision, b.repo, b.bft)
+	if err != nil {
+		if utils.IsRevisionNotFound(err) {
+			return utils.WriteJSON(w, nil)
+		}
+		return err
+	}
+
+	// not more than the blocks since genesis
+	count := min(uint32(blockCount), summary.Header.Number()+1)
+	history := &JSONGasHistory{
+		OldestBlock:  summary.Header.Number() + 1 - count,
+		GasUsed:      make([]uint64, count),
+		GasLimit:     make([]uint64, count),
+		GasUsedRatio: make([]float64, count),
+	}
+	for i := int(count) - 1; i >= 0; i-- {
+		header := summary.Header
+		history.GasUsed[i] = header.GasUsed()
+		history.GasLimit[i] = header.GasLimit()
+		history.GasUsedRatio[i] = float64(header.GasUsed()) / float64(header.GasLimit())
+		if i > 0 {
+			if summary, err = b.repo.GetBlockSummary(header.ParentID()); err != nil {
+				return err
+			}
+		}
+	}
+	return utils.WriteJSON(w, history)
+}
+
 func (b *Blocks) isTrunk(blkID thor.Bytes32, blkNum uint32) (bool, error) {
 	idByNum, err := b.repo.NewBestChain().GetBlockID(blkNum)
 	if err != nil {
@@ -114,8 +223,20 @@ func (b *Blocks) isTrunk(blkID thor.Bytes32, blkNum uint32) (bool, error) {
 
 func (b *Blocks) Mount(root *mux.Router, pathPrefix string) {
 	sub := root.PathPrefix(pathPrefix).Subrouter()
+	sub.Path("/timestamp/{timestamp}").
+		Methods(http.MethodGet).
+		Name("GET /blocks/timestamp/{timestamp}").
+		HandlerFunc(utils.WrapHandlerFunc(b.handleGetBlockByTimestamp))
 	sub.Path("/{revision}").
 		Methods(http.MethodGet).
 		Name("GET /blocks/{revision}").
 		HandlerFunc(utils.WrapHandlerFunc(b.handleGetBlock))
+	sub.Path("/{revision}/forks").
+		Methods(http.MethodGet).
+		Name("GET /blocks/{revision}/forks").
+		HandlerFunc(utils.WrapHandlerFunc(b.handleGetBlockForks))
+	sub.Path("/{revision}/gas-history").
+		Methods(http.MethodGet).
+		Name("GET /blocks/{revision}/gas-history").
+		HandlerFunc(utils.WrapHandlerFunc(b.handleGetGasHistory))
 }
@@ -8,6 +8,7 @@ package blocks_test
 import (
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"math"
 	"math/big"
 	"net/http"
@@ -26,6 +27,7 @@ import (
 	"github.com/vechain/thor/v2/test/testchain"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/thorclient"
+	tccommon "github.com/vechain/thor/v2/thorclient/common"
 	"github.com/vechain/thor/v2/tx"
 )
 
@@ -59,6 +61,10 @@ func TestBlock(t *testing.T) {
 		"testGetBlockWithRevisionNumberTooHigh": testGetBlockWithRevisionNumberTooHigh,
 		"testMutuallyExclusiveQueries":          testMutuallyExclusiveQueries,
 		"testGetRawBlock":                       testGetRawBlock,
+		"testGetBlockForks":                     testGetBlockForks,
+		"testGetGasHistory":                     testGetGasHistory,
+		"testGetBlockByTimestamp":               testGetBlockByTimestamp,
+		"testGetBlockBurnedFee":                 testGetBlockBurnedFee,
 	} {
 		t.Run(name, tt)
 	}
@@ -252,10 +258,136 @@ func initBlockServer(t *testing.T) {
 	blk = allBlocks[1]
 
 	router := mux.NewRouter()
-	blocks.New(thorChain.Repo(), thorChain.Engine()).Mount(router, "/blocks")
+	forkConfig := thor.NoFork
+	forkConfig.BLOCKLIST = 0
+	forkConfig.VIP191 = 1
+	forkConfig.VIP214 = 2
+	blocks.New(thorChain.Repo(), thorChain.Engine(), forkConfig).Mount(router, "/blocks")
 	ts = httptest.NewServer(router)
 }
 
+func testGetBlockForks(t *testing.T) {
+	// the API is configured to activate BLOCKLIST at #0, VIP191 at #1 and VIP214 at #2
+	forks, err := tclient.BlockForks("0")
+	require.NoError(t, err)
+	assert.Equal(t, &blocks.JSONBlockForks{
+		Number:    0,
+		ID:        genesisBlock.Header().ID(),
+		Blocklist: true,
+	}, forks)
+
+	forks, err = tclient.BlockForks(blk.Header().ID().String())
+	require.NoError(t, err)
+	assert.Equal(t, &blocks.JSONBlockForks{
+		Number:    1,
+		ID:        blk.Header().ID(),
+		VIP191:    true,
+		Blocklist: true,
+	}, forks)
+
+	_, err = tclient.BlockForks(strconv.Itoa(math.MaxInt32))
+	assert.ErrorIs(t, err, tccommon.ErrNotFound)
+
+	res, statusCode, err := tclient.RawHTTPClient().RawHTTPGet("/blocks/" + invalidBytes32 + "/forks")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+	assert.Contains(t, string(res), "revision")
+}
+
+func testGetGasHistory(t *testing.T) {
+	history, err := tclient.BlockGasHistory("best", 10)
+	require.NoError(t, err)
+	assert.Equal(t, &blocks.JSONGasHistory{
+		OldestBlock:  0,
+		GasUsed:      []uint64{0, blk.Header().GasUsed()},
+		GasLimit:     []uint64{genesisBlock.Header().GasLimit(), blk.Header().GasLimit()},
+		GasUsedRatio: []float64{0, float64(blk.Header().GasUsed()) / float64(blk.Header().GasLimit())},
+	}, history)
+	assert.Equal(t, uint64(21000), history.GasUsed[1])
+
+	history, err = tclient.BlockGasHistory(blk.Header().ID().String(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), history.OldestBlock)
+	assert.Equal(t, []uint64{blk.Header().GasUsed()}, history.GasUsed)
+
+	_, err = tclient.BlockGasHistory(strconv.Itoa(math.MaxInt32), 1)
+	assert.ErrorIs(t, err, tccommon.ErrNotFound)
+
+	for _, query := range []string{"", "?blockCount=0", "?blockCount=1025", "?blockCount=abc"} {
+		res, statusCode, err := tclient.RawHTTPClient().RawHTTPGet("/blocks/best/gas-history" + query)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Contains(t, string(res), "blockCount")
+	}
+}
+
+func testGetBlockByTimestamp(t *testing.T) {
+	genesisTime := genesisBlock.Header().Timestamp()
+	blkTime := blk.Header().Timestamp()
+
+	for _, tt := range []struct {
+		timestamp uint64
+		want      *block.Block
+	}{
+		{genesisTime, genesisBlock},
+		{blkTime - 1, genesisBlock},
+		{blkTime, blk},
+		// after the best block
+		{blkTime + 1000, blk},
+	} {
+		res, err := tclient.BlockByTimestamp(tt.timestamp)
+		require.NoError(t, err)
+		checkCollapsedBlock(t, tt.want, res)
+	}
+
+	// before the genesis block
+	_, err := tclient.BlockByTimestamp(genesisTime - 1)
+	assert.ErrorIs(t, err, tccommon.ErrNotFound)
+
+	res, statusCode, err := tclient.RawHTTPClient().RawHTTPGet(fmt.Sprintf("/blocks/timestamp/%d?expanded=true", blkTime))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	var expanded blocks.JSONExpandedBlock
+	require.NoError(t, json.Unmarshal(res, &expanded))
+	assert.Equal(t, blk.Header().ID(), expanded.ID)
+	assert.Len(t, expanded.Transactions, 1)
+
+	for _, path := range []string{"/blocks/timestamp/abc", "/blocks/timestamp/-1", fmt.Sprintf("/blocks/timestamp/%d?raw=true&expanded=true", blkTime)} {
+		_, statusCode, err = tclient.RawHTTPClient().RawHTTPGet(path)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, statusCode, path)
+	}
+}
+
+func testGetBlockBurnedFee(t *testing.T) {
+	expanded, err := tclient.ExpandedBlock(blk.Header().ID().String())
+	require.NoError(t, err)
+	require.Len(t, expanded.Transactions, 1)
+
+	tx := expanded.Transactions[0]
+	paid, reward := (*big.Int)(tx.Paid), (*big.Int)(tx.Reward)
+	require.Positive(t, paid.Sign())
+	// 30% of paid is rewarded to the block proposer, the rest is burned
+	assert.Equal(t, new(big.Int).Div(new(big.Int).Mul(paid, big.NewInt(3)), big.NewInt(10)), reward)
+	assert.Equal(t, new(big.Int).Sub(paid, reward), (*big.Int)(tx.BurnedFee))
+	assert.Equal(t, reward, (*big.Int)(tx.PriorityFee))
+	assert.Equal(t, tx.BurnedFee, expanded.BurnedFee)
+
+	// not summed for collapsed blocks
+	collapsed, err := tclient.Block(blk.Header().ID().String())
+	require.NoError(t, err)
+	assert.Nil(t, collapsed.BurnedFee)
+	res, statusCode, err := tclient.RawHTTPClient().RawHTTPGet("/blocks/" + blk.Header().ID().String())
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, statusCode)
+	assert.NotContains(t, string(res), "burnedFee")
+
+	// no tx in the genesis block
+	expanded, err = tclient.ExpandedBlock("0")
+	require.NoError(t, err)
+	assert.Zero(t, (*big.Int)(expanded.BurnedFee).Sign())
+}
+
 func checkCollapsedBlock(t *testing.T, expBl *block.Block, actBl *blocks.JSONCollapsedBlock) {
 	header := expBl.Header()
 	assert.Equal(t, header.Number(), actBl.Number, "Number should be equal")
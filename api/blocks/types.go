@@ -8,6 +8,7 @@ package blocks
 import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/vechain/thor/v2/block"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/tx"
@@ -31,6 +32,8 @@ type JSONBlockSummary struct {
 	Signer       thor.Address `json:"signer"`
 	IsTrunk      bool         `json:"isTrunk"`
 	IsFinalized  bool         `json:"isFinalized"`
+	// energy burned by the txs in the block, net of the rewards to the block proposer, only of expanded blocks
+	BurnedFee *math.HexOrDecimal256 `json:"burnedFee,omitempty"`
 }
 
 type JSONRawBlockSummary struct {
@@ -81,12 +84,14 @@ type JSONEmbeddedTx struct {
 	Size         uint32              `json:"size"`
 
 	// receipt part
-	GasUsed  uint64                `json:"gasUsed"`
-	GasPayer thor.Address          `json:"gasPayer"`
-	Paid     *math.HexOrDecimal256 `json:"paid"`
-	Reward   *math.HexOrDecimal256 `json:"reward"`
-	Reverted bool                  `json:"reverted"`
-	Outputs  []*JSONOutput         `json:"outputs"`
+	GasUsed     uint64                `json:"gasUsed"`
+	GasPayer    thor.Address          `json:"gasPayer"`
+	Paid        *math.HexOrDecimal256 `json:"paid"`
+	Reward      *math.HexOrDecimal256 `json:"reward"`
+	BurnedFee   *math.HexOrDecimal256 `json:"burnedFee"`
+	PriorityFee *math.HexOrDecimal256 `json:"priorityFee"`
+	Reverted    bool                  `json:"reverted"`
+	Outputs     []*JSONOutput         `json:"outputs"`
 }
 
 type JSONExpandedBlock struct {
@@ -94,6 +99,41 @@ type JSONExpandedBlock struct {
 	Transactions []*JSONEmbeddedTx `json:"transactions"`
 }
 
+// JSONBlockForks indicates which forks are active at a block.
+type JSONBlockForks struct {
+	Number    uint32       `json:"number"`
+	ID        thor.Bytes32 `json:"id"`
+	VIP191    bool         `json:"vip191"`
+	ETHConst  bool         `json:"ethConst"`
+	Blocklist bool         `json:"blocklist"`
+	ETHIst    bool         `json:"ethIst"`
+	VIP214    bool         `json:"vip214"`
+	Finality  bool         `json:"finality"`
+}
+
+// JSONGasHistory is the gas usage of a range of blocks, in ascending order of block number.
+type JSONGasHistory struct {
+	OldestBlock  uint32    `json:"oldestBlock"`
+	GasUsed      []uint64  `json:"gasUsed"`
+	GasLimit     []uint64  `json:"gasLimit"`
+	GasUsedRatio []float64 `json:"gasUsedRatio"`
+}
+
+func buildJSONBlockForks(header *block.Header, forkConfig thor.ForkConfig) *JSONBlockForks {
+	flags := forkConfig.FlagsAt(header.Number())
+
+	return &JSONBlockForks{
+		Number:    header.Number(),
+		ID:        header.ID(),
+		VIP191:    flags.VIP191,
+		ETHConst:  flags.ETH_CONST,
+		Blocklist: flags.BLOCKLIST,
+		ETHIst:    flags.ETH_IST,
+		VIP214:    flags.VIP214,
+		Finality:  flags.FINALITY,
+	}
+}
+
 func buildJSONBlockSummary(summary *chain.BlockSummary, isTrunk bool, isFinalized bool) *JSONBlockSummary {
 	header := summary.Header
 	signer, _ := header.Signer()
@@ -184,12 +224,14 @@ func buildJSONEmbeddedTxs(txs tx.Transactions, receipts tx.Receipts) []*JSONEmbe
 			DependsOn:    tx.DependsOn(),
 			Size:         uint32(tx.Size()),
 
-			GasUsed:  receipt.GasUsed,
-			GasPayer: receipt.GasPayer,
-			Paid:     (*math.HexOrDecimal256)(receipt.Paid),
-			Reward:   (*math.HexOrDecimal256)(receipt.Reward),
-			Reverted: receipt.Reverted,
-			Outputs:  jos,
+			GasUsed:     receipt.GasUsed,
+			GasPayer:    receipt.GasPayer,
+			Paid:        (*math.HexOrDecimal256)(receipt.Paid),
+			Reward:      (*math.HexOrDecimal256)(receipt.Reward),
+			BurnedFee:   (*math.HexOrDecimal256)(receipt.BurnedFee()),
+			PriorityFee: (*math.HexOrDecimal256)(receipt.Reward),
+			Reverted:    receipt.Reverted,
+			Outputs:     jos,
 		})
 	}
 	return jTxs
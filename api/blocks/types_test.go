@@ -0,0 +1,88 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package blocks
+
+import (
+	"flag"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/api/utils"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files")
+
+// assertGolden checks the response body of the value is byte-exact to the golden file.
+func assertGolden(t *testing.T, name string, v interface{}) {
+	rec := httptest.NewRecorder()
+	require.NoError(t, utils.WriteJSON(rec, v))
+
+	path := filepath.Join("testdata", name+".golden.json")
+	if *updateGolden {
+		require.NoError(t, os.MkdirAll("testdata", 0o755))
+		require.NoError(t, os.WriteFile(path, rec.Body.Bytes(), 0o600))
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), rec.Body.String())
+}
+
+func TestEncodingGolden(t *testing.T) {
+	acc := genesis.DevAccounts()[0]
+	to := thor.BytesToAddress([]byte("to"))
+	trx := tx.MustSign(new(tx.Builder).
+		ChainTag(0x27).
+		BlockRef(tx.NewBlockRef(100)).
+		Expiration(720).
+		Clause(tx.NewClause(&to).WithValue(big.NewInt(1e18))).
+		GasPriceCoef(128).
+		Gas(21000).
+		Nonce(0x1234).
+		Build(), acc.PrivateKey)
+	blk := new(block.Builder).
+		ParentID(thor.BytesToBytes32([]byte("parent"))).
+		Timestamp(1700000000).
+		GasLimit(40_000_000).
+		GasUsed(21000).
+		TotalScore(101).
+		Beneficiary(acc.Address).
+		StateRoot(thor.BytesToBytes32([]byte("state"))).
+		ReceiptsRoot(thor.BytesToBytes32([]byte("receipts"))).
+		TransactionFeatures(tx.DelegationFeature).
+		Transaction(trx).
+		Build()
+	sig, err := crypto.Sign(blk.Header().SigningHash().Bytes(), acc.PrivateKey)
+	require.NoError(t, err)
+	blk = blk.WithSignature(sig)
+	receipts := tx.Receipts{{
+		GasUsed:  21000,
+		GasPayer: acc.Address,
+		Paid:     big.NewInt(315_000_000_000_000_000),
+		Reward:   big.NewInt(94_500_000_000_000_000),
+		Outputs: []*tx.Output{{
+			Transfers: tx.Transfers{{Sender: acc.Address, Recipient: to, Amount: big.NewInt(1e18)}},
+		}},
+	}}
+
+	summary := &chain.BlockSummary{Header: blk.Header(), Txs: []thor.Bytes32{trx.ID()}, Size: uint64(blk.Size())}
+	jSummary := buildJSONBlockSummary(summary, true, false)
+	jSummary.BurnedFee = (*math.HexOrDecimal256)(receipts.BurnedFee())
+
+	assertGolden(t, "collapsed_block", &JSONCollapsedBlock{jSummary, summary.Txs})
+	assertGolden(t, "expanded_block", &JSONExpandedBlock{jSummary, buildJSONEmbeddedTxs(blk.Transactions(), receipts)})
+}
@@ -0,0 +1,288 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package api
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+)
+
+const (
+	encodingZstd   = "zstd"
+	encodingGzip   = "gzip"
+	encodingBrotli = "br"
+
+	// compressMinSize is the minimum size of a response worth compressing.
+	compressMinSize = 1024
+)
+
+// Compression modes of the API responses.
+const (
+	CompressionGzip   = "gzip"
+	CompressionBrotli = "brotli"
+	CompressionZstd   = "zstd"
+	CompressionNone   = "none"
+)
+
+// compressionEncodings maps the compression modes to the content encodings.
+var compressionEncodings = map[string]string{
+	CompressionGzip:   encodingGzip,
+	CompressionBrotli: encodingBrotli,
+	CompressionZstd:   encodingZstd,
+}
+
+// supportedEncodings in the order of preference.
+var supportedEncodings = []string{encodingZstd, encodingBrotli, encodingGzip}
+
+// ParseCompression parses the comma separated compression modes allowed for the responses,
+// or none alone to turn compression off.
+func ParseCompression(modes string) ([]string, error) {
+	if strings.TrimSpace(modes) == CompressionNone {
+		return []string{CompressionNone}, nil
+	}
+	var parsed []string
+	for _, mode := range strings.Split(modes, ",") {
+		mode = strings.TrimSpace(mode)
+		if _, ok := compressionEncodings[mode]; !ok {
+			return nil, errors.Errorf("unsupported compression %q, should be gzip, brotli, zstd or none alone", mode)
+		}
+		parsed = append(parsed, mode)
+	}
+	return parsed, nil
+}
+
+// allowedEncodings returns the supported encodings of the compression modes in the order of preference,
+// all of them if no mode is given, and none for CompressionNone.
+func allowedEncodings(modes []string) []string {
+	if len(modes) == 0 {
+		return supportedEncodings
+	}
+	allowed := make(map[string]bool)
+	for _, mode := range modes {
+		allowed[compressionEncodings[mode]] = true
+	}
+	var encodings []string
+	for _, enc := range supportedEncodings {
+		if allowed[enc] {
+			encodings = append(encodings, enc)
+		}
+	}
+	return encodings
+}
+
+// compressibleTypes are the prefixes of content types to be compressed.
+var compressibleTypes = []string{"application/json", "application/x-ndjson", "text/"}
+
+// encoder is the common interface of the pooled gzip, brotli and zstd writers.
+type encoder interface {
+	io.WriteCloser
+	Reset(w io.Writer)
+	Flush() error
+}
+
+var encoderPools = map[string]*sync.Pool{
+	encodingZstd: {New: func() interface{} {
+		enc, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1), zstd.WithEncoderLevel(zstd.SpeedDefault))
+		return enc
+	}},
+	encodingGzip: {New: func() interface{} {
+		return gzip.NewWriter(nil)
+	}},
+	encodingBrotli: {New: func() interface{} {
+		return brotli.NewWriter(nil)
+	}},
+}
+
+// negotiateEncoding returns the preferred one of the given encodings accepted by the client, or empty string if none.
+// Encodings not listed explicitly take the weight of the wildcard, if any.
+func negotiateEncoding(acceptEncoding string, encodings ...string) string {
+	weights := make(map[string]float64)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		weights[name] = q
+	}
+
+	var (
+		best  string
+		bestQ float64
+	)
+	for _, enc := range encodings {
+		q, ok := weights[enc]
+		if !ok {
+			q = weights["*"]
+		}
+		if q > bestQ {
+			best, bestQ = enc, q
+		}
+	}
+	return best
+}
+
+func isCompressible(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	for _, t := range compressibleTypes {
+		if strings.HasPrefix(contentType, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// compressResponseWriter buffers the response until it's large enough to be compressed.
+// Responses not compressible or smaller than compressMinSize are written as is.
+type compressResponseWriter struct {
+	http.ResponseWriter
+	encoding string
+	status   int
+	buf      []byte
+	decided  bool
+	enc      encoder
+}
+
+func (c *compressResponseWriter) WriteHeader(code int) {
+	if c.decided {
+		c.ResponseWriter.WriteHeader(code)
+		return
+	}
+	c.status = code
+	// no body to compress
+	if code == http.StatusNoContent || code == http.StatusNotModified {
+		c.passThrough()
+	}
+}
+
+func (c *compressResponseWriter) Write(p []byte) (int, error) {
+	if !c.decided {
+		c.buf = append(c.buf, p...)
+		if len(c.buf) < compressMinSize {
+			return len(p), nil
+		}
+		if err := c.decide(true); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+	if c.enc != nil {
+		return c.enc.Write(p)
+	}
+	return c.ResponseWriter.Write(p)
+}
+
+// Flush sends the buffered data to the client. A streamed response is compressed regardless of its size.
+func (c *compressResponseWriter) Flush() {
+	if !c.decided {
+		if err := c.decide(true); err != nil {
+			return
+		}
+	}
+	if c.enc != nil {
+		if err := c.enc.Flush(); err != nil {
+			return
+		}
+	}
+	if f, ok := c.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// decide writes the header and the buffered data, compressed if allowed and the content type is compressible.
+func (c *compressResponseWriter) decide(compress bool) error {
+	header := c.Header()
+	if header.Get("Content-Type") == "" {
+		header.Set("Content-Type", http.DetectContentType(c.buf))
+	}
+	if !compress || header.Get("Content-Encoding") != "" || !isCompressible(header.Get("Content-Type")) {
+		return c.passThrough()
+	}
+
+	c.decided = true
+	header.Del("Content-Length")
+	header.Set("Content-Encoding", c.encoding)
+	c.ResponseWriter.WriteHeader(c.status)
+
+	c.enc = encoderPools[c.encoding].Get().(encoder)
+	c.enc.Reset(c.ResponseWriter)
+
+	buf := c.buf
+	c.buf = nil
+	_, err := c.enc.Write(buf)
+	return err
+}
+
+func (c *compressResponseWriter) passThrough() error {
+	c.decided = true
+	c.ResponseWriter.WriteHeader(c.status)
+
+	buf := c.buf
+	c.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	_, err := c.ResponseWriter.Write(buf)
+	return err
+}
+
+// close completes the response and recycles the encoder.
+func (c *compressResponseWriter) close() {
+	if !c.decided {
+		// too small to be compressed
+		_ = c.decide(false)
+	}
+	if c.enc != nil {
+		_ = c.enc.Close()
+		encoderPools[c.encoding].Put(c.enc)
+		c.enc = nil
+	}
+}
+
+// compressHandler compresses the responses with the preferred one of the encodings accepted by the
+// Accept-Encoding header. WebSocket upgrades and requests to the paths with the given prefixes are served as is.
+func compressHandler(next http.Handler, encodings []string, skipPrefixes ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Upgrade") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		for _, prefix := range skipPrefixes {
+			if strings.HasPrefix(r.URL.Path, prefix) {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+
+		w.Header().Add("Vary", "Accept-Encoding")
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"), encodings...)
+		if encoding == "" || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressResponseWriter{ResponseWriter: w, encoding: encoding, status: http.StatusOK}
+		defer cw.close()
+		next.ServeHTTP(cw, r)
+	})
+}
@@ -0,0 +1,374 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/txpool"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		acceptEncoding string
+		want           string
+	}{
+		{"", ""},
+		{"identity", ""},
+		{"deflate", ""},
+		{"br", "br"},
+		{"gzip", "gzip"},
+		{"zstd", "zstd"},
+		{"gzip, deflate, br, zstd", "zstd"},
+		{"gzip, deflate, br", "br"},
+		{"gzip;q=1.0, zstd;q=0.5", "gzip"},
+		{"zstd;q=0, gzip", "gzip"},
+		{"GZIP", "gzip"},
+		{"*", "zstd"},
+		{"*;q=0.5, gzip", "gzip"},
+		{"*, zstd;q=0", "br"},
+		{"*;q=0", ""},
+		{"gzip;q=invalid", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, negotiateEncoding(tt.acceptEncoding, supportedEncodings...), tt.acceptEncoding)
+	}
+	// only the given encodings are negotiated
+	assert.Equal(t, "", negotiateEncoding("gzip, deflate, br", encodingZstd))
+	assert.Equal(t, "gzip", negotiateEncoding("gzip, deflate, br", encodingZstd, encodingGzip))
+}
+
+func TestParseCompression(t *testing.T) {
+	modes, err := ParseCompression("gzip, zstd")
+	require.NoError(t, err)
+	assert.Equal(t, []string{CompressionGzip, CompressionZstd}, modes)
+	// in the order of preference, whatever the order of modes
+	assert.Equal(t, []string{encodingZstd, encodingGzip}, allowedEncodings(modes))
+	assert.Equal(t, supportedEncodings, allowedEncodings(nil))
+
+	modes, err = ParseCompression(" none ")
+	require.NoError(t, err)
+	assert.Equal(t, []string{CompressionNone}, modes)
+	assert.Empty(t, allowedEncodings(modes))
+
+	for _, modes := range []string{"", "none,gzip", "br", "gzip,deflate", "GZIP"} {
+		_, err := ParseCompression(modes)
+		assert.Error(t, err, modes)
+	}
+}
+
+func decodeBody(t *testing.T, encoding string, body io.Reader) []byte {
+	switch encoding {
+	case encodingGzip:
+		r, err := gzip.NewReader(body)
+		require.NoError(t, err)
+		defer r.Close()
+		body = r
+	case encodingZstd:
+		r, err := zstd.NewReader(body)
+		require.NoError(t, err)
+		defer r.Close()
+		body = r
+	case encodingBrotli:
+		body = brotli.NewReader(body)
+	}
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	return data
+}
+
+func TestCompressHandler(t *testing.T) {
+	large := strings.Repeat(`{"key":"value"}`, 200)
+	small := `{"key":"value"}`
+
+	serve := func(acceptEncoding string, h http.HandlerFunc, headers ...string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/blocks/best", nil)
+		for i := 0; i+1 < len(headers); i += 2 {
+			req.Header.Set(headers[i], headers[i+1])
+		}
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		rec := httptest.NewRecorder()
+		compressHandler(h, supportedEncodings, "/subscriptions").ServeHTTP(rec, req)
+		return rec
+	}
+	writeJSON := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			// written in chunks to be buffered across writes
+			for i := 0; i < len(body); i += 100 {
+				end := min(i+100, len(body))
+				_, err := w.Write([]byte(body[i:end]))
+				require.NoError(t, err)
+			}
+		}
+	}
+
+	t.Run("compressed", func(t *testing.T) {
+		for _, encoding := range supportedEncodings {
+			rec := serve(encoding, writeJSON(large))
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, encoding, rec.Header().Get("Content-Encoding"))
+			assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+			assert.Less(t, rec.Body.Len(), len(large))
+			assert.Equal(t, large, string(decodeBody(t, encoding, rec.Body)))
+		}
+	})
+
+	t.Run("not accepted", func(t *testing.T) {
+		rec := serve("identity", writeJSON(large))
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+		assert.Equal(t, large, rec.Body.String())
+
+		// encodings not allowed
+		req := httptest.NewRequest(http.MethodGet, "/blocks/best", nil)
+		req.Header.Set("Accept-Encoding", "zstd, br")
+		rec = httptest.NewRecorder()
+		compressHandler(writeJSON(large), []string{encodingGzip}).ServeHTTP(rec, req)
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, large, rec.Body.String())
+	})
+
+	t.Run("small", func(t *testing.T) {
+		rec := serve("gzip", writeJSON(small))
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, small, rec.Body.String())
+	})
+
+	t.Run("not compressible", func(t *testing.T) {
+		rec := serve("gzip", func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write([]byte(large))
+		})
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, large, rec.Body.String())
+	})
+
+	t.Run("status code", func(t *testing.T) {
+		rec := serve("gzip", func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(large))
+		})
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, large, string(decodeBody(t, encodingGzip, rec.Body)))
+
+		rec = serve("gzip", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	})
+
+	t.Run("streamed", func(t *testing.T) {
+		rec := serve("zstd", func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			_, _ = w.Write([]byte(small + "\n"))
+			// flushed before reaching the size threshold
+			w.(http.Flusher).Flush()
+			_, _ = w.Write([]byte(small + "\n"))
+		})
+		assert.True(t, rec.Flushed)
+		assert.Equal(t, "zstd", rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, small+"\n"+small+"\n", string(decodeBody(t, encodingZstd, rec.Body)))
+	})
+
+	t.Run("skipped", func(t *testing.T) {
+		rec := serve("gzip", writeJSON(large), "Upgrade", "websocket")
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Empty(t, rec.Header().Get("Vary"))
+		assert.Equal(t, large, rec.Body.String())
+
+		req := httptest.NewRequest(http.MethodGet, "/subscriptions/beat2", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		rec = httptest.NewRecorder()
+		compressHandler(writeJSON(large), supportedEncodings, "/subscriptions").ServeHTTP(rec, req)
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, large, rec.Body.String())
+	})
+}
+
+func TestCompressedResponses(t *testing.T) {
+	thorChain, err := testchain.NewIntegrationTestChain()
+	require.NoError(t, err)
+
+	sender, recipient := genesis.DevAccounts()[0], genesis.DevAccounts()[1]
+	var txs []*tx.Transaction
+	for i := 0; i < 5; i++ {
+		txs = append(txs, tx.MustSign(new(tx.Builder).
+			ChainTag(thorChain.Repo().ChainTag()).
+			Expiration(100).
+			Gas(21000).
+			Nonce(uint64(i)).
+			Clause(tx.NewClause(&recipient.Address)).
+			Build(), sender.PrivateKey))
+	}
+	require.NoError(t, thorChain.MintTransactions(sender, txs...))
+
+	// the test chain doesn't write logs, write VTHO transfer events of the best block
+	best, err := thorChain.Repo().GetBlock(thorChain.Repo().BestBlockSummary().Header.ID())
+	require.NoError(t, err)
+	transferEvent, ok := builtin.Energy.ABI.EventByName("Transfer")
+	require.True(t, ok)
+	var receipts tx.Receipts
+	for range best.Transactions() {
+		output := &tx.Output{}
+		for i := 0; i < 10; i++ {
+			output.Events = append(output.Events, &tx.Event{
+				Address: builtin.Energy.Address,
+				Topics: []thor.Bytes32{
+					transferEvent.ID(),
+					thor.BytesToBytes32(sender.Address.Bytes()),
+					thor.BytesToBytes32(recipient.Address.Bytes()),
+				},
+				Data: thor.BytesToBytes32([]byte{byte(i + 1)}).Bytes(),
+			})
+		}
+		receipts = append(receipts, &tx.Receipt{Outputs: []*tx.Output{output}})
+	}
+	w := thorChain.LogDB().NewWriter()
+	require.NoError(t, w.Write(best, receipts))
+	require.NoError(t, w.Commit())
+
+	txPool := txpool.New(thorChain.Repo(), thorChain.Stater(), txpool.Options{
+		Limit:           100,
+		LimitPerAccount: 16,
+		MaxLifetime:     time.Hour,
+	})
+	t.Cleanup(txPool.Close)
+
+	newServer := func(config Config) *httptest.Server {
+		config.BacktraceLimit = 10
+		config.CallGasLimit = 10_000_000
+		config.LogsLimit = 100
+		config.EnableReqLogger = &atomic.Bool{}
+		handler, closer := New(thorChain.Repo(), thorChain.Stater(), txPool, thorChain.LogDB(), thorChain.Engine(), nil, thorChain.GetForkConfig(), config)
+		t.Cleanup(closer)
+		ts := httptest.NewServer(handler)
+		t.Cleanup(ts.Close)
+		return ts
+	}
+	var (
+		ts         = newServer(Config{})
+		gzipOnly   = newServer(Config{Compression: []string{CompressionGzip}})
+		disabled   = newServer(Config{DisableCompression: true, Compression: []string{CompressionGzip}})
+		none       = newServer(Config{Compression: []string{CompressionNone}})
+		getEncoded = func(ts *httptest.Server, acceptEncoding string) string {
+			req, err := http.NewRequest(http.MethodGet, ts.URL+"/blocks/best?expanded=true", nil)
+			require.NoError(t, err)
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer res.Body.Close()
+			return res.Header.Get("Content-Encoding")
+		}
+	)
+
+	fetch := func(method, path, body, acceptEncoding string) []byte {
+		req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		// an explicit header disables the transparent decompression of the client
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		if acceptEncoding == "identity" {
+			assert.Empty(t, res.Header.Get("Content-Encoding"))
+		} else {
+			assert.Equal(t, acceptEncoding, res.Header.Get("Content-Encoding"))
+		}
+		return decodeBody(t, res.Header.Get("Content-Encoding"), res.Body)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{"blocks", http.MethodGet, "/blocks/best?expanded=true", ""},
+		{"events", http.MethodPost, "/logs/event", "{}"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			plain := fetch(tc.method, tc.path, tc.body, "identity")
+			require.Greater(t, len(plain), compressMinSize)
+			assert.True(t, json.Valid(plain))
+
+			for _, encoding := range supportedEncodings {
+				assert.Equal(t, plain, fetch(tc.method, tc.path, tc.body, encoding), encoding)
+			}
+		})
+	}
+
+	t.Run("allowed encodings", func(t *testing.T) {
+		assert.Equal(t, encodingZstd, getEncoded(ts, "gzip, br, zstd"))
+		assert.Equal(t, encodingGzip, getEncoded(gzipOnly, "gzip, br, zstd"))
+		assert.Empty(t, getEncoded(gzipOnly, "br, zstd"))
+		assert.Empty(t, getEncoded(disabled, "gzip"))
+		assert.Empty(t, getEncoded(none, "gzip, br, zstd"))
+	})
+
+	t.Run("events content", func(t *testing.T) {
+		var events []map[string]interface{}
+		require.NoError(t, json.Unmarshal(fetch(http.MethodPost, "/logs/event", "{}", encodingZstd), &events))
+		assert.Len(t, events, 10*len(txs))
+	})
+}
+
+func BenchmarkCompressEncoders(b *testing.B) {
+	payload := bytes.Repeat([]byte(`{"number":1,"id":"0x00000001c458949985a6d86b7139690b8811dd3b4647c02d4f41cdefb7d32327"},`), 100)
+
+	for _, encoding := range supportedEncodings {
+		b.Run(encoding+"/pooled", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				enc := encoderPools[encoding].Get().(encoder)
+				enc.Reset(io.Discard)
+				_, _ = enc.Write(payload)
+				_ = enc.Close()
+				encoderPools[encoding].Put(enc)
+			}
+		})
+		b.Run(encoding+"/naive", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var enc io.WriteCloser
+				switch encoding {
+				case encodingGzip:
+					enc = gzip.NewWriter(io.Discard)
+				case encodingBrotli:
+					enc = brotli.NewWriter(io.Discard)
+				default:
+					enc, _ = zstd.NewWriter(io.Discard, zstd.WithEncoderConcurrency(1))
+				}
+				_, _ = enc.Write(payload)
+				_ = enc.Close()
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package debug
+
+import (
+	"math/big"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/api/utils"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+)
+
+const (
+	defaultMaxAccountRangeResult = 1000
+	// accountRangeScanLimit bounds the accounts visited by a request, however few of them are above the threshold.
+	// A full scan of the accounts trie takes as many requests as the count of accounts divided by the limit.
+	accountRangeScanLimit = 100_000
+)
+
+// minAccountRangeThreshold is the least threshold accepted without acknowledging the cost of the scan, 10k VET or VTHO.
+var minAccountRangeThreshold = new(big.Int).Mul(big.NewInt(10_000), big.NewInt(1e18))
+
+// accountRangeAt returns the accounts holding more of the token than the threshold, starting at the hashed address start.
+// It stops after visiting scanLimit accounts or finding maxResult ones, and returns the hashed address to resume from.
+// The addresses are resolved by the preimages recorded by the stater.
+func accountRangeAt(
+	stater *state.Stater,
+	st *state.State,
+	blockTime uint64,
+	start []byte,
+	vtho bool,
+	threshold *big.Int,
+	maxResult int,
+	scanLimit int,
+) (*AccountRangeResult, error) {
+	var (
+		result  = &AccountRangeResult{Accounts: []*AccountRangeEntry{}}
+		scanned int
+		perr    error
+	)
+	err := st.IterateAccounts(start, func(hashedAddr thor.Bytes32, acc *state.Account) bool {
+		if len(result.Accounts) == maxResult || scanned == scanLimit {
+			result.NextKey = &hashedAddr
+			return false
+		}
+		scanned++
+
+		energy := acc.CalcEnergy(blockTime)
+		amount := acc.Balance
+		if vtho {
+			amount = energy
+		}
+		if amount.Cmp(threshold) > 0 {
+			entry := &AccountRangeEntry{
+				AddressHash: hashedAddr,
+				Balance:     (*math.HexOrDecimal256)(acc.Balance),
+				Energy:      (*math.HexOrDecimal256)(energy),
+			}
+			addr, err := stater.GetAddressPreimage(hashedAddr)
+			if err != nil {
+				if !stater.IsNotFound(err) {
+					perr = err
+					return false
+				}
+				result.Unresolved++
+			} else {
+				entry.Address = &addr
+			}
+			result.Accounts = append(result.Accounts, entry)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if perr != nil {
+		return nil, perr
+	}
+	return result, nil
+}
+
+func (d *Debug) handleAccountRange(w http.ResponseWriter, req *http.Request) error {
+	var opt AccountRangeOption
+	if err := utils.ParseJSON(req.Body, &opt); err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "body"))
+	}
+
+	var vtho bool
+	switch opt.Token {
+	case "", "vet":
+	case "vtho":
+		vtho = true
+	default:
+		return utils.BadRequest(errors.New("token: should be vet or vtho"))
+	}
+	if opt.Threshold == nil {
+		return utils.BadRequest(errors.New("threshold: required"))
+	}
+	threshold := (*big.Int)(opt.Threshold)
+	if threshold.Cmp(minAccountRangeThreshold) < 0 && !opt.AcknowledgeFullScan {
+		return utils.BadRequest(errors.Errorf("threshold: below %v, set acknowledgeFullScan to scan anyway", minAccountRangeThreshold))
+	}
+	if opt.MaxResult > defaultMaxAccountRangeResult {
+		return utils.BadRequest(errors.Errorf("maxResult: exceeds limit of %d", defaultMaxAccountRangeResult))
+	}
+	if opt.MaxResult <= 0 {
+		opt.MaxResult = defaultMaxAccountRangeResult
+	}
+	var keyStart []byte
+	if opt.KeyStart != "" {
+		k, err := hexutil.Decode(opt.KeyStart)
+		if err != nil {
+			return utils.BadRequest(errors.New("keyStart: invalid format"))
+		}
+		keyStart = k
+	}
+
+	revision, err := utils.ParseRevision(req.Context(), opt.Revision, false)
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "revision"))
+	}
+	summary, st, err := utils.GetSummaryAndState(revision, d.repo, d.bft, d.stater)
+	if err != nil {
+		if utils.IsRevisionError(err) {
+			return utils.BadRequest(errors.WithMessage(err, "revision"))
+		}
+		return err
+	}
+
+	res, err := accountRangeAt(d.stater, st, summary.Header.Timestamp(), keyStart, vtho, threshold, opt.MaxResult, accountRangeScanLimit)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, res)
+}
@@ -46,6 +46,8 @@ type Debug struct {
 	bft               bft.Committer
 	allowedTracers    map[string]struct{}
 	skipPoA           bool
+	jobs              *jobQueue
+	tracerMetrics     bool
 }
 
 func New(
@@ -57,6 +59,8 @@ func New(
 	bft bft.Committer,
 	allowedTracers []string,
 	soloMode bool,
+	jobOpts JobOptions,
+	tracerMetrics bool,
 ) *Debug {
 	allowedMap := make(map[string]struct{})
 	for _, t := range allowedTracers {
@@ -72,9 +76,16 @@ func New(
 		bft,
 		allowedMap,
 		soloMode,
+		newJobQueue(jobOpts),
+		tracerMetrics,
 	}
 }
 
+// Close stops the asynchronous trace jobs.
+func (d *Debug) Close() {
+	d.jobs.close()
+}
+
 // prepareClauseEnv prepares the runtime environment for the specified clause.
 func (d *Debug) prepareClauseEnv(ctx context.Context, block *block.Block, txID thor.Bytes32, clauseIndex uint32) (*runtime.Runtime, *runtime.TransactionExecutor, thor.Bytes32, error) {
 	rt, err := consensus.New(
@@ -179,6 +190,17 @@ func (d *Debug) handleTraceClause(w http.ResponseWriter, req *http.Request) erro
 	if err := utils.ParseJSON(req.Body, &opt); err != nil {
 		return utils.BadRequest(errors.WithMessage(err, "body"))
 	}
+	stream, err := utils.StringToBoolean(req.URL.Query().Get("stream"), false)
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "stream"))
+	}
+	async, err := utils.StringToBoolean(req.URL.Query().Get("async"), false)
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "async"))
+	}
+	if async && stream {
+		return utils.BadRequest(errors.New("async: not supported with stream"))
+	}
 
 	tracer, err := d.createTracer(opt.Name, opt.Config)
 	if err != nil {
@@ -189,7 +211,30 @@ func (d *Debug) handleTraceClause(w http.ResponseWriter, req *http.Request) erro
 	if err != nil {
 		return err
 	}
-	res, err := d.traceClause(req.Context(), tracer, block, txID, clauseIndex)
+	meter := d.newTraceMeter(opt.Name)
+	if async {
+		// the job outlives the request, it's interrupted only when the API is closed
+		job, err := d.jobs.submit(func(ctx context.Context) (interface{}, error) {
+			return meter.measure(func() (interface{}, error) {
+				return d.traceClause(ctx, tracer, block, txID, clauseIndex)
+			})
+		})
+		if err != nil {
+			if err == errJobQueueFull {
+				return utils.HTTPError(err, http.StatusTooManyRequests)
+			}
+			return err
+		}
+		return utils.WriteJSON(w, job)
+	}
+	if stream {
+		return d.streamTrace(w, tracer, meter, func() (interface{}, error) {
+			return d.traceClause(req.Context(), tracer, block, txID, clauseIndex)
+		})
+	}
+	res, err := meter.measure(func() (interface{}, error) {
+		return d.traceClause(req.Context(), tracer, block, txID, clauseIndex)
+	})
 	if err != nil {
 		return err
 	}
@@ -201,13 +246,17 @@ func (d *Debug) handleTraceCall(w http.ResponseWriter, req *http.Request) error
 	if err := utils.ParseJSON(req.Body, &opt); err != nil {
 		return utils.BadRequest(errors.WithMessage(err, "body"))
 	}
-	revision, err := utils.ParseRevision(req.URL.Query().Get("revision"), true)
+	revision, err := utils.ParseRevision(req.Context(), req.URL.Query().Get("revision"), true)
 	if err != nil {
 		return utils.BadRequest(errors.WithMessage(err, "revision"))
 	}
+	stream, err := utils.StringToBoolean(req.URL.Query().Get("stream"), false)
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "stream"))
+	}
 	summary, st, err := utils.GetSummaryAndState(revision, d.repo, d.bft, d.stater)
 	if err != nil {
-		if d.repo.IsNotFound(err) {
+		if utils.IsRevisionError(err) {
 			return utils.BadRequest(errors.WithMessage(err, "revision"))
 		}
 		return err
@@ -223,7 +272,15 @@ func (d *Debug) handleTraceCall(w http.ResponseWriter, req *http.Request) error
 		return err
 	}
 
-	res, err := d.traceCall(req.Context(), tracer, summary.Header, st, txCtx, gas, clause)
+	meter := d.newTraceMeter(opt.Name)
+	if stream {
+		return d.streamTrace(w, tracer, meter, func() (interface{}, error) {
+			return d.traceCall(req.Context(), tracer, summary.Header, st, txCtx, gas, clause)
+		})
+	}
+	res, err := meter.measure(func() (interface{}, error) {
+		return d.traceCall(req.Context(), tracer, summary.Header, st, txCtx, gas, clause)
+	})
 	if err != nil {
 		return err
 	}
@@ -231,12 +288,18 @@ func (d *Debug) handleTraceCall(w http.ResponseWriter, req *http.Request) error
 	return utils.WriteJSON(w, res)
 }
 
-func (d *Debug) createTracer(name string, config json.RawMessage) (tracers.Tracer, error) {
-	tracerName := strings.TrimSpace(name)
+// normalizeTracerName returns the name of the tracer requested, defaults to the struct log tracer.
+func normalizeTracerName(name string) string {
+	name = strings.TrimSpace(name)
 	// compatible with old API specs
-	if tracerName == "" {
-		tracerName = "structLoggerTracer" // default to struct log tracer
+	if name == "" {
+		return "structLoggerTracer" // default to struct log tracer
 	}
+	return name
+}
+
+func (d *Debug) createTracer(name string, config json.RawMessage) (tracers.Tracer, error) {
+	tracerName := normalizeTracerName(name)
 
 	// if it's builtin tracers
 	if tracers.DefaultDirectory.Lookup(tracerName) {
@@ -513,4 +576,16 @@ func (d *Debug) Mount(root *mux.Router, pathPrefix string) {
 		Methods(http.MethodPost).
 		Name("POST /debug/storage-range").
 		HandlerFunc(utils.WrapHandlerFunc(d.handleDebugStorage))
+	sub.Path("/jobs/{id}").
+		Methods(http.MethodGet).
+		Name("GET /debug/jobs/{id}").
+		HandlerFunc(utils.WrapHandlerFunc(d.handleGetJob))
+	sub.Path("/account-range").
+		Methods(http.MethodPost).
+		Name("POST /debug/account-range").
+		HandlerFunc(utils.WrapHandlerFunc(d.handleAccountRange))
+	sub.Path("/statediff/{revision}").
+		Methods(http.MethodGet).
+		Name("GET /debug/statediff/{revision}").
+		HandlerFunc(utils.WrapHandlerFunc(d.handleStateDiff))
 }
@@ -6,12 +6,16 @@
 package debug
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"math/big"
+	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
@@ -26,7 +30,6 @@ import (
 	"github.com/vechain/thor/v2/test/datagen"
 	"github.com/vechain/thor/v2/test/testchain"
 	"github.com/vechain/thor/v2/thor"
-	"github.com/vechain/thor/v2/thorclient"
 	"github.com/vechain/thor/v2/tracers/logger"
 	"github.com/vechain/thor/v2/tx"
 
@@ -40,15 +43,14 @@ var (
 	blk         *block.Block
 	transaction *tx.Transaction
 	debug       *Debug
-	tclient     *thorclient.Client
 )
 
 func TestDebug(t *testing.T) {
 	initDebugServer(t)
 	defer ts.Close()
+	defer debug.Close()
 
 	// /tracers endpoint
-	tclient = thorclient.New(ts.URL)
 	for name, tt := range map[string]func(*testing.T){
 		"testTraceClauseWithInvalidTracerName":     testTraceClauseWithInvalidTracerName,
 		"testTraceClauseWithEmptyTracerTarget":     testTraceClauseWithEmptyTracerTarget,
@@ -62,6 +64,8 @@ func TestDebug(t *testing.T) {
 		"testTraceClauseWithCustomTracer":          testTraceClauseWithCustomTracer,
 		"testTraceClause":                          testTraceClause,
 		"testTraceClauseWithoutBlockID":            testTraceClauseWithoutBlockID,
+		"testTraceClauseStream":                    testTraceClauseStream,
+		"testTraceClauseAsync":                     testTraceClauseAsync,
 	} {
 		t.Run(name, tt)
 	}
@@ -79,6 +83,8 @@ func TestDebug(t *testing.T) {
 		"testHandleTraceCallWithBadBlockRef":                 testHandleTraceCallWithBadBlockRef,
 		"testHandleTraceCallWithInvalidLengthBlockRef":       testHandleTraceCallWithInvalidLengthBlockRef,
 		"testTraceCallNextBlock":                             testTraceCallNextBlock,
+		"testHandleTraceCallStream":                          testHandleTraceCallStream,
+		"testHandleTraceCallStreamNotSupported":              testHandleTraceCallStreamNotSupported,
 	} {
 		t.Run(name, tt)
 	}
@@ -91,6 +97,26 @@ func TestDebug(t *testing.T) {
 	} {
 		t.Run(name, tt)
 	}
+
+	// /account-range endpoint
+	for name, tt := range map[string]func(*testing.T){
+		"testAccountRange":          testAccountRange,
+		"testAccountRangeWithError": testAccountRangeWithError,
+	} {
+		t.Run(name, tt)
+	}
+
+	// /statediff endpoint
+	for name, tt := range map[string]func(*testing.T){
+		"testStateDiff":                  testStateDiff,
+		"testStateDiffPagination":        testStateDiffPagination,
+		"testStateDiffWithBadRevision":   testStateDiffWithBadRevision,
+		"testStateDiffWithGenesis":       testStateDiffWithGenesis,
+		"testStateDiffNotRecorded":       testStateDiffNotRecorded,
+		"testStateDiffWithBadPagination": testStateDiffWithBadPagination,
+	} {
+		t.Run(name, tt)
+	}
 }
 
 func TestStorageRangeFunc(t *testing.T) {
@@ -156,6 +182,119 @@ func TestStorageRangeMaxResult(t *testing.T) {
 	assert.Equal(t, 10, len(storageRangeRes.Storage))
 }
 
+func TestAccountRangeFunc(t *testing.T) {
+	stater := state.NewStater(muxdb.NewMem())
+	stater.SetPreimageRecording(true)
+	st := stater.NewState(thor.Bytes32{}, 0, 0, 0)
+	for i := 1; i <= 100; i++ {
+		addr := thor.BytesToAddress([]byte{byte(i)})
+		require.NoError(t, st.SetBalance(addr, big.NewInt(int64(i))))
+		require.NoError(t, st.SetEnergy(addr, big.NewInt(int64(1000+i)), 10))
+	}
+	stage, err := st.Stage(1, 0)
+	require.NoError(t, err)
+	root, err := stage.Commit()
+	require.NoError(t, err)
+	st = stater.NewState(root, 1, 0, 0)
+
+	// paged through by either bound
+	scanAll := func(vtho bool, threshold int64, maxResult, scanLimit int) (accounts []*AccountRangeEntry, pages int) {
+		var start []byte
+		for {
+			res, err := accountRangeAt(stater, st, 10, start, vtho, big.NewInt(threshold), maxResult, scanLimit)
+			require.NoError(t, err)
+			assert.LessOrEqual(t, len(res.Accounts), maxResult)
+			accounts = append(accounts, res.Accounts...)
+			pages++
+			if res.NextKey == nil {
+				return
+			}
+			start = res.NextKey.Bytes()
+		}
+	}
+
+	accounts, pages := scanAll(false, 90, 1000, 1000)
+	assert.Len(t, accounts, 10)
+	assert.Equal(t, 1, pages)
+	for _, acc := range accounts {
+		assert.Greater(t, (*big.Int)(acc.Balance).Int64(), int64(90))
+	}
+
+	accounts, pages = scanAll(false, 90, 3, 1000)
+	assert.Len(t, accounts, 10)
+	assert.Equal(t, 4, pages)
+
+	accounts, pages = scanAll(false, 90, 1000, 10)
+	assert.Len(t, accounts, 10)
+	assert.Equal(t, 10, pages)
+
+	accounts, _ = scanAll(true, 1050, 1000, 1000)
+	assert.Len(t, accounts, 50)
+	for _, acc := range accounts {
+		addr := thor.BytesToAddress([]byte{byte((*big.Int)(acc.Balance).Int64())})
+		assert.Equal(t, &addr, acc.Address)
+		assert.Equal(t, thor.Blake2b(addr[:]), acc.AddressHash)
+		assert.Greater(t, (*big.Int)(acc.Energy).Int64(), int64(1050))
+	}
+}
+
+func testAccountRange(t *testing.T) {
+	opt := AccountRangeOption{Threshold: (*math.HexOrDecimal256)(minAccountRangeThreshold)}
+	res := httpPostAndCheckResponseStatus(t, "/debug/account-range", &opt, 200)
+
+	var result AccountRangeResult
+	require.NoError(t, json.Unmarshal([]byte(res), &result))
+	assert.Nil(t, result.NextKey)
+
+	accounts := make(map[thor.Bytes32]*AccountRangeEntry)
+	for _, acc := range result.Accounts {
+		accounts[acc.AddressHash] = acc
+	}
+	// only the sender changed while recording the preimages
+	sender := genesis.DevAccounts()[0].Address
+	for _, acc := range genesis.DevAccounts() {
+		entry := accounts[thor.Blake2b(acc.Address.Bytes())]
+		require.NotNil(t, entry)
+		if acc.Address == sender {
+			assert.Equal(t, &sender, entry.Address)
+		} else {
+			assert.Nil(t, entry.Address)
+		}
+	}
+	assert.Equal(t, len(genesis.DevAccounts())-1, result.Unresolved)
+
+	// a low threshold is acknowledged
+	opt = AccountRangeOption{
+		Revision:            "0",
+		Token:               "vtho",
+		Threshold:           (*math.HexOrDecimal256)(big.NewInt(0)),
+		MaxResult:           1,
+		AcknowledgeFullScan: true,
+	}
+	res = httpPostAndCheckResponseStatus(t, "/debug/account-range", &opt, 200)
+	require.NoError(t, json.Unmarshal([]byte(res), &result))
+	assert.Len(t, result.Accounts, 1)
+	assert.NotNil(t, result.NextKey)
+}
+
+func testAccountRangeWithError(t *testing.T) {
+	httpPostAndCheckResponseStatus(t, "/debug/account-range", 123, 400)
+	// no threshold
+	httpPostAndCheckResponseStatus(t, "/debug/account-range", &AccountRangeOption{}, 400)
+
+	threshold := (*math.HexOrDecimal256)(minAccountRangeThreshold)
+	for _, opt := range []*AccountRangeOption{
+		{Threshold: (*math.HexOrDecimal256)(big.NewInt(1))},
+		{Threshold: threshold, Token: "btc"},
+		{Threshold: threshold, MaxResult: 1001},
+		{Threshold: threshold, KeyStart: "0xzz"},
+		{Threshold: threshold, Revision: "next"},
+		{Threshold: threshold, Revision: "1234"},
+	} {
+		httpPostAndCheckResponseStatus(t, "/debug/account-range", opt, 400)
+	}
+}
+
 func testTraceClauseWithInvalidTracerName(t *testing.T) {
 	res := httpPostAndCheckResponseStatus(t, "/debug/tracers", &TraceClauseOption{Name: "non-existent"}, 403)
 	assert.Contains(t, res, "unable to create custom tracer")
@@ -267,6 +406,54 @@ func testTraceClause(t *testing.T) {
 	assert.Equal(t, expectedExecutionResult, parsedExecutionRes)
 }
 
+func testTraceClauseStream(t *testing.T) {
+	traceClauseOption := &TraceClauseOption{
+		Name:   "structLogger",
+		Target: fmt.Sprintf("%s/%s/1", blk.Header().ID(), transaction.ID()),
+	}
+	res := httpPostAndCheckResponseStatus(t, "/debug/tracers?stream=true", traceClauseOption, 200)
+
+	// no code executed, only the result
+	var frame TraceFrame
+	require.NoError(t, json.Unmarshal([]byte(res), &frame))
+	assert.Nil(t, frame.Frame)
+	assert.Empty(t, frame.Error)
+
+	var parsedExecutionRes *logger.ExecutionResult
+	require.NoError(t, json.Unmarshal(frame.Result, &parsedExecutionRes))
+	assert.Equal(t, &logger.ExecutionResult{StructLogs: make([]logger.StructLogRes, 0)}, parsedExecutionRes)
+
+	httpPostAndCheckResponseStatus(t, "/debug/tracers?stream=yes", traceClauseOption, 400)
+}
+
+func testTraceClauseAsync(t *testing.T) {
+	traceClauseOption := &TraceClauseOption{
+		Name:   "structLogger",
+		Target: fmt.Sprintf("%s/%s/1", blk.Header().ID(), transaction.ID()),
+	}
+	res := httpPostAndCheckResponseStatus(t, "/debug/tracers?async=true", traceClauseOption, 200)
+
+	var job Job
+	require.NoError(t, json.Unmarshal([]byte(res), &job))
+	assert.NotEmpty(t, job.ID)
+	assert.Nil(t, job.Result)
+
+	require.Eventually(t, func() bool {
+		res = httpGetAndCheckResponseStatus(t, "/debug/jobs/"+job.ID, 200)
+		require.NoError(t, json.Unmarshal([]byte(res), &job))
+		return job.Status == JobDone || job.Status == JobFailed
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, JobDone, job.Status)
+
+	var parsedExecutionRes *logger.ExecutionResult
+	require.NoError(t, json.Unmarshal(job.Result, &parsedExecutionRes))
+	assert.Equal(t, &logger.ExecutionResult{StructLogs: make([]logger.StructLogRes, 0)}, parsedExecutionRes)
+
+	httpGetAndCheckResponseStatus(t, "/debug/jobs/unknown", 404)
+	httpPostAndCheckResponseStatus(t, "/debug/tracers?async=yes", traceClauseOption, 400)
+	httpPostAndCheckResponseStatus(t, "/debug/tracers?async=true&stream=true", traceClauseOption, 400)
+}
+
 func testTraceClauseWithoutBlockID(t *testing.T) {
 	traceClauseOption := &TraceClauseOption{
 		Name:   "structLogger",
@@ -369,6 +556,49 @@ func testHandleTraceCall(t *testing.T) {
 	assert.Equal(t, expectedExecutionResult, parsedExecutionRes)
 }
 
+func testHandleTraceCallStream(t *testing.T) {
+	traceCallOption := &TraceCallOption{
+		Name: "structLogger",
+		// PUSH1 1 PUSH1 1 ADD POP STOP
+		Data: "0x60016001015000",
+		Gas:  21000,
+	}
+
+	res := httpPostAndCheckResponseStatus(t, "/debug/tracers/call", traceCallOption, 200)
+	var expected *logger.ExecutionResult
+	require.NoError(t, json.Unmarshal([]byte(res), &expected))
+	require.Len(t, expected.StructLogs, 5)
+
+	res = httpPostAndCheckResponseStatus(t, "/debug/tracers/call?stream=true", traceCallOption, 200)
+	lines := strings.Split(strings.TrimSpace(res), "\n")
+	require.Len(t, lines, len(expected.StructLogs)+1)
+
+	var frames []logger.StructLogRes
+	for _, line := range lines[:len(lines)-1] {
+		var frame TraceFrame
+		require.NoError(t, json.Unmarshal([]byte(line), &frame))
+		var log logger.StructLogRes
+		require.NoError(t, json.Unmarshal(frame.Frame, &log))
+		frames = append(frames, log)
+	}
+	assert.Equal(t, expected.StructLogs, frames)
+
+	// frames are not repeated in the result
+	var frame TraceFrame
+	require.NoError(t, json.Unmarshal([]byte(lines[len(lines)-1]), &frame))
+	var parsedExecutionRes *logger.ExecutionResult
+	require.NoError(t, json.Unmarshal(frame.Result, &parsedExecutionRes))
+	assert.Equal(t, expected.Gas, parsedExecutionRes.Gas)
+	assert.Equal(t, expected.Failed, parsedExecutionRes.Failed)
+	assert.Empty(t, parsedExecutionRes.StructLogs)
+}
+
+func testHandleTraceCallStreamNotSupported(t *testing.T) {
+	traceCallOption := &TraceCallOption{Name: "4byteTracer"}
+	res := httpPostAndCheckResponseStatus(t, "/debug/tracers/call?stream=true", traceCallOption, 400)
+	assert.Contains(t, res, "not supported by the tracer")
+}
+
 func testHandleTraceCallWithValidRevisions(t *testing.T) {
 	revisions := []string{
 		blk.Header().ID().String(),
@@ -406,7 +636,7 @@ func testHandleTraceCallWithRevisionAsNonExistingID(t *testing.T) {
 
 	res := httpPostAndCheckResponseStatus(t, "/debug/tracers/call?revision="+nonExistingRevision, &TraceCallOption{}, 400)
 
-	assert.Equal(t, "revision: leveldb: not found", strings.TrimSpace(res))
+	assert.Equal(t, "revision: not found", strings.TrimSpace(res))
 }
 
 func testHandleTraceCallWithMalfomredRevision(t *testing.T) {
@@ -533,6 +763,108 @@ func testStorageRangeDefaultOption(t *testing.T) {
 	assert.NotZero(t, len(storageRangeRes.Storage))
 }
 
+func testStateDiff(t *testing.T) {
+	res := httpGetAndCheckResponseStatus(t, "/debug/statediff/1", 200)
+	var diff StateDiff
+	require.NoError(t, json.Unmarshal([]byte(res), &diff))
+	require.Equal(t, diff.Total, len(diff.Accounts))
+
+	summary, err := debug.repo.GetBlockSummary(blk.Header().ID())
+	require.NoError(t, err)
+	parent, err := debug.repo.GetBlockSummary(blk.Header().ParentID())
+	require.NoError(t, err)
+	before := debug.stater.NewState(parent.Header.StateRoot(), parent.Header.Number(), parent.Conflicts, parent.SteadyNum)
+	after := debug.stater.NewState(summary.Header.StateRoot(), summary.Header.Number(), summary.Conflicts, summary.SteadyNum)
+
+	accounts := make(map[thor.Address]*AccountDiff)
+	for _, acc := range diff.Accounts {
+		accounts[acc.Address] = acc
+	}
+
+	// the sender pays value and gas
+	sender := genesis.DevAccounts()[0].Address
+	require.Contains(t, accounts, sender)
+	balanceBefore, err := before.GetBalance(sender)
+	require.NoError(t, err)
+	balanceAfter, err := after.GetBalance(sender)
+	require.NoError(t, err)
+	energyBefore, err := before.GetEnergy(sender, parent.Header.Timestamp())
+	require.NoError(t, err)
+	energyAfter, err := after.GetEnergy(sender, summary.Header.Timestamp())
+	require.NoError(t, err)
+	assert.Equal(t, balanceBefore, (*big.Int)(accounts[sender].BalanceBefore))
+	assert.Equal(t, balanceAfter, (*big.Int)(accounts[sender].BalanceAfter))
+	assert.Equal(t, energyBefore, (*big.Int)(accounts[sender].EnergyBefore))
+	assert.Equal(t, energyAfter, (*big.Int)(accounts[sender].EnergyAfter))
+	assert.False(t, accounts[sender].CodeChanged)
+
+	// the recipient receives the value of both clauses
+	recipient := thor.BytesToAddress([]byte("to"))
+	require.Contains(t, accounts, recipient)
+	assert.Zero(t, (*big.Int)(accounts[recipient].BalanceBefore).Sign())
+	assert.Equal(t, big.NewInt(20000), (*big.Int)(accounts[recipient].BalanceAfter))
+	assert.False(t, accounts[recipient].CodeChanged)
+	assert.Equal(t, uint32(0), accounts[recipient].StorageSlots)
+
+	// sorted by address
+	for i := 1; i < len(diff.Accounts); i++ {
+		assert.True(t, bytes.Compare(diff.Accounts[i-1].Address[:], diff.Accounts[i].Address[:]) < 0)
+	}
+}
+
+func testStateDiffPagination(t *testing.T) {
+	res := httpGetAndCheckResponseStatus(t, "/debug/statediff/"+blk.Header().ID().String(), 200)
+	var all StateDiff
+	require.NoError(t, json.Unmarshal([]byte(res), &all))
+	require.True(t, all.Total > 1)
+
+	var paged []*AccountDiff
+	for offset := 0; offset < all.Total; offset++ {
+		res := httpGetAndCheckResponseStatus(t, fmt.Sprintf("/debug/statediff/1?offset=%d&limit=1", offset), 200)
+		var diff StateDiff
+		require.NoError(t, json.Unmarshal([]byte(res), &diff))
+		assert.Equal(t, all.Total, diff.Total)
+		require.Len(t, diff.Accounts, 1)
+		paged = append(paged, diff.Accounts...)
+	}
+	assert.Equal(t, all.Accounts, paged)
+
+	res = httpGetAndCheckResponseStatus(t, fmt.Sprintf("/debug/statediff/1?offset=%d", all.Total), 200)
+	var diff StateDiff
+	require.NoError(t, json.Unmarshal([]byte(res), &diff))
+	assert.Equal(t, all.Total, diff.Total)
+	assert.Empty(t, diff.Accounts)
+}
+
+func testStateDiffWithBadRevision(t *testing.T) {
+	res := httpGetAndCheckResponseStatus(t, "/debug/statediff/badRevision", 400)
+	assert.Contains(t, res, "revision")
+
+	res = httpGetAndCheckResponseStatus(t, "/debug/statediff/12345", 400)
+	assert.Contains(t, res, "revision")
+}
+
+func testStateDiffWithGenesis(t *testing.T) {
+	res := httpGetAndCheckResponseStatus(t, "/debug/statediff/0", 400)
+	assert.Equal(t, "revision: genesis block has no parent\n", res)
+}
+
+func testStateDiffNotRecorded(t *testing.T) {
+	res := httpGetAndCheckResponseStatus(t, "/debug/statediff/best", 404)
+	assert.Equal(t, "state diff not recorded for the block\n", res)
+}
+
+func testStateDiffWithBadPagination(t *testing.T) {
+	res := httpGetAndCheckResponseStatus(t, "/debug/statediff/1?offset=-1", 400)
+	assert.Contains(t, res, "offset")
+
+	res = httpGetAndCheckResponseStatus(t, "/debug/statediff/1?limit=abc", 400)
+	assert.Contains(t, res, "limit")
+
+	res = httpGetAndCheckResponseStatus(t, fmt.Sprintf("/debug/statediff/1?limit=%d", maxStateDiffLimit+1), 400)
+	assert.Equal(t, fmt.Sprintf("limit: exceeds limit of %d\n", maxStateDiffLimit), res)
+}
+
 func initDebugServer(t *testing.T) {
 	thorChain, err := testchain.NewIntegrationTestChain()
 	require.NoError(t, err)
@@ -562,8 +894,13 @@ func initDebugServer(t *testing.T) {
 		Build()
 	transaction = tx.MustSign(transaction, genesis.DevAccounts()[0].PrivateKey)
 
+	thorChain.Stater().SetChangesetRecording(true)
+	thorChain.Stater().SetPreimageRecording(true)
 	require.NoError(t, thorChain.MintTransactions(genesis.DevAccounts()[0], transaction, noClausesTx))
 	require.NoError(t, thorChain.MintTransactions(genesis.DevAccounts()[0]))
+	// the best block is packed without recording its state diff
+	thorChain.Stater().SetChangesetRecording(false)
+	require.NoError(t, thorChain.MintTransactions(genesis.DevAccounts()[0]))
 
 	allBlocks, err := thorChain.GetAllBlocks()
 	require.NoError(t, err)
@@ -571,18 +908,35 @@ func initDebugServer(t *testing.T) {
 
 	forkConfig := thor.GetForkConfig(blk.Header().ID())
 	router := mux.NewRouter()
-	debug = New(thorChain.Repo(), thorChain.Stater(), forkConfig, 21000, true, thorChain.Engine(), []string{"all"}, false)
+	debug = New(thorChain.Repo(), thorChain.Stater(), forkConfig, 21000, true, thorChain.Engine(), []string{"all"}, false, JobOptions{}, true)
 	debug.Mount(router, "/debug")
 	ts = httptest.NewServer(router)
 }
 
 func httpPostAndCheckResponseStatus(t *testing.T, url string, obj interface{}, responseStatusCode int) string {
-	body, status, err := tclient.RawHTTPClient().RawHTTPPost(url, obj)
+	data, err := json.Marshal(obj)
 	require.NoError(t, err)
-	require.Equal(t, responseStatusCode, status)
+	res, err := http.Post(ts.URL+url, "application/json", bytes.NewReader(data)) //#nosec G107
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, responseStatusCode, res.StatusCode)
 
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
 	return string(body)
 }
+
+func httpGetAndCheckResponseStatus(t *testing.T, url string, responseStatusCode int) string {
+	res, err := http.Get(ts.URL + url) //#nosec G107
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, responseStatusCode, res.StatusCode)
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
 func TestCreateTracer(t *testing.T) {
 	debug := &Debug{}
 
@@ -0,0 +1,210 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package debug
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/api/utils"
+)
+
+const (
+	defaultJobWorkers        = 2
+	defaultJobQueueSize      = 16
+	defaultJobTTL            = 10 * time.Minute
+	defaultJobTimeout        = 5 * time.Minute
+	defaultJobMaxResultBytes = 64 * 1024 * 1024
+)
+
+// JobOptions contains the options of asynchronous trace jobs.
+type JobOptions struct {
+	Workers        int           // count of jobs run concurrently, defaults to 2 if zero
+	QueueSize      int           // max count of jobs waiting for a worker, defaults to 16 if zero
+	TTL            time.Duration // retention of finished jobs, defaults to 10m if zero
+	MaxResultBytes int           // max total size of the results retained, defaults to 64MB if zero
+	Timeout        time.Duration // execution deadline of a job, defaults to 5m if zero
+}
+
+var errJobQueueFull = errors.New("too many trace jobs")
+
+type traceJob struct {
+	Job
+	run        func(ctx context.Context) (interface{}, error)
+	finishedAt time.Time
+}
+
+// jobQueue runs trace jobs in a bounded pool of workers, and retains the results of finished jobs
+// for the TTL. The oldest results are evicted early to keep the total size of the results bounded.
+type jobQueue struct {
+	opts   JobOptions
+	now    func() time.Time
+	queue  chan *traceJob
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	lock        sync.Mutex
+	jobs        map[string]*traceJob
+	finished    []*traceJob // in the order of finishing
+	resultBytes int
+}
+
+func newJobQueue(opts JobOptions) *jobQueue {
+	if opts.Workers <= 0 {
+		opts.Workers = defaultJobWorkers
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = defaultJobQueueSize
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = defaultJobTTL
+	}
+	if opts.MaxResultBytes <= 0 {
+		opts.MaxResultBytes = defaultJobMaxResultBytes
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultJobTimeout
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	q := &jobQueue{
+		opts:   opts,
+		now:    time.Now,
+		queue:  make(chan *traceJob, opts.QueueSize),
+		ctx:    ctx,
+		cancel: cancel,
+		jobs:   make(map[string]*traceJob),
+	}
+	for i := 0; i < opts.Workers; i++ {
+		q.wg.Add(1)
+		go func() {
+			defer q.wg.Done()
+			for {
+				select {
+				case <-q.ctx.Done():
+					return
+				case j := <-q.queue:
+					q.runJob(j)
+				}
+			}
+		}()
+	}
+	return q
+}
+
+// submit queues the job, it fails with errJobQueueFull if no more job can wait for a worker.
+func (q *jobQueue) submit(run func(ctx context.Context) (interface{}, error)) (*Job, error) {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, err
+	}
+	j := &traceJob{
+		Job: Job{ID: hex.EncodeToString(id[:]), Status: JobQueued},
+		run: run,
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.evictLocked()
+	select {
+	case q.queue <- j:
+	default:
+		return nil, errJobQueueFull
+	}
+	q.jobs[j.ID] = j
+	job := j.Job
+	return &job, nil
+}
+
+// get returns a copy of the job, or false if the job is unknown or evicted.
+func (q *jobQueue) get(id string) (*Job, bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.evictLocked()
+	j, ok := q.jobs[id]
+	if !ok {
+		return nil, false
+	}
+	job := j.Job
+	return &job, true
+}
+
+func (q *jobQueue) runJob(j *traceJob) {
+	q.lock.Lock()
+	j.Status = JobRunning
+	q.lock.Unlock()
+
+	ctx, cancel := context.WithTimeout(q.ctx, q.opts.Timeout)
+	defer cancel()
+
+	var result json.RawMessage
+	res, err := j.run(ctx)
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		err = errors.Errorf("execution timeout of %v exceeded", q.opts.Timeout)
+	}
+	if err == nil {
+		result, err = json.Marshal(res)
+	}
+	if err == nil && len(result) > q.opts.MaxResultBytes {
+		err = errors.Errorf("result size exceeds limit of %d bytes", q.opts.MaxResultBytes)
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if err != nil {
+		j.Status = JobFailed
+		j.Error = err.Error()
+	} else {
+		for q.resultBytes+len(result) > q.opts.MaxResultBytes {
+			q.removeOldestLocked()
+		}
+		j.Status = JobDone
+		j.Result = result
+		q.resultBytes += len(result)
+	}
+	j.finishedAt = q.now()
+	q.finished = append(q.finished, j)
+}
+
+func (q *jobQueue) evictLocked() {
+	now := q.now()
+	for len(q.finished) > 0 && now.Sub(q.finished[0].finishedAt) >= q.opts.TTL {
+		q.removeOldestLocked()
+	}
+}
+
+func (q *jobQueue) removeOldestLocked() {
+	j := q.finished[0]
+	q.finished[0] = nil
+	q.finished = q.finished[1:]
+	q.resultBytes -= len(j.Result)
+	delete(q.jobs, j.ID)
+}
+
+// close stops the workers, the running jobs are interrupted.
+func (q *jobQueue) close() {
+	q.cancel()
+	q.wg.Wait()
+}
+
+func (d *Debug) handleGetJob(w http.ResponseWriter, req *http.Request) error {
+	job, ok := d.jobs.get(mux.Vars(req)["id"])
+	if !ok {
+		return utils.HTTPError(errors.New("job not found"), http.StatusNotFound)
+	}
+	return utils.WriteJSON(w, job)
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package debug
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// waitJob waits until the job is finished or evicted.
+func waitJob(t *testing.T, q *jobQueue, id string) *Job {
+	var job *Job
+	require.Eventually(t, func() bool {
+		var ok bool
+		job, ok = q.get(id)
+		return !ok || job.Status == JobDone || job.Status == JobFailed
+	}, 5*time.Second, time.Millisecond)
+	return job
+}
+
+func TestJobQueue(t *testing.T) {
+	q := newJobQueue(JobOptions{})
+	defer q.close()
+
+	release := make(chan struct{})
+	job, err := q.submit(func(context.Context) (interface{}, error) {
+		<-release
+		return map[string]int{"gas": 1}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, JobQueued, job.Status)
+
+	require.Eventually(t, func() bool {
+		j, _ := q.get(job.ID)
+		return j.Status == JobRunning
+	}, 5*time.Second, time.Millisecond)
+
+	close(release)
+	job = waitJob(t, q, job.ID)
+	assert.Equal(t, JobDone, job.Status)
+	assert.JSONEq(t, `{"gas":1}`, string(job.Result))
+	assert.Empty(t, job.Error)
+
+	failed, err := q.submit(func(context.Context) (interface{}, error) {
+		return nil, errors.New("boom")
+	})
+	require.NoError(t, err)
+	failed = waitJob(t, q, failed.ID)
+	assert.Equal(t, JobFailed, failed.Status)
+	assert.Equal(t, "boom", failed.Error)
+	assert.Nil(t, failed.Result)
+
+	_, ok := q.get("unknown")
+	assert.False(t, ok)
+}
+
+func TestJobQueueFull(t *testing.T) {
+	q := newJobQueue(JobOptions{Workers: 1, QueueSize: 1})
+	defer q.close()
+
+	// blocks until the queue is closed
+	run := func(ctx context.Context) (interface{}, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	running, err := q.submit(run)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		j, _ := q.get(running.ID)
+		return j.Status == JobRunning
+	}, 5*time.Second, time.Millisecond)
+
+	_, err = q.submit(run)
+	require.NoError(t, err)
+	_, err = q.submit(run)
+	assert.Equal(t, errJobQueueFull, err)
+}
+
+func TestJobQueueTimeout(t *testing.T) {
+	q := newJobQueue(JobOptions{Timeout: 10 * time.Millisecond})
+	defer q.close()
+
+	job, err := q.submit(func(ctx context.Context) (interface{}, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	require.NoError(t, err)
+	job = waitJob(t, q, job.ID)
+	assert.Equal(t, JobFailed, job.Status)
+	assert.Equal(t, "execution timeout of 10ms exceeded", job.Error)
+
+	// the deadline is per job
+	job, err = q.submit(func(ctx context.Context) (interface{}, error) {
+		return "done", ctx.Err()
+	})
+	require.NoError(t, err)
+	job = waitJob(t, q, job.ID)
+	assert.Equal(t, JobDone, job.Status)
+}
+
+func TestJobQueueTTL(t *testing.T) {
+	q := newJobQueue(JobOptions{TTL: time.Minute})
+	defer q.close()
+
+	now := time.Now()
+	q.lock.Lock()
+	q.now = func() time.Time { return now }
+	q.lock.Unlock()
+
+	job, err := q.submit(func(context.Context) (interface{}, error) { return 1, nil })
+	require.NoError(t, err)
+	job = waitJob(t, q, job.ID)
+	require.Equal(t, JobDone, job.Status)
+
+	q.lock.Lock()
+	q.now = func() time.Time { return now.Add(time.Minute - time.Second) }
+	q.lock.Unlock()
+	_, ok := q.get(job.ID)
+	assert.True(t, ok)
+
+	q.lock.Lock()
+	q.now = func() time.Time { return now.Add(time.Minute) }
+	q.lock.Unlock()
+	_, ok = q.get(job.ID)
+	assert.False(t, ok)
+	assert.Zero(t, q.resultBytes)
+}
+
+func TestJobQueueMaxResultBytes(t *testing.T) {
+	q := newJobQueue(JobOptions{Workers: 1, MaxResultBytes: 100})
+	defer q.close()
+
+	// the marshalled string is 42 bytes with quotes
+	result := strings.Repeat("a", 40)
+	run := func(context.Context) (interface{}, error) { return result, nil }
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		job, err := q.submit(run)
+		require.NoError(t, err)
+		job = waitJob(t, q, job.ID)
+		require.Equal(t, JobDone, job.Status)
+		ids = append(ids, job.ID)
+	}
+
+	// the oldest result is evicted to keep the total size under the limit
+	_, ok := q.get(ids[0])
+	assert.False(t, ok)
+	for _, id := range ids[1:] {
+		_, ok := q.get(id)
+		assert.True(t, ok)
+	}
+	assert.Equal(t, 84, q.resultBytes)
+
+	oversized, err := q.submit(func(context.Context) (interface{}, error) {
+		return strings.Repeat("a", 100), nil
+	})
+	require.NoError(t, err)
+	oversized = waitJob(t, q, oversized.ID)
+	assert.Equal(t, JobFailed, oversized.Status)
+	assert.Equal(t, "result size exceeds limit of 100 bytes", oversized.Error)
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package debug
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/vechain/thor/v2/metrics"
+	"github.com/vechain/thor/v2/tracers"
+)
+
+var (
+	metricTracerCount    = metrics.LazyLoadCounterVec("api_tracer_count", []string{"tracer", "success"})
+	metricTracerDuration = metrics.LazyLoadHistogramVec("api_tracer_duration_ms", []string{"tracer"}, metrics.BucketHTTPReqs)
+	metricTracerOutput   = metrics.LazyLoadCounterVec("api_tracer_output_bytes", []string{"tracer"})
+)
+
+// customTracerLabel labels all the custom JS tracers, to keep the cardinality of the metrics bounded.
+const customTracerLabel = "custom"
+
+// traceMeter records the cost of a trace by the tracer. It's nil if the tracer metrics are disabled.
+type traceMeter struct {
+	tracer string
+	output atomic.Int64 // bytes of the frames streamed
+}
+
+func (d *Debug) newTraceMeter(name string) *traceMeter {
+	if !d.tracerMetrics {
+		return nil
+	}
+	label := customTracerLabel
+	if registered, ok := tracers.DefaultDirectory.Resolve(normalizeTracerName(name)); ok {
+		label = registered
+	}
+	return &traceMeter{tracer: label}
+}
+
+// addOutput counts the bytes of a frame streamed, it's called from the execution goroutine.
+func (m *traceMeter) addOutput(n int) {
+	if m != nil {
+		m.output.Add(int64(n))
+	}
+}
+
+// measure runs the trace, and records its duration and the bytes of the output, including the result.
+func (m *traceMeter) measure(trace func() (interface{}, error)) (interface{}, error) {
+	if m == nil {
+		return trace()
+	}
+
+	start := time.Now()
+	res, err := trace()
+	labels := map[string]string{"tracer": m.tracer}
+	metricTracerDuration().ObserveWithLabels(time.Since(start).Milliseconds(), labels)
+	metricTracerCount().AddWithLabel(1, map[string]string{"tracer": m.tracer, "success": strconv.FormatBool(err == nil)})
+
+	output := m.output.Load()
+	if result, ok := res.(json.RawMessage); ok {
+		output += int64(len(result))
+	}
+	metricTracerOutput().AddWithLabel(output, labels)
+	return res, err
+}
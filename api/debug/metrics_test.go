@@ -0,0 +1,85 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package debug
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/metrics"
+)
+
+func init() {
+	metrics.InitializePrometheusMetrics()
+}
+
+// tracerMetrics scrapes the count of successful traces and the bytes of the output by the tracer.
+func tracerMetrics(t *testing.T, tracer string) (count, output float64) {
+	rec := httptest.NewRecorder()
+	metrics.HTTPHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	parser := expfmt.TextParser{}
+	families, err := parser.TextToMetricFamilies(rec.Body)
+	require.NoError(t, err)
+
+	labelsOf := func(m *dto.Metric) map[string]string {
+		labels := make(map[string]string)
+		for _, l := range m.GetLabel() {
+			labels[l.GetName()] = l.GetValue()
+		}
+		return labels
+	}
+	for _, m := range families["thor_metrics_api_tracer_count"].GetMetric() {
+		if labels := labelsOf(m); labels["tracer"] == tracer && labels["success"] == "true" {
+			count = m.GetCounter().GetValue()
+		}
+	}
+	for _, m := range families["thor_metrics_api_tracer_output_bytes"].GetMetric() {
+		if labelsOf(m)["tracer"] == tracer {
+			output = m.GetCounter().GetValue()
+		}
+	}
+	return
+}
+
+func TestTracerMetrics(t *testing.T) {
+	initDebugServer(t)
+	defer ts.Close()
+	defer debug.Close()
+
+	traceCallOption := &TraceCallOption{
+		Name: "structLogger",
+		// PUSH1 1 PUSH1 1 ADD POP STOP
+		Data: "0x60016001015000",
+		Gas:  21000,
+	}
+
+	count, output := tracerMetrics(t, "structLoggerTracer")
+	res := httpPostAndCheckResponseStatus(t, "/debug/tracers/call", traceCallOption, 200)
+	newCount, newOutput := tracerMetrics(t, "structLoggerTracer")
+	assert.Equal(t, count+1, newCount)
+	assert.Equal(t, output+float64(len(strings.TrimSpace(res))), newOutput)
+
+	// the frames streamed are counted as output
+	count, output = newCount, newOutput
+	httpPostAndCheckResponseStatus(t, "/debug/tracers/call?stream=true", traceCallOption, 200)
+	newCount, newOutput = tracerMetrics(t, "structLoggerTracer")
+	assert.Equal(t, count+1, newCount)
+	assert.Greater(t, newOutput, output)
+
+	// custom tracers share a label
+	traceCallOption.Name = "{result: function() { return 'ok' }, fault: function() {}}"
+	count, _ = tracerMetrics(t, customTracerLabel)
+	httpPostAndCheckResponseStatus(t, "/debug/tracers/call", traceCallOption, 200)
+	newCount, _ = tracerMetrics(t, customTracerLabel)
+	assert.Equal(t, count+1, newCount)
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package debug
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/api/utils"
+	"github.com/vechain/thor/v2/chain"
+)
+
+const (
+	defaultStateDiffLimit = 100
+	maxStateDiffLimit     = 1000
+)
+
+// stateDiff returns a page of the accounts changed by the block, with their state before and after the block.
+func (d *Debug) stateDiff(summary *chain.BlockSummary, offset, limit uint64) (*StateDiff, error) {
+	header := summary.Header
+	changeset, err := d.stater.GetChangeset(header.Number(), header.StateRoot())
+	if err != nil {
+		if d.stater.IsNotFound(err) {
+			return nil, utils.HTTPError(errors.New("state diff not recorded for the block"), http.StatusNotFound)
+		}
+		return nil, err
+	}
+	parent, err := d.repo.GetBlockSummary(header.ParentID())
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		before = d.stater.NewState(parent.Header.StateRoot(), parent.Header.Number(), parent.Conflicts, parent.SteadyNum)
+		after  = d.stater.NewState(header.StateRoot(), header.Number(), summary.Conflicts, summary.SteadyNum)
+		result = &StateDiff{Total: len(changeset), Accounts: []*AccountDiff{}}
+	)
+	if offset >= uint64(len(changeset)) {
+		return result, nil
+	}
+	changeset = changeset[offset:]
+	if limit < uint64(len(changeset)) {
+		changeset = changeset[:limit]
+	}
+
+	for _, changed := range changeset {
+		addr := changed.Address
+		balanceBefore, err := before.GetBalance(addr)
+		if err != nil {
+			return nil, err
+		}
+		balanceAfter, err := after.GetBalance(addr)
+		if err != nil {
+			return nil, err
+		}
+		energyBefore, err := before.GetEnergy(addr, parent.Header.Timestamp())
+		if err != nil {
+			return nil, err
+		}
+		energyAfter, err := after.GetEnergy(addr, header.Timestamp())
+		if err != nil {
+			return nil, err
+		}
+		codeHashBefore, err := before.GetCodeHash(addr)
+		if err != nil {
+			return nil, err
+		}
+		codeHashAfter, err := after.GetCodeHash(addr)
+		if err != nil {
+			return nil, err
+		}
+		result.Accounts = append(result.Accounts, &AccountDiff{
+			Address:       addr,
+			BalanceBefore: (*math.HexOrDecimal256)(balanceBefore),
+			BalanceAfter:  (*math.HexOrDecimal256)(balanceAfter),
+			EnergyBefore:  (*math.HexOrDecimal256)(energyBefore),
+			EnergyAfter:   (*math.HexOrDecimal256)(energyAfter),
+			CodeChanged:   codeHashBefore != codeHashAfter,
+			StorageSlots:  changed.StorageSlots,
+		})
+	}
+	return result, nil
+}
+
+func (d *Debug) handleStateDiff(w http.ResponseWriter, req *http.Request) error {
+	revision, err := utils.ParseRevision(req.Context(), mux.Vars(req)["revision"], false)
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "revision"))
+	}
+	query := req.URL.Query()
+	var offset, limit uint64 = 0, defaultStateDiffLimit
+	if s := query.Get("offset"); s != "" {
+		if offset, err = strconv.ParseUint(s, 10, 32); err != nil {
+			return utils.BadRequest(errors.WithMessage(err, "offset"))
+		}
+	}
+	if s := query.Get("limit"); s != "" {
+		if limit, err = strconv.ParseUint(s, 10, 32); err != nil {
+			return utils.BadRequest(errors.WithMessage(err, "limit"))
+		}
+		if limit > maxStateDiffLimit {
+			return utils.BadRequest(errors.Errorf("limit: exceeds limit of %d", maxStateDiffLimit))
+		}
+	}
+
+	summary, err := utils.GetSummary(revision, d.repo, d.bft)
+	if err != nil {
+		if utils.IsRevisionError(err) {
+			return utils.BadRequest(errors.WithMessage(err, "revision"))
+		}
+		return err
+	}
+	if summary.Header.Number() == 0 {
+		return utils.BadRequest(errors.New("revision: genesis block has no parent"))
+	}
+
+	res, err := d.stateDiff(summary, offset, limit)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, res)
+}
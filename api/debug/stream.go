@@ -0,0 +1,88 @@
+// Copyright (c) 2024 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package debug
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/api/utils"
+	"github.com/vechain/thor/v2/tracers"
+)
+
+const ndjsonContentType = "application/x-ndjson"
+
+var errStreamClosed = errors.New("stream closed")
+
+// frameStream writes trace frames as newline delimited JSON, flushing each line.
+// Frames are written by the tracer from the execution goroutine.
+type frameStream struct {
+	mu      sync.Mutex
+	w       http.ResponseWriter
+	started bool
+	closed  bool
+}
+
+func (s *frameStream) write(frame *TraceFrame) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return errStreamClosed
+	}
+	return s.encode(frame)
+}
+
+func (s *frameStream) encode(frame *TraceFrame) error {
+	if !s.started {
+		s.w.Header().Set("Content-Type", ndjsonContentType)
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+	}
+	if err := json.NewEncoder(s.w).Encode(frame); err != nil {
+		return err
+	}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// streamTrace runs the trace and streams the frames produced by the tracer, followed by the result.
+func (d *Debug) streamTrace(w http.ResponseWriter, tracer tracers.Tracer, meter *traceMeter, trace func() (interface{}, error)) error {
+	streamingTracer, ok := tracer.(tracers.StreamingTracer)
+	if !ok {
+		return utils.BadRequest(errors.New("stream: not supported by the tracer"))
+	}
+
+	s := &frameStream{w: w}
+	streamingTracer.SetFrameWriter(func(frame json.RawMessage) error {
+		meter.addOutput(len(frame))
+		return s.write(&TraceFrame{Frame: frame})
+	})
+
+	var result json.RawMessage
+	res, err := meter.measure(trace)
+	if err == nil {
+		result, err = json.Marshal(res)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// the execution may still be running if interrupted, stop taking its frames
+	s.closed = true
+
+	if err != nil {
+		if !s.started {
+			return err
+		}
+		// too late for the status code, report in the stream
+		return s.encode(&TraceFrame{Error: err.Error()})
+	}
+	return s.encode(&TraceFrame{Result: result})
+}
@@ -33,6 +33,31 @@ type TraceCallOption struct {
 	Config     json.RawMessage       `json:"config"` // Config specific to given tracer.
 }
 
+// TraceFrame is a line of a streamed trace. Trace frames are followed by the result,
+// or by the error if tracing failed.
+type TraceFrame struct {
+	Frame  json.RawMessage `json:"frame,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// StateDiff is a page of the accounts changed by a block.
+type StateDiff struct {
+	Total    int            `json:"total"`
+	Accounts []*AccountDiff `json:"accounts"`
+}
+
+// AccountDiff is the change of an account by a block.
+type AccountDiff struct {
+	Address       thor.Address          `json:"address"`
+	BalanceBefore *math.HexOrDecimal256 `json:"balanceBefore"`
+	BalanceAfter  *math.HexOrDecimal256 `json:"balanceAfter"`
+	EnergyBefore  *math.HexOrDecimal256 `json:"energyBefore"`
+	EnergyAfter   *math.HexOrDecimal256 `json:"energyAfter"`
+	CodeChanged   bool                  `json:"codeChanged"`
+	StorageSlots  uint32                `json:"storageSlots"` // count of storage slots written
+}
+
 type StorageRangeOption struct {
 	Address   thor.Address
 	KeyStart  string
@@ -51,3 +76,47 @@ type StorageEntry struct {
 	Key   *thor.Bytes32 `json:"key"`
 	Value *thor.Bytes32 `json:"value"`
 }
+
+// AccountRangeOption selects the accounts holding more VET or VTHO than the threshold, in a range of the accounts trie.
+type AccountRangeOption struct {
+	Revision            string                `json:"revision"`
+	Token               string                `json:"token"` // "vet" (default) or "vtho"
+	Threshold           *math.HexOrDecimal256 `json:"threshold"`
+	KeyStart            string                `json:"keyStart"`
+	MaxResult           int                   `json:"maxResult"`
+	AcknowledgeFullScan bool                  `json:"acknowledgeFullScan"` // required if the threshold is below the minimum
+}
+
+// AccountRangeResult is a page of the accounts selected in the accounts trie.
+type AccountRangeResult struct {
+	Accounts   []*AccountRangeEntry `json:"accounts"`
+	NextKey    *thor.Bytes32        `json:"nextKey"`    // nil if the scan reached the end of the trie.
+	Unresolved int                  `json:"unresolved"` // count of the accounts whose addresses are not recorded
+}
+
+// AccountRangeEntry is an account of the accounts trie. The trie is keyed by the blake2b hashes of addresses,
+// which are resolved by the preimages recorded with --account-preimages.
+type AccountRangeEntry struct {
+	Address     *thor.Address         `json:"address"` // nil if the preimage of the hash is not recorded
+	AddressHash thor.Bytes32          `json:"addressHash"`
+	Balance     *math.HexOrDecimal256 `json:"balance"`
+	Energy      *math.HexOrDecimal256 `json:"energy"`
+}
+
+// JobStatus is the status of an asynchronous trace job.
+type JobStatus string
+
+const (
+	JobQueued  JobStatus = "queued"
+	JobRunning JobStatus = "running"
+	JobDone    JobStatus = "done"
+	JobFailed  JobStatus = "failed"
+)
+
+// Job is an asynchronous trace job, with the result once done, or the error once failed.
+type Job struct {
+	ID     string          `json:"id"`
+	Status JobStatus       `json:"status"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
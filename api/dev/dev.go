@@ -0,0 +1,136 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package dev serves the dev accounts of the devnet, so that dev tools can sign txs without
+// holding the private keys. It must never be mounted on a network other than the devnet.
+package dev
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/api/utils"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/txpool"
+)
+
+type Dev struct {
+	repo     *chain.Repository
+	pool     *txpool.TxPool
+	accounts map[thor.Address]genesis.DevAccount
+	addrs    []thor.Address
+}
+
+// CheckGenesis returns an error if the genesis is not the devnet one, which the dev accounts are funded in.
+func CheckGenesis(genesisID thor.Bytes32) error {
+	if devnetID := genesis.NewDevnet().ID(); genesisID != devnetID {
+		return errors.Errorf("dev signer is only available on devnet, genesis id want %v, have %v", devnetID, genesisID)
+	}
+	return nil
+}
+
+// New creates the dev signer api. It fails if the chain is not the devnet.
+func New(repo *chain.Repository, pool *txpool.TxPool) (*Dev, error) {
+	if err := CheckGenesis(repo.GenesisBlock().Header().ID()); err != nil {
+		return nil, err
+	}
+
+	d := &Dev{
+		repo:     repo,
+		pool:     pool,
+		accounts: make(map[thor.Address]genesis.DevAccount),
+	}
+	for _, acc := range genesis.DevAccounts() {
+		d.accounts[acc.Address] = acc
+		d.addrs = append(d.addrs, acc.Address)
+	}
+	return d, nil
+}
+
+func (d *Dev) handleGetAccounts(w http.ResponseWriter, _ *http.Request) error {
+	accounts := make([]Account, 0, len(d.addrs))
+	for _, addr := range d.addrs {
+		accounts = append(accounts, Account{Address: addr})
+	}
+	return utils.WriteJSON(w, accounts)
+}
+
+func (d *Dev) handleSignTx(w http.ResponseWriter, req *http.Request) error {
+	addr, err := thor.ParseAddress(mux.Vars(req)["address"])
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "address"))
+	}
+	acc, ok := d.accounts[addr]
+	if !ok {
+		return utils.HTTPError(errors.New("not a dev account"), http.StatusNotFound)
+	}
+
+	submit := req.URL.Query().Get("submit")
+	if submit != "" && submit != "false" && submit != "true" {
+		return utils.BadRequest(errors.WithMessage(errors.New("should be boolean"), "submit"))
+	}
+
+	var body *UnsignedTx
+	if err := utils.ParseJSON(req.Body, &body); err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "body"))
+	}
+	if body == nil {
+		return utils.BadRequest(errors.New("body: empty body"))
+	}
+
+	var nonce [8]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return err
+	}
+	best := d.repo.BestBlockSummary().Header
+	unsigned, err := body.build(d.repo.ChainTag(), tx.NewBlockRef(best.Number()), binary.BigEndian.Uint64(nonce[:]))
+	if err != nil {
+		return utils.BadRequest(err)
+	}
+	signed := tx.MustSign(unsigned, acc.PrivateKey)
+
+	raw, err := rlp.EncodeToBytes(signed)
+	if err != nil {
+		return err
+	}
+	// submit by default
+	if submit != "false" {
+		if err := d.pool.AddLocal(signed); err != nil {
+			if txpool.IsBadTx(err) {
+				return utils.BadRequest(err)
+			}
+			if txpool.IsTxRejected(err) {
+				return utils.Forbidden(err)
+			}
+			return err
+		}
+	}
+	return utils.WriteJSON(w, &SignedTx{
+		ID:     signed.ID(),
+		Origin: addr,
+		Raw:    hexutil.Encode(raw),
+	})
+}
+
+func (d *Dev) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+
+	sub.Path("/accounts").
+		Methods(http.MethodGet).
+		Name("GET /dev/accounts").
+		HandlerFunc(utils.WrapHandlerFunc(d.handleGetAccounts))
+	sub.Path("/accounts/{address}/sign-tx").
+		Methods(http.MethodPost).
+		Name("POST /dev/accounts/{address}/sign-tx").
+		HandlerFunc(utils.WrapHandlerFunc(d.handleSignTx))
+}
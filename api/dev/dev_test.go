@@ -0,0 +1,161 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package dev
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/txpool"
+)
+
+func TestCheckGenesis(t *testing.T) {
+	assert.NoError(t, CheckGenesis(genesis.NewDevnet().ID()))
+	assert.Error(t, CheckGenesis(genesis.NewMainnet().ID()))
+	assert.Error(t, CheckGenesis(genesis.NewTestnet().ID()))
+
+	// refuse to serve a non-devnet chain
+	db := muxdb.NewMem()
+	geneBlk, _, _, err := genesis.NewTestnet().Build(state.NewStater(db))
+	require.NoError(t, err)
+	repo, err := chain.NewRepository(db, geneBlk)
+	require.NoError(t, err)
+	_, err = New(repo, nil)
+	assert.Error(t, err)
+}
+
+func TestDev(t *testing.T) {
+	thorChain, err := testchain.NewIntegrationTestChain()
+	require.NoError(t, err)
+	pool := txpool.New(thorChain.Repo(), thorChain.Stater(), txpool.Options{Limit: 100, LimitPerAccount: 16, MaxLifetime: 10 * time.Minute})
+	defer pool.Close()
+
+	d, err := New(thorChain.Repo(), pool)
+	require.NoError(t, err)
+	router := mux.NewRouter()
+	d.Mount(router, "/dev")
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	post := func(path string, body interface{}) (*http.Response, []byte) {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		res, err := http.Post(ts.URL+path, "application/json", bytes.NewReader(data))
+		require.NoError(t, err)
+		defer res.Body.Close()
+		resBody, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		return res, resBody
+	}
+	decodeSigned := func(body []byte) (*SignedTx, *tx.Transaction) {
+		var signed SignedTx
+		require.NoError(t, json.Unmarshal(body, &signed))
+		raw, err := hexutil.Decode(signed.Raw)
+		require.NoError(t, err)
+		var trx *tx.Transaction
+		require.NoError(t, rlp.DecodeBytes(raw, &trx))
+		return &signed, trx
+	}
+
+	t.Run("accounts", func(t *testing.T) {
+		res, err := http.Get(ts.URL + "/dev/accounts")
+		require.NoError(t, err)
+		defer res.Body.Close()
+		var accounts []Account
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&accounts))
+		require.Len(t, accounts, len(genesis.DevAccounts()))
+		for i, acc := range genesis.DevAccounts() {
+			assert.Equal(t, acc.Address, accounts[i].Address)
+		}
+	})
+
+	to := thor.BytesToAddress([]byte("to"))
+	value := math.HexOrDecimal256(*big.NewInt(1000))
+	from := genesis.DevAccounts()[1].Address
+
+	t.Run("sign and submit", func(t *testing.T) {
+		res, body := post("/dev/accounts/"+from.String()+"/sign-tx", &UnsignedTx{
+			Clauses: []Clause{{To: &to, Value: &value}},
+			Gas:     21000,
+		})
+		require.Equal(t, http.StatusOK, res.StatusCode, string(body))
+		signed, trx := decodeSigned(body)
+
+		assert.Equal(t, trx.ID(), signed.ID)
+		assert.Equal(t, from, signed.Origin)
+		origin, err := trx.Origin()
+		require.NoError(t, err)
+		assert.Equal(t, from, origin)
+		assert.Equal(t, thorChain.Repo().ChainTag(), trx.ChainTag())
+		assert.Equal(t, uint32(defaultExpiration), trx.Expiration())
+		assert.Equal(t, big.NewInt(1000), trx.Clauses()[0].Value())
+		assert.NotNil(t, pool.Get(trx.ID()))
+	})
+
+	t.Run("sign only", func(t *testing.T) {
+		chainTag, blockRef, expiration, nonce := uint8(1), "0x0000000100000000", uint32(10), math.HexOrDecimal64(42)
+		res, body := post("/dev/accounts/"+from.String()+"/sign-tx?submit=false", &UnsignedTx{
+			ChainTag:     &chainTag,
+			BlockRef:     &blockRef,
+			Expiration:   &expiration,
+			Clauses:      []Clause{{To: &to, Data: "0x01"}},
+			GasPriceCoef: 128,
+			Gas:          50000,
+			Nonce:        &nonce,
+		})
+		require.Equal(t, http.StatusOK, res.StatusCode, string(body))
+		_, trx := decodeSigned(body)
+
+		assert.Equal(t, chainTag, trx.ChainTag())
+		assert.Equal(t, uint32(1), trx.BlockRef().Number())
+		assert.Equal(t, expiration, trx.Expiration())
+		assert.Equal(t, uint8(128), trx.GasPriceCoef())
+		assert.Equal(t, uint64(50000), trx.Gas())
+		assert.Equal(t, uint64(42), trx.Nonce())
+		assert.Equal(t, []byte{1}, trx.Clauses()[0].Data())
+		assert.Nil(t, pool.Get(trx.ID()))
+	})
+
+	t.Run("bad requests", func(t *testing.T) {
+		badRef := "0x01"
+		for _, tc := range []struct {
+			path   string
+			body   interface{}
+			status int
+		}{
+			{"/dev/accounts/0x01/sign-tx", &UnsignedTx{}, http.StatusBadRequest},
+			{"/dev/accounts/" + to.String() + "/sign-tx", &UnsignedTx{}, http.StatusNotFound},
+			{"/dev/accounts/" + from.String() + "/sign-tx?submit=1", &UnsignedTx{}, http.StatusBadRequest},
+			{"/dev/accounts/" + from.String() + "/sign-tx", nil, http.StatusBadRequest},
+			{"/dev/accounts/" + from.String() + "/sign-tx", &UnsignedTx{BlockRef: &badRef}, http.StatusBadRequest},
+			{"/dev/accounts/" + from.String() + "/sign-tx", &UnsignedTx{Clauses: []Clause{{To: &to, Data: "zz"}}}, http.StatusBadRequest},
+			// intrinsic gas exceeds provided gas
+			{"/dev/accounts/" + from.String() + "/sign-tx", &UnsignedTx{Clauses: []Clause{{To: &to}}, Gas: 1}, http.StatusBadRequest},
+		} {
+			res, body := post(tc.path, tc.body)
+			assert.Equal(t, tc.status, res.StatusCode, "%s: %s", tc.path, body)
+		}
+	})
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package dev
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// Clause for json unmarshal
+type Clause struct {
+	To    *thor.Address         `json:"to"`
+	Value *math.HexOrDecimal256 `json:"value"`
+	Data  string                `json:"data"`
+}
+
+// UnsignedTx is the body of a tx to be signed by a dev account.
+// The chain tag and block ref default to the ones of the best block, expiration defaults
+// to 720 blocks, and a random nonce is used if absent.
+type UnsignedTx struct {
+	ChainTag     *uint8               `json:"chainTag"`
+	BlockRef     *string              `json:"blockRef"`
+	Expiration   *uint32              `json:"expiration"`
+	Clauses      []Clause             `json:"clauses"`
+	GasPriceCoef uint8                `json:"gasPriceCoef"`
+	Gas          uint64               `json:"gas"`
+	Nonce        *math.HexOrDecimal64 `json:"nonce"`
+	DependsOn    *thor.Bytes32        `json:"dependsOn"`
+}
+
+// SignedTx is the result of signing a tx.
+type SignedTx struct {
+	ID     thor.Bytes32 `json:"id"`
+	Origin thor.Address `json:"origin"`
+	Raw    string       `json:"raw"`
+}
+
+// Account is a dev account.
+type Account struct {
+	Address thor.Address `json:"address"`
+}
+
+const defaultExpiration = 720
+
+func (u *UnsignedTx) build(chainTag byte, bestRef tx.BlockRef, nonce uint64) (*tx.Transaction, error) {
+	builder := new(tx.Builder).
+		ChainTag(chainTag).
+		BlockRef(bestRef).
+		Expiration(defaultExpiration).
+		GasPriceCoef(u.GasPriceCoef).
+		Gas(u.Gas).
+		Nonce(nonce).
+		DependsOn(u.DependsOn)
+
+	if u.ChainTag != nil {
+		builder.ChainTag(*u.ChainTag)
+	}
+	if u.BlockRef != nil {
+		ref, err := hexutil.Decode(*u.BlockRef)
+		if err != nil {
+			return nil, errors.WithMessage(err, "blockRef")
+		}
+		if len(ref) != 8 {
+			return nil, errors.New("blockRef: invalid length")
+		}
+		var blockRef tx.BlockRef
+		copy(blockRef[:], ref)
+		builder.BlockRef(blockRef)
+	}
+	if u.Expiration != nil {
+		builder.Expiration(*u.Expiration)
+	}
+	if u.Nonce != nil {
+		builder.Nonce(uint64(*u.Nonce))
+	}
+	for i, c := range u.Clauses {
+		var data []byte
+		if c.Data != "" {
+			var err error
+			if data, err = hexutil.Decode(c.Data); err != nil {
+				return nil, errors.WithMessage(err, fmt.Sprintf("clauses[%d].data", i))
+			}
+		}
+		value := new(big.Int)
+		if c.Value != nil {
+			value = (*big.Int)(c.Value)
+		}
+		builder.Clause(tx.NewClause(c.To).WithValue(value).WithData(data))
+	}
+	return builder.Build(), nil
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/v2/api/utils"
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/block"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/logdb"
 )
@@ -21,23 +23,63 @@ type Events struct {
 	repo  *chain.Repository
 	db    *logdb.LogDB
 	limit uint64
+	bft   bft.Committer
 }
 
-func New(repo *chain.Repository, db *logdb.LogDB, logsLimit uint64) *Events {
+func New(repo *chain.Repository, db *logdb.LogDB, logsLimit uint64, bft bft.Committer) *Events {
 	return &Events{
 		repo,
 		db,
 		logsLimit,
+		bft,
 	}
 }
 
-// Filter query events with option
-func (e *Events) filter(ctx context.Context, ef *EventFilter) ([]*FilteredEvent, error) {
-	chain := e.repo.NewBestChain()
+// RevisionChain returns the chain of the revision the logs are filtered as of, or nil for the best block,
+// as the logs are written ahead of it.
+func RevisionChain(ctx context.Context, repo *chain.Repository, bft bft.Committer, revision string) (*chain.Chain, error) {
+	rev, err := utils.ParseRevision(ctx, revision, false)
+	if err != nil {
+		return nil, utils.BadRequest(errors.WithMessage(err, "revision"))
+	}
+	summary, err := utils.GetSummary(rev, repo, bft)
+	if err != nil {
+		if utils.IsRevisionError(err) {
+			return nil, utils.BadRequest(errors.WithMessage(err, "revision"))
+		}
+		return nil, err
+	}
+	if rev.IsBest() {
+		return nil, nil
+	}
+	return repo.NewChain(summary.Header.ID()), nil
+}
+
+// CapRange excludes the blocks after the head of the chain from the range.
+func CapRange(chain *chain.Chain, rng *logdb.Range) *logdb.Range {
+	head := block.Number(chain.HeadID())
+	if rng == nil {
+		return &logdb.Range{From: 0, To: head}
+	}
+	if rng.To > head {
+		return &logdb.Range{From: rng.From, To: head}
+	}
+	return rng
+}
+
+// Filter query events with option, as of the head of the chain if given, otherwise the best chain.
+func (e *Events) filter(ctx context.Context, ef *EventFilter, revChain *chain.Chain) ([]*FilteredEvent, error) {
+	chain := revChain
+	if chain == nil {
+		chain = e.repo.NewBestChain()
+	}
 	filter, err := convertEventFilter(chain, ef)
 	if err != nil {
 		return nil, err
 	}
+	if revChain != nil {
+		filter.Range = CapRange(chain, filter.Range)
+	}
 	events, err := e.db.FilterEvents(ctx, filter)
 	if err != nil {
 		return nil, err
@@ -66,7 +108,12 @@ func (e *Events) handleFilter(w http.ResponseWriter, req *http.Request) error {
 		}
 	}
 
-	fes, err := e.filter(req.Context(), &filter)
+	revChain, err := RevisionChain(req.Context(), e.repo, e.bft, req.URL.Query().Get("revision"))
+	if err != nil {
+		return err
+	}
+
+	fes, err := e.filter(req.Context(), &filter, revChain)
 	if err != nil {
 		return err
 	}
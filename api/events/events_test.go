@@ -182,7 +182,7 @@ func initEventServer(t *testing.T, limit uint64) *testchain.Chain {
 	require.NoError(t, err)
 
 	router := mux.NewRouter()
-	events.New(thorChain.Repo(), thorChain.LogDB(), limit).Mount(router, "/logs/event")
+	events.New(thorChain.Repo(), thorChain.LogDB(), limit, thorChain.Engine()).Mount(router, "/logs/event")
 	ts = httptest.NewServer(router)
 
 	return thorChain
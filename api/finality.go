@@ -0,0 +1,24 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package api
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Default finalities of the requests without an explicit revision.
+const (
+	FinalityBest      = "best"
+	FinalityFinalized = "finalized"
+)
+
+// ValidateDefaultFinality checks whether the default finality is supported. Empty string is the same as best.
+func ValidateDefaultFinality(finality string) error {
+	if finality == "" || finality == FinalityBest || finality == FinalityFinalized {
+		return nil
+	}
+	return errors.Errorf("unsupported default finality %q, should be one of finalized and best", finality)
+}
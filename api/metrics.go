@@ -60,6 +60,13 @@ func (m *metricsResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 	return h.Hijack()
 }
 
+// Flush sends any buffered data to the client, for streamed responses.
+func (m *metricsResponseWriter) Flush() {
+	if f, ok := m.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
 // metricsMiddleware is a middleware that records metrics for each request.
 func metricsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
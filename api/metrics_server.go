@@ -10,7 +10,6 @@ import (
 	"net/http"
 	"time"
 
-	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/v2/co"
@@ -24,10 +23,10 @@ func StartMetricsServer(addr string) (string, func(), error) {
 	}
 
 	router := mux.NewRouter()
+	// the metrics handler compresses the responses itself
 	router.PathPrefix("/metrics").Handler(metrics.HTTPHandler())
-	handler := handlers.CompressHandler(router)
 
-	srv := &http.Server{Handler: handler, ReadHeaderTimeout: time.Second, ReadTimeout: 5 * time.Second}
+	srv := &http.Server{Handler: router, ReadHeaderTimeout: time.Second, ReadTimeout: 5 * time.Second}
 	var goes co.Goes
 	goes.Go(func() {
 		srv.Serve(listener)
@@ -48,7 +48,7 @@ func TestMetricsMiddleware(t *testing.T) {
 	assert.NotNil(t, err)
 
 	router := mux.NewRouter()
-	acc := accounts.New(thorChain.Repo(), thorChain.Stater(), math.MaxUint64, thor.NoFork, thorChain.Engine(), true)
+	acc := accounts.New(thorChain.Repo(), thorChain.Stater(), nil, math.MaxUint64, thor.NoFork, thorChain.Engine(), true)
 	acc.Mount(router, "/accounts")
 	router.PathPrefix("/metrics").Handler(metrics.HTTPHandler())
 	router.Use(metricsMiddleware)
@@ -103,7 +103,7 @@ func TestWebsocketMetrics(t *testing.T) {
 	require.NoError(t, err)
 
 	router := mux.NewRouter()
-	sub := subscriptions.New(thorChain.Repo(), []string{"*"}, 10, txpool.New(thorChain.Repo(), thorChain.Stater(), txpool.Options{}), true)
+	sub := subscriptions.New(thorChain.Repo(), thorChain.Stater(), thorChain.LogDB(), []string{"*"}, 10, txpool.New(thorChain.Repo(), thorChain.Stater(), txpool.Options{}), true, subscriptions.Options{})
 	sub.Mount(router, "/subscriptions")
 	router.PathPrefix("/metrics").Handler(metrics.HTTPHandler())
 	router.Use(metricsMiddleware)
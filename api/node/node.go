@@ -10,15 +10,40 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/vechain/thor/v2/api/utils"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/txpool"
 )
 
 type Node struct {
-	nw Network
+	nw           Network
+	pool         *txpool.TxPool
+	enableTxPool bool
+	repo         *chain.Repository
+	stater       *state.Stater
+	forkConfig   thor.ForkConfig
+	master       *thor.Address
 }
 
-func New(nw Network) *Node {
+// New creates the node API. The master is the address of the node master, nil if not any, e.g. in solo mode.
+func New(
+	nw Network,
+	pool *txpool.TxPool,
+	enableTxPool bool,
+	repo *chain.Repository,
+	stater *state.Stater,
+	forkConfig thor.ForkConfig,
+	master *thor.Address,
+) *Node {
 	return &Node{
 		nw,
+		pool,
+		enableTxPool,
+		repo,
+		stater,
+		forkConfig,
+		master,
 	}
 }
 
@@ -30,6 +55,12 @@ func (n *Node) handleNetwork(w http.ResponseWriter, _ *http.Request) error {
 	return utils.WriteJSON(w, n.PeersStats())
 }
 
+func (n *Node) handleNetworkStatus(w http.ResponseWriter, _ *http.Request) error {
+	return utils.WriteJSON(w, &NetworkStatus{
+		NATStatus: ConvertNATStatus(n.nw.NATStatus()),
+	})
+}
+
 func (n *Node) Mount(root *mux.Router, pathPrefix string) {
 	sub := root.PathPrefix(pathPrefix).Subrouter()
 
@@ -37,4 +68,18 @@ func (n *Node) Mount(root *mux.Router, pathPrefix string) {
 		Methods(http.MethodGet).
 		Name("GET /node/network/peers").
 		HandlerFunc(utils.WrapHandlerFunc(n.handleNetwork))
+	sub.Path("/network/status").
+		Methods(http.MethodGet).
+		Name("GET /node/network/status").
+		HandlerFunc(utils.WrapHandlerFunc(n.handleNetworkStatus))
+	sub.Path("/schedule").
+		Methods(http.MethodGet).
+		Name("GET /node/schedule").
+		HandlerFunc(utils.WrapHandlerFunc(n.handleSchedule))
+	if n.enableTxPool {
+		sub.Path("/txpool").
+			Methods(http.MethodGet).
+			Name("GET /node/txpool").
+			HandlerFunc(utils.WrapHandlerFunc(n.handleTxPool))
+	}
 }
@@ -5,21 +5,50 @@
 package node_test
 
 import (
+	"math/big"
+	"net"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/vechain/thor/v2/api/node"
+	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/comm"
+	"github.com/vechain/thor/v2/consensus"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/p2psrv"
+	"github.com/vechain/thor/v2/packer"
+	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/thorclient"
+	"github.com/vechain/thor/v2/tx"
 	"github.com/vechain/thor/v2/txpool"
 )
 
-var ts *httptest.Server
+var (
+	ts        *httptest.Server
+	thorChain *testchain.Chain
+	nw        *network
+	pool      *txpool.TxPool
+	chainTag  byte
+)
+
+type network struct {
+	*comm.Communicator
+	natStatus p2psrv.NATStatus
+}
+
+func (n *network) NATStatus() p2psrv.NATStatus {
+	return n.natStatus
+}
 
 func TestNode(t *testing.T) {
 	initCommServer(t)
@@ -30,21 +59,277 @@ func TestNode(t *testing.T) {
 	assert.Equal(t, 0, len(peersStats), "count should be zero")
 }
 
+func TestNetworkStatus(t *testing.T) {
+	initCommServer(t)
+	tclient := thorclient.New(ts.URL)
+
+	nw.natStatus = p2psrv.NATStatus{State: p2psrv.NATUnknown}
+	status, err := tclient.NetworkStatus()
+	require.NoError(t, err)
+	assert.Equal(t, &node.NATStatus{State: p2psrv.NATUnknown}, status.NATStatus)
+
+	lastRenewal := time.Unix(1700000000, 0).UTC()
+	nw.natStatus = p2psrv.NATStatus{
+		State:        p2psrv.NATMapped,
+		Mechanism:    "UPNP IGDv2-IP1",
+		ExternalIP:   net.ParseIP("1.2.3.4"),
+		ExternalPort: 11235,
+		LastRenewal:  lastRenewal,
+	}
+	status, err = tclient.NetworkStatus()
+	require.NoError(t, err)
+	assert.Equal(t, p2psrv.NATMapped, status.NATStatus.State)
+	assert.Equal(t, "UPNP IGDv2-IP1", status.NATStatus.Mechanism)
+	assert.Equal(t, "1.2.3.4", status.NATStatus.ExternalIP)
+	assert.Equal(t, 11235, status.NATStatus.ExternalPort)
+	require.NotNil(t, status.NATStatus.LastRenewal)
+	assert.True(t, lastRenewal.Equal(*status.NATStatus.LastRenewal))
+}
+
+func TestTxPool(t *testing.T) {
+	initCommServer(t)
+	tclient := thorclient.New(ts.URL)
+
+	to := thor.BytesToAddress([]byte("to"))
+	newTx := func(acc genesis.DevAccount, nonce uint64) *tx.Transaction {
+		trx := new(tx.Builder).ChainTag(chainTag).Expiration(100).Gas(21000).Nonce(nonce).Clause(tx.NewClause(&to)).Build()
+		return tx.MustSign(trx, acc.PrivateKey)
+	}
+	accounts := genesis.DevAccounts()
+	for i := range 5 {
+		require.NoError(t, pool.AddLocal(newTx(accounts[i%2], uint64(i))))
+	}
+	txIDs := func(txs []*node.PoolTx) []thor.Bytes32 {
+		ids := make([]thor.Bytes32, 0, len(txs))
+		for _, trx := range txs {
+			ids = append(ids, trx.ID)
+		}
+		return ids
+	}
+
+	all, err := tclient.TxPool(nil)
+	require.NoError(t, err)
+	require.Len(t, all.Transactions, 5)
+	assert.Nil(t, all.Next)
+	order := txIDs(all.Transactions)
+	for _, poolTx := range all.Transactions {
+		var trx *tx.Transaction
+		require.NoError(t, rlp.DecodeBytes(hexutil.MustDecode(poolTx.Raw), &trx))
+		assert.Equal(t, poolTx.ID, trx.ID())
+		origin, err := trx.Origin()
+		require.NoError(t, err)
+		assert.Equal(t, origin, poolTx.Origin)
+		assert.Nil(t, poolTx.Delegator)
+		assert.Equal(t, uint64(21000), poolTx.Gas)
+	}
+
+	t.Run("filters", func(t *testing.T) {
+		byOrigin, err := tclient.TxPool(&node.TxPoolFilter{Origin: &accounts[1].Address})
+		require.NoError(t, err)
+		require.Len(t, byOrigin.Transactions, 2)
+		for _, poolTx := range byOrigin.Transactions {
+			assert.Equal(t, accounts[1].Address, poolTx.Origin)
+		}
+
+		// the test chain is not synced, so nothing is executable
+		executable := true
+		executables, err := tclient.TxPool(&node.TxPoolFilter{Executable: &executable})
+		require.NoError(t, err)
+		assert.Empty(t, executables.Transactions)
+		assert.Nil(t, executables.Next)
+
+		res, status, err := tclient.RawHTTPClient().RawHTTPGet("/node/txpool?type=legacy&executable=false")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, status, string(res))
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		page, err := tclient.TxPoolIDs(&node.TxPoolFilter{Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, order[:2], page.Transactions)
+		require.NotNil(t, page.Next)
+
+		// the pool churns between pages
+		removed := pool.Get(order[2])
+		require.True(t, pool.Remove(removed.Hash(), removed.ID()))
+		added := newTx(accounts[0], 100)
+		require.NoError(t, pool.AddLocal(added))
+
+		page, err = tclient.TxPoolIDs(&node.TxPoolFilter{Limit: 2, Cursor: *page.Next})
+		require.NoError(t, err)
+		assert.Equal(t, order[3:5], page.Transactions)
+		require.NotNil(t, page.Next)
+
+		page, err = tclient.TxPoolIDs(&node.TxPoolFilter{Limit: 2, Cursor: *page.Next})
+		require.NoError(t, err)
+		assert.Equal(t, []thor.Bytes32{added.ID()}, page.Transactions)
+		assert.Nil(t, page.Next)
+	})
+
+	t.Run("bad requests", func(t *testing.T) {
+		for _, query := range []string{"?limit=0", "?limit=1001", "?cursor=abc", "?origin=0x01", "?executable=abc", "?expanded=abc", "?type=dynamic"} {
+			_, status, err := tclient.RawHTTPClient().RawHTTPGet("/node/txpool" + query)
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, status, query)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		router := mux.NewRouter()
+		node.New(nw, pool, false, thorChain.Repo(), thorChain.Stater(), thorChain.GetForkConfig(), nil).Mount(router, "/node")
+		server := httptest.NewServer(router)
+		defer server.Close()
+
+		_, status, err := thorclient.New(server.URL).RawHTTPClient().RawHTTPGet("/node/txpool")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+}
+
 func initCommServer(t *testing.T) {
-	thorChain, err := testchain.NewIntegrationTestChain()
+	var err error
+	thorChain, err = testchain.NewIntegrationTestChain()
 	require.NoError(t, err)
 
-	communicator := comm.New(
-		thorChain.Repo(),
-		txpool.New(thorChain.Repo(), thorChain.Stater(), txpool.Options{
-			Limit:           10000,
-			LimitPerAccount: 16,
-			MaxLifetime:     10 * time.Minute,
-		}),
-	)
+	pool = txpool.New(thorChain.Repo(), thorChain.Stater(), txpool.Options{
+		Limit:           10000,
+		LimitPerAccount: 16,
+		MaxLifetime:     10 * time.Minute,
+	})
+	chainTag = thorChain.Repo().ChainTag()
+	nw = &network{Communicator: comm.New(thorChain.Repo(), pool)}
 
 	router := mux.NewRouter()
-	node.New(communicator).Mount(router, "/node")
+	node.New(nw, pool, true, thorChain.Repo(), thorChain.Stater(), thorChain.GetForkConfig(), nil).Mount(router, "/node")
 
 	ts = httptest.NewServer(router)
 }
+
+func TestSchedule(t *testing.T) {
+	accounts := genesis.DevAccounts()
+
+	forkConfig := thor.NoFork
+	forkConfig.VIP191 = 1
+	forkConfig.BLOCKLIST = 0
+	forkConfig.VIP214 = 3 // to be scheduled by both versions of the scheduler
+
+	var (
+		auth []genesis.Authority
+		accs []genesis.Account
+	)
+	bal, _ := new(big.Int).SetString("1000000000000000000000000000", 10)
+	for _, acc := range accounts {
+		auth = append(auth, genesis.Authority{
+			MasterAddress:   acc.Address,
+			EndorsorAddress: acc.Address,
+			Identity:        thor.BytesToBytes32([]byte("master")),
+		})
+		accs = append(accs, genesis.Account{
+			Address: acc.Address,
+			Balance: (*genesis.HexOrDecimal256)(bal),
+			Energy:  (*genesis.HexOrDecimal256)(bal),
+		})
+	}
+	gene, err := genesis.NewCustomNet(&genesis.CustomGenesis{
+		LaunchTime: 1526400000,
+		GasLimit:   thor.InitialGasLimit,
+		ForkConfig: &forkConfig,
+		Authority:  auth,
+		Accounts:   accs,
+	})
+	require.NoError(t, err)
+
+	db := muxdb.NewMem()
+	stater := state.NewStater(db)
+	geneBlk, _, _, err := gene.Build(stater)
+	require.NoError(t, err)
+	repo, err := chain.NewRepository(db, geneBlk)
+	require.NoError(t, err)
+
+	master := accounts[0].Address
+	router := mux.NewRouter()
+	nd := node.New(nil, nil, false, repo, stater, forkConfig, &master)
+	nd.Mount(router, "/node")
+	server := httptest.NewServer(router)
+	defer server.Close()
+	tclient := thorclient.New(server.URL)
+
+	signer := func(addr thor.Address) genesis.DevAccount {
+		for _, acc := range accounts {
+			if acc.Address == addr {
+				return acc
+			}
+		}
+		t.Fatalf("unknown proposer %v", addr)
+		return genesis.DevAccount{}
+	}
+	cons := consensus.New(repo, stater, forkConfig)
+
+	// mint the block of the slot by its predicted proposer
+	mint := func(slot *node.ScheduleSlot) {
+		best := repo.BestBlockSummary()
+		proposer := signer(slot.Proposer)
+
+		flow, err := packer.New(repo, stater, proposer.Address, nil, forkConfig).Schedule(best, slot.Timestamp)
+		require.NoError(t, err)
+		require.Equal(t, slot.Timestamp, flow.When(), "the predicted proposer should be scheduled at the slot of block #%v", flow.Number())
+
+		blk, stage, receipts, err := flow.Pack(proposer.PrivateKey, 0, false)
+		require.NoError(t, err)
+		// the block is accepted by the consensus
+		_, _, err = cons.Process(best, blk, blk.Header().Timestamp(), 0)
+		require.NoError(t, err)
+
+		_, err = stage.Commit()
+		require.NoError(t, err)
+		require.NoError(t, repo.AddBlock(blk, receipts, 0))
+		require.NoError(t, repo.SetBestBlockID(blk.Header().ID()))
+
+		signerAddr, err := blk.Header().Signer()
+		require.NoError(t, err)
+		assert.Equal(t, slot.Proposer, signerAddr)
+	}
+
+	// blocks minted in consecutive slots are all predicted by one schedule, across the activation of VIP-214
+	now := uint64(time.Now().Unix())
+	schedule, err := nd.Schedule(8, now)
+	require.NoError(t, err)
+	require.Len(t, schedule.Slots, 8)
+	for _, slot := range schedule.Slots {
+		mint(slot)
+	}
+
+	for i := range 6 {
+		best := repo.BestBlockSummary()
+
+		schedule, err := tclient.Schedule(0)
+		require.NoError(t, err)
+		assert.Equal(t, best.Header.ID(), schedule.ParentID)
+		assert.Equal(t, best.Header.Number(), schedule.ParentNumber)
+		require.Len(t, schedule.Slots, 30)
+		for j, slot := range schedule.Slots {
+			assert.Equal(t, j > 0, slot.Probabilistic)
+			assert.Equal(t, slot.Proposer == master, slot.IsMaster)
+			assert.Equal(t, schedule.Slots[0].Timestamp+uint64(j)*thor.BlockInterval, slot.Timestamp)
+		}
+
+		if i%2 == 1 {
+			// the proposer of the first slot misses it, the slot after it is rescheduled
+			missed := schedule.Slots[0]
+			schedule, err = nd.Schedule(30, missed.Timestamp+1)
+			require.NoError(t, err)
+			assert.Equal(t, missed.Timestamp+thor.BlockInterval, schedule.Slots[0].Timestamp)
+		}
+		mint(schedule.Slots[0])
+	}
+
+	schedule, err = tclient.Schedule(5)
+	require.NoError(t, err)
+	assert.Len(t, schedule.Slots, 5)
+
+	for _, query := range []string{"?slots=0", "?slots=361", "?slots=abc"} {
+		_, status, err := tclient.RawHTTPClient().RawHTTPGet("/node/schedule" + query)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, status, query)
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package node
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/api/utils"
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/poa"
+	"github.com/vechain/thor/v2/thor"
+)
+
+const (
+	defaultScheduleSlots = 30
+	maxScheduleSlots     = 360
+)
+
+// loadProposers returns the proposers and the seed to schedule the block on top of the parent.
+func (n *Node) loadProposers(parent *chain.BlockSummary) (proposers []poa.Proposer, seed []byte, err error) {
+	st := n.stater.NewState(parent.Header.StateRoot(), parent.Header.Number(), parent.Conflicts, parent.SteadyNum)
+
+	params := builtin.Params.Native(st)
+	endorsement, err := params.Get(thor.KeyProposerEndorsement)
+	if err != nil {
+		return nil, nil, err
+	}
+	mbp, err := params.Get(thor.KeyMaxBlockProposers)
+	if err != nil {
+		return nil, nil, err
+	}
+	maxBlockProposers := mbp.Uint64()
+	if maxBlockProposers == 0 || maxBlockProposers > thor.InitialMaxBlockProposers {
+		maxBlockProposers = thor.InitialMaxBlockProposers
+	}
+
+	candidates, err := builtin.Authority.Native(st).Candidates(endorsement, maxBlockProposers)
+	if err != nil {
+		return nil, nil, err
+	}
+	proposers = make([]poa.Proposer, 0, len(candidates))
+	for _, c := range candidates {
+		proposers = append(proposers, poa.Proposer{
+			Address: c.NodeMaster,
+			Active:  c.Active,
+		})
+	}
+
+	if parent.Header.Number()+1 >= n.forkConfig.VIP214 {
+		// the seeder caches seeds in a map, so it's not shared between requests
+		if seed, err = poa.NewSeeder(n.repo).Generate(parent.Header.ID()); err != nil {
+			return nil, nil, err
+		}
+	}
+	return proposers, seed, nil
+}
+
+// newScheduler creates the scheduler of the proposer for the block on top of a parent of the given number
+// and timestamp, the same way as the packer and the consensus do.
+func (n *Node) newScheduler(addr thor.Address, proposers []poa.Proposer, parentNum uint32, parentTime uint64, seed []byte) (poa.Scheduler, error) {
+	if parentNum+1 < n.forkConfig.VIP214 {
+		return poa.NewSchedulerV1(addr, proposers, parentNum, parentTime)
+	}
+	return poa.NewSchedulerV2(addr, proposers, parentNum, parentTime, seed)
+}
+
+// Schedule returns the expected proposers of the count of slots following the best block, starting from
+// the first slot not earlier than nowTimestamp.
+//
+// Every slot is predicted on top of the block expected at the previous slot, that is the likely chain if
+// no proposer misses its slot. The expected blocks are assumed to have the seed of the best block, and the
+// proposers updated the way the blocks do. Since a scheduler is built for any of the active proposers,
+// only the actives are scheduled.
+func (n *Node) Schedule(count int, nowTimestamp uint64) (*Schedule, error) {
+	best := n.repo.BestBlockSummary()
+	proposers, seed, err := n.loadProposers(best)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := &Schedule{
+		ParentID:     best.Header.ID(),
+		ParentNumber: best.Header.Number(),
+		Slots:        []*ScheduleSlot{},
+	}
+
+	const T = thor.BlockInterval
+	blockTime := best.Header.Timestamp() + T
+	if nowTimestamp > blockTime {
+		// ensure T aligned, and >= nowTimestamp
+		blockTime += (nowTimestamp - blockTime + T - 1) / T * T
+	}
+	parentNum, parentTime := best.Header.Number(), best.Header.Timestamp()
+	for i := range count {
+		var active *thor.Address
+		for _, p := range proposers {
+			if p.Active {
+				active = &p.Address
+				break
+			}
+		}
+		if active == nil {
+			break
+		}
+		sched, err := n.newScheduler(*active, proposers, parentNum, parentTime, seed)
+		if err != nil {
+			return nil, err
+		}
+		proposer := sched.WhoseTurn(blockTime)
+		schedule.Slots = append(schedule.Slots, &ScheduleSlot{
+			Timestamp: blockTime,
+			Proposer:  proposer,
+			IsMaster:  n.master != nil && *n.master == proposer,
+			// the following slots are predicted on top of blocks not yet produced
+			Probabilistic: i > 0,
+		})
+
+		// the block expected at the slot deactivates the proposers of the slots skipped before it
+		if sched, err = n.newScheduler(proposer, proposers, parentNum, parentTime, seed); err != nil {
+			return nil, err
+		}
+		updates, _ := sched.Updates(blockTime)
+		proposers = append([]poa.Proposer(nil), proposers...)
+		for _, u := range updates {
+			for j := range proposers {
+				if proposers[j].Address == u.Address {
+					proposers[j].Active = u.Active
+				}
+			}
+		}
+		parentNum, parentTime = parentNum+1, blockTime
+		blockTime += T
+	}
+	return schedule, nil
+}
+
+func (n *Node) handleSchedule(w http.ResponseWriter, req *http.Request) error {
+	count := defaultScheduleSlots
+	if s := req.URL.Query().Get("slots"); s != "" {
+		slots, err := strconv.Atoi(s)
+		if err != nil || slots <= 0 || slots > maxScheduleSlots {
+			return utils.BadRequest(errors.Errorf("slots: should be in range [1, %d]", maxScheduleSlots))
+		}
+		count = slots
+	}
+
+	schedule, err := n.Schedule(count, uint64(time.Now().Unix()))
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, schedule)
+}
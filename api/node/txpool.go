@@ -0,0 +1,163 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package node
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/api/utils"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/txpool"
+)
+
+const (
+	defaultTxPoolLimit = 100
+	maxTxPoolLimit     = 1000
+)
+
+// txPoolCursor is the position of the last tx of a page, the next page starts right after it.
+// Since the pool is ordered by the time added, txs added or removed meanwhile don't shift the pages.
+type txPoolCursor struct {
+	timeAdded int64
+	id        thor.Bytes32
+}
+
+func newTxPoolCursor(entry *txpool.TxEntry) *txPoolCursor {
+	return &txPoolCursor{entry.TimeAdded, entry.ID()}
+}
+
+func parseTxPoolCursor(s string) (*txPoolCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(data) != 8+32 {
+		return nil, errors.New("invalid cursor")
+	}
+	return &txPoolCursor{
+		timeAdded: int64(binary.BigEndian.Uint64(data)),
+		id:        thor.BytesToBytes32(data[8:]),
+	}, nil
+}
+
+func (c *txPoolCursor) String() string {
+	var data [8 + 32]byte
+	binary.BigEndian.PutUint64(data[:], uint64(c.timeAdded))
+	copy(data[8:], c.id[:])
+	return base64.RawURLEncoding.EncodeToString(data[:])
+}
+
+// before returns whether the cursor is before the entry in the pool order.
+func (c *txPoolCursor) before(entry *txpool.TxEntry) bool {
+	if c.timeAdded != entry.TimeAdded {
+		return c.timeAdded < entry.TimeAdded
+	}
+	return bytes.Compare(c.id[:], entry.ID().Bytes()) < 0
+}
+
+func (n *Node) handleTxPool(w http.ResponseWriter, req *http.Request) error {
+	query := req.URL.Query()
+
+	var origin *thor.Address
+	if s := query.Get("origin"); s != "" {
+		addr, err := thor.ParseAddress(s)
+		if err != nil {
+			return utils.BadRequest(errors.WithMessage(err, "origin"))
+		}
+		origin = &addr
+	}
+	var executable *bool
+	if s := query.Get("executable"); s != "" {
+		b, err := utils.StringToBoolean(s, false)
+		if err != nil {
+			return utils.BadRequest(errors.WithMessage(err, "executable"))
+		}
+		executable = &b
+	}
+	// only legacy txs exist, the param is reserved for other tx types
+	if s := query.Get("type"); s != "" && s != "legacy" {
+		return utils.BadRequest(errors.New("type: unsupported tx type"))
+	}
+	limit := uint64(defaultTxPoolLimit)
+	if s := query.Get("limit"); s != "" {
+		parsed, err := strconv.ParseUint(s, 10, 64)
+		if err != nil || parsed == 0 || parsed > maxTxPoolLimit {
+			return utils.BadRequest(fmt.Errorf("limit: should be between 1 and %d", maxTxPoolLimit))
+		}
+		limit = parsed
+	}
+	var cursor *txPoolCursor
+	if s := query.Get("cursor"); s != "" {
+		c, err := parseTxPoolCursor(s)
+		if err != nil {
+			return utils.BadRequest(errors.WithMessage(err, "cursor"))
+		}
+		cursor = c
+	}
+	expanded, err := utils.StringToBoolean(query.Get("expanded"), true)
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "expanded"))
+	}
+
+	entries := n.pool.Snapshot()
+	if cursor != nil {
+		entries = entries[sort.Search(len(entries), func(i int) bool {
+			return cursor.before(entries[i])
+		}):]
+	}
+
+	var (
+		page []*txpool.TxEntry
+		next *string
+	)
+	for _, entry := range entries {
+		if origin != nil && entry.Origin != *origin {
+			continue
+		}
+		if executable != nil && entry.Executable != *executable {
+			continue
+		}
+		if uint64(len(page)) == limit {
+			s := newTxPoolCursor(page[len(page)-1]).String()
+			next = &s
+			break
+		}
+		page = append(page, entry)
+	}
+
+	if !expanded {
+		ids := make([]thor.Bytes32, 0, len(page))
+		for _, entry := range page {
+			ids = append(ids, entry.ID())
+		}
+		return utils.WriteJSON(w, &TxPoolIDs{Transactions: ids, Next: next})
+	}
+
+	txs := make([]*PoolTx, 0, len(page))
+	for _, entry := range page {
+		raw, err := rlp.EncodeToBytes(entry.Transaction)
+		if err != nil {
+			return err
+		}
+		txs = append(txs, &PoolTx{
+			ID:           entry.ID(),
+			Origin:       entry.Origin,
+			Delegator:    entry.Delegator,
+			Executable:   entry.Executable,
+			GasPriceCoef: entry.GasPriceCoef(),
+			Gas:          entry.Gas(),
+			Size:         uint64(entry.Size()),
+			Raw:          hexutil.Encode(raw),
+		})
+	}
+	return utils.WriteJSON(w, &TxPool{Transactions: txs, Next: next})
+}
@@ -6,12 +6,60 @@
 package node
 
 import (
+	"time"
+
 	"github.com/vechain/thor/v2/comm"
+	"github.com/vechain/thor/v2/p2psrv"
 	"github.com/vechain/thor/v2/thor"
 )
 
 type Network interface {
 	PeersStats() []*comm.PeerStats
+	NATStatus() p2psrv.NATStatus
+}
+
+type NetworkStatus struct {
+	NATStatus *NATStatus `json:"natStatus"`
+}
+
+type NATStatus struct {
+	State        string     `json:"state"` // one of mapped, unmapped, static and unknown
+	Mechanism    string     `json:"mechanism"`
+	ExternalIP   string     `json:"externalIP"`
+	ExternalPort int        `json:"externalPort"`
+	LastRenewal  *time.Time `json:"lastRenewal"`
+}
+
+// PoolTx is a tx in the pool.
+type PoolTx struct {
+	ID           thor.Bytes32  `json:"id"`
+	Origin       thor.Address  `json:"origin"`
+	Delegator    *thor.Address `json:"delegator"`
+	Executable   bool          `json:"executable"`
+	GasPriceCoef uint8         `json:"gasPriceCoef"`
+	Gas          uint64        `json:"gas"`
+	Size         uint64        `json:"size"`
+	Raw          string        `json:"raw"` // RLP encoded tx
+}
+
+// TxPool is a page of the txs in the pool. Next is the cursor of the next page, nil if no more.
+type TxPool struct {
+	Transactions []*PoolTx `json:"transactions"`
+	Next         *string   `json:"next"`
+}
+
+// TxPoolIDs is a page of the IDs of the txs in the pool.
+type TxPoolIDs struct {
+	Transactions []thor.Bytes32 `json:"transactions"`
+	Next         *string        `json:"next"`
+}
+
+// TxPoolFilter filters and paginates the txs listed from the pool, zero values for no filtering.
+type TxPoolFilter struct {
+	Origin     *thor.Address
+	Executable *bool
+	Limit      uint64
+	Cursor     string // the next cursor of the previous page
 }
 
 type PeerStats struct {
@@ -22,6 +70,9 @@ type PeerStats struct {
 	NetAddr     string       `json:"netAddr"`
 	Inbound     bool         `json:"inbound"`
 	Duration    uint64       `json:"duration"`
+	Throughput  uint64       `json:"throughput"`
+	Violations  int          `json:"violations"`
+	Timeouts    int          `json:"timeouts"`
 }
 
 func ConvertPeersStats(ss []*comm.PeerStats) []*PeerStats {
@@ -38,7 +89,42 @@ func ConvertPeersStats(ss []*comm.PeerStats) []*PeerStats {
 			NetAddr:     peerStats.NetAddr,
 			Inbound:     peerStats.Inbound,
 			Duration:    peerStats.Duration,
+			Throughput:  peerStats.Throughput,
+			Violations:  peerStats.Violations,
+			Timeouts:    peerStats.Timeouts,
 		}
 	}
 	return peersStats
 }
+
+func ConvertNATStatus(s p2psrv.NATStatus) *NATStatus {
+	status := &NATStatus{
+		State:        s.State,
+		Mechanism:    s.Mechanism,
+		ExternalPort: s.ExternalPort,
+	}
+	if s.ExternalIP != nil {
+		status.ExternalIP = s.ExternalIP.String()
+	}
+	if !s.LastRenewal.IsZero() {
+		lastRenewal := s.LastRenewal
+		status.LastRenewal = &lastRenewal
+	}
+	return status
+}
+
+// Schedule is the expected proposers of the slots following the parent, i.e. the best block.
+type Schedule struct {
+	ParentID     thor.Bytes32    `json:"parentID"`
+	ParentNumber uint32          `json:"parentNumber"`
+	Slots        []*ScheduleSlot `json:"slots"`
+}
+
+// ScheduleSlot is the expected proposer at the slot timestamp. The slots except the first are probabilistic,
+// since they are predicted on top of the blocks expected at the previous slots.
+type ScheduleSlot struct {
+	Timestamp     uint64       `json:"timestamp"`
+	Proposer      thor.Address `json:"proposer"`
+	IsMaster      bool         `json:"isMaster"` // whether the proposer is the master of this node
+	Probabilistic bool         `json:"probabilistic"`
+}
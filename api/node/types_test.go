@@ -42,6 +42,9 @@ func TestConvertPeersStats(t *testing.T) {
 			NetAddr:     "netAddr2",
 			Inbound:     false,
 			Duration:    20,
+			Throughput:  1024,
+			Violations:  1,
+			Timeouts:    2,
 		},
 	}
 	expected = []*node.PeerStats{
@@ -62,6 +65,9 @@ func TestConvertPeersStats(t *testing.T) {
 			NetAddr:     "netAddr2",
 			Inbound:     false,
 			Duration:    20,
+			Throughput:  1024,
+			Violations:  1,
+			Timeouts:    2,
 		},
 	}
 	assert.Equal(t, expected, node.ConvertPeersStats(ss))
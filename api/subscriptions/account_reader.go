@@ -0,0 +1,137 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package subscriptions
+
+import (
+	"bytes"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/vechain/thor/v2/abi"
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/thor/bloom"
+	"github.com/vechain/thor/v2/tx"
+)
+
+var energyTransferEvent *abi.Event
+
+func init() {
+	var found bool
+	if energyTransferEvent, found = builtin.Energy.ABI.EventByName("Transfer"); !found {
+		panic("transfer event not found")
+	}
+}
+
+// accountReader emits the balances of the account once a block transfers VET or VTHO in or out of it.
+// It goes through the beats of blocks, and reads the receipts and the state only if the bloom
+// filter of the beat contains the account.
+type accountReader struct {
+	repo    *chain.Repository
+	stater  *state.Stater
+	address thor.Address
+	beats   *beat2Reader
+}
+
+func newAccountReader(repo *chain.Repository, stater *state.Stater, position thor.Bytes32, address thor.Address, cache *messageCache[Beat2Message]) *accountReader {
+	return &accountReader{
+		repo:    repo,
+		stater:  stater,
+		address: address,
+		beats:   newBeat2Reader(repo, position, cache),
+	}
+}
+
+func (ar *accountReader) Read() ([]interface{}, bool, error) {
+	beats, hasMore, err := ar.beats.Read()
+	if err != nil {
+		return nil, false, err
+	}
+	var msgs []interface{}
+	for _, beat := range beats {
+		beat := beat.(Beat2Message)
+		mayContain, err := ar.bloomContains(&beat)
+		if err != nil {
+			return nil, false, err
+		}
+		if !mayContain {
+			continue
+		}
+
+		receipts, err := ar.repo.GetBlockReceipts(beat.ID)
+		if err != nil {
+			return nil, false, err
+		}
+		if !ar.transferred(receipts) {
+			continue
+		}
+		msg, err := ar.accountMessage(&beat)
+		if err != nil {
+			return nil, false, err
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, hasMore, nil
+}
+
+func (ar *accountReader) bloomContains(beat *Beat2Message) (bool, error) {
+	bits, err := hexutil.Decode(beat.Bloom)
+	if err != nil {
+		return false, err
+	}
+	filter := bloom.Filter{Bits: bits, K: beat.K}
+	// keys are added to the bloom with leading zeros trimmed
+	return filter.Contains(bytes.TrimLeft(ar.address.Bytes(), "\x00")), nil
+}
+
+// transferred returns whether VET or VTHO is transferred in or out of the account.
+func (ar *accountReader) transferred(receipts tx.Receipts) bool {
+	topic := thor.BytesToBytes32(ar.address.Bytes())
+	for _, receipt := range receipts {
+		for _, output := range receipt.Outputs {
+			for _, transfer := range output.Transfers {
+				if transfer.Sender == ar.address || transfer.Recipient == ar.address {
+					return true
+				}
+			}
+			for _, event := range output.Events {
+				if event.Address != builtin.Energy.Address || len(event.Topics) != 3 || event.Topics[0] != energyTransferEvent.ID() {
+					continue
+				}
+				if event.Topics[1] == topic || event.Topics[2] == topic {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (ar *accountReader) accountMessage(beat *Beat2Message) (*AccountMessage, error) {
+	summary, err := ar.repo.GetBlockSummary(beat.ID)
+	if err != nil {
+		return nil, err
+	}
+	st := ar.stater.NewState(summary.Header.StateRoot(), summary.Header.Number(), summary.Conflicts, summary.SteadyNum)
+	balance, err := st.GetBalance(ar.address)
+	if err != nil {
+		return nil, err
+	}
+	energy, err := st.GetEnergy(ar.address, summary.Header.Timestamp())
+	if err != nil {
+		return nil, err
+	}
+	return &AccountMessage{
+		Address:     ar.address,
+		VETBalance:  (*math.HexOrDecimal256)(balance),
+		VTHOBalance: (*math.HexOrDecimal256)(energy),
+		BlockID:     beat.ID,
+		BlockNumber: beat.Number,
+		Obsolete:    beat.Obsolete,
+	}, nil
+}
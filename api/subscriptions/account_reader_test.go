@@ -0,0 +1,142 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package subscriptions
+
+import (
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/txpool"
+)
+
+func newTransferTx(thorChain *testchain.Chain, nonce uint64, clause *tx.Clause) *tx.Transaction {
+	trx := new(tx.Builder).
+		ChainTag(thorChain.Repo().ChainTag()).
+		Expiration(100).
+		Gas(100_000).
+		Nonce(nonce).
+		Clause(clause).
+		Build()
+	return tx.MustSign(trx, genesis.DevAccounts()[0].PrivateKey)
+}
+
+func newVTHOTransferClause(t *testing.T, to thor.Address, amount *big.Int) *tx.Clause {
+	method, ok := builtin.Energy.ABI.MethodByName("transfer")
+	require.True(t, ok)
+	data, err := method.EncodeInput(to, amount)
+	require.NoError(t, err)
+	return tx.NewClause(&builtin.Energy.Address).WithData(data)
+}
+
+func TestAccountReader(t *testing.T) {
+	thorChain, err := testchain.NewIntegrationTestChain()
+	require.NoError(t, err)
+
+	account := thor.BytesToAddress([]byte("account"))
+	other := genesis.DevAccounts()[1].Address
+	sender := genesis.DevAccounts()[0]
+
+	// VET in, unrelated, VTHO in
+	require.NoError(t, thorChain.MintTransactions(sender, newTransferTx(thorChain, 1, tx.NewClause(&account).WithValue(big.NewInt(100)))))
+	require.NoError(t, thorChain.MintTransactions(sender, newTransferTx(thorChain, 2, tx.NewClause(&other).WithValue(big.NewInt(100)))))
+	require.NoError(t, thorChain.MintTransactions(sender, newTransferTx(thorChain, 3, newVTHOTransferClause(t, account, big.NewInt(200)))))
+
+	blocks, err := thorChain.GetAllBlocks()
+	require.NoError(t, err)
+	require.Len(t, blocks, 4)
+
+	reader := newAccountReader(thorChain.Repo(), thorChain.Stater(), blocks[0].Header().ID(), account, newMessageCache[Beat2Message](10))
+	var msgs []interface{}
+	for {
+		read, hasMore, err := reader.Read()
+		require.NoError(t, err)
+		if !hasMore {
+			break
+		}
+		msgs = append(msgs, read...)
+	}
+	require.Len(t, msgs, 2)
+
+	for i, want := range []struct {
+		blockNum    int
+		vet, vtho   int64
+		vthoAtLeast bool
+	}{
+		{1, 100, 0, false},
+		// VET generates VTHO as time goes
+		{3, 100, 200, true},
+	} {
+		msg := msgs[i].(*AccountMessage)
+		assert.Equal(t, account, msg.Address)
+		assert.Equal(t, blocks[want.blockNum].Header().ID(), msg.BlockID)
+		assert.Equal(t, blocks[want.blockNum].Header().Number(), msg.BlockNumber)
+		assert.Equal(t, want.vet, (*big.Int)(msg.VETBalance).Int64())
+		if want.vthoAtLeast {
+			assert.True(t, (*big.Int)(msg.VTHOBalance).Cmp(big.NewInt(want.vtho)) >= 0)
+		} else {
+			assert.Zero(t, (*big.Int)(msg.VTHOBalance).Sign())
+		}
+	}
+
+	// a transfer out is notified as well
+	assert.False(t, reader.transferred(tx.Receipts{{Outputs: []*tx.Output{{Transfers: tx.Transfers{{Sender: other, Recipient: sender.Address, Amount: big.NewInt(1)}}}}}}))
+	assert.True(t, reader.transferred(tx.Receipts{{Outputs: []*tx.Output{{Transfers: tx.Transfers{{Sender: account, Recipient: other, Amount: big.NewInt(1)}}}}}}))
+}
+
+func TestAccountSubscription(t *testing.T) {
+	thorChain, err := testchain.NewIntegrationTestChain()
+	require.NoError(t, err)
+
+	txPool := txpool.New(thorChain.Repo(), thorChain.Stater(), txpool.Options{
+		Limit:           100,
+		LimitPerAccount: 16,
+		MaxLifetime:     time.Hour,
+	})
+	t.Cleanup(txPool.Close)
+
+	router := mux.NewRouter()
+	sub := New(thorChain.Repo(), thorChain.Stater(), thorChain.LogDB(), []string{}, 5, txPool, false, Options{})
+	sub.Mount(router, "/subscriptions")
+	srv := httptest.NewServer(router)
+	t.Cleanup(func() {
+		srv.Close()
+		sub.Close()
+	})
+
+	account := thor.BytesToAddress([]byte("account"))
+	conn, resp, err := dialSubscription(srv, "/subscriptions/account/"+account.String(), "")
+	require.NoError(t, err)
+	defer conn.Close()
+	assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+
+	require.NoError(t, thorChain.MintTransactions(genesis.DevAccounts()[0], newTransferTx(thorChain, 1, tx.NewClause(&account).WithValue(big.NewInt(100)))))
+	best := thorChain.Repo().BestBlockSummary().Header
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var msg AccountMessage
+	require.NoError(t, conn.ReadJSON(&msg))
+	assert.Equal(t, account, msg.Address)
+	assert.Equal(t, best.ID(), msg.BlockID)
+	assert.Equal(t, best.Number(), msg.BlockNumber)
+	assert.Equal(t, int64(100), (*big.Int)(msg.VETBalance).Int64())
+	assert.Zero(t, (*big.Int)(msg.VTHOBalance).Sign())
+
+	_, resp, err = dialSubscription(srv, "/subscriptions/account/0xinvalid", "")
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp.Body.Close()
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package subscriptions
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+)
+
+// disconnect reasons, used as the label of the disconnect metric
+const (
+	disconnectClosed      = "closed"       // closed by the peer, or the read loop failed
+	disconnectPongTimeout = "pong_timeout" // the peer missed too many pongs
+	disconnectShutdown    = "shutdown"     // the server is shutting down
+	disconnectError       = "error"        // failed to read or write messages
+)
+
+var errPongTimeout = errors.New("pong timeout")
+
+// connection is an upgraded subscription connection.
+// Except for the read loop, it's driven by a single goroutine.
+type connection struct {
+	*websocket.Conn
+	topic       string
+	closed      chan struct{} // closed once the read loop ends
+	missedPongs atomic.Int32  // count of pings sent since the last pong
+	heartbeat   bool          // whether to send heartbeat messages on a quiet connection
+	idle        bool          // no message written since the last tick
+}
+
+func (c *connection) write(msg interface{}) error {
+	c.idle = false
+	if err := c.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+		return err
+	}
+	return c.WriteJSON(msg)
+}
+
+// tick sends a ping frame, and a heartbeat message if opted in and nothing was written since the last tick.
+// It returns errPongTimeout if the peer has missed too many pongs.
+func (c *connection) tick() error {
+	if c.missedPongs.Add(1) > maxMissedPongs {
+		return errPongTimeout
+	}
+	if err := c.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+		return err
+	}
+	if c.heartbeat && c.idle {
+		if err := c.write(&HeartbeatMessage{Timestamp: uint64(time.Now().Unix())}); err != nil {
+			return err
+		}
+	}
+	c.idle = true
+	return nil
+}
+
+func reasonOf(err error) string {
+	if err == errPongTimeout {
+		return disconnectPongTimeout
+	}
+	return disconnectError
+}
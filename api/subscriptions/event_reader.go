@@ -6,7 +6,10 @@
 package subscriptions
 
 import (
+	"context"
+
 	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/logdb"
 	"github.com/vechain/thor/v2/thor"
 )
 
@@ -14,17 +17,37 @@ type eventReader struct {
 	repo        *chain.Repository
 	filter      *EventFilter
 	blockReader chain.BlockReader
+	replay      *logReplay // replays historical events before reading blocks, nil if done
 }
 
-func newEventReader(repo *chain.Repository, position thor.Bytes32, filter *EventFilter) *eventReader {
+func newEventReader(repo *chain.Repository, position thor.Bytes32, filter *EventFilter, replay *logReplay) *eventReader {
 	return &eventReader{
 		repo:        repo,
 		filter:      filter,
 		blockReader: repo.NewBlockReader(position),
+		replay:      replay,
 	}
 }
 
 func (er *eventReader) Read() ([]interface{}, bool, error) {
+	if er.replay != nil {
+		if rng := er.replay.nextRange(); rng != nil {
+			events, err := er.replay.logDB.FilterEvents(context.Background(), &logdb.EventFilter{
+				CriteriaSet: []*logdb.EventCriteria{er.filter.criteria()},
+				Range:       rng,
+			})
+			if err != nil {
+				return nil, false, err
+			}
+			msgs := make([]interface{}, 0, len(events))
+			for _, event := range events {
+				msgs = append(msgs, convertLogEvent(event))
+			}
+			return msgs, true, nil
+		}
+		er.replay = nil
+	}
+
 	blocks, err := er.blockReader.Read()
 	if err != nil {
 		return nil, false, err
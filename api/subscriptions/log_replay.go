@@ -0,0 +1,91 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package subscriptions
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// replayBlockRange is the max count of blocks replayed from the logs db in a single read.
+const replayBlockRange = 100
+
+// logReplay tracks the historical blocks to be replayed from the logs db
+// before a subscription switches to live updates.
+type logReplay struct {
+	logDB *logdb.LogDB
+	next  uint32 // the next block to be replayed
+	to    uint32 // the last block to be replayed, live updates start after it
+}
+
+// nextRange returns the next range of blocks to be replayed, or nil if all done.
+func (r *logReplay) nextRange() *logdb.Range {
+	if r.next > r.to {
+		return nil
+	}
+	rng := &logdb.Range{From: r.next, To: r.to}
+	if r.to-r.next >= replayBlockRange {
+		rng.To = r.next + replayBlockRange - 1
+	}
+	r.next = rng.To + 1
+	return rng
+}
+
+func (ef *EventFilter) criteria() *logdb.EventCriteria {
+	return &logdb.EventCriteria{
+		Address: ef.Address,
+		Topics:  [5]*thor.Bytes32{ef.Topic0, ef.Topic1, ef.Topic2, ef.Topic3, ef.Topic4},
+	}
+}
+
+func (tf *TransferFilter) criteria() *logdb.TransferCriteria {
+	return &logdb.TransferCriteria{
+		TxOrigin:  tf.TxOrigin,
+		Sender:    tf.Sender,
+		Recipient: tf.Recipient,
+	}
+}
+
+func convertLogEvent(event *logdb.Event) *EventMessage {
+	topics := make([]thor.Bytes32, 0, len(event.Topics))
+	for _, topic := range event.Topics {
+		if topic == nil {
+			break
+		}
+		topics = append(topics, *topic)
+	}
+	return &EventMessage{
+		Address: event.Address,
+		Topics:  topics,
+		Data:    hexutil.Encode(event.Data),
+		Meta: LogMeta{
+			BlockID:        event.BlockID,
+			BlockNumber:    event.BlockNumber,
+			BlockTimestamp: event.BlockTime,
+			TxID:           event.TxID,
+			TxOrigin:       event.TxOrigin,
+			ClauseIndex:    event.ClauseIndex,
+		},
+	}
+}
+
+func convertLogTransfer(transfer *logdb.Transfer) *TransferMessage {
+	return &TransferMessage{
+		Sender:    transfer.Sender,
+		Recipient: transfer.Recipient,
+		Amount:    (*math.HexOrDecimal256)(transfer.Amount),
+		Meta: LogMeta{
+			BlockID:        transfer.BlockID,
+			BlockNumber:    transfer.BlockNumber,
+			BlockTimestamp: transfer.BlockTime,
+			TxID:           transfer.TxID,
+			TxOrigin:       transfer.TxOrigin,
+			ClauseIndex:    transfer.ClauseIndex,
+		},
+	}
+}
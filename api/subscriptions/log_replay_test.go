@@ -0,0 +1,24 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package subscriptions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/v2/logdb"
+)
+
+func TestLogReplay_NextRange(t *testing.T) {
+	r := &logReplay{next: 10, to: 10 + replayBlockRange + 4}
+	assert.Equal(t, &logdb.Range{From: 10, To: 10 + replayBlockRange - 1}, r.nextRange())
+	assert.Equal(t, &logdb.Range{From: 10 + replayBlockRange, To: 10 + replayBlockRange + 4}, r.nextRange())
+	assert.Nil(t, r.nextRange())
+
+	// nothing to replay when resuming right after the best block
+	r = &logReplay{next: 11, to: 10}
+	assert.Nil(t, r.nextRange())
+}
@@ -0,0 +1,16 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package subscriptions
+
+import (
+	"github.com/vechain/thor/v2/metrics"
+)
+
+var (
+	metricActiveConnGauge     = metrics.LazyLoadGaugeVec("api_subscriptions_active_count", []string{"topic"})
+	metricDisconnectCounter   = metrics.LazyLoadCounterVec("api_subscriptions_disconnect_count", []string{"topic", "reason"})
+	metricRejectedConnCounter = metrics.LazyLoadCounterVec("api_subscriptions_rejected_count", []string{"topic"})
+)
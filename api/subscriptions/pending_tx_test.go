@@ -206,7 +206,7 @@ func TestPendingTx_UnsubscribeOnWebSocketClose(t *testing.T) {
 	})
 
 	// Subscriptions setup
-	sub := New(thorChain.Repo(), []string{"*"}, 100, txPool, false)
+	sub := New(thorChain.Repo(), thorChain.Stater(), thorChain.LogDB(), []string{"*"}, 100, txPool, false, Options{})
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		utils.WrapHandlerFunc(sub.handlePendingTransactions)(w, r)
 	}))
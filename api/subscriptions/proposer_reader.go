@@ -0,0 +1,153 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package subscriptions
+
+import (
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/builtin/authority"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// Changes of the active proposers.
+const (
+	ProposerAdded       = "added"       // a new candidate joins as active
+	ProposerActivated   = "activated"   // an inactive candidate becomes active
+	ProposerDeactivated = "deactivated" // an active candidate becomes inactive, e.g. after missing its slots
+	ProposerRemoved     = "removed"     // an active candidate is revoked, no longer endorsed or beyond the max block proposers
+)
+
+// proposerReader emits the changes of the active proposers made by each block. The proposers are picked the
+// same way as the scheduler, i.e. the endorsed candidates of the authority up to the max block proposers.
+type proposerReader struct {
+	repo        *chain.Repository
+	stater      *state.Stater
+	blockReader chain.BlockReader
+	cache       *messageCache[[]ProposerMessage]
+}
+
+func newProposerReader(repo *chain.Repository, stater *state.Stater, position thor.Bytes32, cache *messageCache[[]ProposerMessage]) *proposerReader {
+	return &proposerReader{
+		repo:        repo,
+		stater:      stater,
+		blockReader: repo.NewBlockReader(position),
+		cache:       cache,
+	}
+}
+
+func (pr *proposerReader) Read() ([]interface{}, bool, error) {
+	blocks, err := pr.blockReader.Read()
+	if err != nil {
+		return nil, false, err
+	}
+	var msgs []interface{}
+	for _, block := range blocks {
+		changes, _, err := pr.cache.GetOrAdd(block.Header().ID(), func() ([]ProposerMessage, error) {
+			return pr.proposerChanges(block.Header().ID())
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		for _, change := range changes {
+			// the cached messages are shared, and obsolete depends on the chain of the reader
+			msg := change
+			msg.Obsolete = block.Obsolete
+			msgs = append(msgs, &msg)
+		}
+	}
+	return msgs, len(blocks) > 0, nil
+}
+
+func (pr *proposerReader) proposerChanges(blockID thor.Bytes32) ([]ProposerMessage, error) {
+	summary, err := pr.repo.GetBlockSummary(blockID)
+	if err != nil {
+		return nil, err
+	}
+	parent, err := pr.repo.GetBlockSummary(summary.Header.ParentID())
+	if err != nil {
+		return nil, err
+	}
+	before, err := pr.proposers(parent)
+	if err != nil {
+		return nil, err
+	}
+	after, err := pr.proposers(summary)
+	if err != nil {
+		return nil, err
+	}
+
+	var msgs []ProposerMessage
+	for _, change := range diffProposers(before, after) {
+		msgs = append(msgs, ProposerMessage{
+			Address:     change.address,
+			Change:      change.change,
+			BlockID:     blockID,
+			BlockNumber: summary.Header.Number(),
+		})
+	}
+	return msgs, nil
+}
+
+func (pr *proposerReader) proposers(summary *chain.BlockSummary) ([]*authority.Candidate, error) {
+	st := pr.stater.NewState(summary.Header.StateRoot(), summary.Header.Number(), summary.Conflicts, summary.SteadyNum)
+
+	params := builtin.Params.Native(st)
+	endorsement, err := params.Get(thor.KeyProposerEndorsement)
+	if err != nil {
+		return nil, err
+	}
+	mbp, err := params.Get(thor.KeyMaxBlockProposers)
+	if err != nil {
+		return nil, err
+	}
+	maxBlockProposers := mbp.Uint64()
+	if maxBlockProposers == 0 || maxBlockProposers > thor.InitialMaxBlockProposers {
+		maxBlockProposers = thor.InitialMaxBlockProposers
+	}
+	return builtin.Authority.Native(st).Candidates(endorsement, maxBlockProposers)
+}
+
+type proposerChange struct {
+	address thor.Address
+	change  string
+}
+
+// diffProposers returns the changes of the active proposers, the joined ones in the order of the proposers
+// after, followed by the left ones in the order of the proposers before.
+func diffProposers(before, after []*authority.Candidate) []proposerChange {
+	status := func(list []*authority.Candidate) map[thor.Address]bool {
+		m := make(map[thor.Address]bool, len(list))
+		for _, c := range list {
+			m[c.NodeMaster] = c.Active
+		}
+		return m
+	}
+	was, is := status(before), status(after)
+
+	var changes []proposerChange
+	for _, c := range after {
+		if !c.Active || was[c.NodeMaster] {
+			continue
+		}
+		if _, ok := was[c.NodeMaster]; ok {
+			changes = append(changes, proposerChange{c.NodeMaster, ProposerActivated})
+		} else {
+			changes = append(changes, proposerChange{c.NodeMaster, ProposerAdded})
+		}
+	}
+	for _, c := range before {
+		if !c.Active || is[c.NodeMaster] {
+			continue
+		}
+		if _, ok := is[c.NodeMaster]; ok {
+			changes = append(changes, proposerChange{c.NodeMaster, ProposerDeactivated})
+		} else {
+			changes = append(changes, proposerChange{c.NodeMaster, ProposerRemoved})
+		}
+	}
+	return changes
+}
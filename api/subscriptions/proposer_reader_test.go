@@ -0,0 +1,79 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package subscriptions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/builtin/authority"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+func newAuthorityClause(t *testing.T, name string, args ...interface{}) *tx.Clause {
+	method, ok := builtin.Authority.ABI.MethodByName(name)
+	require.True(t, ok)
+	data, err := method.EncodeInput(args...)
+	require.NoError(t, err)
+	return tx.NewClause(&builtin.Authority.Address).WithData(data)
+}
+
+func TestProposerReader(t *testing.T) {
+	thorChain, err := testchain.NewIntegrationTestChain()
+	require.NoError(t, err)
+
+	// the executor adds a proposer, then revokes it
+	master := thor.BytesToAddress([]byte("master"))
+	endorsor := genesis.DevAccounts()[1].Address
+	executor := genesis.DevAccounts()[0]
+	require.NoError(t, thorChain.MintTransactions(executor, newTransferTx(thorChain, 1, newAuthorityClause(t, "add", master, endorsor, thor.BytesToBytes32([]byte("identity"))))))
+	require.NoError(t, thorChain.MintTransactions(executor, newTransferTx(thorChain, 2, tx.NewClause(&master))))
+	require.NoError(t, thorChain.MintTransactions(executor, newTransferTx(thorChain, 3, newAuthorityClause(t, "revoke", master))))
+
+	blocks, err := thorChain.GetAllBlocks()
+	require.NoError(t, err)
+	require.Len(t, blocks, 4)
+
+	reader := newProposerReader(thorChain.Repo(), thorChain.Stater(), blocks[0].Header().ID(), newMessageCache[[]ProposerMessage](10))
+	var msgs []interface{}
+	for {
+		read, hasMore, err := reader.Read()
+		require.NoError(t, err)
+		if !hasMore {
+			break
+		}
+		msgs = append(msgs, read...)
+	}
+	assert.Equal(t, []interface{}{
+		&ProposerMessage{Address: master, Change: ProposerAdded, BlockID: blocks[1].Header().ID(), BlockNumber: 1},
+		&ProposerMessage{Address: master, Change: ProposerRemoved, BlockID: blocks[3].Header().ID(), BlockNumber: 3},
+	}, msgs)
+}
+
+func TestDiffProposers(t *testing.T) {
+	a, b, c, d, e := thor.Address{1}, thor.Address{2}, thor.Address{3}, thor.Address{4}, thor.Address{5}
+	candidate := func(addr thor.Address, active bool) *authority.Candidate {
+		return &authority.Candidate{NodeMaster: addr, Active: active}
+	}
+
+	before := []*authority.Candidate{candidate(a, true), candidate(b, false), candidate(c, true), candidate(d, true)}
+	after := []*authority.Candidate{candidate(a, true), candidate(b, true), candidate(c, false), candidate(e, true)}
+	assert.Equal(t, []proposerChange{
+		{b, ProposerActivated},
+		{e, ProposerAdded},
+		{c, ProposerDeactivated},
+		{d, ProposerRemoved},
+	}, diffProposers(before, after))
+
+	assert.Empty(t, diffProposers(after, after))
+	// inactive candidates are not in the active proposers
+	assert.Empty(t, diffProposers(nil, []*authority.Candidate{candidate(a, false)}))
+}
@@ -7,7 +7,10 @@ package subscriptions
 
 import (
 	"net/http"
+	"net/url"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -17,6 +20,8 @@ import (
 	"github.com/vechain/thor/v2/block"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/tx"
 	"github.com/vechain/thor/v2/txpool"
@@ -24,16 +29,28 @@ import (
 
 const txQueueSize = 20
 
+// Options contains the options of subscription connections.
+type Options struct {
+	PingInterval time.Duration // interval of ping frames, defaults to 15s if zero
+	MaxConns     int           // max count of concurrent subscription connections, 0 means unlimited
+}
+
 type Subscriptions struct {
 	backtraceLimit    uint32
 	enabledDeprecated bool
+	pingInterval      time.Duration
+	maxConns          int64
+	conns             atomic.Int64
 	repo              *chain.Repository
+	stater            *state.Stater
+	logDB             *logdb.LogDB
 	upgrader          *websocket.Upgrader
 	pendingTx         *pendingTx
 	done              chan struct{}
 	wg                sync.WaitGroup
 	beat2Cache        *messageCache[Beat2Message]
 	beatCache         *messageCache[BeatMessage]
+	proposerCache     *messageCache[[]ProposerMessage]
 }
 
 type msgReader interface {
@@ -45,16 +62,38 @@ var (
 )
 
 const (
-	// Time allowed to read the next pong message from the peer.
-	pongWait = 60 * time.Second
-	// Send pings to peer with this period. Must be less than pongWait.
-	pingPeriod = (pongWait * 7) / 10
+	defaultPingInterval = 15 * time.Second
+	// The connection is terminated once the peer misses this count of consecutive pongs.
+	maxMissedPongs = 3
+	// Time allowed to write a message to the peer.
+	writeWait = 10 * time.Second
 )
 
-func New(repo *chain.Repository, allowedOrigins []string, backtraceLimit uint32, txpool *txpool.TxPool, enabledDeprecated bool) *Subscriptions {
+var errTooManyConns = errors.New("too many subscriptions")
+
+// New creates the subscriptions API. The logDB is used to replay historical events and transfers,
+// it can be nil if logs are disabled.
+func New(
+	repo *chain.Repository,
+	stater *state.Stater,
+	logDB *logdb.LogDB,
+	allowedOrigins []string,
+	backtraceLimit uint32,
+	txpool *txpool.TxPool,
+	enabledDeprecated bool,
+	opts Options,
+) *Subscriptions {
+	pingInterval := opts.PingInterval
+	if pingInterval <= 0 {
+		pingInterval = defaultPingInterval
+	}
 	sub := &Subscriptions{
 		backtraceLimit:    backtraceLimit,
+		pingInterval:      pingInterval,
+		maxConns:          int64(opts.MaxConns),
 		repo:              repo,
+		stater:            stater,
+		logDB:             logDB,
 		enabledDeprecated: enabledDeprecated,
 		upgrader: &websocket.Upgrader{
 			EnableCompression: true,
@@ -71,10 +110,11 @@ func New(repo *chain.Repository, allowedOrigins []string, backtraceLimit uint32,
 				return false
 			},
 		},
-		pendingTx:  newPendingTx(txpool),
-		done:       make(chan struct{}),
-		beat2Cache: newMessageCache[Beat2Message](backtraceLimit),
-		beatCache:  newMessageCache[BeatMessage](backtraceLimit),
+		pendingTx:     newPendingTx(txpool),
+		done:          make(chan struct{}),
+		beat2Cache:    newMessageCache[Beat2Message](backtraceLimit),
+		beatCache:     newMessageCache[BeatMessage](backtraceLimit),
+		proposerCache: newMessageCache[[]ProposerMessage](backtraceLimit),
 	}
 
 	sub.wg.Add(1)
@@ -95,7 +135,7 @@ func (s *Subscriptions) handleBlockReader(_ http.ResponseWriter, req *http.Reque
 }
 
 func (s *Subscriptions) handleEventReader(w http.ResponseWriter, req *http.Request) (msgReader, error) {
-	position, err := s.parsePosition(req.URL.Query().Get("pos"))
+	position, replay, err := s.parseReplay(req.URL.Query())
 	if err != nil {
 		return nil, err
 	}
@@ -131,11 +171,11 @@ func (s *Subscriptions) handleEventReader(w http.ResponseWriter, req *http.Reque
 		Topic3:  t3,
 		Topic4:  t4,
 	}
-	return newEventReader(s.repo, position, eventFilter), nil
+	return newEventReader(s.repo, position, eventFilter, replay), nil
 }
 
 func (s *Subscriptions) handleTransferReader(_ http.ResponseWriter, req *http.Request) (msgReader, error) {
-	position, err := s.parsePosition(req.URL.Query().Get("pos"))
+	position, replay, err := s.parseReplay(req.URL.Query())
 	if err != nil {
 		return nil, err
 	}
@@ -156,7 +196,7 @@ func (s *Subscriptions) handleTransferReader(_ http.ResponseWriter, req *http.Re
 		Sender:    sender,
 		Recipient: recipient,
 	}
-	return newTransferReader(s.repo, position, transferFilter), nil
+	return newTransferReader(s.repo, position, transferFilter, replay), nil
 }
 
 func (s *Subscriptions) handleBeatReader(w http.ResponseWriter, req *http.Request) (msgReader, error) {
@@ -175,20 +215,45 @@ func (s *Subscriptions) handleBeat2Reader(_ http.ResponseWriter, req *http.Reque
 	return newBeat2Reader(s.repo, position, s.beat2Cache), nil
 }
 
+func (s *Subscriptions) handleAccountReader(_ http.ResponseWriter, req *http.Request) (msgReader, error) {
+	address, err := thor.ParseAddress(mux.Vars(req)["address"])
+	if err != nil {
+		return nil, utils.BadRequest(errors.WithMessage(err, "address"))
+	}
+	position, err := s.parsePosition(req.URL.Query().Get("pos"))
+	if err != nil {
+		return nil, err
+	}
+	return newAccountReader(s.repo, s.stater, position, address, s.beat2Cache), nil
+}
+
+func (s *Subscriptions) handleProposerReader(_ http.ResponseWriter, req *http.Request) (msgReader, error) {
+	position, err := s.parsePosition(req.URL.Query().Get("pos"))
+	if err != nil {
+		return nil, err
+	}
+	return newProposerReader(s.repo, s.stater, position, s.proposerCache), nil
+}
+
 func (s *Subscriptions) handlePendingTransactions(w http.ResponseWriter, req *http.Request) error {
 	s.wg.Add(1)
 	defer s.wg.Done()
 
-	conn, closed, err := s.setupConn(w, req)
+	heartbeat, err := parseHeartbeat(req.URL.Query().Get("heartbeat"))
+	if err != nil {
+		return err
+	}
+	if !s.acquireConn("txpool") {
+		return utils.HTTPError(errTooManyConns, http.StatusServiceUnavailable)
+	}
+	defer s.releaseConn("txpool")
+
+	conn, err := s.setupConn(w, req, "txpool", heartbeat)
 	// since the conn is hijacked here, no error should be returned in lines below
 	if err != nil {
 		logger.Debug("upgrade to websocket", "err", err)
 		return nil
 	}
-	defer s.closeConn(conn, err)
-
-	pingTicker := time.NewTicker(pingPeriod)
-	defer pingTicker.Stop()
 
 	txCh := make(chan *tx.Transaction, txQueueSize)
 	s.pendingTx.Subscribe(txCh)
@@ -197,52 +262,85 @@ func (s *Subscriptions) handlePendingTransactions(w http.ResponseWriter, req *ht
 		close(txCh)
 	}()
 
-	for {
-		select {
-		case tx := <-txCh:
-			err = conn.WriteJSON(&PendingTxIDMessage{ID: tx.ID()})
-			if err != nil {
-				return nil
+	reason := func() string {
+		pingTicker := time.NewTicker(s.pingInterval)
+		defer pingTicker.Stop()
+		for {
+			select {
+			case tx := <-txCh:
+				if err := conn.write(&PendingTxIDMessage{ID: tx.ID()}); err != nil {
+					return disconnectError
+				}
+			case <-s.done:
+				return disconnectShutdown
+			case <-conn.closed:
+				return disconnectClosed
+			case <-pingTicker.C:
+				if err := conn.tick(); err != nil {
+					return reasonOf(err)
+				}
 			}
-		case <-s.done:
-			return nil
-		case <-closed:
-			return nil
-		case <-pingTicker.C:
-			conn.WriteMessage(websocket.PingMessage, nil)
 		}
+	}()
+	s.closeConn(conn, reason, nil)
+	return nil
+}
+
+// acquireConn reserves a slot for a new connection of the topic, it returns false if the limit is reached.
+func (s *Subscriptions) acquireConn(topic string) bool {
+	if n := s.conns.Add(1); s.maxConns > 0 && n > s.maxConns {
+		s.conns.Add(-1)
+		metricRejectedConnCounter().AddWithLabel(1, map[string]string{"topic": topic})
+		return false
 	}
+	metricActiveConnGauge().AddWithLabel(1, map[string]string{"topic": topic})
+	return true
+}
+
+func (s *Subscriptions) releaseConn(topic string) {
+	s.conns.Add(-1)
+	metricActiveConnGauge().AddWithLabel(-1, map[string]string{"topic": topic})
 }
 
-func (s *Subscriptions) setupConn(w http.ResponseWriter, req *http.Request) (*websocket.Conn, chan struct{}, error) {
+func (s *Subscriptions) setupConn(w http.ResponseWriter, req *http.Request, topic string, heartbeat bool) (*connection, error) {
 	conn, err := s.upgrader.Upgrade(w, req, nil)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	closed := make(chan struct{})
+	c := &connection{
+		Conn:      conn,
+		topic:     topic,
+		closed:    make(chan struct{}),
+		heartbeat: heartbeat,
+	}
+	// the read deadline is a backstop, the connection is normally terminated by missing pongs first
+	pongWait := s.pingInterval * (maxMissedPongs + 2)
 	// start read loop to handle close event
 	s.wg.Add(1)
 	go func() {
 		defer s.wg.Done()
 		conn.SetReadDeadline(time.Now().Add(pongWait))
 		conn.SetPongHandler(func(string) error {
+			c.missedPongs.Store(0)
 			conn.SetReadDeadline(time.Now().Add(pongWait))
 			return nil
 		})
 		for {
 			if _, _, err := conn.ReadMessage(); err != nil {
 				logger.Debug("websocket read err", "err", err)
-				close(closed)
+				close(c.closed)
 				break
 			}
 		}
 	}()
 
-	return conn, closed, nil
+	return c, nil
 }
 
-func (s *Subscriptions) closeConn(conn *websocket.Conn, err error) {
+func (s *Subscriptions) closeConn(c *connection, reason string, err error) {
+	metricDisconnectCounter().AddWithLabel(1, map[string]string{"topic": c.topic, "reason": reason})
+
 	var closeMsg []byte
 	if err != nil {
 		closeMsg = websocket.FormatCloseMessage(websocket.CloseInternalServerErr, err.Error())
@@ -250,48 +348,54 @@ func (s *Subscriptions) closeConn(conn *websocket.Conn, err error) {
 		closeMsg = websocket.FormatCloseMessage(websocket.CloseGoingAway, "")
 	}
 
-	if err := conn.WriteMessage(websocket.CloseMessage, closeMsg); err != nil {
+	if err := c.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(writeWait)); err != nil {
 		logger.Debug("write close message", "err", err)
 	}
 
-	if err := conn.Close(); err != nil {
+	if err := c.Close(); err != nil {
 		logger.Debug("close websocket", "err", err)
 	}
 }
 
-func (s *Subscriptions) pipe(conn *websocket.Conn, reader msgReader, closed chan struct{}) error {
+// pipe streams the messages of the reader to the connection, it returns the disconnect reason,
+// and the error to be reported to the peer, if any.
+func (s *Subscriptions) pipe(conn *connection, reader msgReader) (string, error) {
 	ticker := s.repo.NewTicker()
-	pingTicker := time.NewTicker(pingPeriod)
+	pingTicker := time.NewTicker(s.pingInterval)
 	defer pingTicker.Stop()
 	for {
 		msgs, hasMore, err := reader.Read()
 		if err != nil {
-			return err
+			return disconnectError, err
 		}
 		for _, msg := range msgs {
-			if err := conn.WriteJSON(msg); err != nil {
-				return err
+			if err := conn.write(msg); err != nil {
+				return disconnectError, nil
 			}
 		}
 		if hasMore {
 			select {
 			case <-s.done:
-				return nil
-			case <-closed:
-				return nil
+				return disconnectShutdown, nil
+			case <-conn.closed:
+				return disconnectClosed, nil
 			case <-pingTicker.C:
-				conn.WriteMessage(websocket.PingMessage, nil)
+				if err := conn.tick(); err != nil {
+					return reasonOf(err), nil
+				}
 			default:
 			}
 		} else {
 			select {
 			case <-s.done:
-				return nil
-			case <-closed:
-				return nil
+				return disconnectShutdown, nil
+			case <-conn.closed:
+				return disconnectClosed, nil
 			case <-ticker.C():
 			case <-pingTicker.C:
-				conn.WriteMessage(websocket.PingMessage, nil)
+				if err := conn.tick(); err != nil {
+					return reasonOf(err), nil
+				}
 			}
 		}
 	}
@@ -312,6 +416,33 @@ func (s *Subscriptions) parsePosition(posStr string) (thor.Bytes32, error) {
 	return pos, nil
 }
 
+// parseReplay parses the position to read blocks after, and the historical blocks to be replayed from the logs db
+// if fromBlock is specified. Unlike pos, fromBlock is not restricted by the backtrace limit.
+func (s *Subscriptions) parseReplay(query url.Values) (thor.Bytes32, *logReplay, error) {
+	fromStr := query.Get("fromBlock")
+	if fromStr == "" {
+		position, err := s.parsePosition(query.Get("pos"))
+		return position, nil, err
+	}
+	if query.Get("pos") != "" {
+		return thor.Bytes32{}, nil, utils.BadRequest(errors.New("pos and fromBlock are mutually exclusive"))
+	}
+	if s.logDB == nil {
+		return thor.Bytes32{}, nil, utils.Forbidden(errors.New("fromBlock: logs are disabled"))
+	}
+	from, err := strconv.ParseUint(fromStr, 0, 32)
+	if err != nil {
+		return thor.Bytes32{}, nil, utils.BadRequest(errors.WithMessage(err, "fromBlock"))
+	}
+
+	// logs are written before the block becomes the best, so the logs db is never behind
+	bestID := s.repo.BestBlockSummary().Header.ID()
+	if from > uint64(block.Number(bestID))+1 {
+		return thor.Bytes32{}, nil, utils.BadRequest(errors.New("fromBlock: exceeds the best block"))
+	}
+	return bestID, &logReplay{logDB: s.logDB, next: uint32(from), to: block.Number(bestID)}, nil
+}
+
 func parseTopic(t string) (*thor.Bytes32, error) {
 	if t == "" {
 		return nil, nil
@@ -339,30 +470,50 @@ func (s *Subscriptions) Close() {
 	s.wg.Wait()
 }
 
-func (s *Subscriptions) websocket(readerFunc func(http.ResponseWriter, *http.Request) (msgReader, error)) utils.HandlerFunc {
+func parseHeartbeat(s string) (bool, error) {
+	if s == "" {
+		return false, nil
+	}
+	heartbeat, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, utils.BadRequest(errors.WithMessage(err, "heartbeat"))
+	}
+	return heartbeat, nil
+}
+
+func (s *Subscriptions) websocket(topic string, readerFunc func(http.ResponseWriter, *http.Request) (msgReader, error)) utils.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) error {
 		s.wg.Add(1)
 		defer s.wg.Done()
 
+		heartbeat, err := parseHeartbeat(req.URL.Query().Get("heartbeat"))
+		if err != nil {
+			return err
+		}
 		// Call the provided reader function
 		reader, err := readerFunc(w, req)
 		if err != nil {
 			return err
 		}
 
+		if !s.acquireConn(topic) {
+			return utils.HTTPError(errTooManyConns, http.StatusServiceUnavailable)
+		}
+		defer s.releaseConn(topic)
+
 		// Setup WebSocket connection
-		conn, closed, err := s.setupConn(w, req)
+		conn, err := s.setupConn(w, req, topic, heartbeat)
 		if err != nil {
 			logger.Debug("upgrade to websocket", "err", err)
 			return err
 		}
-		defer s.closeConn(conn, err)
 
 		// Stream messages
-		err = s.pipe(conn, reader, closed)
+		reason, err := s.pipe(conn, reader)
 		if err != nil {
 			logger.Debug("error in websocket pipe", "err", err)
 		}
+		s.closeConn(conn, reason, err)
 		return err
 	}
 }
@@ -378,27 +529,37 @@ func (s *Subscriptions) Mount(root *mux.Router, pathPrefix string) {
 	sub.Path("/block").
 		Methods(http.MethodGet).
 		Name("WS /subscriptions/block"). // metrics middleware relies on this name
-		HandlerFunc(utils.WrapHandlerFunc(s.websocket(s.handleBlockReader)))
+		HandlerFunc(utils.WrapHandlerFunc(s.websocket("block", s.handleBlockReader)))
 
 	sub.Path("/event").
 		Methods(http.MethodGet).
 		Name("WS /subscriptions/event"). // metrics middleware relies on this name
-		HandlerFunc(utils.WrapHandlerFunc(s.websocket(s.handleEventReader)))
+		HandlerFunc(utils.WrapHandlerFunc(s.websocket("event", s.handleEventReader)))
 
 	sub.Path("/transfer").
 		Methods(http.MethodGet).
 		Name("WS /subscriptions/transfer"). // metrics middleware relies on this name
-		HandlerFunc(utils.WrapHandlerFunc(s.websocket(s.handleTransferReader)))
+		HandlerFunc(utils.WrapHandlerFunc(s.websocket("transfer", s.handleTransferReader)))
 
 	sub.Path("/beat2").
 		Methods(http.MethodGet).
 		Name("WS /subscriptions/beat2"). // metrics middleware relies on this name
-		HandlerFunc(utils.WrapHandlerFunc(s.websocket(s.handleBeat2Reader)))
+		HandlerFunc(utils.WrapHandlerFunc(s.websocket("beat2", s.handleBeat2Reader)))
+
+	sub.Path("/account/{address}").
+		Methods(http.MethodGet).
+		Name("WS /subscriptions/account"). // metrics middleware relies on this name
+		HandlerFunc(utils.WrapHandlerFunc(s.websocket("account", s.handleAccountReader)))
+
+	sub.Path("/proposer").
+		Methods(http.MethodGet).
+		Name("WS /subscriptions/proposer"). // metrics middleware relies on this name
+		HandlerFunc(utils.WrapHandlerFunc(s.websocket("proposer", s.handleProposerReader)))
 
 	// This method is currently deprecated
 	beatHandler := utils.HandleGone
 	if s.enabledDeprecated {
-		beatHandler = s.websocket(s.handleBeatReader)
+		beatHandler = s.websocket("beat", s.handleBeatReader)
 	}
 	sub.Path("/beat").
 		Methods(http.MethodGet).
@@ -274,7 +274,7 @@ func initSubscriptionsServer(t *testing.T, enabledDeprecated bool) {
 	require.NoError(t, err)
 
 	router := mux.NewRouter()
-	New(thorChain.Repo(), []string{}, 5, txPool, enabledDeprecated).
+	New(thorChain.Repo(), thorChain.Stater(), thorChain.LogDB(), []string{}, 5, txPool, enabledDeprecated, Options{}).
 		Mount(router, "/subscriptions")
 	ts = httptest.NewServer(router)
 }
@@ -330,7 +330,7 @@ func TestSubscriptionsBacktrace(t *testing.T) {
 	require.NoError(t, err)
 
 	router := mux.NewRouter()
-	New(thorChain.Repo(), []string{}, 5, txPool, true).Mount(router, "/subscriptions")
+	New(thorChain.Repo(), thorChain.Stater(), thorChain.LogDB(), []string{}, 5, txPool, true, Options{}).Mount(router, "/subscriptions")
 	ts = httptest.NewServer(router)
 
 	defer ts.Close()
@@ -355,3 +355,241 @@ func testHandleSubjectWithTransferBacktraceLimit(t *testing.T) {
 	assert.Equal(t, body, []byte("pos: backtrace limit exceeded\n"))
 	assert.Nil(t, conn)
 }
+
+func TestSubscriptionsFromBlock(t *testing.T) {
+	thorChain, err := testchain.NewIntegrationTestChain()
+	require.NoError(t, err)
+
+	txPool := txpool.New(thorChain.Repo(), thorChain.Stater(), txpool.Options{
+		Limit:           100,
+		LimitPerAccount: 16,
+		MaxLifetime:     time.Hour,
+	})
+
+	addr := thor.BytesToAddress([]byte("to"))
+	newTransferTx := func(nonce uint64) *tx.Transaction {
+		return tx.MustSign(new(tx.Builder).
+			ChainTag(thorChain.Repo().ChainTag()).
+			Expiration(100).
+			Gas(21000).
+			Nonce(nonce).
+			Clause(tx.NewClause(&addr).WithValue(big.NewInt(10000))).
+			BlockRef(tx.NewBlockRef(0)).
+			Build(), genesis.DevAccounts()[0].PrivateKey)
+	}
+	txDeploy := tx.MustSign(new(tx.Builder).
+		ChainTag(thorChain.Repo().ChainTag()).
+		Expiration(100).
+		Gas(1_000_000).
+		Nonce(3).
+		Clause(tx.NewClause(nil).WithData(common.Hex2Bytes(eventcontract.HexBytecode))).
+		BlockRef(tx.NewBlockRef(0)).
+		Build(), genesis.DevAccounts()[1].PrivateKey)
+
+	require.NoError(t, thorChain.MintTransactions(genesis.DevAccounts()[0], newTransferTx(1), txDeploy))
+	// beyond the backtrace limit
+	for i := 0; i < 10; i++ {
+		require.NoError(t, thorChain.MintTransactions(genesis.DevAccounts()[0]))
+	}
+
+	blocks, err := thorChain.GetAllBlocks()
+	require.NoError(t, err)
+
+	w := thorChain.LogDB().NewWriter()
+	for _, blk := range blocks[1:] {
+		receipts, err := thorChain.Repo().GetBlockReceipts(blk.Header().ID())
+		require.NoError(t, err)
+		require.NoError(t, w.Write(blk, receipts))
+	}
+	require.NoError(t, w.Commit())
+
+	router := mux.NewRouter()
+	New(thorChain.Repo(), thorChain.Stater(), thorChain.LogDB(), []string{}, 5, txPool, false, Options{}).Mount(router, "/subscriptions")
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	dial := func(path, query string) (*websocket.Conn, *http.Response, error) {
+		u := url.URL{Scheme: "ws", Host: strings.TrimPrefix(srv.URL, "http://"), Path: path, RawQuery: query}
+		return websocket.DefaultDialer.Dial(u.String(), nil)
+	}
+
+	t.Run("transfer", func(t *testing.T) {
+		conn, _, err := dial("/subscriptions/transfer", "fromBlock=0")
+		require.NoError(t, err)
+		defer conn.Close()
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+		// replayed from the logs db
+		var msg TransferMessage
+		require.NoError(t, conn.ReadJSON(&msg))
+		assert.Equal(t, blocks[1].Header().ID(), msg.Meta.BlockID)
+		assert.Equal(t, blocks[1].Header().Number(), msg.Meta.BlockNumber)
+		assert.Equal(t, genesis.DevAccounts()[0].Address, msg.Meta.TxOrigin)
+		assert.Equal(t, addr, msg.Recipient)
+		assert.False(t, msg.Obsolete)
+
+		// then live updates
+		require.NoError(t, thorChain.MintTransactions(genesis.DevAccounts()[0], newTransferTx(2)))
+		best, err := thorChain.BestBlock()
+		require.NoError(t, err)
+
+		require.NoError(t, conn.ReadJSON(&msg))
+		assert.Equal(t, best.Header().ID(), msg.Meta.BlockID)
+		assert.Equal(t, blocks[len(blocks)-1].Header().Number()+1, msg.Meta.BlockNumber)
+		assert.Equal(t, addr, msg.Recipient)
+	})
+
+	t.Run("event", func(t *testing.T) {
+		conn, _, err := dial("/subscriptions/event", "fromBlock=1")
+		require.NoError(t, err)
+		defer conn.Close()
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+		var msg EventMessage
+		require.NoError(t, conn.ReadJSON(&msg))
+		assert.Equal(t, blocks[1].Header().ID(), msg.Meta.BlockID)
+		assert.Equal(t, genesis.DevAccounts()[1].Address, msg.Meta.TxOrigin)
+		assert.NotEmpty(t, msg.Topics)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for query, expected := range map[string]string{
+			"fromBlock=abc": "fromBlock: strconv.ParseUint: parsing \"abc\": invalid syntax\n",
+			"fromBlock=100": "fromBlock: exceeds the best block\n",
+			fmt.Sprintf("fromBlock=0&pos=%s", blocks[0].Header().ID()): "pos and fromBlock are mutually exclusive\n",
+		} {
+			_, resp, err := dial("/subscriptions/event", query)
+			assert.Error(t, err)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, expected, string(body))
+		}
+	})
+
+	t.Run("logs disabled", func(t *testing.T) {
+		router := mux.NewRouter()
+		New(thorChain.Repo(), thorChain.Stater(), nil, []string{}, 5, txPool, false, Options{}).Mount(router, "/subscriptions")
+		srv := httptest.NewServer(router)
+		defer srv.Close()
+
+		u := url.URL{Scheme: "ws", Host: strings.TrimPrefix(srv.URL, "http://"), Path: "/subscriptions/transfer", RawQuery: "fromBlock=0"}
+		_, resp, err := websocket.DefaultDialer.Dial(u.String(), nil)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		resp.Body.Close()
+	})
+}
+
+func newTestSubscriptionsServer(t *testing.T, opts Options) *httptest.Server {
+	thorChain, err := testchain.NewIntegrationTestChain()
+	require.NoError(t, err)
+
+	txPool := txpool.New(thorChain.Repo(), thorChain.Stater(), txpool.Options{
+		Limit:           100,
+		LimitPerAccount: 16,
+		MaxLifetime:     time.Hour,
+	})
+	t.Cleanup(txPool.Close)
+
+	router := mux.NewRouter()
+	sub := New(thorChain.Repo(), thorChain.Stater(), thorChain.LogDB(), []string{}, 5, txPool, false, opts)
+	sub.Mount(router, "/subscriptions")
+	srv := httptest.NewServer(router)
+	t.Cleanup(func() {
+		srv.Close()
+		sub.Close()
+	})
+	return srv
+}
+
+func dialSubscription(srv *httptest.Server, path, query string) (*websocket.Conn, *http.Response, error) {
+	u := url.URL{Scheme: "ws", Host: strings.TrimPrefix(srv.URL, "http://"), Path: path, RawQuery: query}
+	return websocket.DefaultDialer.Dial(u.String(), nil)
+}
+
+func TestSubscriptionsPongTimeout(t *testing.T) {
+	srv := newTestSubscriptionsServer(t, Options{PingInterval: 50 * time.Millisecond})
+
+	t.Run("no pongs", func(t *testing.T) {
+		for _, path := range []string{"/subscriptions/block", "/subscriptions/txpool"} {
+			conn, _, err := dialSubscription(srv, path, "")
+			require.NoError(t, err)
+			var pings int
+			conn.SetPingHandler(func(string) error {
+				pings++
+				return nil
+			})
+
+			conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+			_, _, err = conn.ReadMessage()
+			assert.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway), path)
+			assert.Equal(t, maxMissedPongs, pings, path)
+			conn.Close()
+		}
+	})
+
+	t.Run("pongs", func(t *testing.T) {
+		conn, _, err := dialSubscription(srv, "/subscriptions/block", "")
+		require.NoError(t, err)
+		defer conn.Close()
+
+		// nothing but pings, which are answered by the default handler
+		conn.SetReadDeadline(time.Now().Add(500 * time.Millisecond))
+		_, _, err = conn.ReadMessage()
+		var netErr interface{ Timeout() bool }
+		require.ErrorAs(t, err, &netErr)
+		assert.True(t, netErr.Timeout())
+	})
+
+	t.Run("heartbeat", func(t *testing.T) {
+		conn, _, err := dialSubscription(srv, "/subscriptions/block", "heartbeat=true")
+		require.NoError(t, err)
+		defer conn.Close()
+
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		for i := 0; i < 2; i++ {
+			var msg HeartbeatMessage
+			require.NoError(t, conn.ReadJSON(&msg))
+			assert.NotZero(t, msg.Timestamp)
+		}
+
+		_, resp, err := dialSubscription(srv, "/subscriptions/block", "heartbeat=yes")
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		resp.Body.Close()
+	})
+}
+
+func TestSubscriptionsMaxConns(t *testing.T) {
+	srv := newTestSubscriptionsServer(t, Options{MaxConns: 2})
+
+	conn1, _, err := dialSubscription(srv, "/subscriptions/block", "")
+	require.NoError(t, err)
+	conn2, _, err := dialSubscription(srv, "/subscriptions/txpool", "")
+	require.NoError(t, err)
+	defer conn2.Close()
+
+	// the limit is shared by all topics
+	for _, path := range []string{"/subscriptions/block", "/subscriptions/beat2", "/subscriptions/txpool"} {
+		_, resp, err := dialSubscription(srv, path, "")
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, "too many subscriptions\n", string(body))
+	}
+
+	// the slot is released once a connection ends
+	conn1.Close()
+	assert.Eventually(t, func() bool {
+		conn, _, err := dialSubscription(srv, "/subscriptions/event", "")
+		if err != nil {
+			return false
+		}
+		conn.Close()
+		return true
+	}, 5*time.Second, 50*time.Millisecond)
+}
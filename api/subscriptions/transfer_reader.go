@@ -6,7 +6,10 @@
 package subscriptions
 
 import (
+	"context"
+
 	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/logdb"
 	"github.com/vechain/thor/v2/thor"
 )
 
@@ -14,17 +17,37 @@ type transferReader struct {
 	repo        *chain.Repository
 	filter      *TransferFilter
 	blockReader chain.BlockReader
+	replay      *logReplay // replays historical transfers before reading blocks, nil if done
 }
 
-func newTransferReader(repo *chain.Repository, position thor.Bytes32, filter *TransferFilter) *transferReader {
+func newTransferReader(repo *chain.Repository, position thor.Bytes32, filter *TransferFilter, replay *logReplay) *transferReader {
 	return &transferReader{
 		repo:        repo,
 		filter:      filter,
 		blockReader: repo.NewBlockReader(position),
+		replay:      replay,
 	}
 }
 
 func (tr *transferReader) Read() ([]interface{}, bool, error) {
+	if tr.replay != nil {
+		if rng := tr.replay.nextRange(); rng != nil {
+			transfers, err := tr.replay.logDB.FilterTransfers(context.Background(), &logdb.TransferFilter{
+				CriteriaSet: []*logdb.TransferCriteria{tr.filter.criteria()},
+				Range:       rng,
+			})
+			if err != nil {
+				return nil, false, err
+			}
+			msgs := make([]interface{}, 0, len(transfers))
+			for _, transfer := range transfers {
+				msgs = append(msgs, convertLogTransfer(transfer))
+			}
+			return msgs, true, nil
+		}
+		tr.replay = nil
+	}
+
 	blocks, err := tr.blockReader.Read()
 	if err != nil {
 		return nil, false, err
@@ -24,7 +24,7 @@ func TestTransferReader_Read(t *testing.T) {
 	filter := &TransferFilter{}
 
 	// Act
-	br := newTransferReader(thorChain.Repo(), genesisBlk.Header().ID(), filter)
+	br := newTransferReader(thorChain.Repo(), genesisBlk.Header().ID(), filter, nil)
 	res, ok, err := br.Read()
 
 	// Assert
@@ -49,7 +49,7 @@ func TestTransferReader_Read_NoNewBlocksToRead(t *testing.T) {
 	filter := &TransferFilter{}
 
 	// Act
-	br := newTransferReader(thorChain.Repo(), newBlock.Header().ID(), filter)
+	br := newTransferReader(thorChain.Repo(), newBlock.Header().ID(), filter, nil)
 	res, ok, err := br.Read()
 
 	// Assert
@@ -64,7 +64,7 @@ func TestTransferReader_Read_ErrorWhenReadingBlocks(t *testing.T) {
 	filter := &TransferFilter{}
 
 	// Act
-	br := newTransferReader(thorChain.Repo(), thor.MustParseBytes32("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"), filter)
+	br := newTransferReader(thorChain.Repo(), thor.MustParseBytes32("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"), filter, nil)
 	res, ok, err := br.Read()
 
 	// Assert
@@ -86,7 +86,7 @@ func TestTransferReader_Read_NoTransferMatchingTheFilter(t *testing.T) {
 	}
 
 	// Act
-	br := newTransferReader(thorChain.Repo(), genesisBlk.Header().ID(), badFilter)
+	br := newTransferReader(thorChain.Repo(), genesisBlk.Header().ID(), badFilter, nil)
 	res, ok, err := br.Read()
 
 	// Assert
@@ -220,6 +220,30 @@ type Beat2Message struct {
 	Obsolete    bool         `json:"obsolete"`
 }
 
+// ProposerMessage carries a change of the active proposers made by a block, see the Proposer* changes.
+type ProposerMessage struct {
+	Address     thor.Address `json:"address"`
+	Change      string       `json:"change"`
+	BlockID     thor.Bytes32 `json:"blockID"`
+	BlockNumber uint32       `json:"blockNumber"`
+	Obsolete    bool         `json:"obsolete"`
+}
+
+// AccountMessage carries the balances of an account after a block transferring VET or VTHO in or out of it.
+type AccountMessage struct {
+	Address     thor.Address          `json:"address"`
+	VETBalance  *math.HexOrDecimal256 `json:"vetBalance"`
+	VTHOBalance *math.HexOrDecimal256 `json:"vthoBalance"`
+	BlockID     thor.Bytes32          `json:"blockID"`
+	BlockNumber uint32                `json:"blockNumber"`
+	Obsolete    bool                  `json:"obsolete"`
+}
+
 type PendingTxIDMessage struct {
 	ID thor.Bytes32 `json:"id"`
 }
+
+// HeartbeatMessage is sent on a quiet subscription which opted in with heartbeat=true.
+type HeartbeatMessage struct {
+	Timestamp uint64 `json:"heartbeat"` // unix timestamp of the server
+}
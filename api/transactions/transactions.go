@@ -6,6 +6,8 @@
 package transactions
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -13,6 +15,8 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/v2/api/utils"
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/block"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/txpool"
@@ -21,12 +25,14 @@ import (
 type Transactions struct {
 	repo *chain.Repository
 	pool *txpool.TxPool
+	bft  bft.Committer
 }
 
-func New(repo *chain.Repository, pool *txpool.TxPool) *Transactions {
+func New(repo *chain.Repository, pool *txpool.TxPool, bft bft.Committer) *Transactions {
 	return &Transactions{
 		repo,
 		pool,
+		bft,
 	}
 }
 
@@ -42,7 +48,7 @@ func (t *Transactions) getRawTransaction(txID thor.Bytes32, head thor.Bytes32, a
 						return nil, err
 					}
 					return &RawTransaction{
-						RawTx: RawTx{hexutil.Encode(raw)},
+						RawTx: RawTx{Raw: hexutil.Encode(raw)},
 					}, nil
 				}
 			}
@@ -60,7 +66,7 @@ func (t *Transactions) getRawTransaction(txID thor.Bytes32, head thor.Bytes32, a
 		return nil, err
 	}
 	return &RawTransaction{
-		RawTx: RawTx{hexutil.Encode(raw)},
+		RawTx: RawTx{Raw: hexutil.Encode(raw)},
 		Meta: &TxMeta{
 			BlockID:        summary.Header.ID(),
 			BlockNumber:    summary.Header.Number(),
@@ -76,7 +82,7 @@ func (t *Transactions) getTransactionByID(txID thor.Bytes32, head thor.Bytes32,
 		if t.repo.IsNotFound(err) {
 			if allowPending {
 				if pending := t.pool.Get(txID); pending != nil {
-					return convertTransaction(pending, nil), nil
+					return convertPendingTransaction(pending, t.repo.BestBlockSummary().Header), nil
 				}
 			}
 			return nil, nil
@@ -114,6 +120,24 @@ func (t *Transactions) getTransactionReceiptByID(txID thor.Bytes32, head thor.By
 
 	return convertReceipt(receipt, summary.Header, tx)
 }
+
+// getTransactionPosition locates the tx by the tx index, without resolving the block summary or the receipt.
+func (t *Transactions) getTransactionPosition(txID thor.Bytes32, head thor.Bytes32) (*TxPosition, error) {
+	tx, meta, err := t.repo.NewChain(head).GetTransaction(txID)
+	if err != nil {
+		if t.repo.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &TxPosition{
+		BlockID:     meta.BlockID,
+		BlockNumber: block.Number(meta.BlockID),
+		Index:       meta.Index,
+		ClauseCount: len(tx.Clauses()),
+	}, nil
+}
+
 func (t *Transactions) handleSendTransaction(w http.ResponseWriter, req *http.Request) error {
 	var rawTx *RawTx
 	if err := utils.ParseJSON(req.Body, &rawTx); err != nil {
@@ -124,17 +148,81 @@ func (t *Transactions) handleSendTransaction(w http.ResponseWriter, req *http.Re
 		return utils.BadRequest(errors.WithMessage(err, "raw"))
 	}
 
-	if err := t.pool.AddLocal(tx); err != nil {
+	if rawTx.ExpectedID != nil {
+		if txID := tx.ID(); txID != *rawTx.ExpectedID {
+			mismatch := &TxIDMismatch{
+				Error:      "tx id mismatch",
+				ExpectedID: *rawTx.ExpectedID,
+				ID:         txID,
+			}
+			if origin, err := tx.Origin(); err == nil {
+				mismatch.Origin = &origin
+			}
+			w.Header().Set("Content-Type", utils.JSONContentType)
+			w.WriteHeader(http.StatusBadRequest)
+			return json.NewEncoder(w).Encode(mismatch)
+		}
+	}
+
+	if rawTx.Replaces != nil {
+		err = t.pool.Replace(*rawTx.Replaces, tx)
+	} else {
+		err = t.pool.AddLocal(tx)
+	}
+	if err != nil {
 		if txpool.IsBadTx(err) {
 			return utils.BadRequest(err)
 		}
+		if txpool.IsTxNotFound(err) {
+			return utils.HTTPError(err, http.StatusNotFound)
+		}
 		if txpool.IsTxRejected(err) {
 			return utils.Forbidden(err)
 		}
 		return err
 	}
 	txID := tx.ID()
-	return utils.WriteJSON(w, &SendTxResult{ID: &txID})
+	// origin is always available once the tx is accepted by the pool
+	origin, _ := tx.Origin()
+	return utils.WriteJSON(w, &SendTxResult{
+		ID:        &txID,
+		Origin:    origin,
+		Delegated: tx.Features().IsDelegated(),
+		Replaced:  rawTx.Replaces,
+	})
+}
+
+func (t *Transactions) handleCancelTransaction(w http.ResponseWriter, req *http.Request) error {
+	txID, err := thor.ParseBytes32(mux.Vars(req)["id"])
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "id"))
+	}
+
+	var cancel *CancelTx
+	if err := utils.ParseJSON(req.Body, &cancel); err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "body"))
+	}
+	if cancel == nil {
+		return utils.BadRequest(errors.New("body: empty body"))
+	}
+	sig, err := hexutil.Decode(cancel.Signature)
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "signature"))
+	}
+
+	if err := t.pool.Cancel(txID, sig); err != nil {
+		if txpool.IsBadTx(err) {
+			return utils.BadRequest(err)
+		}
+		if txpool.IsTxNotFound(err) {
+			return utils.HTTPError(err, http.StatusNotFound)
+		}
+		if txpool.IsTxRejected(err) {
+			return utils.Forbidden(err)
+		}
+		return err
+	}
+	return utils.WriteJSON(w, utils.M{"id": txID})
 }
 
 func (t *Transactions) handleGetTransactionByID(w http.ResponseWriter, req *http.Request) error {
@@ -144,24 +232,27 @@ func (t *Transactions) handleGetTransactionByID(w http.ResponseWriter, req *http
 		return utils.BadRequest(errors.WithMessage(err, "id"))
 	}
 
-	head, err := t.parseHead(req.URL.Query().Get("head"))
-	if err != nil {
-		return utils.BadRequest(errors.WithMessage(err, "head"))
+	pending := req.URL.Query().Get("pending")
+	if pending != "" && pending != "false" && pending != "true" {
+		return utils.BadRequest(errors.WithMessage(errors.New("should be boolean"), "pending"))
 	}
-	if _, err := t.repo.GetBlockSummary(head); err != nil {
-		if t.repo.IsNotFound(err) {
+	headStr := req.URL.Query().Get("head")
+	if pending == "true" && headStr == "" {
+		// pending txs are looked up on top of the best block, not affected by the default revision
+		headStr = "best"
+	}
+	head, err := t.parseHead(req.Context(), headStr)
+	if err != nil {
+		if utils.IsRevisionError(err) {
 			return utils.BadRequest(errors.WithMessage(err, "head"))
 		}
+		return err
 	}
 
 	raw := req.URL.Query().Get("raw")
 	if raw != "" && raw != "false" && raw != "true" {
 		return utils.BadRequest(errors.WithMessage(errors.New("should be boolean"), "raw"))
 	}
-	pending := req.URL.Query().Get("pending")
-	if pending != "" && pending != "false" && pending != "true" {
-		return utils.BadRequest(errors.WithMessage(errors.New("should be boolean"), "pending"))
-	}
 
 	if raw == "true" {
 		tx, err := t.getRawTransaction(txID, head, pending == "true")
@@ -184,15 +275,12 @@ func (t *Transactions) handleGetTransactionReceiptByID(w http.ResponseWriter, re
 		return utils.BadRequest(errors.WithMessage(err, "id"))
 	}
 
-	head, err := t.parseHead(req.URL.Query().Get("head"))
+	head, err := t.parseHead(req.Context(), req.URL.Query().Get("head"))
 	if err != nil {
-		return utils.BadRequest(errors.WithMessage(err, "head"))
-	}
-
-	if _, err := t.repo.GetBlockSummary(head); err != nil {
-		if t.repo.IsNotFound(err) {
+		if utils.IsRevisionError(err) {
 			return utils.BadRequest(errors.WithMessage(err, "head"))
 		}
+		return err
 	}
 
 	receipt, err := t.getTransactionReceiptByID(txID, head)
@@ -202,15 +290,39 @@ func (t *Transactions) handleGetTransactionReceiptByID(w http.ResponseWriter, re
 	return utils.WriteJSON(w, receipt)
 }
 
-func (t *Transactions) parseHead(head string) (thor.Bytes32, error) {
-	if head == "" {
-		return t.repo.BestBlockSummary().Header.ID(), nil
+func (t *Transactions) handleGetTransactionPositionByID(w http.ResponseWriter, req *http.Request) error {
+	id := mux.Vars(req)["id"]
+	txID, err := thor.ParseBytes32(id)
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "id"))
+	}
+
+	head, err := t.parseHead(req.Context(), req.URL.Query().Get("head"))
+	if err != nil {
+		if utils.IsRevisionError(err) {
+			return utils.BadRequest(errors.WithMessage(err, "head"))
+		}
+		return err
+	}
+
+	position, err := t.getTransactionPosition(txID, head)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, position)
+}
+
+// parseHead resolves the head param, a revision other than "next", into the ID of the head block.
+func (t *Transactions) parseHead(ctx context.Context, head string) (thor.Bytes32, error) {
+	rev, err := utils.ParseRevision(ctx, head, false)
+	if err != nil {
+		return thor.Bytes32{}, err
 	}
-	h, err := thor.ParseBytes32(head)
+	summary, err := utils.GetSummary(rev, t.repo, t.bft)
 	if err != nil {
 		return thor.Bytes32{}, err
 	}
-	return h, nil
+	return summary.Header.ID(), nil
 }
 
 func (t *Transactions) Mount(root *mux.Router, pathPrefix string) {
@@ -224,8 +336,16 @@ func (t *Transactions) Mount(root *mux.Router, pathPrefix string) {
 		Methods(http.MethodGet).
 		Name("GET /transactions/{id}").
 		HandlerFunc(utils.WrapHandlerFunc(t.handleGetTransactionByID))
+	sub.Path("/{id}/cancel").
+		Methods(http.MethodPost).
+		Name("POST /transactions/{id}/cancel").
+		HandlerFunc(utils.WrapHandlerFunc(t.handleCancelTransaction))
 	sub.Path("/{id}/receipt").
 		Methods(http.MethodGet).
 		Name("GET /transactions/{id}/receipt").
 		HandlerFunc(utils.WrapHandlerFunc(t.handleGetTransactionReceiptByID))
+	sub.Path("/{id}/position").
+		Methods(http.MethodGet).
+		Name("GET /transactions/{id}/position").
+		HandlerFunc(utils.WrapHandlerFunc(t.handleGetTransactionPositionByID))
 }
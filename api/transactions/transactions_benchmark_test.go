@@ -161,7 +161,7 @@ func BenchmarkFetchTx_RandomSigners_OneClausePerTx(b *testing.B) {
 
 func benchmarkGetTransaction(b *testing.B, thorChain *testchain.Chain, randTxs tx.Transactions) {
 	mempool := txpool.New(thorChain.Repo(), thorChain.Stater(), txpool.Options{Limit: 10, LimitPerAccount: 16, MaxLifetime: 10 * time.Minute})
-	transactionAPI := New(thorChain.Repo(), mempool)
+	transactionAPI := New(thorChain.Repo(), mempool, thorChain.Engine())
 	head := thorChain.Repo().BestBlockSummary().Header.ID()
 	var err error
 
@@ -181,7 +181,7 @@ func benchmarkGetTransaction(b *testing.B, thorChain *testchain.Chain, randTxs t
 
 func benchmarkGetReceipt(b *testing.B, thorChain *testchain.Chain, randTxs tx.Transactions) {
 	mempool := txpool.New(thorChain.Repo(), thorChain.Stater(), txpool.Options{Limit: 10, LimitPerAccount: 16, MaxLifetime: 10 * time.Minute})
-	transactionAPI := New(thorChain.Repo(), mempool)
+	transactionAPI := New(thorChain.Repo(), mempool, thorChain.Engine())
 	head := thorChain.Repo().BestBlockSummary().Header.ID()
 	var err error
 
@@ -8,6 +8,7 @@ package transactions_test
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"math/big"
 	"net/http/httptest"
 	"strings"
@@ -15,6 +16,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
@@ -32,6 +34,8 @@ var (
 	ts          *httptest.Server
 	transaction *tx.Transaction
 	mempoolTx   *tx.Transaction
+	mempool     *txpool.TxPool
+	thorChain   *testchain.Chain
 	tclient     *thorclient.Client
 	chainTag    byte
 )
@@ -46,6 +50,10 @@ func TestTransaction(t *testing.T) {
 		"sendTx":              sendTx,
 		"sendTxWithBadFormat": sendTxWithBadFormat,
 		"sendTxThatCannotBeAcceptedInLocalMempool": sendTxThatCannotBeAcceptedInLocalMempool,
+		"sendTxWithIDMismatch":                     sendTxWithIDMismatch,
+		"sendTxWithInsufficientGas":                sendTxWithInsufficientGas,
+		"cancelTx":                                 cancelTx,
+		"replaceTx":                                replaceTx,
 	} {
 		t.Run(name, tt)
 	}
@@ -60,6 +68,7 @@ func TestTransaction(t *testing.T) {
 		"getRawTransactionWhenTxStillInMempool":            getRawTransactionWhenTxStillInMempool,
 		"getTransactionByIDTxNotFound":                     getTransactionByIDTxNotFound,
 		"getTransactionByIDPendingTxNotFound":              getTransactionByIDPendingTxNotFound,
+		"getPendingTxExpiry":                               getPendingTxExpiry,
 		"handleGetTransactionByIDWithBadQueryParams":       handleGetTransactionByIDWithBadQueryParams,
 		"handleGetTransactionByIDWithNonExistingHead":      handleGetTransactionByIDWithNonExistingHead,
 	} {
@@ -74,6 +83,14 @@ func TestTransaction(t *testing.T) {
 	} {
 		t.Run(name, tt)
 	}
+
+	// Get tx position
+	for name, tt := range map[string]func(*testing.T){
+		"getTxPosition":        getTxPosition,
+		"getPositionWithBadID": getPositionWithBadID,
+	} {
+		t.Run(name, tt)
+	}
 }
 
 func getTx(t *testing.T) {
@@ -83,6 +100,9 @@ func getTx(t *testing.T) {
 		t.Fatal(err)
 	}
 	checkMatchingTx(t, transaction, rtx)
+	assert.Equal(t, uint32(0), rtx.BlockRefNumber)
+	assert.Equal(t, uint64(10), rtx.ExpirationBlock)
+	assert.Nil(t, rtx.BlocksUntilExpiry)
 
 	res = httpGetAndCheckResponseStatus(t, "/transactions/"+transaction.ID().String()+"?raw=true", 200)
 	var rawTx map[string]interface{}
@@ -105,6 +125,35 @@ func getTxReceipt(t *testing.T) {
 	assert.Equal(t, receipt.GasUsed, transaction.Gas(), "receipt gas used not equal to transaction gas")
 }
 
+func getTxPosition(t *testing.T) {
+	best := thorChain.Repo().BestBlockSummary().Header
+	res := httpGetAndCheckResponseStatus(t, "/transactions/"+transaction.ID().String()+"/position", 200)
+	var position *transactions.TxPosition
+	if err := json.Unmarshal(res, &position); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, &transactions.TxPosition{
+		BlockID:     best.ID(),
+		BlockNumber: best.Number(),
+		Index:       0,
+		ClauseCount: 1,
+	}, position)
+
+	// not in the chain of the head
+	res = httpGetAndCheckResponseStatus(t, "/transactions/"+transaction.ID().String()+"/position?head="+best.ParentID().String(), 200)
+	assert.Equal(t, "null\n", string(res))
+
+	// pending txs have no position
+	res = httpGetAndCheckResponseStatus(t, "/transactions/"+mempoolTx.ID().String()+"/position", 200)
+	assert.Equal(t, "null\n", string(res))
+}
+
+func getPositionWithBadID(t *testing.T) {
+	txBadID := "0x123"
+	res := httpGetAndCheckResponseStatus(t, "/transactions/"+txBadID+"/position", 400)
+	assert.Contains(t, string(res), "invalid length")
+}
+
 func sendTx(t *testing.T) {
 	var blockRef = tx.NewBlockRef(0)
 	var expiration = uint32(10)
@@ -125,12 +174,136 @@ func sendTx(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	res := httpPostAndCheckResponseStatus(t, "/transactions", transactions.RawTx{Raw: hexutil.Encode(rlpTx)}, 200)
-	var txObj map[string]string
+	txID := trx.ID()
+	res := httpPostAndCheckResponseStatus(t, "/transactions", transactions.RawTx{Raw: hexutil.Encode(rlpTx), ExpectedID: &txID}, 200)
+	var txObj *transactions.SendTxResult
 	if err = json.Unmarshal(res, &txObj); err != nil {
 		t.Fatal(err)
 	}
-	assert.Equal(t, trx.ID().String(), txObj["id"], "should be the same transaction id")
+	assert.Equal(t, txID, *txObj.ID, "should be the same transaction id")
+	assert.Equal(t, genesis.DevAccounts()[0].Address, txObj.Origin)
+	assert.False(t, txObj.Delegated)
+}
+
+func sendTxWithIDMismatch(t *testing.T) {
+	builder := new(tx.Builder).
+		BlockRef(tx.NewBlockRef(0)).
+		Expiration(10).
+		Gas(21000).
+		Nonce(3)
+	expected := tx.MustSign(builder.ChainTag(chainTag).Build(), genesis.DevAccounts()[0].PrivateKey)
+	// the same tx signed for another chain
+	trx := tx.MustSign(builder.ChainTag(chainTag+1).Build(), genesis.DevAccounts()[0].PrivateKey)
+
+	rlpTx, err := rlp.EncodeToBytes(trx)
+	require.NoError(t, err)
+
+	expectedID := expected.ID()
+	res := httpPostAndCheckResponseStatus(t, "/transactions", transactions.RawTx{Raw: hexutil.Encode(rlpTx), ExpectedID: &expectedID}, 400)
+
+	var mismatch *transactions.TxIDMismatch
+	require.NoError(t, json.Unmarshal(res, &mismatch))
+	assert.Equal(t, expectedID, mismatch.ExpectedID)
+	assert.Equal(t, trx.ID(), mismatch.ID)
+	assert.Equal(t, genesis.DevAccounts()[0].Address, *mismatch.Origin)
+
+	// rejected before reaching the pool
+	res = httpGetAndCheckResponseStatus(t, "/transactions/"+trx.ID().String()+"?pending=true", 200)
+	assert.Equal(t, "null\n", string(res))
+}
+
+func cancelTx(t *testing.T) {
+	trx := tx.MustSign(
+		new(tx.Builder).
+			BlockRef(tx.NewBlockRef(0)).
+			ChainTag(chainTag).
+			Expiration(10).
+			Gas(21000).
+			Nonce(2).
+			Build(),
+		genesis.DevAccounts()[1].PrivateKey,
+	)
+	rlpTx, err := rlp.EncodeToBytes(trx)
+	require.NoError(t, err)
+	httpPostAndCheckResponseStatus(t, "/transactions", transactions.RawTx{Raw: hexutil.Encode(rlpTx)}, 200)
+
+	hash := txpool.CancelSigningHash(trx.ID())
+	url := "/transactions/" + trx.ID().String() + "/cancel"
+
+	// bad signature
+	res := httpPostAndCheckResponseStatus(t, url, transactions.CancelTx{Signature: "0x1234"}, 400)
+	assert.Contains(t, string(res), "invalid cancel signature")
+
+	// not signed by origin
+	sig, err := crypto.Sign(hash[:], genesis.DevAccounts()[0].PrivateKey)
+	require.NoError(t, err)
+	res = httpPostAndCheckResponseStatus(t, url, transactions.CancelTx{Signature: hexutil.Encode(sig)}, 403)
+	assert.Contains(t, string(res), "cancel not signed by tx origin")
+
+	sig, err = crypto.Sign(hash[:], genesis.DevAccounts()[1].PrivateKey)
+	require.NoError(t, err)
+	res = httpPostAndCheckResponseStatus(t, url, transactions.CancelTx{Signature: hexutil.Encode(sig)}, 200)
+	var txObj map[string]string
+	require.NoError(t, json.Unmarshal(res, &txObj))
+	assert.Equal(t, trx.ID().String(), txObj["id"])
+
+	res = httpGetAndCheckResponseStatus(t, "/transactions/"+trx.ID().String()+"?pending=true", 200)
+	assert.Equal(t, "null\n", string(res))
+
+	// already canceled
+	res = httpPostAndCheckResponseStatus(t, url, transactions.CancelTx{Signature: hexutil.Encode(sig)}, 404)
+	assert.Contains(t, string(res), "tx not found")
+}
+
+func replaceTx(t *testing.T) {
+	var nonce uint64 = 100
+	newRawTx := func(coef uint8) (*tx.Transaction, string) {
+		nonce++
+		trx := tx.MustSign(
+			new(tx.Builder).
+				BlockRef(tx.NewBlockRef(0)).
+				ChainTag(chainTag).
+				Expiration(10).
+				Gas(21000).
+				GasPriceCoef(coef).
+				Nonce(nonce).
+				Build(),
+			genesis.DevAccounts()[2].PrivateKey,
+		)
+		rlpTx, err := rlp.EncodeToBytes(trx)
+		require.NoError(t, err)
+		return trx, hexutil.Encode(rlpTx)
+	}
+
+	old, raw := newRawTx(100)
+	httpPostAndCheckResponseStatus(t, "/transactions", transactions.RawTx{Raw: raw}, 200)
+	oldID := old.ID()
+
+	// insufficient bump
+	_, raw = newRawTx(109)
+	res := httpPostAndCheckResponseStatus(t, "/transactions", transactions.RawTx{Raw: raw, Replaces: &oldID}, 403)
+	assert.Contains(t, string(res), "replacement priority too low, bump of 10% required")
+
+	replacement, raw := newRawTx(110)
+	res = httpPostAndCheckResponseStatus(t, "/transactions", transactions.RawTx{Raw: raw, Replaces: &oldID}, 200)
+	var result *transactions.SendTxResult
+	require.NoError(t, json.Unmarshal(res, &result))
+	assert.Equal(t, replacement.ID(), *result.ID)
+	assert.Equal(t, oldID, *result.Replaced)
+
+	res = httpGetAndCheckResponseStatus(t, "/transactions/"+oldID.String()+"?pending=true", 200)
+	assert.Equal(t, "null\n", string(res))
+
+	// the tx is no longer pending
+	_, raw = newRawTx(200)
+	res = httpPostAndCheckResponseStatus(t, "/transactions", transactions.RawTx{Raw: raw, Replaces: &oldID}, 404)
+	assert.Contains(t, string(res), "tx not found")
+
+	// the tx is already included
+	includedID := transaction.ID()
+	_, raw = newRawTx(200)
+	res = httpPostAndCheckResponseStatus(t, "/transactions", transactions.RawTx{Raw: raw, Replaces: &includedID}, 403)
+	assert.Contains(t, string(res), "tx already included")
 }
 
 func getTxWithBadID(t *testing.T) {
@@ -149,7 +322,7 @@ func txWithBadHeader(t *testing.T) {
 
 	for _, url := range badHeaderURL {
 		res := httpGetAndCheckResponseStatus(t, url, 400)
-		assert.Contains(t, string(res), "invalid length")
+		assert.Contains(t, string(res), "head: strconv.ParseUint")
 	}
 }
 
@@ -214,6 +387,43 @@ func getTransactionByIDPendingTxNotFound(t *testing.T) {
 	checkMatchingTx(t, mempoolTx, rtx)
 }
 
+func getPendingTxExpiry(t *testing.T) {
+	best := thorChain.Repo().BestBlockSummary().Header
+
+	for _, tc := range []struct {
+		name            string
+		blockRef        uint32
+		expiration      uint32
+		expirationBlock uint64
+	}{
+		{"fresh", best.Number(), 100, uint64(best.Number()) + 100},
+		{"near expiry", 0, best.Number() + 1, uint64(best.Number()) + 1},
+		{"expired", 0, best.Number(), uint64(best.Number())},
+		{"block ref ahead", best.Number() + 10, 10, uint64(best.Number()) + 20},
+		{"max", math.MaxUint32, math.MaxUint32, 2 * math.MaxUint32},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			trx := tx.MustSign(new(tx.Builder).
+				ChainTag(chainTag).
+				BlockRef(tx.NewBlockRef(tc.blockRef)).
+				Expiration(tc.expiration).
+				Gas(21000).
+				Nonce(uint64(tc.expiration)).
+				Build(), genesis.DevAccounts()[1].PrivateKey)
+			require.NoError(t, mempool.Add(trx))
+
+			res := httpGetAndCheckResponseStatus(t, "/transactions/"+trx.ID().String()+"?pending=true", 200)
+			var rtx *transactions.Transaction
+			require.NoError(t, json.Unmarshal(res, &rtx))
+
+			assert.Equal(t, tc.blockRef, rtx.BlockRefNumber)
+			assert.Equal(t, tc.expirationBlock, rtx.ExpirationBlock)
+			require.NotNil(t, rtx.BlocksUntilExpiry)
+			assert.Equal(t, int64(tc.expirationBlock)-int64(best.Number()), *rtx.BlocksUntilExpiry)
+		})
+	}
+}
+
 func sendTxWithBadFormat(t *testing.T) {
 	badRawTx := transactions.RawTx{Raw: "badRawTx"}
 
@@ -235,6 +445,28 @@ func sendTxThatCannotBeAcceptedInLocalMempool(t *testing.T) {
 	assert.Contains(t, string(res), "bad tx: chain tag mismatch")
 }
 
+func sendTxWithInsufficientGas(t *testing.T) {
+	to := thor.BytesToAddress([]byte("to"))
+	builder := new(tx.Builder).
+		BlockRef(tx.NewBlockRef(0)).
+		ChainTag(chainTag).
+		Expiration(10).
+		Clause(tx.NewClause(&to)).
+		Clause(tx.NewClause(&to))
+	intrinsicGas, err := builder.Build().IntrinsicGas()
+	require.NoError(t, err)
+
+	// one gas unit short
+	trx := tx.MustSign(builder.Gas(intrinsicGas-1).Build(), genesis.DevAccounts()[0].PrivateKey)
+	rlpTx, err := rlp.EncodeToBytes(trx)
+	require.NoError(t, err)
+
+	res := httpPostAndCheckResponseStatus(t, "/transactions", transactions.RawTx{Raw: hexutil.Encode(rlpTx)}, 400)
+	assert.Equal(t,
+		fmt.Sprintf("bad tx: intrinsic gas exceeds provided gas: required %d, provided %d, clauses 2", intrinsicGas, intrinsicGas-1),
+		strings.TrimSpace(string(res)))
+}
+
 func handleGetTransactionByIDWithBadQueryParams(t *testing.T) {
 	badQueryParams := []string{
 		"?pending=badPending",
@@ -249,12 +481,12 @@ func handleGetTransactionByIDWithBadQueryParams(t *testing.T) {
 
 func handleGetTransactionByIDWithNonExistingHead(t *testing.T) {
 	res := httpGetAndCheckResponseStatus(t, "/transactions/"+transaction.ID().String()+"?head=0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", 400)
-	assert.Equal(t, "head: leveldb: not found", strings.TrimSpace(string(res)))
+	assert.Equal(t, "head: not found", strings.TrimSpace(string(res)))
 }
 
 func handleGetTransactionReceiptByIDWithNonExistingHead(t *testing.T) {
 	res := httpGetAndCheckResponseStatus(t, "/transactions/"+transaction.ID().String()+"/receipt?head=0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", 400)
-	assert.Equal(t, "head: leveldb: not found", strings.TrimSpace(string(res)))
+	assert.Equal(t, "head: not found", strings.TrimSpace(string(res)))
 }
 
 func httpPostAndCheckResponseStatus(t *testing.T, url string, obj interface{}, responseStatusCode int) []byte {
@@ -266,7 +498,8 @@ func httpPostAndCheckResponseStatus(t *testing.T, url string, obj interface{}, r
 }
 
 func initTransactionServer(t *testing.T) {
-	thorChain, err := testchain.NewIntegrationTestChain()
+	var err error
+	thorChain, err = testchain.NewIntegrationTestChain()
 	require.NoError(t, err)
 
 	chainTag = thorChain.Repo().ChainTag()
@@ -286,7 +519,7 @@ func initTransactionServer(t *testing.T) {
 
 	require.NoError(t, thorChain.MintTransactions(genesis.DevAccounts()[0], transaction))
 
-	mempool := txpool.New(thorChain.Repo(), thorChain.Stater(), txpool.Options{Limit: 10000, LimitPerAccount: 16, MaxLifetime: 10 * time.Minute})
+	mempool = txpool.New(thorChain.Repo(), thorChain.Stater(), txpool.Options{Limit: 10000, LimitPerAccount: 16, MaxLifetime: 10 * time.Minute, PriceBumpPct: 10})
 
 	mempoolTx = new(tx.Builder).
 		ChainTag(chainTag).
@@ -303,7 +536,7 @@ func initTransactionServer(t *testing.T) {
 	}
 
 	router := mux.NewRouter()
-	transactions.New(thorChain.Repo(), mempool).Mount(router, "/transactions")
+	transactions.New(thorChain.Repo(), mempool, thorChain.Engine()).Mount(router, "/transactions")
 
 	ts = httptest.NewServer(router)
 }
@@ -60,10 +60,16 @@ type Transaction struct {
 	DependsOn    *thor.Bytes32       `json:"dependsOn"`
 	Size         uint32              `json:"size"`
 	Meta         *TxMeta             `json:"meta"`
+
+	BlockRefNumber    uint32 `json:"blockRefNumber"`
+	ExpirationBlock   uint64 `json:"expirationBlock"`             // the last block the tx can be included in
+	BlocksUntilExpiry *int64 `json:"blocksUntilExpiry,omitempty"` // for pending txs only, count of blocks after the best block the tx can be included in
 }
 
 type RawTx struct {
-	Raw string `json:"raw"`
+	Raw        string        `json:"raw"`
+	ExpectedID *thor.Bytes32 `json:"expectedID,omitempty"`
+	Replaces   *thor.Bytes32 `json:"replaces,omitempty"` // the pending tx from the same origin to be replaced
 }
 
 func (rtx *RawTx) decode() (*tx.Transaction, error) {
@@ -107,6 +113,9 @@ func convertTransaction(tx *tx.Transaction, header *block.Header) *Transaction {
 		DependsOn:    tx.DependsOn(),
 		Clauses:      cls,
 		Delegator:    delegator,
+
+		BlockRefNumber:  br.Number(),
+		ExpirationBlock: expirationBlock(tx),
 	}
 
 	if header != nil {
@@ -119,12 +128,36 @@ func convertTransaction(tx *tx.Transaction, header *block.Header) *Transaction {
 	return t
 }
 
+// expirationBlock returns the last block the tx can be included in.
+// It's computed in uint64, since it may overflow uint32.
+func expirationBlock(tx *tx.Transaction) uint64 {
+	return uint64(tx.BlockRef().Number()) + uint64(tx.Expiration())
+}
+
+// convertPendingTransaction converts a pending tx into a json format transaction,
+// with the count of blocks after the best block the tx can be included in.
+// The tx is effectively dead if the count is not positive.
+func convertPendingTransaction(tx *tx.Transaction, best *block.Header) *Transaction {
+	t := convertTransaction(tx, nil)
+	untilExpiry := int64(t.ExpirationBlock) - int64(best.Number())
+	t.BlocksUntilExpiry = &untilExpiry
+	return t
+}
+
 type TxMeta struct {
 	BlockID        thor.Bytes32 `json:"blockID"`
 	BlockNumber    uint32       `json:"blockNumber"`
 	BlockTimestamp uint64       `json:"blockTimestamp"`
 }
 
+// TxPosition is the location of a tx in the chain.
+type TxPosition struct {
+	BlockID     thor.Bytes32 `json:"blockID"`
+	BlockNumber uint32       `json:"blockNumber"`
+	Index       uint64       `json:"index"` // of the tx in the block
+	ClauseCount int          `json:"clauseCount"`
+}
+
 type ReceiptMeta struct {
 	BlockID        thor.Bytes32 `json:"blockID"`
 	BlockNumber    uint32       `json:"blockNumber"`
@@ -135,13 +168,15 @@ type ReceiptMeta struct {
 
 // Receipt for json marshal
 type Receipt struct {
-	GasUsed  uint64                `json:"gasUsed"`
-	GasPayer thor.Address          `json:"gasPayer"`
-	Paid     *math.HexOrDecimal256 `json:"paid"`
-	Reward   *math.HexOrDecimal256 `json:"reward"`
-	Reverted bool                  `json:"reverted"`
-	Meta     ReceiptMeta           `json:"meta"`
-	Outputs  []*Output             `json:"outputs"`
+	GasUsed     uint64                `json:"gasUsed"`
+	GasPayer    thor.Address          `json:"gasPayer"`
+	Paid        *math.HexOrDecimal256 `json:"paid"`
+	Reward      *math.HexOrDecimal256 `json:"reward"`
+	BurnedFee   *math.HexOrDecimal256 `json:"burnedFee"`   // part of paid burned
+	PriorityFee *math.HexOrDecimal256 `json:"priorityFee"` // part of paid rewarded to the block proposer
+	Reverted    bool                  `json:"reverted"`
+	Meta        ReceiptMeta           `json:"meta"`
+	Outputs     []*Output             `json:"outputs"`
 }
 
 // Output output of clause execution.
@@ -169,16 +204,19 @@ type Transfer struct {
 func convertReceipt(txReceipt *tx.Receipt, header *block.Header, tx *tx.Transaction) (*Receipt, error) {
 	reward := math.HexOrDecimal256(*txReceipt.Reward)
 	paid := math.HexOrDecimal256(*txReceipt.Paid)
+	burned := math.HexOrDecimal256(*txReceipt.BurnedFee())
 	origin, err := tx.Origin()
 	if err != nil {
 		return nil, err
 	}
 	receipt := &Receipt{
-		GasUsed:  txReceipt.GasUsed,
-		GasPayer: txReceipt.GasPayer,
-		Paid:     &paid,
-		Reward:   &reward,
-		Reverted: txReceipt.Reverted,
+		GasUsed:     txReceipt.GasUsed,
+		GasPayer:    txReceipt.GasPayer,
+		Paid:        &paid,
+		Reward:      &reward,
+		BurnedFee:   &burned,
+		PriorityFee: &reward,
+		Reverted:    txReceipt.Reverted,
 		Meta: ReceiptMeta{
 			header.ID(),
 			header.Number(),
@@ -221,7 +259,25 @@ func convertReceipt(txReceipt *tx.Receipt, header *block.Header, tx *tx.Transact
 	return receipt, nil
 }
 
+// CancelTx is the request to cancel a pending tx, the signature is signed by
+// the tx origin over txpool.CancelSigningHash(txID).
+type CancelTx struct {
+	Signature string `json:"signature"`
+}
+
 // SendTxResult is the response to the Send Tx method
 type SendTxResult struct {
-	ID *thor.Bytes32 `json:"id"`
+	ID        *thor.Bytes32 `json:"id"`
+	Origin    thor.Address  `json:"origin"`
+	Delegated bool          `json:"delegated"`
+	Replaced  *thor.Bytes32 `json:"replaced,omitempty"` // the ID of the tx dropped from the local pool, which may still be packed
+}
+
+// TxIDMismatch is the response to the Send Tx method when the decoded tx
+// doesn't match the expected id.
+type TxIDMismatch struct {
+	Error      string        `json:"error"`
+	ExpectedID thor.Bytes32  `json:"expectedID"`
+	ID         thor.Bytes32  `json:"id"`
+	Origin     *thor.Address `json:"origin"`
 }
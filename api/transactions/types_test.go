@@ -7,7 +7,11 @@ package transactions
 
 import (
 	"crypto/rand"
+	"flag"
 	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -15,7 +19,10 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/secp256k1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/api/utils"
 	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/genesis"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/tx"
 )
@@ -72,6 +79,29 @@ func TestConvertReceipt(t *testing.T) {
 	assert.Equal(t, (*math.HexOrDecimal256)(receipt.Outputs[0].Transfers[0].Amount), convRec.Outputs[0].Transfers[0].Amount)
 }
 
+func TestConvertReceiptFees(t *testing.T) {
+	addr := randAddress()
+	tr := newTx(tx.NewClause(&addr))
+	header := new(block.Builder).Build().Header()
+	origin, err := tr.Origin()
+	assert.NoError(t, err)
+
+	// the fees are broken down the same way, whoever pays the gas
+	for _, payer := range []thor.Address{origin, randAddress()} {
+		receipt := newReceipt()
+		receipt.GasPayer = payer
+		receipt.Paid = big.NewInt(1000)
+		receipt.Reward = big.NewInt(300)
+
+		convRec, err := convertReceipt(receipt, header, tr)
+
+		assert.NoError(t, err)
+		assert.Equal(t, payer, convRec.GasPayer)
+		assert.Equal(t, big.NewInt(700), (*big.Int)(convRec.BurnedFee))
+		assert.Equal(t, big.NewInt(300), (*big.Int)(convRec.PriorityFee))
+	}
+}
+
 // Utilities functions
 func randAddress() (addr thor.Address) {
 	rand.Read(addr[:])
@@ -114,3 +144,70 @@ func randomBytes32() thor.Bytes32 {
 	rand.Read(b32[:])
 	return b32
 }
+
+var updateGolden = flag.Bool("update", false, "update the golden files")
+
+// assertGolden checks the response body of the value is byte-exact to the golden file.
+func assertGolden(t *testing.T, name string, v interface{}) {
+	rec := httptest.NewRecorder()
+	require.NoError(t, utils.WriteJSON(rec, v))
+
+	path := filepath.Join("testdata", name+".golden.json")
+	if *updateGolden {
+		require.NoError(t, os.MkdirAll("testdata", 0o755))
+		require.NoError(t, os.WriteFile(path, rec.Body.Bytes(), 0o600))
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), rec.Body.String())
+}
+
+func TestEncodingGolden(t *testing.T) {
+	to := thor.BytesToAddress([]byte("to"))
+	dependsOn := thor.BytesToBytes32([]byte("dependsOn"))
+	trx := tx.MustSign(new(tx.Builder).
+		ChainTag(0x27).
+		BlockRef(tx.NewBlockRef(100)).
+		Expiration(720).
+		Clause(tx.NewClause(&to).WithValue(big.NewInt(1e18)).WithData([]byte{0xde, 0xad})).
+		Clause(tx.NewClause(nil).WithData([]byte{0x60, 0x80})).
+		GasPriceCoef(128).
+		Gas(100000).
+		DependsOn(&dependsOn).
+		Nonce(0x1234).
+		Build(), genesis.DevAccounts()[0].PrivateKey)
+	header := new(block.Builder).
+		ParentID(thor.BytesToBytes32([]byte("parent"))).
+		Timestamp(1700000000).
+		GasLimit(40_000_000).
+		GasUsed(90000).
+		TotalScore(101).
+		Build().Header()
+	receipt := &tx.Receipt{
+		GasUsed:  90000,
+		GasPayer: genesis.DevAccounts()[0].Address,
+		Paid:     big.NewInt(1_350_000_000_000_000_000),
+		Reward:   big.NewInt(405_000_000_000_000_000),
+		Outputs: []*tx.Output{
+			{
+				Events: tx.Events{{
+					Address: to,
+					Topics:  []thor.Bytes32{thor.BytesToBytes32([]byte("topic"))},
+					Data:    []byte{1, 2, 3},
+				}},
+				Transfers: tx.Transfers{{
+					Sender:    genesis.DevAccounts()[0].Address,
+					Recipient: to,
+					Amount:    big.NewInt(1e18),
+				}},
+			},
+			{},
+		},
+	}
+
+	assertGolden(t, "transaction", convertTransaction(trx, header))
+	assertGolden(t, "pending_transaction", convertPendingTransaction(trx, header))
+	converted, err := convertReceipt(receipt, header, trx)
+	require.NoError(t, err)
+	assertGolden(t, "receipt", converted)
+}
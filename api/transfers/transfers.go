@@ -14,6 +14,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/v2/api/events"
 	"github.com/vechain/thor/v2/api/utils"
+	"github.com/vechain/thor/v2/bft"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/logdb"
 )
@@ -22,22 +23,31 @@ type Transfers struct {
 	repo  *chain.Repository
 	db    *logdb.LogDB
 	limit uint64
+	bft   bft.Committer
 }
 
-func New(repo *chain.Repository, db *logdb.LogDB, logsLimit uint64) *Transfers {
+func New(repo *chain.Repository, db *logdb.LogDB, logsLimit uint64, bft bft.Committer) *Transfers {
 	return &Transfers{
 		repo,
 		db,
 		logsLimit,
+		bft,
 	}
 }
 
-// Filter query logs with option
-func (t *Transfers) filter(ctx context.Context, filter *TransferFilter) ([]*FilteredTransfer, error) {
-	rng, err := events.ConvertRange(t.repo.NewBestChain(), filter.Range)
+// Filter query logs with option, as of the head of the chain if given, otherwise the best chain.
+func (t *Transfers) filter(ctx context.Context, filter *TransferFilter, revChain *chain.Chain) ([]*FilteredTransfer, error) {
+	chain := revChain
+	if chain == nil {
+		chain = t.repo.NewBestChain()
+	}
+	rng, err := events.ConvertRange(chain, filter.Range)
 	if err != nil {
 		return nil, err
 	}
+	if revChain != nil {
+		rng = events.CapRange(chain, rng)
+	}
 
 	transfers, err := t.db.FilterTransfers(ctx, &logdb.TransferFilter{
 		CriteriaSet: filter.CriteriaSet,
@@ -72,7 +82,12 @@ func (t *Transfers) handleFilterTransferLogs(w http.ResponseWriter, req *http.Re
 		}
 	}
 
-	tLogs, err := t.filter(req.Context(), &filter)
+	revChain, err := events.RevisionChain(req.Context(), t.repo, t.bft, req.URL.Query().Get("revision"))
+	if err != nil {
+		return err
+	}
+
+	tLogs, err := t.filter(req.Context(), &filter, revChain)
 	if err != nil {
 		return err
 	}
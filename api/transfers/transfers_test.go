@@ -175,7 +175,7 @@ func initTransferServer(t *testing.T, logDb *logdb.LogDB, limit uint64) {
 	require.NoError(t, err)
 
 	router := mux.NewRouter()
-	transfers.New(thorChain.Repo(), logDb, limit).Mount(router, "/logs/transfers")
+	transfers.New(thorChain.Repo(), logDb, limit, thorChain.Engine()).Mount(router, "/logs/transfers")
 
 	ts = httptest.NewServer(router)
 }
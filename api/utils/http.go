@@ -11,6 +11,7 @@ import (
 	"net/http"
 
 	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/state"
 )
 
 type httpError struct {
@@ -59,6 +60,7 @@ func Forbidden(cause error) error {
 
 // HandlerFunc like http.HandlerFunc, bu it returns an error.
 // If the returned error is httpError type, httpError.status will be responded,
+// if it's caused by accessing the pruned state, http.StatusGone responded,
 // otherwise http.StatusInternalServerError responded.
 type HandlerFunc func(http.ResponseWriter, *http.Request) error
 
@@ -73,6 +75,8 @@ func WrapHandlerFunc(f HandlerFunc) http.HandlerFunc {
 				} else {
 					w.WriteHeader(he.status)
 				}
+			} else if pruned, ok := errors.Cause(err).(*state.ErrStatePruned); ok {
+				http.Error(w, pruned.Error()+", the state is no longer available on this node", http.StatusGone)
 			} else {
 				http.Error(w, err.Error(), http.StatusInternalServerError)
 			}
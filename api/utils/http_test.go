@@ -16,6 +16,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/vechain/thor/v2/api/utils"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
 )
 
 func TestWrapHandlerFunc(t *testing.T) {
@@ -82,6 +84,19 @@ func TestWrapHandlerFuncWithNilCauseError(t *testing.T) {
 	assert.Equal(t, "", response.Body.String())
 }
 
+func TestWrapHandlerFuncWithStatePrunedError(t *testing.T) {
+	pruned := &state.ErrStatePruned{Root: thor.BytesToBytes32([]byte("root")), BlockNum: 1}
+	handlerFunc := func(w http.ResponseWriter, r *http.Request) error {
+		return errors.WithMessage(pruned, "get balance")
+	}
+	wrapped := utils.WrapHandlerFunc(handlerFunc)
+
+	response := callWrappedFunc(&wrapped)
+
+	assert.Equal(t, http.StatusGone, response.Code)
+	assert.True(t, strings.HasPrefix(response.Body.String(), pruned.Error()))
+}
+
 func callWrappedFunc(wrapped *http.HandlerFunc) *httptest.ResponseRecorder {
 	req := httptest.NewRequest("GET", "http://example.com", nil)
 
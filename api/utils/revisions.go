@@ -6,8 +6,10 @@
 package utils
 
 import (
+	"context"
 	"errors"
 	"math"
+	"net/http"
 	"strconv"
 
 	"github.com/vechain/thor/v2/bft"
@@ -25,32 +27,126 @@ const (
 )
 
 type Revision struct {
-	val interface{}
+	val    interface{}
+	header http.Header // of the response the resolved block ID is set to, nil if not parsed for a request
+}
+
+// ResolvedRevisionHeader is the response header of the ID of the block the revision of a request resolves to.
+const ResolvedRevisionHeader = "X-Thor-Resolved-Revision"
+
+type requestRevisionKey struct{}
+
+// requestRevision is attached to the context of a request by RevisionHandler.
+type requestRevision struct {
+	defaultRevision string
+	header          http.Header
+}
+
+// RevisionHandler attaches the default revision to the requests, i.e. the one an omitted revision is parsed
+// as by ParseRevision, "best" if empty. The ID of the block a revision of the request resolves to by GetSummary
+// is then responded in the ResolvedRevisionHeader.
+func RevisionHandler(next http.Handler, defaultRevision string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), requestRevisionKey{}, &requestRevision{defaultRevision, w.Header()})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RevisionErrorKind tells why a revision can't be resolved.
+type RevisionErrorKind int
+
+const (
+	// RevisionMalformed is the kind of revisions that can't be parsed.
+	RevisionMalformed RevisionErrorKind = iota
+	// RevisionUnsupported is the kind of revisions not supported by the endpoint, i.e. "next".
+	RevisionUnsupported
+	// RevisionNotFound is the kind of revisions that refer to no known block.
+	RevisionNotFound
+)
+
+var (
+	errNextNotAllowed   = errors.New("invalid revision: next is not allowed")
+	errRevisionNotFound = errors.New("not found")
+)
+
+// RevisionError is the error returned by ParseRevision, GetSummary and GetSummaryAndState when the
+// revision can't be resolved. All endpoints respond it as a bad request of the revision param, except
+// the ones responding null for unknown blocks.
+type RevisionError struct {
+	Kind RevisionErrorKind
+	err  error
+}
+
+func (e *RevisionError) Error() string {
+	return e.err.Error()
+}
+
+func (e *RevisionError) Unwrap() error {
+	return e.err
+}
+
+// IsRevisionError returns whether the error is a RevisionError.
+func IsRevisionError(err error) bool {
+	var revErr *RevisionError
+	return errors.As(err, &revErr)
+}
+
+// IsRevisionNotFound returns whether the error is a RevisionError of the kind RevisionNotFound.
+func IsRevisionNotFound(err error) bool {
+	var revErr *RevisionError
+	return errors.As(err, &revErr) && revErr.Kind == RevisionNotFound
 }
 
 func (rev *Revision) IsNext() bool {
 	return rev.val == revNext
 }
 
-// ParseRevision parses a query parameter into a block number or block ID.
-func ParseRevision(revision string, allowNext bool) (*Revision, error) {
+// IsBest returns whether the revision is the best block.
+func (rev *Revision) IsBest() bool {
+	return rev.val == revBest
+}
+
+// ParseRevision parses a query parameter into a block number or block ID. Endpoints not able to
+// serve the upcoming block pass allowNext false, and "next" is rejected as unsupported.
+// The context is the one of the request, an omitted revision is parsed as the default revision
+// attached by RevisionHandler, if any.
+func ParseRevision(ctx context.Context, revision string, allowNext bool) (*Revision, error) {
+	reqRev, _ := ctx.Value(requestRevisionKey{}).(*requestRevision)
+	if revision == "" && reqRev != nil {
+		revision = reqRev.defaultRevision
+	}
+	rev, err := parseRevision(revision, allowNext)
+	if err != nil {
+		var revErr *RevisionError
+		if !errors.As(err, &revErr) {
+			revErr = &RevisionError{RevisionMalformed, err}
+		}
+		return nil, revErr
+	}
+	if reqRev != nil {
+		rev.header = reqRev.header
+	}
+	return rev, nil
+}
+
+func parseRevision(revision string, allowNext bool) (*Revision, error) {
 	if revision == "" || revision == "best" {
-		return &Revision{revBest}, nil
+		return &Revision{val: revBest}, nil
 	}
 
 	if revision == "finalized" {
-		return &Revision{revFinalized}, nil
+		return &Revision{val: revFinalized}, nil
 	}
 
 	if revision == "justified" {
-		return &Revision{revJustified}, nil
+		return &Revision{val: revJustified}, nil
 	}
 
 	if revision == "next" {
 		if !allowNext {
-			return nil, errors.New("invalid revision: next is not allowed")
+			return nil, &RevisionError{RevisionUnsupported, errNextNotAllowed}
 		}
-		return &Revision{revNext}, nil
+		return &Revision{val: revNext}, nil
 	}
 
 	if len(revision) == 66 || len(revision) == 64 {
@@ -58,7 +154,7 @@ func ParseRevision(revision string, allowNext bool) (*Revision, error) {
 		if err != nil {
 			return nil, err
 		}
-		return &Revision{blockID}, nil
+		return &Revision{val: blockID}, nil
 	}
 	n, err := strconv.ParseUint(revision, 0, 0)
 	if err != nil {
@@ -67,20 +163,24 @@ func ParseRevision(revision string, allowNext bool) (*Revision, error) {
 	if n > math.MaxUint32 {
 		return nil, errors.New("block number out of max uint32")
 	}
-	return &Revision{uint32(n)}, err
+	return &Revision{val: uint32(n)}, err
 }
 
 // GetSummary returns the block summary for the given revision,
 // revision required to be a deterministic block other than "next".
-func GetSummary(rev *Revision, repo *chain.Repository, bft bft.Committer) (sum *chain.BlockSummary, err error) {
+// The ID of the block is set to the ResolvedRevisionHeader of the response, if parsed for a request.
+func GetSummary(rev *Revision, repo *chain.Repository, bft bft.Committer) (*chain.BlockSummary, error) {
 	var id thor.Bytes32
 	switch rev := rev.val.(type) {
 	case thor.Bytes32:
 		id = rev
 	case uint32:
-		id, err = repo.NewBestChain().GetBlockID(rev)
-		if err != nil {
-			return
+		var err error
+		if id, err = repo.NewBestChain().GetBlockID(rev); err != nil {
+			if repo.IsNotFound(err) {
+				return nil, &RevisionError{RevisionNotFound, errRevisionNotFound}
+			}
+			return nil, err
 		}
 	case int64:
 		switch rev {
@@ -89,19 +189,27 @@ func GetSummary(rev *Revision, repo *chain.Repository, bft bft.Committer) (sum *
 		case revFinalized:
 			id = bft.Finalized()
 		case revJustified:
-			id, err = bft.Justified()
-			if err != nil {
+			var err error
+			if id, err = bft.Justified(); err != nil {
 				return nil, err
 			}
+		case revNext:
+			return nil, &RevisionError{RevisionUnsupported, errNextNotAllowed}
 		}
 	}
 	if id.IsZero() {
-		return nil, errors.New("invalid revision")
+		return nil, &RevisionError{RevisionNotFound, errRevisionNotFound}
 	}
 	summary, err := repo.GetBlockSummary(id)
 	if err != nil {
+		if repo.IsNotFound(err) {
+			return nil, &RevisionError{RevisionNotFound, errRevisionNotFound}
+		}
 		return nil, err
 	}
+	if rev.header != nil {
+		rev.header.Set(ResolvedRevisionHeader, id.String())
+	}
 	return summary, nil
 }
 
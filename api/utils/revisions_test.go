@@ -6,6 +6,7 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"testing"
@@ -26,36 +27,36 @@ func TestParseRevision(t *testing.T) {
 		{
 			revision: "",
 			err:      nil,
-			expected: &Revision{revBest},
+			expected: &Revision{val: revBest},
 		},
 		{
 			revision: "1234",
 			err:      nil,
-			expected: &Revision{uint32(1234)},
+			expected: &Revision{val: uint32(1234)},
 		},
 		{
 			revision: "best",
 			err:      nil,
-			expected: &Revision{revBest},
+			expected: &Revision{val: revBest},
 		},
 		{
 			revision: "justified",
 			err:      nil,
-			expected: &Revision{revJustified},
+			expected: &Revision{val: revJustified},
 		},
 		{
 			revision: "finalized",
 			err:      nil,
-			expected: &Revision{revFinalized},
+			expected: &Revision{val: revFinalized},
 		}, {
 			revision: "next",
 			err:      nil,
-			expected: &Revision{revNext},
+			expected: &Revision{val: revNext},
 		},
 		{
 			revision: "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef",
 			err:      nil,
-			expected: &Revision{thor.MustParseBytes32("0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef")},
+			expected: &Revision{val: thor.MustParseBytes32("0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef")},
 		},
 		{
 			revision: "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdzz",
@@ -76,7 +77,7 @@ func TestParseRevision(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.revision, func(t *testing.T) {
-			result, err := ParseRevision(tc.revision, true)
+			result, err := ParseRevision(context.Background(), tc.revision, true)
 			if tc.err != nil {
 				assert.Equal(t, tc.err.Error(), err.Error())
 			} else {
@@ -88,12 +89,12 @@ func TestParseRevision(t *testing.T) {
 }
 
 func TestAllowNext(t *testing.T) {
-	_, err := ParseRevision("next", false)
+	_, err := ParseRevision(context.Background(), "next", false)
 	assert.Error(t, err, "invalid revision: next is not allowed")
 
-	_, err = ParseRevision("next", true)
+	_, err = ParseRevision(context.Background(), "next", true)
 	assert.Nil(t, err)
-	_, err = ParseRevision("finalized", false)
+	_, err = ParseRevision(context.Background(), "finalized", false)
 	assert.Nil(t, err)
 }
 
@@ -109,33 +110,33 @@ func TestGetSummary(t *testing.T) {
 	}{
 		{
 			name:     "best",
-			revision: &Revision{revBest},
+			revision: &Revision{val: revBest},
 			err:      nil,
 		},
 		{
 			name:     "1234",
-			revision: &Revision{uint32(1234)},
+			revision: &Revision{val: uint32(1234)},
 			err:      errors.New("not found"),
 		},
 		{
 			name:     "justified",
-			revision: &Revision{revJustified},
+			revision: &Revision{val: revJustified},
 			err:      nil,
 		},
 		{
 			name:     "finalized",
-			revision: &Revision{revFinalized},
+			revision: &Revision{val: revFinalized},
 			err:      nil,
 		},
 		{
 			name:     "0x00000000c05a20fbca2bf6ae3affba6af4a74b800b585bf7a4988aba7aea69f6",
-			revision: &Revision{thor.MustParseBytes32("0x00000000c05a20fbca2bf6ae3affba6af4a74b800b585bf7a4988aba7aea69f6")},
+			revision: &Revision{val: thor.MustParseBytes32("0x00000000c05a20fbca2bf6ae3affba6af4a74b800b585bf7a4988aba7aea69f6")},
 			err:      nil,
 		},
 		{
 			name:     "next",
-			revision: &Revision{revNext},
-			err:      errors.New("invalid revision"),
+			revision: &Revision{val: revNext},
+			err:      errors.New("invalid revision: next is not allowed"),
 		},
 	}
 
@@ -158,12 +159,12 @@ func TestGetSummaryAndState(t *testing.T) {
 
 	b := thorChain.GenesisBlock()
 
-	summary, _, err := GetSummaryAndState(&Revision{revBest}, thorChain.Repo(), thorChain.Engine(), thorChain.Stater())
+	summary, _, err := GetSummaryAndState(&Revision{val: revBest}, thorChain.Repo(), thorChain.Engine(), thorChain.Stater())
 	assert.Nil(t, err)
 	assert.Equal(t, summary.Header.Number(), b.Header().Number())
 	assert.Equal(t, summary.Header.Timestamp(), b.Header().Timestamp())
 
-	summary, _, err = GetSummaryAndState(&Revision{revNext}, thorChain.Repo(), thorChain.Engine(), thorChain.Stater())
+	summary, _, err = GetSummaryAndState(&Revision{val: revNext}, thorChain.Repo(), thorChain.Engine(), thorChain.Stater())
 	assert.Nil(t, err)
 	assert.Equal(t, summary.Header.Number(), b.Header().Number()+1)
 	assert.Equal(t, summary.Header.Timestamp(), b.Header().Timestamp()+thor.BlockInterval)
@@ -172,3 +173,34 @@ func TestGetSummaryAndState(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.True(t, signer.IsZero())
 }
+
+func TestRevisionError(t *testing.T) {
+	thorChain, err := testchain.NewIntegrationTestChain()
+	require.NoError(t, err)
+
+	kindOf := func(err error) RevisionErrorKind {
+		revErr, ok := err.(*RevisionError)
+		require.True(t, ok, err)
+		return revErr.Kind
+	}
+
+	_, err = ParseRevision(context.Background(), "abc", false)
+	assert.Equal(t, RevisionMalformed, kindOf(err))
+	_, err = ParseRevision(context.Background(), fmt.Sprintf("%v", uint64(math.MaxUint64)), false)
+	assert.Equal(t, RevisionMalformed, kindOf(err))
+	_, err = ParseRevision(context.Background(), "next", false)
+	assert.Equal(t, RevisionUnsupported, kindOf(err))
+
+	_, err = GetSummary(&Revision{val: uint32(1234)}, thorChain.Repo(), thorChain.Engine())
+	assert.True(t, IsRevisionNotFound(err))
+	assert.Equal(t, "not found", err.Error())
+	_, err = GetSummary(&Revision{val: thor.Bytes32{0xff}}, thorChain.Repo(), thorChain.Engine())
+	assert.True(t, IsRevisionNotFound(err))
+	assert.Equal(t, "not found", err.Error())
+	_, _, err = GetSummaryAndState(&Revision{val: thor.Bytes32{0xff}}, thorChain.Repo(), thorChain.Engine(), thorChain.Stater())
+	assert.True(t, IsRevisionNotFound(err))
+
+	assert.True(t, IsRevisionError(err))
+	assert.False(t, IsRevisionError(errors.New("not found")))
+	assert.False(t, IsRevisionNotFound(&RevisionError{Kind: RevisionMalformed, err: errors.New("malformed")}))
+}
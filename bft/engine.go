@@ -74,12 +74,59 @@ func NewEngine(repo *chain.Repository, mainDB *muxdb.MuxDB, forkConfig thor.Fork
 		}
 		engine.finalized.Store(engine.repo.GenesisBlock().Header().ID())
 	} else {
-		engine.finalized.Store(thor.BytesToBytes32(val))
+		finalized := thor.BytesToBytes32(val)
+		// the finalized block is not durable until the blocks imported in bulk are committed, and is
+		// discarded with them on crash, roll back to the one of the best chain if so
+		if ok, err := engine.repo.NewBestChain().HasBlock(finalized); err != nil {
+			return nil, err
+		} else if !ok {
+			if finalized, err = engine.recoverFinalized(); err != nil {
+				return nil, errors.Wrap(err, "recover finalized")
+			}
+			if err := engine.data.Put(finalizedKey, finalized[:]); err != nil {
+				return nil, err
+			}
+		}
+		engine.finalized.Store(finalized)
 	}
 
 	return &engine, nil
 }
 
+// recoverFinalized computes the finalized checkpoint of the best chain, from its latest committed round.
+func (engine *Engine) recoverFinalized() (thor.Bytes32, error) {
+	var (
+		genesisID = engine.repo.GenesisBlock().Header().ID()
+		best      = engine.repo.BestBlockSummary().Header
+		bestChain = engine.repo.NewBestChain()
+	)
+
+	num := getStorePoint(best.Number())
+	if num > best.Number() {
+		if num < thor.CheckpointInterval {
+			return genesisID, nil
+		}
+		num -= thor.CheckpointInterval
+	}
+	for ; num >= engine.forkConfig.FINALITY; num -= thor.CheckpointInterval {
+		sum, err := bestChain.GetBlockSummary(num)
+		if err != nil {
+			return thor.Bytes32{}, err
+		}
+		state, err := engine.computeState(sum.Header)
+		if err != nil {
+			return thor.Bytes32{}, err
+		}
+		if state.Committed && state.Quality > 1 {
+			return engine.findCheckpointByQuality(state.Quality-1, genesisID, sum.Header.ID())
+		}
+		if num < thor.CheckpointInterval {
+			break
+		}
+	}
+	return genesisID, nil
+}
+
 // Finalized returns the finalized checkpoint.
 func (engine *Engine) Finalized() thor.Bytes32 {
 	return engine.finalized.Load().(thor.Bytes32)
@@ -336,6 +336,47 @@ func TestReCreate(t *testing.T) {
 	assert.Equal(t, 1, len(votes))
 }
 
+func TestRecoverBulkImport(t *testing.T) {
+	testBFT, err := newTestBft(defaultFC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := testBFT.fastForward(thor.CheckpointInterval*3 - 1); err != nil {
+		t.Fatal(err)
+	}
+	finalized := testBFT.engine.Finalized()
+	assert.Equal(t, uint32(thor.CheckpointInterval), block.Number(finalized))
+
+	// finalize a block of the batch
+	if err := testBFT.repo.BeginBulkImport(thor.CheckpointInterval*4, 1<<30); err != nil {
+		t.Fatal(err)
+	}
+	if err := testBFT.fastForward(thor.CheckpointInterval * 3); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint32(thor.CheckpointInterval*4), block.Number(testBFT.engine.Finalized()))
+
+	// reopened as crashed before the batch committed, the finalized block is discarded
+	repo, err := chain.NewRepository(testBFT.db, testBFT.repo.GenesisBlock())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint32(thor.CheckpointInterval*3-1), repo.BestBlockSummary().Header.Number())
+
+	testBFT.repo = repo
+	if err := testBFT.reCreateEngine(); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, finalized, testBFT.engine.Finalized())
+
+	// and finalized again once imported again
+	if err := testBFT.fastForward(thor.CheckpointInterval * 3); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint32(thor.CheckpointInterval*4), block.Number(testBFT.engine.Finalized()))
+}
+
 func TestFinalized(t *testing.T) {
 	testBFT, err := newTestBft(defaultFC)
 	if err != nil {
@@ -9,13 +9,14 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/v2/builtin/storagekey"
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
 )
 
 var (
-	headKey = thor.Blake2b([]byte("head"))
-	tailKey = thor.Blake2b([]byte("tail"))
+	headKey = storagekey.Named("head")
+	tailKey = storagekey.Named("tail")
 )
 
 // Authority implements native methods of `Authority` contract.
@@ -31,7 +32,7 @@ func New(addr thor.Address, state *state.State) *Authority {
 
 func (a *Authority) getEntry(nodeMaster thor.Address) (*entry, error) {
 	var entry entry
-	if err := a.state.DecodeStorage(a.addr, thor.BytesToBytes32(nodeMaster[:]), func(raw []byte) error {
+	if err := a.state.DecodeStorage(a.addr, storagekey.Direct(nodeMaster[:]), func(raw []byte) error {
 		if len(raw) == 0 {
 			return nil
 		}
@@ -43,7 +44,7 @@ func (a *Authority) getEntry(nodeMaster thor.Address) (*entry, error) {
 }
 
 func (a *Authority) setEntry(nodeMaster thor.Address, entry *entry) error {
-	return a.state.EncodeStorage(a.addr, thor.BytesToBytes32(nodeMaster[:]), func() ([]byte, error) {
+	return a.state.EncodeStorage(a.addr, storagekey.Direct(nodeMaster[:]), func() ([]byte, error) {
 		if entry.IsEmpty() {
 			return nil, nil
 		}
@@ -251,6 +252,54 @@ func (a *Authority) AllCandidates() ([]*Candidate, error) {
 	return candidates, nil
 }
 
+// Status returns the status of the node master against the given endorsement.
+// A candidate is endorsed the same way as the scheduler picks proposers.
+func (a *Authority) Status(nodeMaster thor.Address, endorsement *big.Int) (*CandidateStatus, error) {
+	listed, endorsor, _, active, err := a.Get(nodeMaster)
+	if err != nil {
+		return nil, err
+	}
+	status := &CandidateStatus{
+		NodeMaster:          nodeMaster,
+		Listed:              listed,
+		EndorsorBalance:     new(big.Int),
+		RequiredEndorsement: endorsement,
+	}
+	if !listed {
+		return status, nil
+	}
+	if status.EndorsorBalance, err = a.state.GetBalance(endorsor); err != nil {
+		return nil, err
+	}
+	status.Active = active
+	status.Endorsed = status.EndorsorBalance.Cmp(endorsement) >= 0
+	return status, nil
+}
+
+// AllStatuses returns the statuses of all registered candidates against the given endorsement.
+func (a *Authority) AllStatuses(endorsement *big.Int) ([]*CandidateStatus, error) {
+	candidates, err := a.AllCandidates()
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]*CandidateStatus, 0, len(candidates))
+	for _, c := range candidates {
+		bal, err := a.state.GetBalance(c.Endorsor)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, &CandidateStatus{
+			NodeMaster:          c.NodeMaster,
+			Listed:              true,
+			Active:              c.Active,
+			Endorsed:            bal.Cmp(endorsement) >= 0,
+			EndorsorBalance:     bal,
+			RequiredEndorsement: endorsement,
+		})
+	}
+	return statuses, nil
+}
+
 // First returns node master address of first entry.
 func (a *Authority) First() (*thor.Address, error) {
 	return a.getAddressPtr(headKey)
@@ -76,3 +76,39 @@ func TestAuthority(t *testing.T) {
 		assert.Equal(t, tt.expected, tt.ret, "#%v", i)
 	}
 }
+
+func TestStatus(t *testing.T) {
+	db := muxdb.NewMem()
+	st := state.New(db, thor.Bytes32{}, 0, 0, 0)
+
+	p1 := thor.BytesToAddress([]byte("p1"))
+	p2 := thor.BytesToAddress([]byte("p2"))
+	e1 := thor.BytesToAddress([]byte("e1"))
+	e2 := thor.BytesToAddress([]byte("e2"))
+	endorsement := big.NewInt(20)
+
+	st.SetBalance(e1, big.NewInt(10))
+	st.SetBalance(e2, big.NewInt(20))
+
+	aut := New(thor.BytesToAddress([]byte("aut")), st)
+	aut.Add(p1, e1, thor.Bytes32{})
+	aut.Add(p2, e2, thor.Bytes32{})
+	aut.Update(p2, false)
+
+	s1 := &CandidateStatus{p1, true, true, false, big.NewInt(10), endorsement}
+	s2 := &CandidateStatus{p2, true, false, true, big.NewInt(20), endorsement}
+	assert.Equal(t, M(s1, nil), M(aut.Status(p1, endorsement)))
+	assert.Equal(t, M(s2, nil), M(aut.Status(p2, endorsement)))
+	assert.Equal(t, M([]*CandidateStatus{s1, s2}, nil), M(aut.AllStatuses(endorsement)))
+
+	// endorsed once the endorsor's balance rises
+	st.SetBalance(e1, big.NewInt(30))
+	status, err := aut.Status(p1, endorsement)
+	assert.Nil(t, err)
+	assert.True(t, status.Endorsed)
+
+	// not endorsed if not listed, whatever the balance
+	aut.Revoke(p1)
+	assert.Equal(t, M(&CandidateStatus{p1, false, false, false, new(big.Int), endorsement}, nil), M(aut.Status(p1, endorsement)))
+	assert.Equal(t, M([]*CandidateStatus{s2}, nil), M(aut.AllStatuses(endorsement)))
+}
@@ -6,6 +6,8 @@
 package authority
 
 import (
+	"math/big"
+
 	"github.com/vechain/thor/v2/thor"
 )
 
@@ -25,6 +27,16 @@ type (
 		Identity   thor.Bytes32
 		Active     bool
 	}
+
+	// CandidateStatus the eligibility of a node master to propose blocks.
+	CandidateStatus struct {
+		NodeMaster          thor.Address
+		Listed              bool
+		Active              bool
+		Endorsed            bool // listed and the endorsor's balance satisfies the endorsement
+		EndorsorBalance     *big.Int
+		RequiredEndorsement *big.Int
+	}
 )
 
 // IsEmpty returns whether the entry can be treated as empty.
@@ -9,15 +9,28 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/v2/builtin/storagekey"
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
 )
 
 var (
-	initialSupplyKey = thor.Blake2b([]byte("initial-supply"))
-	totalAddSubKey   = thor.Blake2b([]byte("total-add-sub"))
+	initialSupplyKey = storagekey.Named("initial-supply")
+	totalAddSubKey   = storagekey.Named("total-add-sub")
 )
 
+// SupplyBreakdown is the total supply of energy broken down by component.
+type SupplyBreakdown struct {
+	// Initial is the energy supply at genesis.
+	Initial *big.Int
+	// Growth is the energy grown from the token supply since genesis.
+	Growth *big.Int
+	// Burned is the energy burned by gas payment, net of the rewards to block producers.
+	Burned *big.Int
+	// Total is the resulting supply, which equals Initial + Growth - Burned.
+	Total *big.Int
+}
+
 // Energy implements energy operations.
 type Energy struct {
 	addr      thor.Address
@@ -101,6 +114,30 @@ func (e *Energy) TotalBurned() (*big.Int, error) {
 	return new(big.Int).Sub(total.TotalSub, total.TotalAdd), nil
 }
 
+// SupplyBreakdown returns the components of the energy supply.
+// The sum of Initial and Growth is what TotalSupply returns.
+func (e *Energy) SupplyBreakdown() (*SupplyBreakdown, error) {
+	initialSupply, err := e.getInitialSupply()
+	if err != nil {
+		return nil, err
+	}
+	supply, err := e.TotalSupply()
+	if err != nil {
+		return nil, err
+	}
+	burned, err := e.TotalBurned()
+	if err != nil {
+		return nil, err
+	}
+
+	return &SupplyBreakdown{
+		Initial: initialSupply.Energy,
+		Growth:  new(big.Int).Sub(supply, initialSupply.Energy),
+		Burned:  burned,
+		Total:   new(big.Int).Sub(supply, burned),
+	}, nil
+}
+
 // Get returns energy of an account at given block time.
 func (e *Energy) Get(addr thor.Address) (*big.Int, error) {
 	return e.state.GetEnergy(addr, e.blockTime)
@@ -141,3 +141,34 @@ func TestEnergyGrowth(t *testing.T) {
 	assert.Equal(t, x, bal1)
 
 }
+
+func TestSupplyBreakdown(t *testing.T) {
+	db := muxdb.NewMem()
+	st := state.New(db, thor.Bytes32{}, 0, 0, 0)
+
+	acc := thor.BytesToAddress([]byte("a1"))
+	token := big.NewInt(1e18)
+
+	New(thor.BytesToAddress([]byte("eng")), st, 10).SetInitialSupply(token, big.NewInt(456))
+
+	eng := New(thor.BytesToAddress([]byte("eng")), st, 1000)
+	eng.Add(acc, big.NewInt(100))
+	eng.Sub(acc, big.NewInt(30))
+	eng.Sub(acc, big.NewInt(200))
+
+	growth := new(big.Int).Mul(thor.EnergyGrowthRate, token)
+	growth.Mul(growth, new(big.Int).SetUint64(1000-10))
+	growth.Div(growth, big.NewInt(1e18))
+
+	supply, err := eng.SupplyBreakdown()
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(456), supply.Initial)
+	assert.Equal(t, growth, supply.Growth)
+	assert.Equal(t, big.NewInt(-70), supply.Burned)
+
+	total, _ := eng.TotalSupply()
+	burned, _ := eng.TotalBurned()
+	assert.Equal(t, total, new(big.Int).Add(supply.Initial, supply.Growth))
+	assert.Equal(t, burned, supply.Burned)
+	assert.Equal(t, new(big.Int).Sub(total, burned), supply.Total)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/vechain/thor/v2/abi"
 	"github.com/vechain/thor/v2/block"
 	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/builtin/storagekey"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/genesis"
 	"github.com/vechain/thor/v2/muxdb"
@@ -731,9 +732,9 @@ func TestPrototypeNative(t *testing.T) {
 		Assert(t)
 
 	// should be hash of rlp raw
-	expected, err := st.GetStorage(builtin.Prototype.Address, thor.Blake2b(contract.Bytes(), []byte("credit-plan")))
+	expected, err := st.GetStorage(builtin.Prototype.Address, storagekey.Mapping("credit-plan", contract.Bytes()))
 	assert.Nil(t, err)
-	test.Case("storageFor", builtin.Prototype.Address, thor.Blake2b(contract.Bytes(), []byte("credit-plan"))).
+	test.Case("storageFor", builtin.Prototype.Address, storagekey.Mapping("credit-plan", contract.Bytes())).
 		ShouldOutput(expected).
 		Assert(t)
 
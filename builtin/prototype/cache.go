@@ -0,0 +1,23 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package prototype
+
+// Cache memoizes the credit plans and user objects by their encodings, to save decoding them for every
+// sponsored tx of a block. The storage values are still read each time, and a value always decodes to the
+// same object, so the cache never serves stale objects, even if the state is reverted.
+// The cached objects are shared, and must never be modified. It's not safe for concurrent use.
+type Cache struct {
+	plans map[string]*creditPlan
+	users map[string]*userObject
+}
+
+// NewCache creates a cache, which is expected to live no longer than the execution of a block.
+func NewCache() *Cache {
+	return &Cache{
+		plans: make(map[string]*creditPlan),
+		users: make(map[string]*userObject),
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/v2/builtin/storagekey"
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
 )
@@ -23,29 +24,38 @@ func New(addr thor.Address, state *state.State) *Prototype {
 }
 
 func (p *Prototype) Bind(self thor.Address) *Binding {
-	return &Binding{p.addr, p.state, self}
+	return &Binding{p.addr, p.state, self, nil}
 }
 
 type Binding struct {
 	addr  thor.Address
 	state *state.State
 	self  thor.Address
+	cache *Cache
+}
+
+// WithCache returns a copy of the binding decoding the credit plan and user objects through the cache,
+// or directly if the cache is nil.
+func (b *Binding) WithCache(cache *Cache) *Binding {
+	cpy := *b
+	cpy.cache = cache
+	return &cpy
 }
 
 func (b *Binding) userKey(user thor.Address) thor.Bytes32 {
-	return thor.Blake2b(b.self.Bytes(), user.Bytes(), []byte("user"))
+	return storagekey.Mapping("user", b.self.Bytes(), user.Bytes())
 }
 
 func (b *Binding) creditPlanKey() thor.Bytes32 {
-	return thor.Blake2b(b.self.Bytes(), []byte("credit-plan"))
+	return storagekey.Mapping("credit-plan", b.self.Bytes())
 }
 
 func (b *Binding) sponsorKey(sponsor thor.Address) thor.Bytes32 {
-	return thor.Blake2b(b.self.Bytes(), sponsor.Bytes(), []byte("sponsor"))
+	return storagekey.Mapping("sponsor", b.self.Bytes(), sponsor.Bytes())
 }
 
 func (b *Binding) curSponsorKey() thor.Bytes32 {
-	return thor.Blake2b(b.self.Bytes(), []byte("cur-sponsor"))
+	return storagekey.Mapping("cur-sponsor", b.self.Bytes())
 }
 
 func (b *Binding) getUserObject(user thor.Address) (uo *userObject, err error) {
@@ -54,7 +64,19 @@ func (b *Binding) getUserObject(user thor.Address) (uo *userObject, err error) {
 			uo = &userObject{&big.Int{}, 0}
 			return nil
 		}
-		return rlp.DecodeBytes(raw, &uo)
+		if b.cache != nil {
+			if cached, ok := b.cache.users[string(raw)]; ok {
+				uo = cached
+				return nil
+			}
+		}
+		if err := rlp.DecodeBytes(raw, &uo); err != nil {
+			return err
+		}
+		if b.cache != nil {
+			b.cache.users[string(raw)] = uo
+		}
+		return nil
 	})
 	return
 }
@@ -64,7 +86,15 @@ func (b *Binding) setUserObject(user thor.Address, uo *userObject) error {
 		if uo.IsEmpty() {
 			return nil, nil
 		}
-		return rlp.EncodeToBytes(uo)
+		raw, err := rlp.EncodeToBytes(uo)
+		if err != nil {
+			return nil, err
+		}
+		if b.cache != nil {
+			// the user object is usually read back by the next tx of the user
+			b.cache.users[string(raw)] = uo
+		}
+		return raw, nil
 	})
 }
 
@@ -74,7 +104,19 @@ func (b *Binding) getCreditPlan() (cp *creditPlan, err error) {
 			cp = &creditPlan{&big.Int{}, &big.Int{}}
 			return nil
 		}
-		return rlp.DecodeBytes(raw, &cp)
+		if b.cache != nil {
+			if cached, ok := b.cache.plans[string(raw)]; ok {
+				cp = cached
+				return nil
+			}
+		}
+		if err := rlp.DecodeBytes(raw, &cp); err != nil {
+			return err
+		}
+		if b.cache != nil {
+			b.cache.plans[string(raw)] = cp
+		}
+		return nil
 	})
 	return
 }
@@ -71,3 +71,36 @@ func TestPrototype(t *testing.T) {
 		assert.Equal(t, tt.expected, tt.fn(), tt.msg)
 	}
 }
+
+func TestPrototypeCache(t *testing.T) {
+	db := muxdb.NewMem()
+	st := state.New(db, thor.Bytes32{}, 0, 0, 0)
+
+	proto := prototype.New(thor.BytesToAddress([]byte("proto")), st)
+	binding := proto.Bind(thor.BytesToAddress([]byte("binding"))).WithCache(prototype.NewCache())
+
+	user := thor.BytesToAddress([]byte("user"))
+	planCredit := big.NewInt(100000)
+	planRecRate := big.NewInt(2222)
+
+	assert.Nil(t, binding.SetCreditPlan(planCredit, planRecRate))
+	assert.Nil(t, binding.AddUser(user, 1))
+	assert.Equal(t, M(planCredit, nil), M(binding.UserCredit(user, 1)))
+
+	// the cache follows the state, including the reverted changes
+	checkpoint := st.NewCheckpoint()
+	assert.Nil(t, binding.SetUserCredit(user, &big.Int{}, 1))
+	assert.Equal(t, M(planRecRate, nil), M(binding.UserCredit(user, 2)))
+	assert.Nil(t, binding.SetCreditPlan(big.NewInt(1), big.NewInt(1)))
+	assert.Equal(t, M(big.NewInt(1), big.NewInt(1), nil), M(binding.CreditPlan()))
+
+	st.RevertTo(checkpoint)
+	assert.Equal(t, M(planCredit, planRecRate, nil), M(binding.CreditPlan()))
+	assert.Equal(t, M(planCredit, nil), M(binding.UserCredit(user, 2)))
+
+	// the bindings with and without the cache agree
+	assert.Nil(t, binding.SetUserCredit(user, big.NewInt(5000), 3))
+	uncached := proto.Bind(thor.BytesToAddress([]byte("binding")))
+	assert.Equal(t, M(uncached.UserCredit(user, 10)), M(binding.UserCredit(user, 10)))
+	assert.Equal(t, M(uncached.CreditPlan()), M(binding.CreditPlan()))
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package storagekey computes the storage keys used by the native parts of builtin contracts.
+//
+// Unlike solidity contracts, builtins don't follow the solidity storage layout. Each value is
+// stored RLP encoded in a single slot, and the slot key is derived by one of the patterns below:
+//
+//   - Named: a single value identified by a name, the key is Blake2b(name).
+//     e.g. the "head" and "tail" of the authority node list, and the "initial-supply" of energy.
+//   - Mapping: an entry of a mapping identified by a name, the key is Blake2b(k0 + k1 + ... + name),
+//     where k0, k1... are the mapping keys concatenated in order.
+//     e.g. the "credit-plan" of a contract, which is Blake2b(contract + "credit-plan"),
+//     and the "user" of a contract, which is Blake2b(contract + user + "user").
+//   - Direct: the key itself, left padded with zeros to 32 bytes.
+//     e.g. the authority entry of a node master, and the values of params, which use the param key.
+//
+// Tools inspecting the storage of builtins should use these helpers instead of deriving keys by hand.
+package storagekey
+
+import "github.com/vechain/thor/v2/thor"
+
+// Named returns the key of the single value identified by name.
+func Named(name string) thor.Bytes32 {
+	return thor.Blake2b([]byte(name))
+}
+
+// Mapping returns the key of the entry for the given mapping keys, in the mapping identified by name.
+// Mapping keys are concatenated as is, so they should be of fixed length, e.g. addresses.
+func Mapping(name string, keys ...[]byte) thor.Bytes32 {
+	data := make([][]byte, 0, len(keys)+1)
+	data = append(data, keys...)
+	data = append(data, []byte(name))
+	return thor.Blake2b(data...)
+}
+
+// Direct returns the key used as is. If b is longer than 32 bytes, it is cropped from the left.
+func Direct(b []byte) thor.Bytes32 {
+	return thor.BytesToBytes32(b)
+}
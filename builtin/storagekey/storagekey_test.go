@@ -0,0 +1,63 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package storagekey_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/builtin/authority"
+	"github.com/vechain/thor/v2/builtin/energy"
+	"github.com/vechain/thor/v2/builtin/prototype"
+	"github.com/vechain/thor/v2/builtin/storagekey"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+)
+
+func TestKeys(t *testing.T) {
+	a := thor.BytesToAddress([]byte("a"))
+	b := thor.BytesToAddress([]byte("b"))
+
+	assert.Equal(t, thor.Blake2b([]byte("head")), storagekey.Named("head"))
+	assert.Equal(t, thor.Blake2b(a.Bytes(), []byte("credit-plan")), storagekey.Mapping("credit-plan", a.Bytes()))
+	assert.Equal(t, thor.Blake2b(a.Bytes(), b.Bytes(), []byte("user")), storagekey.Mapping("user", a.Bytes(), b.Bytes()))
+	assert.Equal(t, storagekey.Named("user"), storagekey.Mapping("user"))
+	assert.Equal(t, thor.BytesToBytes32(a.Bytes()), storagekey.Direct(a.Bytes()))
+}
+
+func TestBuiltinLayouts(t *testing.T) {
+	st := state.New(muxdb.NewMem(), thor.Bytes32{}, 0, 0, 0)
+	nonEmpty := func(addr thor.Address, key thor.Bytes32) {
+		raw, err := st.GetRawStorage(addr, key)
+		require.NoError(t, err)
+		assert.NotEmpty(t, raw)
+	}
+
+	energyAddr := thor.BytesToAddress([]byte("energy"))
+	require.NoError(t, energy.New(energyAddr, st, 0).SetInitialSupply(big.NewInt(1), big.NewInt(1)))
+	nonEmpty(energyAddr, storagekey.Named("initial-supply"))
+
+	authorityAddr := thor.BytesToAddress([]byte("authority"))
+	master := thor.BytesToAddress([]byte("master"))
+	ok, err := authority.New(authorityAddr, st).Add(master, thor.BytesToAddress([]byte("endorsor")), thor.Bytes32{1})
+	require.NoError(t, err)
+	require.True(t, ok)
+	nonEmpty(authorityAddr, storagekey.Named("head"))
+	nonEmpty(authorityAddr, storagekey.Named("tail"))
+	nonEmpty(authorityAddr, storagekey.Direct(master.Bytes()))
+
+	protoAddr := thor.BytesToAddress([]byte("proto"))
+	contract := thor.BytesToAddress([]byte("contract"))
+	user := thor.BytesToAddress([]byte("user"))
+	binding := prototype.New(protoAddr, st).Bind(contract)
+	require.NoError(t, binding.SetCreditPlan(big.NewInt(100), big.NewInt(1)))
+	require.NoError(t, binding.AddUser(user, 1))
+	nonEmpty(protoAddr, storagekey.Mapping("credit-plan", contract.Bytes()))
+	nonEmpty(protoAddr, storagekey.Mapping("user", contract.Bytes(), user.Bytes()))
+}
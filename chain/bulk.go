@@ -0,0 +1,191 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package chain
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/kv"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// the key of the number of the last durable block of bulk import.
+// it exists only when in bulk import mode, and blocks above the number
+// are discarded on reopen.
+var bulkImportKey = []byte("bulk-import")
+
+// bulkImport is the pending batch of blocks imported in bulk.
+type bulkImport struct {
+	maxBlocks int
+	maxSize   uint64
+
+	blocks   int
+	size     uint64
+	bestID   *thor.Bytes32 // the best block id to be persisted on commit
+	steadyID *thor.Bytes32 // the steady block id to be persisted on commit
+}
+
+func (b *bulkImport) full() bool {
+	return b.blocks >= b.maxBlocks || b.size >= b.maxSize
+}
+
+// BeginBulkImport switches the repository into bulk import mode, to import blocks in bulk, e.g. during sync.
+//
+// Instead of being made durable one by one, the added blocks are committed in batches of up to maxBlocks
+// blocks or maxSize bytes, and the best and steady block ids are persisted only when a batch is committed.
+// If it crashes before a batch is committed, the blocks of the batch are discarded on reopen.
+func (r *Repository) BeginBulkImport(maxBlocks int, maxSize uint64) error {
+	r.bulkLock.Lock()
+	defer r.bulkLock.Unlock()
+
+	if r.bulk != nil {
+		return errors.New("already in bulk import mode")
+	}
+	var num [4]byte
+	binary.BigEndian.PutUint32(num[:], r.BestBlockSummary().Header.Number())
+	if err := r.props.Put(bulkImportKey, num[:]); err != nil {
+		return err
+	}
+	// no block can be saved without its state durable, until the marker is durable
+	if err := r.db.CommitBarrier(); err != nil {
+		return err
+	}
+	r.bulk = &bulkImport{maxBlocks: maxBlocks, maxSize: maxSize}
+	return nil
+}
+
+// EndBulkImport commits the pending batch and leaves the bulk import mode.
+func (r *Repository) EndBulkImport() error {
+	r.bulkLock.Lock()
+	defer r.bulkLock.Unlock()
+
+	if r.bulk == nil {
+		return nil
+	}
+	if err := r.commitBulk(); err != nil {
+		return err
+	}
+	if err := r.props.Delete(bulkImportKey); err != nil {
+		return err
+	}
+	if err := r.db.CommitBarrier(); err != nil {
+		return err
+	}
+	r.bulk = nil
+	return nil
+}
+
+// commitBulk makes the blocks of the pending batch durable, and persists the best and steady block ids.
+func (r *Repository) commitBulk() error {
+	if err := r.db.CommitBarrier(); err != nil {
+		return err
+	}
+
+	bulk := r.props.Bulk()
+	if id := r.bulk.bestID; id != nil {
+		if err := bulk.Put(bestBlockIDKey, id[:]); err != nil {
+			return err
+		}
+	}
+	if id := r.bulk.steadyID; id != nil {
+		if err := bulk.Put(steadyBlockIDKey, id[:]); err != nil {
+			return err
+		}
+	}
+	var num [4]byte
+	binary.BigEndian.PutUint32(num[:], r.BestBlockSummary().Header.Number())
+	if err := bulk.Put(bulkImportKey, num[:]); err != nil {
+		return err
+	}
+	// the ids and the marker are written atomically, and refer to durable blocks only
+	if err := bulk.Write(); err != nil {
+		return err
+	}
+
+	r.bulk.blocks = 0
+	r.bulk.size = 0
+	r.bulk.bestID = nil
+	r.bulk.steadyID = nil
+	return nil
+}
+
+// recoverBulkImport discards the blocks of the batch not committed before the last crash, if any.
+func (r *Repository) recoverBulkImport() error {
+	val, err := r.props.Get(bulkImportKey)
+	if err != nil {
+		if r.props.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	num := binary.BigEndian.Uint32(val)
+	if err := r.discardBlocksAbove(num); err != nil {
+		return errors.Wrap(err, "discard uncommitted blocks")
+	}
+	if err := r.db.CommitBarrier(); err != nil {
+		return err
+	}
+	return r.props.Delete(bulkImportKey)
+}
+
+// discardBlocksAbove removes blocks with number above num, and their tx index entries.
+func (r *Repository) discardBlocksAbove(num uint32) error {
+	var start [4]byte
+	binary.BigEndian.PutUint32(start[:], num+1)
+	rng := kv.Range{Start: start[:]}
+
+	var (
+		indexBulk = r.txIndexer.Bulk()
+		headBulk  = r.head.Bulk()
+		key       = make([]byte, 64)
+	)
+	iter := r.data.Iterate(rng)
+	for iter.Next() {
+		if len(iter.Key()) != 32 { // skip txs and receipts
+			continue
+		}
+		var summary BlockSummary
+		if err := rlp.DecodeBytes(iter.Value(), &summary); err != nil {
+			iter.Release()
+			return err
+		}
+		id := summary.Header.ID()
+		copy(key[32:], id[:])
+		for _, txid := range summary.Txs {
+			copy(key, txid[:])
+			if err := indexBulk.Delete(key); err != nil {
+				iter.Release()
+				return err
+			}
+		}
+		// the parent was the head until the block saved
+		if parentID := summary.Header.ParentID(); block.Number(parentID) <= num {
+			if err := headBulk.Put(parentID[:], nil); err != nil {
+				iter.Release()
+				return err
+			}
+		}
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	if err := indexBulk.Write(); err != nil {
+		return err
+	}
+	if err := r.head.DeleteRange(context.Background(), rng); err != nil {
+		return err
+	}
+	if err := headBulk.Write(); err != nil {
+		return err
+	}
+	return r.data.DeleteRange(context.Background(), rng)
+}
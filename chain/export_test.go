@@ -0,0 +1,27 @@
+// Copyright (c) 2024 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package chain
+
+import (
+	"time"
+
+	"github.com/vechain/thor/v2/kv"
+)
+
+type slowStore struct {
+	kv.Store
+	latency time.Duration
+}
+
+func (s *slowStore) Get(key []byte) ([]byte, error) {
+	time.Sleep(s.latency)
+	return s.Store.Get(key)
+}
+
+// SetReadLatency makes every read of the block data take extra time, as if it hits the disk.
+func SetReadLatency(r *Repository, latency time.Duration) {
+	r.data = &slowStore{r.data, latency}
+}
@@ -9,4 +9,6 @@ import "github.com/vechain/thor/v2/metrics"
 
 var (
 	metricCacheHitMiss = metrics.LazyLoadGaugeVec("repo_cache_hit_miss_count", []string{"type", "event"})
+	metricReorgDepth   = metrics.LazyLoadHistogram(
+		"consensus_reorg_depth", []int64{1, 2, 3, 5, 8, 13, 21, 34, 55, 89}, metrics.WithNamespace("thor"))
 )
@@ -7,6 +7,7 @@ package chain
 
 import (
 	"encoding/binary"
+	"sync"
 	"sync/atomic"
 
 	"github.com/pkg/errors"
@@ -49,6 +50,9 @@ type Repository struct {
 	tag         byte
 	tick        co.Signal
 
+	bulkLock sync.Mutex
+	bulk     *bulkImport // nil if not in bulk import mode
+
 	caches struct {
 		summaries *cache
 		txs       *cache
@@ -86,17 +90,30 @@ func NewRepository(db *muxdb.MuxDB, genesis *block.Block) (*Repository, error) {
 	repo.caches.txs = newCache(2048)
 	repo.caches.receipts = newCache(2048)
 
+	if err := repo.recoverBulkImport(); err != nil {
+		return nil, err
+	}
+
 	if val, err := repo.props.Get(bestBlockIDKey); err != nil {
 		if !repo.props.IsNotFound(err) {
 			return nil, err
 		}
 
+		// genesis state should be durable before the genesis block saved
+		if err := db.CommitBarrier(); err != nil {
+			return nil, err
+		}
 		if err := repo.indexBlock(0, genesis.Header().ID(), 0); err != nil {
 			return nil, err
 		}
-		if summary, err := repo.saveBlock(genesis, nil, 0, 0); err != nil {
+		summary, err := repo.saveBlock(genesis, nil, 0, 0)
+		if err != nil {
 			return nil, err
-		} else if err := repo.setBestBlockSummary(summary); err != nil {
+		}
+		if err := db.CommitBarrier(); err != nil {
+			return nil, err
+		}
+		if err := repo.setBestBlockSummary(summary); err != nil {
 			return nil, err
 		}
 	} else {
@@ -153,11 +170,37 @@ func (r *Repository) SetBestBlockID(id thor.Bytes32) (err error) {
 	if err != nil {
 		return err
 	}
-	return r.setBestBlockSummary(summary)
+	prevID := r.BestBlockSummary().Header.ID()
+	if err := r.setBestBlockSummary(summary); err != nil {
+		return err
+	}
+	if summary.Header.ParentID() != prevID && id != prevID {
+		r.observeReorg(prevID, id)
+	}
+	return nil
+}
+
+// observeReorg measures how many blocks of the previous canonical chain were
+// abandoned by switching the head to newBestID.
+func (r *Repository) observeReorg(prevBestID, newBestID thor.Bytes32) {
+	abandoned, err := r.NewChain(prevBestID).Exclude(r.NewChain(newBestID))
+	if err != nil {
+		return
+	}
+	if len(abandoned) > 0 {
+		metricReorgDepth().Observe(int64(len(abandoned)))
+	}
 }
 
 func (r *Repository) setBestBlockSummary(summary *BlockSummary) error {
-	if err := r.props.Put(bestBlockIDKey, summary.Header.ID().Bytes()); err != nil {
+	r.bulkLock.Lock()
+	defer r.bulkLock.Unlock()
+
+	if r.bulk != nil {
+		// persisted when the batch committed
+		id := summary.Header.ID()
+		r.bulk.bestID = &id
+	} else if err := r.props.Put(bestBlockIDKey, summary.Header.ID().Bytes()); err != nil {
 		return err
 	}
 	r.bestSummary.Store(summary)
@@ -179,7 +222,14 @@ func (r *Repository) SetSteadyBlockID(id thor.Bytes32) error {
 		// the previous steady id is not on the chain of the new id.
 		return errors.New("invalid new steady block id")
 	}
-	if err := r.props.Put(steadyBlockIDKey, id[:]); err != nil {
+
+	r.bulkLock.Lock()
+	defer r.bulkLock.Unlock()
+
+	if r.bulk != nil {
+		// persisted when the batch committed
+		r.bulk.steadyID = &id
+	} else if err := r.props.Put(steadyBlockIDKey, id[:]); err != nil {
 		return err
 	}
 	r.steadyID.Store(id)
@@ -250,7 +300,14 @@ func (r *Repository) saveBlock(block *block.Block, receipts tx.Receipts, conflic
 }
 
 // AddBlock add a new block with its receipts into repository.
+//
+// The state of the block is expected to be committed before, and the block becomes
+// durable along with it once it returns, so that it can be referred by other stores,
+// e.g. the log db. In bulk import mode, it becomes durable when its batch committed.
 func (r *Repository) AddBlock(newBlock *block.Block, receipts tx.Receipts, conflicts uint32) error {
+	r.bulkLock.Lock()
+	defer r.bulkLock.Unlock()
+
 	parentSummary, err := r.GetBlockSummary(newBlock.Header().ParentID())
 	if err != nil {
 		if r.IsNotFound(err) {
@@ -258,6 +315,11 @@ func (r *Repository) AddBlock(newBlock *block.Block, receipts tx.Receipts, confl
 		}
 		return err
 	}
+	if r.bulk != nil && r.bulk.full() {
+		if err := r.commitBulk(); err != nil {
+			return err
+		}
+	}
 	if err := r.indexBlock(parentSummary.Conflicts, newBlock.Header().ID(), conflicts); err != nil {
 		return err
 	}
@@ -275,7 +337,13 @@ func (r *Repository) AddBlock(newBlock *block.Block, receipts tx.Receipts, confl
 	if _, err := r.saveBlock(newBlock, receipts, conflicts, steadyNum); err != nil {
 		return err
 	}
-	return nil
+	if r.bulk != nil {
+		r.bulk.blocks++
+		r.bulk.size += uint64(newBlock.Size())
+		return nil
+	}
+	// writes survive a crash in their order, so the block never survives without its state
+	return r.db.CommitBarrier()
 }
 
 // ScanConflicts returns the count of saved blocks with the given blockNum.
@@ -404,6 +472,16 @@ func (r *Repository) GetBlock(id thor.Bytes32) (*block.Block, error) {
 	return block.Compose(summary.Header, txs), nil
 }
 
+// GetBlockByTransactionID returns the block of the best chain which includes the tx.
+// The tx index is looked up, so it takes no chain scan.
+func (r *Repository) GetBlockByTransactionID(txID thor.Bytes32) (*block.Block, error) {
+	meta, err := r.NewBestChain().GetTransactionMeta(txID)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetBlock(meta.BlockID)
+}
+
 func (r *Repository) getReceipt(key txKey) (*tx.Receipt, error) {
 	receipt, cached, err := r.caches.receipts.GetOrLoad(key, func() (interface{}, error) {
 		return loadReceipt(r.data, key)
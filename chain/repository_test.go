@@ -9,10 +9,13 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/vechain/thor/v2/block"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/metrics"
 	"github.com/vechain/thor/v2/muxdb"
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
@@ -99,6 +102,78 @@ func TestRepository(t *testing.T) {
 	}
 }
 
+func TestGetBlockByTransactionID(t *testing.T) {
+	_, repo := newTestRepo()
+	b0 := repo.GenesisBlock()
+
+	tx1 := newTx()
+	b1 := newBlock(b0, 10, tx1)
+	require.NoError(t, repo.AddBlock(b1, tx.Receipts{&tx.Receipt{}}, 0))
+	// the tx is also included by a fork block, which is not the best
+	b1x := newBlock(b0, 20, tx1)
+	require.NoError(t, repo.AddBlock(b1x, tx.Receipts{&tx.Receipt{}}, 1))
+
+	// not on the best chain yet
+	_, err := repo.GetBlockByTransactionID(tx1.ID())
+	assert.True(t, repo.IsNotFound(err))
+
+	require.NoError(t, repo.SetBestBlockID(b1.Header().ID()))
+	blk, err := repo.GetBlockByTransactionID(tx1.ID())
+	require.NoError(t, err)
+	assert.Equal(t, b1.Header().ID(), blk.Header().ID())
+	assert.Equal(t, tx1.ID(), blk.Transactions()[0].ID())
+
+	_, err = repo.GetBlockByTransactionID(thor.Bytes32{})
+	assert.True(t, repo.IsNotFound(err))
+}
+
+func TestBulkImport(t *testing.T) {
+	db, repo := newTestRepo()
+	b0 := repo.GenesisBlock()
+
+	require.NoError(t, repo.BeginBulkImport(2, 1<<20))
+	assert.Error(t, repo.BeginBulkImport(2, 1<<20))
+
+	var (
+		blocks = []*block.Block{b0}
+		txs    []*tx.Transaction
+	)
+	for i := 1; i <= 5; i++ {
+		trx := newTx()
+		blk := newBlock(blocks[i-1], uint64(i*10), trx)
+		require.NoError(t, repo.AddBlock(blk, tx.Receipts{&tx.Receipt{}}, 0))
+		require.NoError(t, repo.SetBestBlockID(blk.Header().ID()))
+		blocks = append(blocks, blk)
+		txs = append(txs, trx)
+	}
+	assert.Equal(t, blocks[5].Header().ID(), repo.BestBlockSummary().Header.ID())
+
+	// reopened as crashed, the batch of b5 is not committed yet
+	reopened := reopenRepo(db, b0)
+	assert.Equal(t, blocks[4].Header().ID(), reopened.BestBlockSummary().Header.ID())
+	assert.Equal(t, M(uint32(4), nil), M(reopened.GetMaxBlockNum()))
+	assert.Equal(t, M([]thor.Bytes32{blocks[4].Header().ID()}, nil), M(reopened.ScanHeads(0)))
+	_, err := reopened.GetBlockSummary(blocks[5].Header().ID())
+	assert.True(t, reopened.IsNotFound(err))
+	_, err = reopened.NewChain(blocks[5].Header().ID()).GetTransactionMeta(txs[4].ID())
+	assert.Error(t, err)
+	_, err = reopened.GetBlockByTransactionID(txs[3].ID())
+	assert.NoError(t, err)
+
+	// all committed when ended
+	db, repo = newTestRepo()
+	require.NoError(t, repo.BeginBulkImport(2, 1<<20))
+	for _, blk := range blocks[1:] {
+		require.NoError(t, repo.AddBlock(blk, tx.Receipts{&tx.Receipt{}}, 0))
+		require.NoError(t, repo.SetBestBlockID(blk.Header().ID()))
+	}
+	require.NoError(t, repo.EndBulkImport())
+
+	reopened = reopenRepo(db, b0)
+	assert.Equal(t, blocks[5].Header().ID(), reopened.BestBlockSummary().Header.ID())
+	assert.Equal(t, M(uint32(5), nil), M(reopened.GetMaxBlockNum()))
+}
+
 func TestConflicts(t *testing.T) {
 	_, repo := newTestRepo()
 	b0 := repo.GenesisBlock()
@@ -212,3 +287,47 @@ func TestScanHeads(t *testing.T) {
 		assert.Equal(t, []thor.Bytes32{b3x.Header().ID(), b3.Header().ID(), b2x.Header().ID()}, heads)
 	}
 }
+
+func TestReorgDepthMetric(t *testing.T) {
+	metrics.InitializePrometheusMetrics()
+
+	reorgs := func() (uint64, float64) {
+		families, err := prometheus.DefaultGatherer.Gather()
+		require.NoError(t, err)
+		for _, mf := range families {
+			if mf.GetName() == "thor_consensus_reorg_depth" {
+				h := mf.GetMetric()[0].GetHistogram()
+				return h.GetSampleCount(), h.GetSampleSum()
+			}
+		}
+		return 0, 0
+	}
+
+	_, repo := newTestRepo()
+	b0 := repo.GenesisBlock()
+
+	b1 := newBlock(b0, 10)
+	b2 := newBlock(b1, 20)
+	assert.Nil(t, repo.AddBlock(b1, nil, 0))
+	assert.Nil(t, repo.AddBlock(b2, nil, 0))
+	assert.Nil(t, repo.SetBestBlockID(b1.Header().ID()))
+	assert.Nil(t, repo.SetBestBlockID(b2.Header().ID()))
+
+	count, sum := reorgs()
+
+	// extending the canonical chain is not a reorg
+	b3 := newBlock(b2, 30)
+	assert.Nil(t, repo.AddBlock(b3, nil, 0))
+	assert.Nil(t, repo.SetBestBlockID(b3.Header().ID()))
+	assert.Equal(t, []interface{}{count, sum}, M(reorgs()))
+
+	// switch to a side chain forked after b1, abandoning b2 and b3
+	b2x := newBlock(b1, 21)
+	b3x := newBlock(b2x, 31)
+	b4x := newBlock(b3x, 41)
+	assert.Nil(t, repo.AddBlock(b2x, nil, 1))
+	assert.Nil(t, repo.AddBlock(b3x, nil, 1))
+	assert.Nil(t, repo.AddBlock(b4x, nil, 0))
+	assert.Nil(t, repo.SetBestBlockID(b4x.Header().ID()))
+	assert.Equal(t, []interface{}{count + 1, sum + 2}, M(reorgs()))
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2024 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package chain
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/block"
+	"golang.org/x/sync/errgroup"
+)
+
+// walkWindowPerWorker is the count of blocks each worker may fetch ahead of the walk.
+const walkWindowPerWorker = 64
+
+// walkResult is a fetched block, or the error met while fetching it.
+type walkResult struct {
+	b   *block.Block
+	err error
+}
+
+// WalkBlocksParallel calls fn for each block of the canonical chain in range [fromBlock, toBlock],
+// in ascending order. Blocks are fetched concurrently by workers, each taking the next block to fetch,
+// up to a window of blocks ahead of the one being walked.
+//
+// It stops at the first error returned by fn or met while fetching blocks.
+func (r *Repository) WalkBlocksParallel(fromBlock, toBlock uint32, workers int, fn func(*block.Block) error) error {
+	if fromBlock > toBlock {
+		return errors.New("invalid range")
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	total := uint64(toBlock) - uint64(fromBlock) + 1
+	if uint64(workers) > total {
+		workers = int(total)
+	}
+
+	// pin the chain, so that all workers see the same blocks
+	headID := r.BestBlockSummary().Header.ID()
+
+	var (
+		window = uint64(workers * walkWindowPerWorker)
+		// a token is held by each block fetched or being fetched but not yet walked,
+		// so the block n is fetched after the block n-window is walked, and the slots are reused
+		tokens = make(chan struct{}, window)
+		slots  = make([]chan walkResult, window)
+		next   atomic.Uint64 // offset of the next block to fetch
+	)
+	for i := range slots {
+		slots[i] = make(chan walkResult, 1)
+	}
+
+	g, ctx := errgroup.WithContext(context.Background())
+	for range workers {
+		g.Go(func() error {
+			// Chain is not thread-safe, every worker has its own one
+			chain := r.NewChain(headID)
+			for {
+				select {
+				case tokens <- struct{}{}:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+				i := next.Add(1) - 1
+				if i >= total {
+					return nil
+				}
+				b, err := chain.GetBlock(fromBlock + uint32(i))
+				slots[i%window] <- walkResult{b, err}
+				if err != nil {
+					return err
+				}
+			}
+		})
+	}
+
+	g.Go(func() error {
+		for i := uint64(0); i < total; i++ {
+			var res walkResult
+			select {
+			case res = <-slots[i%window]:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			if res.err != nil {
+				return res.err
+			}
+			if err := fn(res.b); err != nil {
+				return err
+			}
+			<-tokens
+		}
+		return nil
+	})
+
+	return g.Wait()
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2024 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package chain_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/muxdb"
+)
+
+func newWalkTestRepo(tb testing.TB, n int) *chain.Repository {
+	_, repo := newWalkTestDB(tb, n)
+	return repo
+}
+
+func newWalkTestDB(tb testing.TB, n int) (*muxdb.MuxDB, *chain.Repository) {
+	db, repo := newTestRepo()
+
+	parent := repo.GenesisBlock()
+	for i := 1; i <= n; i++ {
+		b := newBlock(parent, uint64(i)*10)
+		require.NoError(tb, repo.AddBlock(b, nil, 0))
+		parent = b
+	}
+	require.NoError(tb, repo.SetBestBlockID(parent.Header().ID()))
+	return db, repo
+}
+
+func TestWalkBlocksParallel(t *testing.T) {
+	repo := newWalkTestRepo(t, 100)
+
+	tests := []struct {
+		from, to uint32
+		workers  int
+	}{
+		{0, 100, 1},
+		{0, 100, 8},
+		{1, 99, 3},
+		{50, 50, 4},
+		{90, 100, 32},
+		{0, 100, 0},
+	}
+
+	for _, tt := range tests {
+		var nums []uint32
+		err := repo.WalkBlocksParallel(tt.from, tt.to, tt.workers, func(b *block.Block) error {
+			nums = append(nums, b.Header().Number())
+			return nil
+		})
+		require.NoError(t, err)
+
+		var expected []uint32
+		for n := tt.from; n <= tt.to; n++ {
+			expected = append(expected, n)
+		}
+		assert.Equal(t, expected, nums, "from %v to %v with %v workers", tt.from, tt.to, tt.workers)
+	}
+
+	assert.Error(t, repo.WalkBlocksParallel(10, 9, 1, func(*block.Block) error { return nil }))
+
+	// beyond the best block
+	assert.Error(t, repo.WalkBlocksParallel(0, 101, 8, func(*block.Block) error { return nil }))
+}
+
+func TestWalkBlocksParallelError(t *testing.T) {
+	repo := newWalkTestRepo(t, 100)
+
+	errStop := errors.New("stop")
+	var nums []uint32
+	err := repo.WalkBlocksParallel(0, 100, 8, func(b *block.Block) error {
+		if b.Header().Number() == 42 {
+			return errStop
+		}
+		nums = append(nums, b.Header().Number())
+		return nil
+	})
+	assert.Equal(t, errStop, err)
+	assert.Len(t, nums, 42)
+	for i, n := range nums {
+		assert.Equal(t, uint32(i), n)
+	}
+}
+
+func TestWalkBlocksParallelFetchError(t *testing.T) {
+	repo := newWalkTestRepo(t, 100)
+
+	// fetching block 101 fails, the blocks delivered before are in order without gaps
+	for range 20 {
+		var nums []uint32
+		err := repo.WalkBlocksParallel(0, 200, 4, func(b *block.Block) error {
+			nums = append(nums, b.Header().Number())
+			return nil
+		})
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, context.Canceled)
+		assert.LessOrEqual(t, len(nums), 101)
+		for i, n := range nums {
+			assert.Equal(t, uint32(i), n)
+		}
+	}
+}
+
+// BenchmarkWalkBlocks walks blocks not cached, which are read from a store as slow as a disk.
+func BenchmarkWalkBlocks(b *testing.B) {
+	const n = 1000
+	db, repo := newWalkTestDB(b, n)
+
+	coldRepo := func() *chain.Repository {
+		repo, err := chain.NewRepository(db, repo.GenesisBlock())
+		require.NoError(b, err)
+		chain.SetReadLatency(repo, 100*time.Microsecond)
+		return repo
+	}
+
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			chain := coldRepo().NewBestChain()
+			for num := uint32(0); num <= n; num++ {
+				if _, err := chain.GetBlock(num); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	for _, workers := range []int{1, 8} {
+		b.Run(fmt.Sprintf("parallel-%v", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if err := coldRepo().WalkBlocksParallel(0, n, workers, func(*block.Block) error { return nil }); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
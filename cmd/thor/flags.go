@@ -6,7 +6,9 @@
 package main
 
 import (
+	"github.com/vechain/thor/v2/cmd/thor/node"
 	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/tx"
 	cli "gopkg.in/urfave/cli.v1"
 )
 
@@ -73,6 +75,71 @@ var (
 		Name:  "api-enable-deprecated",
 		Usage: "enable deprecated API endpoints (POST /accounts/{address}, POST /accounts, WS /subscriptions/beat",
 	}
+	apiTxPoolFlag = cli.BoolFlag{
+		Name:  "api-txpool",
+		Usage: "enable GET /node/txpool to list the txs in the pool",
+	}
+	apiChecksumAddressesFlag = cli.BoolFlag{
+		Name:  "api-checksum-addresses",
+		Usage: "render addresses in API responses in EIP-55 checksum form, and reject mixed-case addresses with invalid checksums in requests",
+	}
+	apiSubscriptionsPingIntervalFlag = cli.Uint64Flag{
+		Name:  "api-subscriptions-ping-interval",
+		Value: 15,
+		Usage: "interval in seconds of pings sent to subscribers, a subscriber missing 3 consecutive pongs is disconnected",
+	}
+	apiSubscriptionsMaxConnsFlag = cli.IntFlag{
+		Name:  "api-subscriptions-max-conns",
+		Value: 1000,
+		Usage: "limit the number of concurrent subscription connections, 0 for unlimited",
+	}
+	apiTraceJobsWorkersFlag = cli.IntFlag{
+		Name:  "api-trace-jobs-workers",
+		Value: 2,
+		Usage: "number of asynchronous trace jobs run concurrently",
+	}
+	apiTraceJobsQueueSizeFlag = cli.IntFlag{
+		Name:  "api-trace-jobs-queue-size",
+		Value: 16,
+		Usage: "max number of asynchronous trace jobs waiting to run, new jobs are rejected once reached",
+	}
+	apiTraceJobsTTLFlag = cli.Uint64Flag{
+		Name:  "api-trace-jobs-ttl",
+		Value: 600,
+		Usage: "retention in seconds of the results of asynchronous trace jobs",
+	}
+	apiTraceJobsMaxResultMBFlag = cli.IntFlag{
+		Name:  "api-trace-jobs-max-result-mb",
+		Value: 64,
+		Usage: "max total size in megabytes of the retained results of asynchronous trace jobs",
+	}
+	apiTraceJobsTimeoutFlag = cli.Uint64Flag{
+		Name:  "api-trace-jobs-timeout",
+		Value: 300,
+		Usage: "execution timeout in seconds of an asynchronous trace job",
+	}
+	apiTracerMetricsFlag = cli.BoolFlag{
+		Name:  "api-tracer-metrics",
+		Usage: "export metrics about the execution cost of each tracer, requires --enable-metrics",
+	}
+	apiDisableCompressionFlag = cli.BoolFlag{
+		Name:  "api-disable-compression",
+		Usage: "disable compression of API responses",
+	}
+	apiResponseCompressionFlag = cli.StringFlag{
+		Name:  "api-response-compression",
+		Value: "gzip",
+		Usage: "comma separated compressions allowed for API responses over 1KB, negotiated with the client (gzip|brotli|zstd), or none",
+	}
+	apiDefaultFinalityFlag = cli.StringFlag{
+		Name:  "api-default-finality",
+		Value: "best",
+		Usage: "block the requests without an explicit revision are answered as of (finalized|best)",
+	}
+	devSignerFlag = cli.BoolFlag{
+		Name:  "dev-signer",
+		Usage: "serve /dev API to sign txs with the dev accounts, devnet only",
+	}
 	enableAPILogsFlag = cli.BoolFlag{
 		Name:  "enable-api-logs",
 		Usage: "enables API requests logging",
@@ -99,7 +166,7 @@ var (
 	natFlag = cli.StringFlag{
 		Name:  "nat",
 		Value: "any",
-		Usage: "port mapping mechanism (any|none|upnp|pmp|extip:<IP>)",
+		Usage: "port mapping mechanism (any|none|upnp|pmp|extip:<IP>[:<port>])",
 	}
 	bootNodeFlag = cli.StringFlag{
 		Name:  "bootnode",
@@ -123,6 +190,11 @@ var (
 		Value: 0,
 		Usage: "target block gas limit (adaptive if set to 0)",
 	}
+	blockGasLimitAlgorithmFlag = cli.StringFlag{
+		Name:  "block-gas-limit-algorithm",
+		Value: "simple",
+		Usage: "algorithm adjusting the block gas limit (simple|pid)",
+	}
 	pprofFlag = cli.BoolFlag{
 		Name:  "pprof",
 		Usage: "turn on go-pprof",
@@ -131,20 +203,64 @@ var (
 		Name:  "skip-logs",
 		Usage: "skip writing event|transfer logs (/logs API will be disabled)",
 	}
+	stateDiffFlag = cli.BoolFlag{
+		Name:  "state-diff",
+		Usage: "record accounts changed by each block (served by /debug/statediff)",
+	}
+	accountPreimagesFlag = cli.BoolFlag{
+		Name:  "account-preimages",
+		Usage: "record the addresses of the changed accounts (resolved by /debug/account-range)",
+	}
 	verifyLogsFlag = cli.BoolFlag{
 		Name:   "verify-logs",
 		Usage:  "verify log db at startup",
 		Hidden: true,
 	}
+	logsSlowQueryThresholdFlag = cli.Uint64Flag{
+		Name:  "logs-slow-query-threshold",
+		Value: 0,
+		Usage: "log queries to the log db slower than the threshold in milliseconds (disabled if set to 0)",
+	}
 	cacheFlag = cli.Uint64Flag{
 		Name:  "cache",
 		Usage: "megabytes of ram allocated to trie nodes cache",
 		Value: 4096,
 	}
+	trieCommitBatchSizeFlag = cli.UintFlag{
+		Name:  "trie-commit-batch-size",
+		Usage: "max count of trie nodes written in a batch on commit, to bound write latency (0 for no limit)",
+	}
+	signerCacheSizeFlag = cli.IntFlag{
+		Name:  "signer-cache-size",
+		Usage: "count of recovered tx signers cached (disabled if set to 0)",
+		Value: tx.DefaultSignerCacheSize,
+	}
+	suppressEmptyBlocksFlag = cli.BoolFlag{
+		Name:  "suppress-empty-blocks",
+		Usage: "skip packing blocks without txs, only honored on private networks",
+	}
+	maxBlockBuildTimeFlag = cli.Uint64Flag{
+		Name:  "max-block-build-time",
+		Value: uint64(node.DefaultMaxBlockBuildTime.Milliseconds()),
+		Usage: "max time in milliseconds spent on adopting txs into a packed block, bounded by the time of the block",
+	}
+	maxReorgDepthFlag = cli.Uint64Flag{
+		Name:  "max-reorg-depth",
+		Value: node.DefaultMaxReorgDepth,
+		Usage: "halt block processing on a reorg reverting more blocks than this, instead of switching the chain (unlimited if set to 0)",
+	}
 	disablePrunerFlag = cli.BoolFlag{
 		Name:  "disable-pruner",
 		Usage: "disable state pruner to keep all history",
 	}
+	prunerIOLimitFlag = cli.UintFlag{
+		Name:  "pruner-io-limit",
+		Usage: "limit the megabytes per second deleted by the state pruner, 0 for unlimited",
+	}
+	prunerRetainForkStatesFlag = cli.BoolFlag{
+		Name:  "pruner-retain-fork-states",
+		Usage: "keep the states of fork activation blocks and their parents accessible after pruned",
+	}
 	enableMetricsFlag = cli.BoolFlag{
 		Name:  "enable-metrics",
 		Usage: "enables metrics collection",
@@ -169,6 +285,11 @@ var (
 		Value: 16,
 		Usage: "set tx limit per account in pool",
 	}
+	txPoolPriceBumpPctFlag = cli.UintFlag{
+		Name:  "tx-pool-price-bump-pct",
+		Value: 10,
+		Usage: "min percentage of priority bump required to replace a pending tx in pool (1-100)",
+	}
 
 	allowedTracersFlag = cli.StringFlag{
 		Name:  "api-allowed-tracers",
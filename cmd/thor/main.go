@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"path/filepath"
 	"sync/atomic"
@@ -20,6 +21,8 @@ import (
 	"github.com/pborman/uuid"
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/api/admin/health"
+	"github.com/vechain/thor/v2/api/dev"
 	"github.com/vechain/thor/v2/bft"
 	"github.com/vechain/thor/v2/cmd/thor/node"
 	"github.com/vechain/thor/v2/cmd/thor/optimizer"
@@ -31,6 +34,7 @@ import (
 	"github.com/vechain/thor/v2/muxdb"
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
 	"github.com/vechain/thor/v2/txpool"
 	"gopkg.in/urfave/cli.v1"
 
@@ -49,6 +53,7 @@ var (
 		Limit:           10000,
 		LimitPerAccount: 16,
 		MaxLifetime:     20 * time.Minute,
+		PriceBumpPct:    10,
 	}
 )
 
@@ -72,6 +77,8 @@ func main() {
 			masterKeyStdinFlag,
 			dataDirFlag,
 			cacheFlag,
+			trieCommitBatchSizeFlag,
+			signerCacheSizeFlag,
 			beneficiaryFlag,
 			targetGasLimitFlag,
 			apiAddrFlag,
@@ -81,6 +88,19 @@ func main() {
 			apiBacktraceLimitFlag,
 			apiAllowCustomTracerFlag,
 			apiEnableDeprecatedFlag,
+			apiTxPoolFlag,
+			apiChecksumAddressesFlag,
+			apiSubscriptionsPingIntervalFlag,
+			apiSubscriptionsMaxConnsFlag,
+			apiTraceJobsWorkersFlag,
+			apiTraceJobsQueueSizeFlag,
+			apiTraceJobsTTLFlag,
+			apiTraceJobsMaxResultMBFlag,
+			apiTraceJobsTimeoutFlag,
+			apiTracerMetricsFlag,
+			apiDisableCompressionFlag,
+			apiResponseCompressionFlag,
+			apiDefaultFinalityFlag,
 			enableAPILogsFlag,
 			apiLogsLimitFlag,
 			verbosityFlag,
@@ -93,12 +113,22 @@ func main() {
 			skipLogsFlag,
 			pprofFlag,
 			verifyLogsFlag,
+			logsSlowQueryThresholdFlag,
+			stateDiffFlag,
+			accountPreimagesFlag,
+			suppressEmptyBlocksFlag,
+			maxBlockBuildTimeFlag,
+			blockGasLimitAlgorithmFlag,
+			maxReorgDepthFlag,
 			disablePrunerFlag,
+			prunerIOLimitFlag,
+			prunerRetainForkStatesFlag,
 			enableMetricsFlag,
 			metricsAddrFlag,
 			adminAddrFlag,
 			enableAdminFlag,
 			txPoolLimitPerAccountFlag,
+			txPoolPriceBumpPctFlag,
 			allowedTracersFlag,
 		},
 		Action: defaultAction,
@@ -110,6 +140,8 @@ func main() {
 					genesisFlag,
 					dataDirFlag,
 					cacheFlag,
+					trieCommitBatchSizeFlag,
+					signerCacheSizeFlag,
 					apiAddrFlag,
 					apiCorsFlag,
 					apiTimeoutFlag,
@@ -117,6 +149,20 @@ func main() {
 					apiBacktraceLimitFlag,
 					apiAllowCustomTracerFlag,
 					apiEnableDeprecatedFlag,
+					apiTxPoolFlag,
+					apiChecksumAddressesFlag,
+					apiSubscriptionsPingIntervalFlag,
+					apiSubscriptionsMaxConnsFlag,
+					apiTraceJobsWorkersFlag,
+					apiTraceJobsQueueSizeFlag,
+					apiTraceJobsTTLFlag,
+					apiTraceJobsMaxResultMBFlag,
+					apiTraceJobsTimeoutFlag,
+					apiTracerMetricsFlag,
+					apiDisableCompressionFlag,
+					apiResponseCompressionFlag,
+					apiDefaultFinalityFlag,
+					devSignerFlag,
 					enableAPILogsFlag,
 					apiLogsLimitFlag,
 					onDemandFlag,
@@ -127,10 +173,16 @@ func main() {
 					jsonLogsFlag,
 					pprofFlag,
 					verifyLogsFlag,
+					logsSlowQueryThresholdFlag,
+					stateDiffFlag,
+					accountPreimagesFlag,
 					skipLogsFlag,
 					txPoolLimitFlag,
 					txPoolLimitPerAccountFlag,
+					txPoolPriceBumpPctFlag,
 					disablePrunerFlag,
+					prunerIOLimitFlag,
+					prunerRetainForkStatesFlag,
 					enableMetricsFlag,
 					metricsAddrFlag,
 					adminAddrFlag,
@@ -181,6 +233,8 @@ func defaultAction(ctx *cli.Context) error {
 		defer func() { log.Info("stopping metrics server..."); closeFunc() }()
 	}
 
+	tx.SetSignerCacheSize(ctx.Int(signerCacheSizeFlag.Name))
+
 	gene, forkConfig, err := selectGenesis(ctx)
 	if err != nil {
 		return err
@@ -201,8 +255,10 @@ func defaultAction(ctx *cli.Context) error {
 		return err
 	}
 	defer func() { log.Info("closing log database..."); logDB.Close() }()
+	logDB.SetSlowQueryThreshold(time.Duration(ctx.Uint64(logsSlowQueryThresholdFlag.Name)) * time.Millisecond)
 
-	repo, err := initChainRepository(gene, mainDB, logDB)
+	stater := newStater(ctx, mainDB)
+	repo, err := initChainRepository(gene, mainDB, stater, logDB)
 	if err != nil {
 		return err
 	}
@@ -221,11 +277,20 @@ func defaultAction(ctx *cli.Context) error {
 		}
 	}
 
+	// public networks rely on a block every slot
+	suppressEmptyBlocks := ctx.Bool(suppressEmptyBlocksFlag.Name) && thor.IsPrivateNetwork(gene.ID())
+
 	txpoolOpt := defaultTxPoolOptions
+	// the head block goes old while no txs are pending
+	txpoolOpt.IgnoreStaleHead = suppressEmptyBlocks
 	txpoolOpt.LimitPerAccount, err = readIntFromUInt64Flag(ctx.Uint64(txPoolLimitPerAccountFlag.Name))
 	if err != nil {
 		return errors.Wrap(err, "parse txpool-limit-per-account flag")
 	}
+	txpoolOpt.PriceBumpPct, err = readPriceBumpPct(ctx.Uint(txPoolPriceBumpPctFlag.Name))
+	if err != nil {
+		return errors.Wrap(err, "parse tx-pool-price-bump-pct flag")
+	}
 	txPool := txpool.New(repo, state.NewStater(mainDB), txpoolOpt)
 	defer func() { log.Info("closing tx pool..."); txPool.Close() }()
 
@@ -234,16 +299,27 @@ func defaultAction(ctx *cli.Context) error {
 		return err
 	}
 
+	bftEngine, err := bft.NewEngine(repo, mainDB, forkConfig, master.Address())
+	if err != nil {
+		return errors.Wrap(err, "init bft engine")
+	}
+
 	adminURL := ""
 	logAPIRequests := &atomic.Bool{}
 	logAPIRequests.Store(ctx.Bool(enableAPILogsFlag.Name))
+	healthStatus := health.New(repo, p2pCommunicator.Communicator())
 	if ctx.Bool(enableAdminFlag.Name) {
 		url, closeFunc, err := api.StartAdminServer(
 			ctx.String(adminAddrFlag.Name),
 			logLevel,
 			repo,
+			bftEngine,
 			p2pCommunicator.Communicator(),
+			healthStatus,
 			logAPIRequests,
+			txPool,
+			mainDB,
+			logDB,
 		)
 		if err != nil {
 			return fmt.Errorf("unable to start admin server - %w", err)
@@ -252,20 +328,21 @@ func defaultAction(ctx *cli.Context) error {
 		defer func() { log.Info("stopping admin server..."); closeFunc() }()
 	}
 
-	bftEngine, err := bft.NewEngine(repo, mainDB, forkConfig, master.Address())
+	apiConfig, err := makeAPIConfig(ctx, logAPIRequests, false)
 	if err != nil {
-		return errors.Wrap(err, "init bft engine")
+		return err
 	}
-
+	nodeMaster := master.Address()
+	apiConfig.NodeMaster = &nodeMaster
 	apiHandler, apiCloser := api.New(
 		repo,
 		state.NewStater(mainDB),
 		txPool,
 		logDB,
 		bftEngine,
-		p2pCommunicator.Communicator(),
+		p2pCommunicator,
 		forkConfig,
-		makeAPIConfig(ctx, logAPIRequests, false),
+		apiConfig,
 	)
 	defer func() { log.Info("closing API..."); apiCloser() }()
 
@@ -282,14 +359,14 @@ func defaultAction(ctx *cli.Context) error {
 	}
 	defer p2pCommunicator.Stop()
 
-	optimizer := optimizer.New(mainDB, repo, !ctx.Bool(disablePrunerFlag.Name))
+	optimizer := optimizer.New(mainDB, repo, !ctx.Bool(disablePrunerFlag.Name), retainedStates(ctx, forkConfig))
 	defer func() { log.Info("stopping optimizer..."); optimizer.Stop() }()
 
-	return node.New(
+	n := node.New(
 		master,
 		repo,
 		bftEngine,
-		state.NewStater(mainDB),
+		stater,
 		logDB,
 		txPool,
 		filepath.Join(instanceDir, "tx.stash"),
@@ -297,7 +374,26 @@ func defaultAction(ctx *cli.Context) error {
 		ctx.Uint64(targetGasLimitFlag.Name),
 		skipLogs,
 		forkConfig,
-	).Run(exitSignal)
+	)
+	gasLimitAlgorithm, err := makeGasLimitAlgorithm(ctx, instanceDir)
+	if err != nil {
+		return err
+	}
+	n.SetGasLimitAlgorithm(gasLimitAlgorithm)
+	if suppressEmptyBlocks {
+		n.SetSuppressEmptyBlocks(true)
+	} else if ctx.Bool(suppressEmptyBlocksFlag.Name) {
+		log.Warn("--suppress-empty-blocks is ignored on public networks")
+	}
+	if err := n.SetMaxBlockBuildTime(time.Duration(ctx.Uint64(maxBlockBuildTimeFlag.Name)) * time.Millisecond); err != nil {
+		return errors.Wrap(err, "max-block-build-time")
+	}
+	maxReorgDepth := ctx.Uint64(maxReorgDepthFlag.Name)
+	if maxReorgDepth > math.MaxUint32 {
+		return errors.New("max-reorg-depth out of range")
+	}
+	n.SetMaxReorgDepth(uint32(maxReorgDepth), healthStatus.ReportHalted)
+	return n.Run(exitSignal)
 }
 
 func soloAction(ctx *cli.Context) error {
@@ -334,6 +430,8 @@ func soloAction(ctx *cli.Context) error {
 		forkConfig thor.ForkConfig
 	)
 
+	tx.SetSignerCacheSize(ctx.Int(signerCacheSizeFlag.Name))
+
 	flagGenesis := ctx.String(genesisFlag.Name)
 	if flagGenesis == "" {
 		gene = genesis.NewDevnet()
@@ -344,6 +442,11 @@ func soloAction(ctx *cli.Context) error {
 			return err
 		}
 	}
+	if ctx.Bool(devSignerFlag.Name) {
+		if err := dev.CheckGenesis(gene.ID()); err != nil {
+			return err
+		}
+	}
 
 	var mainDB *muxdb.MuxDB
 	var logDB *logdb.LogDB
@@ -367,30 +470,14 @@ func soloAction(ctx *cli.Context) error {
 		mainDB = openMemMainDB()
 		logDB = openMemLogDB()
 	}
+	logDB.SetSlowQueryThreshold(time.Duration(ctx.Uint64(logsSlowQueryThresholdFlag.Name)) * time.Millisecond)
 
-	repo, err := initChainRepository(gene, mainDB, logDB)
+	stater := newStater(ctx, mainDB)
+	repo, err := initChainRepository(gene, mainDB, stater, logDB)
 	if err != nil {
 		return err
 	}
 
-	adminURL := ""
-	logAPIRequests := &atomic.Bool{}
-	logAPIRequests.Store(ctx.Bool(enableAPILogsFlag.Name))
-	if ctx.Bool(enableAdminFlag.Name) {
-		url, closeFunc, err := api.StartAdminServer(
-			ctx.String(adminAddrFlag.Name),
-			logLevel,
-			repo,
-			nil,
-			logAPIRequests,
-		)
-		if err != nil {
-			return fmt.Errorf("unable to start admin server - %w", err)
-		}
-		adminURL = url
-		defer func() { log.Info("stopping admin server..."); closeFunc() }()
-	}
-
 	printStartupMessage1(gene, repo, nil, instanceDir, forkConfig)
 
 	skipLogs := ctx.Bool(skipLogsFlag.Name)
@@ -409,12 +496,43 @@ func soloAction(ctx *cli.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "parse txpool-limit-per-account flag")
 	}
+	txPoolOption.PriceBumpPct, err = readPriceBumpPct(ctx.Uint(txPoolPriceBumpPctFlag.Name))
+	if err != nil {
+		return errors.Wrap(err, "parse tx-pool-price-bump-pct flag")
+	}
 
 	txPool := txpool.New(repo, state.NewStater(mainDB), txPoolOption)
 	defer func() { log.Info("closing tx pool..."); txPool.Close() }()
 
 	bftEngine := solo.NewBFTEngine(repo)
 
+	adminURL := ""
+	logAPIRequests := &atomic.Bool{}
+	logAPIRequests.Store(ctx.Bool(enableAPILogsFlag.Name))
+	if ctx.Bool(enableAdminFlag.Name) {
+		url, closeFunc, err := api.StartAdminServer(
+			ctx.String(adminAddrFlag.Name),
+			logLevel,
+			repo,
+			bftEngine,
+			nil,
+			health.New(repo, nil),
+			logAPIRequests,
+			txPool,
+			mainDB,
+			logDB,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to start admin server - %w", err)
+		}
+		adminURL = url
+		defer func() { log.Info("stopping admin server..."); closeFunc() }()
+	}
+
+	apiConfig, err := makeAPIConfig(ctx, logAPIRequests, true)
+	if err != nil {
+		return err
+	}
 	apiHandler, apiCloser := api.New(
 		repo,
 		state.NewStater(mainDB),
@@ -423,7 +541,7 @@ func soloAction(ctx *cli.Context) error {
 		bftEngine,
 		&solo.Communicator{},
 		forkConfig,
-		makeAPIConfig(ctx, logAPIRequests, true),
+		apiConfig,
 	)
 	defer func() { log.Info("closing API..."); apiCloser() }()
 
@@ -443,11 +561,11 @@ func soloAction(ctx *cli.Context) error {
 
 	printStartupMessage2(gene, apiURL, "", metricsURL, adminURL)
 
-	optimizer := optimizer.New(mainDB, repo, !ctx.Bool(disablePrunerFlag.Name))
+	optimizer := optimizer.New(mainDB, repo, !ctx.Bool(disablePrunerFlag.Name), retainedStates(ctx, forkConfig))
 	defer func() { log.Info("stopping optimizer..."); optimizer.Stop() }()
 
 	return solo.New(repo,
-		state.NewStater(mainDB),
+		stater,
 		logDB,
 		txPool,
 		ctx.Uint64(gasLimitFlag.Name),
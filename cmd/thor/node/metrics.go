@@ -16,4 +16,15 @@ var (
 	metricBlockProcessedDuration = metrics.LazyLoadHistogram("block_processed_duration_ms", metrics.Bucket10s)
 	metricChainForkCount         = metrics.LazyLoadCounter("chain_fork_count")
 	metricChainForkSize          = metrics.LazyLoadGauge("chain_fork_gauge")
+	metricChainReorgHalted       = metrics.LazyLoadGauge("chain_reorg_halted") // 1 once halted by a deep reorg
+
+	// consensus events, exported as thor_consensus_*
+	metricConsensusBlocks = metrics.LazyLoadCounterVec(
+		"consensus_blocks_count", []string{"source"}, metrics.WithNamespace("thor"))
+	metricConsensusBlocksRejected = metrics.LazyLoadCounterVec(
+		"consensus_blocks_rejected_count", []string{"reason"}, metrics.WithNamespace("thor"))
+	metricConsensusDoubleProposals = metrics.LazyLoadCounter(
+		"consensus_double_proposal_count", metrics.WithNamespace("thor"))
+	metricConsensusSlotsMissed = metrics.LazyLoadCounterVec(
+		"consensus_packer_slot_missed_count", []string{"reason"}, metrics.WithNamespace("thor"))
 )
@@ -0,0 +1,172 @@
+// Copyright (c) 2024 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package node
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/metrics"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/packer"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+func init() {
+	metrics.InitializePrometheusMetrics()
+}
+
+func gatherFamilies(t *testing.T) map[string]*dto.MetricFamily {
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+
+	m := make(map[string]*dto.MetricFamily)
+	for _, mf := range families {
+		m[mf.GetName()] = mf
+	}
+	return m
+}
+
+// counterValue returns the value of the counter with the given label, or the
+// plain counter value if label is empty.
+func counterValue(t *testing.T, name, label, value string) float64 {
+	mf, ok := gatherFamilies(t)[name]
+	if !ok {
+		return 0
+	}
+	for _, m := range mf.GetMetric() {
+		if label == "" {
+			return m.GetCounter().GetValue()
+		}
+		for _, l := range m.GetLabel() {
+			if l.GetName() == label && l.GetValue() == value {
+				return m.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+func packBlock(t *testing.T, thorChain *testchain.Chain, proposer genesis.DevAccount, parent *block.Block, targetGasLimit uint64) *block.Block {
+	p := packer.New(thorChain.Repo(), thorChain.Stater(), proposer.Address, &proposer.Address, thorChain.GetForkConfig())
+	p.SetTargetGasLimit(targetGasLimit)
+
+	parentSum, err := thorChain.Repo().GetBlockSummary(parent.Header().ID())
+	require.NoError(t, err)
+
+	flow, err := p.Schedule(parentSum, parent.Header().Timestamp()+1)
+	require.NoError(t, err)
+
+	blk, _, _, err := flow.Pack(proposer.PrivateKey, 0, false)
+	require.NoError(t, err)
+	return blk
+}
+
+func TestConsensusMetrics(t *testing.T) {
+	accounts := genesis.DevAccounts()[:2]
+
+	// blocks are produced on a separate chain with the same genesis
+	fakeChain, err := createChain(muxdb.NewMem(), accounts)
+	require.NoError(t, err)
+	thorChain, err := createChain(muxdb.NewMem(), accounts)
+	require.NoError(t, err)
+
+	engine, err := bft.NewEngine(thorChain.Repo(), thorChain.Database(), thorChain.GetForkConfig(), accounts[0].Address)
+	require.NoError(t, err)
+
+	node := New(
+		&Master{PrivateKey: accounts[0].PrivateKey},
+		thorChain.Repo(),
+		engine,
+		thorChain.Stater(),
+		nil,
+		nil,
+		"",
+		nil,
+		10_000_000,
+		true,
+		thor.NoFork,
+	)
+
+	received := counterValue(t, "thor_consensus_blocks_count", "source", "received")
+	doubleProposals := counterValue(t, "thor_consensus_double_proposal_count", "", "")
+	rejected := counterValue(t, "thor_consensus_blocks_rejected_count", "reason", "consensus")
+
+	b1, err := packTxsIntoBlock(fakeChain, &accounts[0], fakeChain.GenesisBlock(), nil)
+	require.NoError(t, err)
+	_, err = node.processBlock(b1, &blockStats{})
+	require.NoError(t, err)
+	assert.Equal(t, received+1, counterValue(t, "thor_consensus_blocks_count", "source", "received"))
+
+	// the same signer proposes two different blocks for the same slot
+	b2 := packBlock(t, fakeChain, accounts[1], b1, 0)
+	b2x := packBlock(t, fakeChain, accounts[1], b1, b1.Header().GasLimit()*2)
+	require.Equal(t, b2.Header().Timestamp(), b2x.Header().Timestamp())
+	require.NotEqual(t, b2.Header().ID(), b2x.Header().ID())
+
+	_, err = node.processBlock(b2, &blockStats{})
+	require.NoError(t, err)
+	assert.Equal(t, doubleProposals, counterValue(t, "thor_consensus_double_proposal_count", "", ""))
+
+	_, err = node.processBlock(b2x, &blockStats{})
+	require.NoError(t, err)
+	assert.Equal(t, doubleProposals+1, counterValue(t, "thor_consensus_double_proposal_count", "", ""))
+	assert.Equal(t, received+3, counterValue(t, "thor_consensus_blocks_count", "source", "received"))
+
+	// re-sign a block with an unauthorized key
+	pk, _ := crypto.GenerateKey()
+	b3 := packBlock(t, fakeChain, accounts[0], b1, 0)
+	sig, err := crypto.Sign(b3.Header().SigningHash().Bytes(), pk)
+	require.NoError(t, err)
+	b3 = b3.WithSignature(sig)
+
+	_, err = node.processBlock(b3, &blockStats{})
+	assert.Error(t, err)
+	assert.Equal(t, rejected+1, counterValue(t, "thor_consensus_blocks_rejected_count", "reason", "consensus"))
+	assert.Equal(t, received+3, counterValue(t, "thor_consensus_blocks_count", "source", "received"))
+}
+
+func TestConsensusMetricsNaming(t *testing.T) {
+	// touch all the consensus metrics to have them registered
+	metricConsensusBlocks().AddWithLabel(0, map[string]string{"source": "received"})
+	metricConsensusBlocksRejected().AddWithLabel(0, map[string]string{"reason": "consensus"})
+	metricConsensusDoubleProposals().Add(0)
+	metricConsensusSlotsMissed().AddWithLabel(0, map[string]string{"reason": "pack_error"})
+
+	valid := regexp.MustCompile(`^thor_consensus_[a-z]+(_[a-z]+)*$`)
+
+	var names []string
+	for name, mf := range gatherFamilies(t) {
+		if !strings.Contains(name, "consensus") {
+			continue
+		}
+		names = append(names, name)
+
+		assert.Regexp(t, valid, name)
+		if mf.GetType() == dto.MetricType_COUNTER {
+			assert.True(t, strings.HasSuffix(name, "_count"), "counter %v should end with _count", name)
+		} else {
+			assert.False(t, strings.HasSuffix(name, "_count"), "non-counter %v should not end with _count", name)
+		}
+	}
+	assert.Subset(t, names, []string{
+		"thor_consensus_blocks_count",
+		"thor_consensus_blocks_rejected_count",
+		"thor_consensus_double_proposal_count",
+		"thor_consensus_packer_slot_missed_count",
+	})
+}
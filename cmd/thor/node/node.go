@@ -20,6 +20,7 @@ import (
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/vechain/thor/v2/bft"
 	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/builtin"
 	"github.com/vechain/thor/v2/cache"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/cmd/thor/bandwidth"
@@ -43,6 +44,17 @@ var (
 	errKnownBlock                  = errors.New("block already in the chain")
 	errParentMissing               = errors.New("parent block is missing")
 	errBFTRejected                 = errors.New("block rejected by BFT engine")
+	errEmptyBlockSuppressed        = errors.New("empty block suppressed")
+	errReorgHalted                 = errors.New("block processing halted by a deep reorg")
+)
+
+// DefaultMaxReorgDepth is the default max number of blocks reverted by a reorg before halting.
+const DefaultMaxReorgDepth = 1000
+
+// limits of a batch of blocks committed at once, when importing blocks before synced.
+const (
+	bulkImportMaxBlocks = 256
+	bulkImportMaxSize   = 16 * 1024 * 1024
 )
 
 type Node struct {
@@ -50,6 +62,7 @@ type Node struct {
 	cons           *consensus.Consensus
 	master         *Master
 	repo           *chain.Repository
+	stater         *state.Stater
 	bft            *bft.Engine
 	logDB          *logdb.LogDB
 	txPool         *txpool.TxPool
@@ -59,11 +72,27 @@ type Node struct {
 	skipLogs       bool
 	forkConfig     thor.ForkConfig
 
+	suppressEmptyBlocks bool
+	maxBlockBuildTime   time.Duration
+
 	logDBFailed bool
 	bandwidth   bandwidth.Bandwidth
 	maxBlockNum uint32
 	processLock sync.Mutex
 	logWorker   *worker
+	proposals   *cache.RandCache
+
+	masterEndorsed *bool // whether the master is endorsed at the best block, nil if not checked yet, guarded by processLock
+
+	maxReorgDepth uint32 // zero for unlimited
+	onReorgHalt   func(err error)
+	halted        bool // set once a reorg deeper than maxReorgDepth is observed, guarded by processLock
+}
+
+// proposalKey identifies a block proposal slot of a signer.
+type proposalKey struct {
+	signer    thor.Address
+	timestamp uint64
 }
 
 func New(
@@ -84,6 +113,7 @@ func New(
 		cons:           consensus.New(repo, stater, forkConfig),
 		master:         master,
 		repo:           repo,
+		stater:         stater,
 		bft:            bft,
 		logDB:          logDB,
 		txPool:         txPool,
@@ -92,9 +122,45 @@ func New(
 		targetGasLimit: targetGasLimit,
 		skipLogs:       skipLogs,
 		forkConfig:     forkConfig,
+		proposals:      cache.NewRandCache(1024),
+
+		maxBlockBuildTime: DefaultMaxBlockBuildTime,
+		maxReorgDepth:     DefaultMaxReorgDepth,
 	}
 }
 
+// SetSuppressEmptyBlocks sets whether to skip packing blocks without txs. Blocks activating forks are
+// always packed. The skipped slots are handled by the scheduler the same way as slots missed by
+// offline proposers, so the chain stays valid when production resumes.
+func (n *Node) SetSuppressEmptyBlocks(suppress bool) {
+	n.suppressEmptyBlocks = suppress
+}
+
+// SetMaxBlockBuildTime sets the max time spent on adopting txs into a block, the block is packed with the
+// txs adopted so far once exceeded. It must be less than the block interval. The adoption also stops at the
+// time of the block, however long it's allowed.
+func (n *Node) SetMaxBlockBuildTime(d time.Duration) error {
+	if d <= 0 || d >= time.Duration(thor.BlockInterval)*time.Second {
+		return errors.Errorf("max block build time must be in (0, %ds)", thor.BlockInterval)
+	}
+	n.maxBlockBuildTime = d
+	return nil
+}
+
+// SetMaxReorgDepth sets the max number of trunk blocks a reorg may revert, zero for unlimited. A deeper reorg
+// halts block processing instead of switching the chain, and onHalt, if not nil, is called with the reason.
+// The halt is not persisted, but the block causing it is not stored either, so a restarted node halts again
+// when it receives the block.
+func (n *Node) SetMaxReorgDepth(depth uint32, onHalt func(err error)) {
+	n.maxReorgDepth = depth
+	n.onReorgHalt = onHalt
+}
+
+// SetGasLimitAlgorithm sets the algorithm deciding the gas limit of packed blocks.
+func (n *Node) SetGasLimitAlgorithm(alg packer.GasLimitAlgorithm) {
+	n.packer.SetGasLimitAlgorithm(alg)
+}
+
 func (n *Node) Run(ctx context.Context) error {
 	logWorker := newWorker()
 	defer logWorker.Close()
@@ -106,6 +172,7 @@ func (n *Node) Run(ctx context.Context) error {
 		return err
 	}
 	n.maxBlockNum = maxBlockNum
+	n.checkEndorsement()
 
 	var goes co.Goes
 	goes.Go(func() { n.comm.Sync(ctx, n.handleBlockStream) })
@@ -129,6 +196,20 @@ func (n *Node) handleBlockStream(ctx context.Context, stream <-chan *block.Block
 		startTime = mclock.Now()
 	}
 
+	// blocks are committed in batches until synced, to reduce syncs of the db
+	select {
+	case <-n.comm.Synced():
+	default:
+		if err := n.repo.BeginBulkImport(bulkImportMaxBlocks, bulkImportMaxSize); err != nil {
+			return errors.Wrap(err, "begin bulk import")
+		}
+		defer func() {
+			if endErr := n.repo.EndBulkImport(); endErr != nil && err == nil {
+				err = errors.Wrap(endErr, "end bulk import")
+			}
+		}()
+	}
+
 	var blk *block.Block
 	for blk = range stream {
 		if blk == nil {
@@ -258,8 +339,8 @@ func (n *Node) txStashLoop(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case txEv := <-txCh:
-			// skip executables
-			if txEv.Executable != nil && *txEv.Executable {
+			// skip executables and removed ones
+			if (txEv.Executable != nil && *txEv.Executable) || txEv.Removed {
 				continue
 			}
 			// only stash non-executable txs
@@ -277,6 +358,10 @@ func (n *Node) guardBlockProcessing(blockNum uint32, process func(conflicts uint
 	n.processLock.Lock()
 	defer n.processLock.Unlock()
 
+	if n.halted {
+		return errReorgHalted
+	}
+
 	if blockNum > n.maxBlockNum {
 		if blockNum > n.maxBlockNum+1 {
 			// the block is surely unprocessable now
@@ -332,6 +417,7 @@ func (n *Node) processBlock(newBlock *block.Block, stats *blockStats) (bool, err
 		if err != nil {
 			return err
 		}
+		n.checkDoubleProposal(newBlock.Header())
 
 		var becomeNewBest bool
 		// let bft engine decide the best block after fork FINALITY
@@ -343,6 +429,12 @@ func (n *Node) processBlock(newBlock *block.Block, stats *blockStats) (bool, err
 		} else {
 			becomeNewBest = newBlock.Header().BetterThan(oldBest.Header)
 		}
+		// halt before anything of the block is stored, so it's checked again after a restart
+		if becomeNewBest {
+			if err := n.checkReorgDepth(newBlock, oldBest.Header.ID()); err != nil {
+				return err
+			}
+		}
 		logEnabled := becomeNewBest && !n.skipLogs && !n.logDBFailed
 		isTrunk = &becomeNewBest
 
@@ -364,6 +456,9 @@ func (n *Node) processBlock(newBlock *block.Block, stats *blockStats) (bool, err
 		if err := n.repo.AddBlock(newBlock, receipts, conflicts); err != nil {
 			return errors.Wrap(err, "add block")
 		}
+		if err := stage.RecordChangeset(); err != nil {
+			return errors.Wrap(err, "record changeset")
+		}
 
 		// commit block in bft engine
 		if newBlock.Header().Number() >= n.forkConfig.FINALITY {
@@ -387,6 +482,7 @@ func (n *Node) processBlock(newBlock *block.Block, stats *blockStats) (bool, err
 				return err
 			}
 			n.processFork(newBlock, oldBest.Header.ID())
+			n.checkEndorsement()
 		}
 
 		commitElapsed := mclock.Now() - startTime - execElapsed
@@ -407,22 +503,82 @@ func (n *Node) processBlock(newBlock *block.Block, stats *blockStats) (bool, err
 			return false, nil
 		case consensus.IsFutureBlock(err) || err == errParentMissing || err == errBlockTemporaryUnprocessable:
 			stats.UpdateQueued(1)
+		case err == errReorgHalted:
+			// already reported
 		case err == errBFTRejected:
-			// TODO: capture metrics
 			logger.Debug(fmt.Sprintf("block rejected by BFT engine\n%v\n", newBlock.Header()))
+			metricConsensusBlocksRejected().AddWithLabel(1, map[string]string{"reason": "bft"})
 		case consensus.IsCritical(err):
 			msg := fmt.Sprintf(`failed to process block due to consensus failure\n%v\n`, newBlock.Header())
 			logger.Error(msg, "err", err)
+			metricConsensusBlocksRejected().AddWithLabel(1, map[string]string{"reason": "consensus"})
 		default:
 			logger.Error("failed to process block", "err", err)
+			metricConsensusBlocksRejected().AddWithLabel(1, map[string]string{"reason": "error"})
 		}
 		metricBlockProcessedCount().AddWithLabel(1, map[string]string{"type": "received", "success": "false"})
 		return false, err
 	}
 	metricBlockProcessedCount().AddWithLabel(1, map[string]string{"type": "received", "success": "true"})
+	metricConsensusBlocks().AddWithLabel(1, map[string]string{"source": "received"})
 	return *isTrunk, nil
 }
 
+// checkDoubleProposal records the slot of the given block, and reports if its signer
+// has already proposed a different block for the same slot.
+func (n *Node) checkDoubleProposal(header *block.Header) {
+	signer, err := header.Signer()
+	if err != nil {
+		return
+	}
+	key := proposalKey{signer, header.Timestamp()}
+	if id, ok := n.proposals.Get(key); ok {
+		if id.(thor.Bytes32) != header.ID() {
+			metricConsensusDoubleProposals().Add(1)
+			logger.Warn("double proposal detected", "signer", signer, "timestamp", header.Timestamp(), "first", id, "second", header.ID())
+		}
+		return
+	}
+	n.proposals.Set(key, header.ID())
+}
+
+// checkEndorsement reports if the master transitions between endorsed and unendorsed at the best block.
+// An unendorsed master is no longer scheduled to propose blocks.
+func (n *Node) checkEndorsement() {
+	best := n.repo.BestBlockSummary()
+	st := n.stater.NewState(best.Header.StateRoot(), best.Header.Number(), best.Conflicts, best.SteadyNum)
+	endorsement, err := builtin.Params.Native(st).Get(thor.KeyProposerEndorsement)
+	if err != nil {
+		logger.Warn("failed to get endorsement", "err", err)
+		return
+	}
+	status, err := builtin.Authority.Native(st).Status(n.master.Address(), endorsement)
+	if err != nil {
+		logger.Warn("failed to get master status", "err", err)
+		return
+	}
+
+	if n.masterEndorsed != nil && *n.masterEndorsed == status.Endorsed {
+		return
+	}
+	switch {
+	case status.Endorsed:
+		if n.masterEndorsed != nil {
+			logger.Info("master endorsed", "master", status.NodeMaster, "block", best.Header.Number())
+		}
+	case status.Listed:
+		logger.Warn("master not endorsed, it will not be scheduled to propose blocks",
+			"master", status.NodeMaster,
+			"block", best.Header.Number(),
+			"endorsorBalance", status.EndorsorBalance,
+			"requiredEndorsement", status.RequiredEndorsement,
+		)
+	case n.masterEndorsed != nil:
+		logger.Warn("master not listed, it will not be scheduled to propose blocks", "master", status.NodeMaster, "block", best.Header.Number())
+	}
+	n.masterEndorsed = &status.Endorsed
+}
+
 func (n *Node) writeLogs(newBlock *block.Block, newReceipts tx.Receipts, oldBestBlockID thor.Bytes32) (err error) {
 	var w *logdb.Writer
 	if int64(newBlock.Header().Timestamp()) < time.Now().Unix()-24*3600 {
@@ -480,6 +636,36 @@ func (n *Node) writeLogs(newBlock *block.Block, newReceipts tx.Receipts, oldBest
 	return nil
 }
 
+// checkReorgDepth halts block processing if switching the best block to the new block reverts more
+// trunk blocks than the max reorg depth.
+func (n *Node) checkReorgDepth(newBlock *block.Block, oldBestBlockID thor.Bytes32) error {
+	if n.maxReorgDepth == 0 || newBlock.Header().ParentID() == oldBestBlockID {
+		return nil
+	}
+	oldTrunk := n.repo.NewChain(oldBestBlockID)
+	newTrunk := n.repo.NewChain(newBlock.Header().ParentID())
+
+	sideIDs, err := oldTrunk.Exclude(newTrunk)
+	if err != nil {
+		return errors.Wrap(err, "check reorg depth")
+	}
+	if depth := uint32(len(sideIDs)); depth > n.maxReorgDepth {
+		n.halted = true
+		metricChainReorgHalted().Set(1)
+		logger.Error(fmt.Sprintf(
+			`‼‼‼‼‼‼‼‼ DEEP REORG, BLOCK PROCESSING HALTED ‼‼‼‼‼‼‼‼
+depth:     %v > max %v
+old-best:  %v
+new-best:  %v`,
+			depth, n.maxReorgDepth, oldBestBlockID, newBlock.Header().ID()))
+		if n.onReorgHalt != nil {
+			n.onReorgHalt(errors.Errorf("reorg of depth %d exceeds the max %d, from %v to %v", depth, n.maxReorgDepth, oldBestBlockID, newBlock.Header().ID()))
+		}
+		return errReorgHalted
+	}
+	return nil
+}
+
 func (n *Node) processFork(newBlock *block.Block, oldBestBlockID thor.Bytes32) {
 	oldTrunk := n.repo.NewChain(oldBestBlockID)
 	newTrunk := n.repo.NewChain(newBlock.Header().ParentID())
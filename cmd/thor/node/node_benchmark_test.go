@@ -354,11 +354,17 @@ func packTxsIntoBlock(thorChain *testchain.Chain, proposerAccount *genesis.DevAc
 	return b1, nil
 }
 
-func createChain(db *muxdb.MuxDB, accounts []genesis.DevAccount) (*testchain.Chain, error) {
+// testForkConfig returns the fork config of the genesis built by createChain.
+func testForkConfig() thor.ForkConfig {
 	forkConfig := thor.NoFork
 	forkConfig.VIP191 = 1
 	forkConfig.BLOCKLIST = 0
 	forkConfig.VIP214 = 2
+	return forkConfig
+}
+
+func createChain(db *muxdb.MuxDB, accounts []genesis.DevAccount) (*testchain.Chain, error) {
+	forkConfig := testForkConfig()
 
 	// Create the state manager (Stater) with the initialized database.
 	stater := state.NewStater(db)
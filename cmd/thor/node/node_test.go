@@ -0,0 +1,89 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/thor"
+)
+
+func TestMaxReorgDepth(t *testing.T) {
+	accounts := genesis.DevAccounts()[:2]
+	n := newTestNode(t, accounts, accounts[0])
+
+	// mint a branch of empty blocks on top of the parent, the delay makes branches differ
+	mint := func(parentID thor.Bytes32, delay uint64, count int) []*block.Block {
+		var blocks []*block.Block
+		for range count {
+			parent, err := n.repo.GetBlockSummary(parentID)
+			require.NoError(t, err)
+			flow, err := n.packer.Mock(parent, parent.Header.Timestamp()+thor.BlockInterval+delay, parent.Header.GasLimit())
+			require.NoError(t, err)
+			conflicts, err := n.repo.ScanConflicts(flow.Number())
+			require.NoError(t, err)
+			blk, stage, receipts, err := flow.Pack(accounts[0].PrivateKey, conflicts, false)
+			require.NoError(t, err)
+			_, err = stage.Commit()
+			require.NoError(t, err)
+			require.NoError(t, n.repo.AddBlock(blk, receipts, conflicts))
+			blocks = append(blocks, blk)
+			parentID = blk.Header().ID()
+		}
+		return blocks
+	}
+	genesisID := n.repo.GenesisBlock().Header().ID()
+	trunk := mint(genesisID, 0, 3)
+	oldBestID := trunk[2].Header().ID()
+	require.NoError(t, n.repo.SetBestBlockID(oldBestID))
+	side := mint(genesisID, thor.BlockInterval, 2)
+
+	var haltErr error
+	n.SetMaxReorgDepth(3, func(err error) { haltErr = err })
+
+	// extending the trunk reverts nothing
+	assert.NoError(t, n.checkReorgDepth(mint(oldBestID, 0, 1)[0], oldBestID))
+	// reverts 3 trunk blocks
+	assert.NoError(t, n.checkReorgDepth(side[1], oldBestID))
+	assert.False(t, n.halted)
+
+	n.SetMaxReorgDepth(2, func(err error) { haltErr = err })
+	assert.Equal(t, errReorgHalted, n.checkReorgDepth(side[1], oldBestID))
+	assert.True(t, n.halted)
+	require.Error(t, haltErr)
+	assert.Contains(t, haltErr.Error(), "reorg of depth 3 exceeds the max 2")
+	assert.Equal(t, oldBestID, n.repo.BestBlockSummary().Header.ID())
+
+	// no more blocks processed
+	assert.Equal(t, errReorgHalted, n.guardBlockProcessing(1, func(uint32) error { return nil }))
+
+	// a processed block is checked before it's stored, so it halts again after a restart
+	n.halted = false
+	side = append(side, mint(side[1].Header().ID(), 0, 2)...)
+	parent, err := n.repo.GetBlockSummary(side[3].Header().ID())
+	require.NoError(t, err)
+	flow, err := n.packer.Schedule(parent, parent.Header.Timestamp())
+	require.NoError(t, err)
+	blk, _, _, err := flow.Pack(accounts[0].PrivateKey, 0, false)
+	require.NoError(t, err)
+	require.True(t, blk.Header().BetterThan(n.repo.BestBlockSummary().Header))
+
+	n.maxBlockNum = parent.Header.Number()
+	_, err = n.processBlock(blk, &blockStats{})
+	assert.Equal(t, errReorgHalted, err)
+	_, err = n.repo.GetBlockSummary(blk.Header().ID())
+	assert.True(t, n.repo.IsNotFound(err))
+	assert.Equal(t, oldBestID, n.repo.BestBlockSummary().Header.ID())
+
+	// unlimited
+	n.halted = false
+	n.SetMaxReorgDepth(0, nil)
+	assert.NoError(t, n.checkReorgDepth(side[1], oldBestID))
+}
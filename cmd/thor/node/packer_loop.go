@@ -21,6 +21,9 @@ import (
 // gasLimitSoftLimit is the soft limit of the adaptive block gaslimit.
 const gasLimitSoftLimit uint64 = 40_000_000
 
+// DefaultMaxBlockBuildTime is the default max time spent on adopting txs into a block.
+const DefaultMaxBlockBuildTime = 8 * time.Second
+
 func (n *Node) packerLoop(ctx context.Context) {
 	logger.Debug("enter packer loop")
 	defer logger.Debug("leave packer loop")
@@ -78,7 +81,12 @@ func (n *Node) packerLoop(ctx context.Context) {
 				// time to pack block
 				// blockInterval/2 early to allow more time for processing txs
 				if err := n.pack(flow); err != nil {
-					logger.Error("failed to pack block", "err", err)
+					if err == errEmptyBlockSuppressed {
+						logger.Debug("empty block suppressed", "number", flow.Number())
+					} else {
+						logger.Error("failed to pack block", "err", err)
+						metricConsensusSlotsMissed().AddWithLabel(1, map[string]string{"reason": "pack_error"})
+					}
 				}
 				break
 			}
@@ -95,8 +103,11 @@ func (n *Node) packerLoop(ctx context.Context) {
 				s1, _ := best.Signer()
 				s2, _ := flow.ParentHeader().Signer()
 
-				if (best.Number() == flow.ParentHeader().Number() && s1 != s2) ||
-					best.TotalScore() > flow.TotalScore() {
+				lostRace := best.TotalScore() > flow.TotalScore()
+				if (best.Number() == flow.ParentHeader().Number() && s1 != s2) || lostRace {
+					if lostRace {
+						metricConsensusSlotsMissed().AddWithLabel(1, map[string]string{"reason": "lost_race"})
+					}
 					logger.Debug("re-schedule packer due to new best block")
 					goto RE_SCHEDULE
 				}
@@ -106,15 +117,55 @@ func (n *Node) packerLoop(ctx context.Context) {
 	}
 }
 
+// adoptTxs adopts txs into the flow until the gas limit is reached, the max block build time since
+// startTime is exceeded or the time of the block is up. It returns the txs to be removed from the pool.
+func (n *Node) adoptTxs(flow *packer.Flow, txs tx.Transactions, startTime time.Time) (txsToRemove []*tx.Transaction) {
+	deadline := startTime.Add(n.maxBlockBuildTime)
+	if when := time.Unix(int64(flow.When()), 0); when.Before(deadline) {
+		deadline = when
+	}
+	for _, tx := range txs {
+		if err := flow.Adopt(tx); err != nil {
+			if packer.IsGasLimitReached(err) {
+				break
+			}
+			if !packer.IsTxNotAdoptableNow(err) {
+				txsToRemove = append(txsToRemove, tx)
+			}
+		}
+		// leave the rest to the following blocks rather than missing the slot
+		if now := time.Now(); now.After(deadline) {
+			logger.Debug("block build time is up", "number", flow.Number(), "elapsed", now.Sub(startTime))
+			break
+		}
+	}
+	return
+}
+
+// shouldSuppress returns whether to skip packing the block of the flow, when empty block suppression is on.
+// The block is not skipped if it has txs or activates forks.
+func (n *Node) shouldSuppress(flow *packer.Flow) bool {
+	if !n.suppressEmptyBlocks || !flow.IsEmpty() {
+		return false
+	}
+	num := flow.Number()
+	return n.forkConfig.FlagsAt(num) == n.forkConfig.FlagsAt(num-1)
+}
+
 func (n *Node) pack(flow *packer.Flow) (err error) {
 	txs := n.txPool.Executables()
 	var txsToRemove []*tx.Transaction
 	defer func() {
+		if err == errEmptyBlockSuppressed {
+			metricConsensusSlotsMissed().AddWithLabel(1, map[string]string{"reason": "empty_block_policy"})
+			return
+		}
 		if err == nil {
 			for _, tx := range txsToRemove {
 				n.txPool.Remove(tx.Hash(), tx.ID())
 			}
 			metricBlockProcessedCount().AddWithLabel(1, map[string]string{"type": "proposed", "success": "true"})
+			metricConsensusBlocks().AddWithLabel(1, map[string]string{"source": "produced"})
 		} else {
 			metricBlockProcessedCount().AddWithLabel(1, map[string]string{"type": "proposed", "success": "false"})
 		}
@@ -127,17 +178,10 @@ func (n *Node) pack(flow *packer.Flow) (err error) {
 			oldBest    = n.repo.BestBlockSummary()
 		)
 
-		// adopt txs
-		for _, tx := range txs {
-			if err := flow.Adopt(tx); err != nil {
-				if packer.IsGasLimitReached(err) {
-					break
-				}
-				if packer.IsTxNotAdoptableNow(err) {
-					continue
-				}
-				txsToRemove = append(txsToRemove, tx)
-			}
+		txsToRemove = n.adoptTxs(flow, txs, time.Now())
+
+		if n.shouldSuppress(flow) {
+			return errEmptyBlockSuppressed
 		}
 
 		var shouldVote bool
@@ -154,6 +198,7 @@ func (n *Node) pack(flow *packer.Flow) (err error) {
 		if err != nil {
 			return errors.Wrap(err, "failed to pack block")
 		}
+		n.checkDoubleProposal(newBlock.Header())
 		execElapsed := mclock.Now() - startTime
 
 		// write logs
@@ -172,6 +217,9 @@ func (n *Node) pack(flow *packer.Flow) (err error) {
 		if err := n.repo.AddBlock(newBlock, receipts, conflicts); err != nil {
 			return errors.Wrap(err, "add block")
 		}
+		if err := stage.RecordChangeset(); err != nil {
+			return errors.Wrap(err, "record changeset")
+		}
 
 		// commit block in bft engine
 		if newBlock.Header().Number() >= n.forkConfig.FINALITY {
@@ -194,6 +242,7 @@ func (n *Node) pack(flow *packer.Flow) (err error) {
 		}
 
 		n.processFork(newBlock, oldBest.Header.ID())
+		n.checkEndorsement()
 		commitElapsed := mclock.Now() - startTime - execElapsed
 
 		n.comm.BroadcastBlock(newBlock)
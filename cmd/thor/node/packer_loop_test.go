@@ -0,0 +1,283 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package node
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/comm"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/packer"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/txpool"
+	"github.com/vechain/thor/v2/vm"
+)
+
+func newTestNode(t *testing.T, accounts []genesis.DevAccount, proposer genesis.DevAccount) *Node {
+	return newTestNodeWithPool(t, accounts, proposer, txpool.Options{
+		Limit:           100,
+		LimitPerAccount: 16,
+		MaxLifetime:     time.Hour,
+	})
+}
+
+func newTestNodeWithPool(t *testing.T, accounts []genesis.DevAccount, proposer genesis.DevAccount, poolOptions txpool.Options) *Node {
+	thorChain, err := createChain(muxdb.NewMem(), accounts)
+	require.NoError(t, err)
+	// the test chain reports no forks, take the ones of its genesis
+	forkConfig := testForkConfig()
+
+	engine, err := bft.NewEngine(thorChain.Repo(), thorChain.Database(), forkConfig, proposer.Address)
+	require.NoError(t, err)
+
+	pool := txpool.New(thorChain.Repo(), thorChain.Stater(), poolOptions)
+	t.Cleanup(pool.Close)
+	communicator := comm.New(thorChain.Repo(), pool)
+	t.Cleanup(communicator.Stop)
+
+	return New(
+		&Master{PrivateKey: proposer.PrivateKey},
+		thorChain.Repo(),
+		engine,
+		thorChain.Stater(),
+		nil,
+		pool,
+		"",
+		communicator,
+		10_000_000,
+		true,
+		forkConfig,
+	)
+}
+
+func TestSuppressEmptyBlocks(t *testing.T) {
+	accounts := genesis.DevAccounts()[:2]
+	a := newTestNode(t, accounts, accounts[0])
+	b := newTestNode(t, accounts, accounts[1])
+	a.SetSuppressEmptyBlocks(true)
+	b.SetSuppressEmptyBlocks(true)
+
+	schedule := func(n *Node, now uint64) *packer.Flow {
+		flow, err := n.packer.Schedule(n.repo.BestBlockSummary(), now)
+		require.NoError(t, err)
+		return flow
+	}
+	// relay the best block of a node to the other, which fully validates it
+	relay := func(from, to *Node) {
+		blk, err := from.repo.GetBlock(from.repo.BestBlockSummary().Header.ID())
+		require.NoError(t, err)
+		isTrunk, err := to.processBlock(blk, &blockStats{})
+		require.NoError(t, err)
+		assert.True(t, isTrunk)
+		assert.Equal(t, blk.Header().ID(), to.repo.BestBlockSummary().Header.ID())
+	}
+	best := func() *Node {
+		require.Equal(t, a.repo.BestBlockSummary().Header.ID(), b.repo.BestBlockSummary().Header.ID())
+		return a
+	}
+
+	// blocks activating forks are packed even if empty
+	now := a.repo.GenesisBlock().Header().Timestamp()
+	require.NoError(t, a.pack(schedule(a, now)))
+	relay(a, b)
+	require.NoError(t, b.pack(schedule(b, now)))
+	relay(b, a)
+	assert.Equal(t, uint32(2), best().repo.BestBlockSummary().Header.Number())
+
+	// idle period
+	missed := counterValue(t, "thor_consensus_packer_slot_missed_count", "reason", "empty_block_policy")
+	idleStart := best().repo.BestBlockSummary().Header
+	for i := 0; i < 10; i++ {
+		now += thor.BlockInterval
+		for _, n := range []*Node{a, b} {
+			assert.Equal(t, errEmptyBlockSuppressed, n.pack(schedule(n, now)))
+		}
+	}
+	assert.Equal(t, idleStart.ID(), best().repo.BestBlockSummary().Header.ID())
+	assert.Equal(t, missed+20, counterValue(t, "thor_consensus_packer_slot_missed_count", "reason", "empty_block_policy"))
+
+	// busy period, the block is produced at a slot later than the skipped ones
+	trx := tx.MustSign(new(tx.Builder).
+		ChainTag(a.repo.ChainTag()).
+		Clause(tx.NewClause(&accounts[1].Address)).
+		Gas(21000).
+		Expiration(1000).
+		Nonce(1).
+		Build(), accounts[0].PrivateKey)
+
+	flow := schedule(a, now)
+	require.NoError(t, flow.Adopt(trx))
+	assert.False(t, a.shouldSuppress(flow))
+	blk, stage, receipts, err := flow.Pack(accounts[0].PrivateKey, 0, false)
+	require.NoError(t, err)
+	_, err = stage.Commit()
+	require.NoError(t, err)
+	require.NoError(t, a.repo.AddBlock(blk, receipts, 0))
+	require.NoError(t, a.repo.SetBestBlockID(blk.Header().ID()))
+	relay(a, b)
+
+	assert.Equal(t, idleStart.Number()+1, blk.Header().Number())
+	assert.True(t, blk.Header().Timestamp() >= now)
+	assert.True(t, blk.Header().TotalScore() > idleStart.TotalScore())
+
+	// idle again
+	now = blk.Header().Timestamp()
+	for i := 0; i < 5; i++ {
+		now += thor.BlockInterval
+		for _, n := range []*Node{a, b} {
+			assert.Equal(t, errEmptyBlockSuppressed, n.pack(schedule(n, now)))
+		}
+	}
+	assert.Equal(t, blk.Header().ID(), best().repo.BestBlockSummary().Header.ID())
+
+	// empty blocks are packed once suppression is off, and the chain goes on
+	b.SetSuppressEmptyBlocks(false)
+	require.NoError(t, b.pack(schedule(b, now)))
+	relay(b, a)
+	assert.Equal(t, blk.Header().Number()+1, best().repo.BestBlockSummary().Header.Number())
+}
+
+func TestSuppressEmptyBlocksLongIdle(t *testing.T) {
+	accounts := genesis.DevAccounts()[:1]
+	n := newTestNodeWithPool(t, accounts, accounts[0], txpool.Options{
+		Limit:           100,
+		LimitPerAccount: 16,
+		MaxLifetime:     time.Hour,
+		IgnoreStaleHead: true,
+	})
+	n.SetSuppressEmptyBlocks(true)
+
+	schedule := func(now uint64) *packer.Flow {
+		flow, err := n.packer.Schedule(n.repo.BestBlockSummary(), now)
+		require.NoError(t, err)
+		return flow
+	}
+
+	// idle for much longer than the pool takes a head block as synced
+	now := n.repo.GenesisBlock().Header().Timestamp()
+	for i := 0; i < 20; i++ {
+		now += thor.BlockInterval
+		if err := n.pack(schedule(now)); err != nil {
+			assert.Equal(t, errEmptyBlockSuppressed, err)
+		}
+	}
+	head := n.repo.BestBlockSummary().Header
+	assert.True(t, now-head.Timestamp() > thor.BlockInterval*6)
+
+	// a tx sent to the pool still becomes executable and gets packed
+	trx := tx.MustSign(new(tx.Builder).
+		ChainTag(n.repo.ChainTag()).
+		Clause(tx.NewClause(&accounts[0].Address)).
+		Gas(21000).
+		Expiration(1000).
+		Nonce(1).
+		Build(), accounts[0].PrivateKey)
+	require.NoError(t, n.txPool.AddLocal(trx))
+	require.Eventually(t, func() bool { return len(n.txPool.Executables()) == 1 }, 5*time.Second, 100*time.Millisecond)
+
+	require.NoError(t, n.pack(schedule(now)))
+	blk, err := n.repo.GetBlock(n.repo.BestBlockSummary().Header.ID())
+	require.NoError(t, err)
+	assert.Equal(t, head.Number()+1, blk.Header().Number())
+	require.Len(t, blk.Transactions(), 1)
+	assert.Equal(t, trx.ID(), blk.Transactions()[0].ID())
+}
+
+func TestMaxBlockBuildTime(t *testing.T) {
+	accounts := genesis.DevAccounts()[:1]
+	n := newTestNode(t, accounts, accounts[0])
+
+	assert.Error(t, n.SetMaxBlockBuildTime(0))
+	assert.Error(t, n.SetMaxBlockBuildTime(time.Duration(thor.BlockInterval)*time.Second))
+	require.NoError(t, n.SetMaxBlockBuildTime(time.Millisecond))
+
+	// contract creations looping until out of gas
+	loop := []byte{byte(vm.JUMPDEST), byte(vm.PUSH1), 0, byte(vm.JUMP)}
+	const txGas = 1_000_000
+	txs := make(tx.Transactions, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		txs = append(txs, tx.MustSign(new(tx.Builder).
+			ChainTag(n.repo.ChainTag()).
+			Clause(tx.NewClause(nil).WithData(loop)).
+			Gas(txGas).
+			Expiration(1000).
+			Nonce(uint64(i)).
+			Build(), accounts[0].PrivateKey))
+	}
+
+	schedule := func(now uint64) *packer.Flow {
+		flow, err := n.packer.Schedule(n.repo.BestBlockSummary(), now)
+		require.NoError(t, err)
+		return flow
+	}
+
+	start := time.Now()
+	flow := schedule(uint64(start.Unix()))
+	assert.Empty(t, n.adoptTxs(flow, txs, start))
+	blk, _, _, err := flow.Pack(accounts[0].PrivateKey, 0, false)
+	require.NoError(t, err)
+	elapsed := time.Since(start)
+
+	assert.True(t, elapsed < n.maxBlockBuildTime+500*time.Millisecond, "elapsed %v", elapsed)
+	// stopped before the gas limit is reached
+	assert.NotEmpty(t, blk.Transactions())
+	assert.Less(t, len(blk.Transactions()), int(blk.Header().GasLimit()/txGas))
+
+	// bounded by the time of the block, which is long past at the genesis timestamp
+	require.NoError(t, n.SetMaxBlockBuildTime(9*time.Second))
+	flow = schedule(n.repo.GenesisBlock().Header().Timestamp())
+	assert.Empty(t, n.adoptTxs(flow, txs, time.Now()))
+	blk, _, _, err = flow.Pack(accounts[0].PrivateKey, 0, false)
+	require.NoError(t, err)
+	assert.Len(t, blk.Transactions(), 1)
+}
+
+func TestCheckEndorsement(t *testing.T) {
+	accounts := genesis.DevAccounts()[:2]
+	n := newTestNode(t, accounts, accounts[0])
+
+	// mint a block transferring VET, the master is its own endorsor
+	transfer := func(from, to genesis.DevAccount, amount *big.Int, nonce uint64) {
+		best := n.repo.BestBlockSummary()
+		flow, err := n.packer.Mock(best, best.Header.Timestamp()+thor.BlockInterval, best.Header.GasLimit())
+		require.NoError(t, err)
+		require.NoError(t, flow.Adopt(tx.MustSign(new(tx.Builder).
+			ChainTag(n.repo.ChainTag()).
+			Clause(tx.NewClause(&to.Address).WithValue(amount)).
+			Gas(21000).
+			Expiration(1000).
+			Nonce(nonce).
+			Build(), from.PrivateKey)))
+		blk, stage, receipts, err := flow.Pack(accounts[0].PrivateKey, 0, false)
+		require.NoError(t, err)
+		_, err = stage.Commit()
+		require.NoError(t, err)
+		require.NoError(t, n.repo.AddBlock(blk, receipts, 0))
+		require.NoError(t, n.repo.SetBestBlockID(blk.Header().ID()))
+		n.checkEndorsement()
+	}
+
+	assert.Nil(t, n.masterEndorsed)
+	n.checkEndorsement()
+	require.NotNil(t, n.masterEndorsed)
+	assert.True(t, *n.masterEndorsed)
+
+	// leave less than the endorsement
+	balance, _ := new(big.Int).SetString("1000000000000000000000000000", 10)
+	kept := new(big.Int).Sub(thor.InitialProposerEndorsement, big.NewInt(1))
+	transfer(accounts[0], accounts[1], new(big.Int).Sub(balance, kept), 1)
+	assert.False(t, *n.masterEndorsed)
+
+	transfer(accounts[1], accounts[0], big.NewInt(1), 2)
+	assert.True(t, *n.masterEndorsed)
+}
@@ -28,25 +28,29 @@ var logger = log.WithContext("pkg", "optimizer")
 const (
 	propsStoreName = "optimizer.props"
 	statusKey      = "status"
+	retainedKey    = "retained"
 )
 
 // Optimizer is a background task to optimize tries.
 type Optimizer struct {
-	db     *muxdb.MuxDB
-	repo   *chain.Repository
-	ctx    context.Context
-	cancel func()
-	goes   co.Goes
+	db       *muxdb.MuxDB
+	repo     *chain.Repository
+	retained []uint32
+	ctx      context.Context
+	cancel   func()
+	goes     co.Goes
 }
 
-// New creates and starts the optimizer.
-func New(db *muxdb.MuxDB, repo *chain.Repository, prune bool) *Optimizer {
+// New creates and starts the optimizer. The states of the retained blocks are kept
+// accessible after the tries pruned.
+func New(db *muxdb.MuxDB, repo *chain.Repository, prune bool, retained []uint32) *Optimizer {
 	ctx, cancel := context.WithCancel(context.Background())
 	o := &Optimizer{
-		db:     db,
-		repo:   repo,
-		ctx:    ctx,
-		cancel: cancel,
+		db:       db,
+		repo:     repo,
+		retained: retained,
+		ctx:      ctx,
+		cancel:   cancel,
 	}
 	o.goes.Go(func() {
 		if err := o.loop(prune); err != nil {
@@ -82,6 +86,15 @@ func (p *Optimizer) loop(prune bool) error {
 	if err := status.Load(propsStore); err != nil {
 		return errors.Wrap(err, "load status")
 	}
+	retained, err := loadRetainedStates(propsStore)
+	if err != nil {
+		return errors.Wrap(err, "load retained states")
+	}
+	for _, num := range p.retained {
+		if _, ok := retained[num]; !ok && num < status.PruneBase {
+			logger.Warn("unable to retain state, already pruned", "number", num)
+		}
+	}
 
 	for {
 		// select target
@@ -98,6 +111,22 @@ func (p *Optimizer) loop(prune bool) error {
 			return errors.Wrap(err, "dump state trie leaves")
 		}
 
+		// retain states before pruned, including the ones not pruned yet when the node started retaining
+		for _, num := range p.retained {
+			if _, ok := retained[num]; ok || num < status.PruneBase || num >= target {
+				continue
+			}
+			rs, err := p.retainState(targetChain, num)
+			if err != nil {
+				return errors.Wrap(err, "retain state")
+			}
+			retained[num] = rs
+			if err := saveRetainedStates(propsStore, retained); err != nil {
+				return errors.Wrap(err, "save retained states")
+			}
+			logger.Info("retained state", "number", rs.Number, "id", rs.ID, "root", rs.StateRoot)
+		}
+
 		// prune index/account/storage tries
 		if prune && target > pruneReserved {
 			if pruneTarget := target - pruneReserved; pruneTarget >= status.PruneBase+prunePeriod {
@@ -180,6 +209,36 @@ func (p *Optimizer) dumpStateLeaves(targetChain *chain.Chain, base, target uint3
 	return nil
 }
 
+// retainState dumps all nodes of the account/storage tries of the block into the retained space.
+func (p *Optimizer) retainState(targetChain *chain.Chain, num uint32) (*RetainedState, error) {
+	summary, err := targetChain.GetBlockSummary(num)
+	if err != nil {
+		return nil, err
+	}
+	accTrie := p.db.NewTrie(state.AccountTrieName, summary.Header.StateRoot(), summary.Header.Number(), summary.Conflicts)
+	accTrie.SetNoFillCache(true)
+
+	var sTries []*muxdb.Trie
+	if err := accTrie.DumpRetainedNodes(p.ctx, func(leaf *trie.Leaf) {
+		if sTrie := p.newStorageTrieIfUpdated(leaf, 0); sTrie != nil {
+			sTries = append(sTries, sTrie)
+		}
+	}); err != nil {
+		return nil, err
+	}
+	for _, sTrie := range sTries {
+		sTrie.SetNoFillCache(true)
+		if err := sTrie.DumpRetainedNodes(p.ctx, nil); err != nil {
+			return nil, err
+		}
+	}
+	return &RetainedState{
+		Number:    num,
+		ID:        summary.Header.ID(),
+		StateRoot: summary.Header.StateRoot(),
+	}, nil
+}
+
 // dumpTrieNodes dumps index/account/storage trie nodes committed within [base, target] into deduped space.
 func (p *Optimizer) dumpTrieNodes(targetChain *chain.Chain, base, target uint32) error {
 	summary, err := targetChain.GetBlockSummary(target - 1)
@@ -218,7 +277,7 @@ func (p *Optimizer) dumpTrieNodes(targetChain *chain.Chain, base, target uint32)
 	return nil
 }
 
-// pruneTries prunes index/account/storage tries in the range [base, target).
+// pruneTries prunes index/account/storage tries in the range [base, target), and the changesets below target.
 func (p *Optimizer) pruneTries(targetChain *chain.Chain, base, target uint32) error {
 	if err := p.dumpTrieNodes(targetChain, base, target); err != nil {
 		return errors.Wrap(err, "dump trie nodes")
@@ -232,6 +291,10 @@ func (p *Optimizer) pruneTries(targetChain *chain.Chain, base, target uint32) er
 	if err := p.db.CleanTrieHistory(p.ctx, cleanBase, target); err != nil {
 		return errors.Wrap(err, "clean trie history")
 	}
+	// the changesets are of no use once the states are pruned
+	if err := state.NewStater(p.db).PruneChangesets(p.ctx, target); err != nil {
+		return errors.Wrap(err, "prune changesets")
+	}
 	return nil
 }
 
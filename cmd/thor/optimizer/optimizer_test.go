@@ -109,7 +109,7 @@ func TestNewOptimizer(t *testing.T) {
 	b0, _, _, _ := gene.Build(stater)
 	repo, _ := chain.NewRepository(db, b0)
 
-	op := New(db, repo, false)
+	op := New(db, repo, false, nil)
 	op.Stop()
 }
 
@@ -176,7 +176,7 @@ func TestProcessDump(t *testing.T) {
 
 	repo.SetBestBlockID(parentID)
 
-	op := New(db, repo, false)
+	op := New(db, repo, false, nil)
 	op.Stop()
 
 	var s status
@@ -198,7 +198,7 @@ func TestProcessDump(t *testing.T) {
 	}
 	repo.SetBestBlockID(parentID)
 
-	op = New(db, repo, true)
+	op = New(db, repo, true, nil)
 	op.Stop()
 
 	assert.Nil(t, s.Load(op.db.NewStore(propsStoreName)))
@@ -340,3 +340,84 @@ func TestDumpAndPrune(t *testing.T) {
 
 	closeDB()
 }
+
+func TestRetainState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.db")
+	opts := muxdb.Options{
+		TrieNodeCacheSizeMB:        16,
+		TrieRootCacheCapacity:      16,
+		TrieCachedNodeTTL:          30,
+		TrieLeafBankSlotCapacity:   16,
+		TrieDedupedPartitionFactor: math.MaxUint32,
+		TrieWillCleanHistory:       true,
+		OpenFilesCacheCapacity:     16,
+		ReadCacheMB:                16,
+		WriteBufferMB:              16,
+		TrieHistPartitionFactor:    1,
+	}
+	db, err := muxdb.Open(path, &opts)
+	assert.Nil(t, err)
+
+	stater := state.NewStater(db)
+	b0, _, _, _ := genesis.NewDevnet().Build(stater)
+	repo, _ := chain.NewRepository(db, b0)
+	acc := thor.BytesToAddress([]byte("account"))
+	key := thor.BytesToBytes32([]byte("key"))
+
+	// each block changes the balance and storage of the account
+	roots := []thor.Bytes32{b0.Header().StateRoot()}
+	parentID := b0.Header().ID()
+	for i := uint32(1); i <= 10; i++ {
+		st := stater.NewState(roots[i-1], i-1, 0, 0)
+		st.SetBalance(acc, big.NewInt(int64(i)))
+		st.SetStorage(acc, key, thor.BytesToBytes32([]byte{byte(i)}))
+		stage, err := st.Stage(i, 0)
+		assert.Nil(t, err)
+		root, err := stage.Commit()
+		assert.Nil(t, err)
+		roots = append(roots, root)
+
+		blk := newBlock(parentID, uint64(i)*2, root, nil)
+		assert.Nil(t, repo.AddBlock(blk, tx.Receipts{}, 0))
+		parentID = blk.Header().ID()
+	}
+	assert.Nil(t, repo.SetBestBlockID(parentID))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	op := &Optimizer{repo: repo, db: db, ctx: ctx, cancel: cancel}
+
+	rs, err := op.retainState(repo.NewBestChain(), 3)
+	assert.Nil(t, err)
+	id3, err := repo.NewBestChain().GetBlockID(3)
+	assert.Nil(t, err)
+	assert.Equal(t, &RetainedState{Number: 3, ID: id3, StateRoot: roots[3]}, rs)
+
+	// prune twice, the deduped nodes of the first pruning are replaced by the second one
+	assert.Nil(t, op.pruneTries(repo.NewBestChain(), 0, 6))
+	assert.Nil(t, op.pruneTries(repo.NewBestChain(), 6, 11))
+
+	// reopen to drop the caches
+	assert.Nil(t, db.Close())
+	db, err = muxdb.Open(path, &opts)
+	assert.Nil(t, err)
+	defer db.Close()
+	stater = state.NewStater(db)
+
+	st := stater.NewState(roots[3], 3, 0, 0)
+	balance, err := st.GetBalance(acc)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(3), balance)
+	value, err := st.GetStorage(acc, key)
+	assert.Nil(t, err)
+	assert.Equal(t, thor.BytesToBytes32([]byte{3}), value)
+
+	// not retained
+	_, err = stater.NewState(roots[4], 4, 0, 0).GetBalance(acc)
+	assert.NotNil(t, err)
+
+	// the latest pruned state is kept as ever
+	balance, err = stater.NewState(roots[10], 10, 0, 0).GetBalance(acc)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(10), balance)
+}
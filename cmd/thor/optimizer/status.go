@@ -7,8 +7,10 @@ package optimizer
 
 import (
 	"encoding/json"
+	"sort"
 
 	"github.com/vechain/thor/v2/kv"
+	"github.com/vechain/thor/v2/thor"
 )
 
 type status struct {
@@ -34,3 +36,43 @@ func (s *status) Save(putter kv.Putter) error {
 	}
 	return putter.Put([]byte(statusKey), data)
 }
+
+// RetainedState is the state of a block kept accessible after the tries pruned,
+// it can be queried by the APIs at the revision of the block.
+type RetainedState struct {
+	Number    uint32
+	ID        thor.Bytes32
+	StateRoot thor.Bytes32
+}
+
+func loadRetainedStates(getter kv.Getter) (map[uint32]*RetainedState, error) {
+	data, err := getter.Get([]byte(retainedKey))
+	if err != nil && !getter.IsNotFound(err) {
+		return nil, err
+	}
+	retained := make(map[uint32]*RetainedState)
+	if len(data) == 0 {
+		return retained, nil
+	}
+	var list []*RetainedState
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	for _, rs := range list {
+		retained[rs.Number] = rs
+	}
+	return retained, nil
+}
+
+func saveRetainedStates(putter kv.Putter, retained map[uint32]*RetainedState) error {
+	list := make([]*RetainedState, 0, len(retained))
+	for _, rs := range retained {
+		list = append(list, rs)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Number < list[j].Number })
+	data, err := json.Marshal(list)
+	if err != nil {
+		return err
+	}
+	return putter.Put([]byte(retainedKey), data)
+}
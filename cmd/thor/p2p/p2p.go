@@ -120,6 +120,16 @@ func (p *P2P) Enode() string {
 	return p.enode
 }
 
+// PeersStats returns the stats of the connected peers.
+func (p *P2P) PeersStats() []*comm.PeerStats {
+	return p.comm.PeersStats()
+}
+
+// NATStatus returns the status of the port mapping.
+func (p *P2P) NATStatus() p2psrv.NATStatus {
+	return p.p2pSrv.NATStatus()
+}
+
 func dedupNodeSlice(slice1, slice2 p2psrv.Nodes) p2psrv.Nodes {
 	foundMap := map[string]bool{}
 	var dedupedSlice p2psrv.Nodes
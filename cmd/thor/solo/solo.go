@@ -178,6 +178,9 @@ func (s *Solo) packing(pendingTxs tx.Transactions, onDemand bool) error {
 	if err := s.repo.AddBlock(b, receipts, 0); err != nil {
 		return errors.WithMessage(err, "commit block")
 	}
+	if err := stage.RecordChangeset(); err != nil {
+		return errors.WithMessage(err, "record changeset")
+	}
 	realElapsed := mclock.Now() - startTime
 
 	if !s.skipLogs {
@@ -9,6 +9,7 @@ import (
 	"github.com/vechain/thor/v2/bft"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/comm"
+	"github.com/vechain/thor/v2/p2psrv"
 	"github.com/vechain/thor/v2/thor"
 )
 
@@ -21,6 +22,11 @@ func (comm *Communicator) PeersStats() []*comm.PeerStats {
 	return nil
 }
 
+// NATStatus returns unknown status since solo doesn't join p2p network.
+func (comm *Communicator) NATStatus() p2psrv.NATStatus {
+	return p2psrv.NATStatus{State: p2psrv.NATUnknown}
+}
+
 // BFTEngine is a fake bft engine for solo.
 type BFTEngine struct {
 	finalized thor.Bytes32
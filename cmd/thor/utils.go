@@ -22,6 +22,8 @@ import (
 	"path/filepath"
 	"runtime"
 	"runtime/debug"
+	"sort"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"syscall"
@@ -33,13 +35,14 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	ethlog "github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/p2p/discover"
-	"github.com/ethereum/go-ethereum/p2p/nat"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/mattn/go-isatty"
 	"github.com/mattn/go-tty"
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/v2/api"
+	apidebug "github.com/vechain/thor/v2/api/debug"
 	"github.com/vechain/thor/v2/api/doc"
+	"github.com/vechain/thor/v2/api/subscriptions"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/cmd/thor/node"
 	"github.com/vechain/thor/v2/cmd/thor/p2p"
@@ -50,6 +53,7 @@ import (
 	"github.com/vechain/thor/v2/logdb"
 	"github.com/vechain/thor/v2/muxdb"
 	"github.com/vechain/thor/v2/p2psrv"
+	"github.com/vechain/thor/v2/packer"
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/tx"
@@ -276,7 +280,16 @@ func parseGenesisFile(filePath string) (*genesis.Genesis, thor.ForkConfig, error
 	return customGen, forkConfig, nil
 }
 
-func makeAPIConfig(ctx *cli.Context, logAPIRequests *atomic.Bool, soloMode bool) api.Config {
+func makeAPIConfig(ctx *cli.Context, logAPIRequests *atomic.Bool, soloMode bool) (api.Config, error) {
+	compression, err := api.ParseCompression(ctx.String(apiResponseCompressionFlag.Name))
+	if err != nil {
+		return api.Config{}, errors.Wrap(err, "parse -api-response-compression flag")
+	}
+	finality := ctx.String(apiDefaultFinalityFlag.Name)
+	if err := api.ValidateDefaultFinality(finality); err != nil {
+		return api.Config{}, errors.Wrap(err, "parse -api-default-finality flag")
+	}
+
 	return api.Config{
 		AllowedOrigins:    ctx.String(apiCorsFlag.Name),
 		BacktraceLimit:    uint32(ctx.Uint64(apiBacktraceLimitFlag.Name)),
@@ -289,8 +302,56 @@ func makeAPIConfig(ctx *cli.Context, logAPIRequests *atomic.Bool, soloMode bool)
 		LogsLimit:         ctx.Uint64(apiLogsLimitFlag.Name),
 		AllowedTracers:    parseTracerList(strings.TrimSpace(ctx.String(allowedTracersFlag.Name))),
 		EnableDeprecated:  ctx.Bool(apiEnableDeprecatedFlag.Name),
+		EnableTxPool:      ctx.Bool(apiTxPoolFlag.Name),
+		ChecksumAddresses: ctx.Bool(apiChecksumAddressesFlag.Name),
 		SoloMode:          soloMode,
+		TracerMetrics:     ctx.Bool(apiTracerMetricsFlag.Name),
+		Subscriptions: subscriptions.Options{
+			PingInterval: time.Duration(ctx.Uint64(apiSubscriptionsPingIntervalFlag.Name)) * time.Second,
+			MaxConns:     ctx.Int(apiSubscriptionsMaxConnsFlag.Name),
+		},
+		TraceJobs: apidebug.JobOptions{
+			Workers:        ctx.Int(apiTraceJobsWorkersFlag.Name),
+			QueueSize:      ctx.Int(apiTraceJobsQueueSizeFlag.Name),
+			TTL:            time.Duration(ctx.Uint64(apiTraceJobsTTLFlag.Name)) * time.Second,
+			MaxResultBytes: ctx.Int(apiTraceJobsMaxResultMBFlag.Name) * 1024 * 1024,
+			Timeout:        time.Duration(ctx.Uint64(apiTraceJobsTimeoutFlag.Name)) * time.Second,
+		},
+		DisableCompression: ctx.Bool(apiDisableCompressionFlag.Name),
+		Compression:        compression,
+		DefaultFinality:    finality,
+		DevSigner:          soloMode && ctx.Bool(devSignerFlag.Name),
+	}, nil
+}
+
+// retainedStates returns the blocks whose states are kept accessible by the pruner,
+// i.e. the fork activation blocks and their parents if enabled.
+func retainedStates(ctx *cli.Context, forkConfig thor.ForkConfig) []uint32 {
+	if !ctx.Bool(prunerRetainForkStatesFlag.Name) {
+		return nil
+	}
+	set := make(map[uint32]struct{})
+	for _, num := range []uint32{
+		forkConfig.VIP191,
+		forkConfig.ETH_CONST,
+		forkConfig.BLOCKLIST,
+		forkConfig.ETH_IST,
+		forkConfig.VIP214,
+		forkConfig.FINALITY,
+	} {
+		// the genesis state is always kept
+		if num == 0 || num == math.MaxUint32 {
+			continue
+		}
+		set[num-1] = struct{}{}
+		set[num] = struct{}{}
+	}
+	nums := make([]uint32, 0, len(set))
+	for num := range set {
+		nums = append(nums, num)
 	}
+	sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
+	return nums
 }
 
 func makeConfigDir(ctx *cli.Context) (string, error) {
@@ -336,6 +397,7 @@ func openMainDB(ctx *cli.Context, dir string) (*muxdb.MuxDB, error) {
 		TrieLeafBankSlotCapacity:   256,
 		TrieDedupedPartitionFactor: math.MaxUint32,
 		TrieWillCleanHistory:       !ctx.Bool(disablePrunerFlag.Name),
+		TrieCommitBatchSize:        int(ctx.Uint(trieCommitBatchSizeFlag.Name)),
 		OpenFilesCacheCapacity:     fdCache,
 		ReadCacheMB:                256, // rely on os page cache other than huge db read cache.
 		WriteBufferMB:              128,
@@ -360,6 +422,7 @@ func openMainDB(ctx *cli.Context, dir string) (*muxdb.MuxDB, error) {
 	if err != nil {
 		return nil, errors.Wrapf(err, "open main database [%v]", path)
 	}
+	db.SetTrieCleanIOLimit(int64(ctx.Uint(prunerIOLimitFlag.Name)) * 1024 * 1024)
 	return db, nil
 }
 
@@ -415,8 +478,21 @@ func openLogDB(dir string) (*logdb.LogDB, error) {
 	return db, nil
 }
 
-func initChainRepository(gene *genesis.Genesis, mainDB *muxdb.MuxDB, logDB *logdb.LogDB) (*chain.Repository, error) {
-	genesisBlock, genesisEvents, genesisTransfers, err := gene.Build(state.NewStater(mainDB))
+// newStater creates the stater of the blocks added to the chain, recording what the flags require.
+func newStater(ctx *cli.Context, mainDB *muxdb.MuxDB) *state.Stater {
+	stater := state.NewStater(mainDB)
+	stater.SetChangesetRecording(ctx.Bool(stateDiffFlag.Name))
+	stater.SetPreimageRecording(ctx.Bool(accountPreimagesFlag.Name))
+	return stater
+}
+
+func initChainRepository(
+	gene *genesis.Genesis,
+	mainDB *muxdb.MuxDB,
+	stater *state.Stater,
+	logDB *logdb.LogDB,
+) (*chain.Repository, error) {
+	genesisBlock, genesisEvents, genesisTransfers, err := gene.Build(stater)
 	if err != nil {
 		return nil, errors.Wrap(err, "build genesis block")
 	}
@@ -522,7 +598,7 @@ func newP2PCommunicator(ctx *cli.Context, repo *chain.Repository, txPool *txpool
 		return nil, errors.Wrap(err, "load or generate P2P key")
 	}
 
-	userNAT, err := nat.Parse(ctx.String(natFlag.Name))
+	userNAT, err := p2psrv.ParseNAT(ctx.String(natFlag.Name))
 	if err != nil {
 		cli.ShowAppHelp(ctx)
 		return nil, errors.Wrap(err, "parse -nat flag")
@@ -729,6 +805,42 @@ func readIntFromUInt64Flag(val uint64) (int, error) {
 	return i, nil
 }
 
+// makeGasLimitAlgorithm creates the block gas limit algorithm selected by the flag. The gains of
+// the pid controller can be overridden by the env variables THOR_GAS_LIMIT_PID_KP, _KI and _KD.
+func makeGasLimitAlgorithm(ctx *cli.Context, instanceDir string) (packer.GasLimitAlgorithm, error) {
+	switch name := ctx.String(blockGasLimitAlgorithmFlag.Name); name {
+	case "simple":
+		return packer.SimpleGasLimit{}, nil
+	case "pid":
+		params := packer.DefaultPIDParams
+		for env, gain := range map[string]*float64{
+			"THOR_GAS_LIMIT_PID_KP": &params.Kp,
+			"THOR_GAS_LIMIT_PID_KI": &params.Ki,
+			"THOR_GAS_LIMIT_PID_KD": &params.Kd,
+		} {
+			val := os.Getenv(env)
+			if val == "" {
+				continue
+			}
+			f, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return nil, errors.Wrap(err, env)
+			}
+			*gain = f
+		}
+		return packer.NewPIDGasLimit(params, filepath.Join(instanceDir, "gas-limit-pid.json"))
+	default:
+		return nil, fmt.Errorf("invalid block gas limit algorithm %q, should be simple or pid", name)
+	}
+}
+
+func readPriceBumpPct(val uint) (uint8, error) {
+	if val < 1 || val > 100 {
+		return 0, fmt.Errorf("invalid value %d, should be in range [1, 100]", val)
+	}
+	return uint8(val), nil
+}
+
 func parseTracerList(list string) []string {
 	inputs := strings.Split(list, ",")
 	tracers := make([]string, 0, len(inputs))
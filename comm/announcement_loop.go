@@ -83,6 +83,7 @@ func (c *Communicator) fetchBlockByID(peer *Peer, newBlockID thor.Bytes32) {
 		peer.logger.Debug("failed to decode block got by id", "err", err)
 		return
 	}
+	peer.countBlocks(1)
 
 	c.newBlockFeed.Send(&NewBlockEvent{
 		Block: &blk,
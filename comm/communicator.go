@@ -6,6 +6,7 @@
 package comm
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math"
@@ -35,6 +36,7 @@ type Communicator struct {
 	ctx            context.Context
 	cancel         context.CancelFunc
 	peerSet        *PeerSet
+	bans           *banList
 	syncedCh       chan struct{}
 	newBlockFeed   event.Feed
 	announcementCh chan *announcement
@@ -52,6 +54,7 @@ func New(repo *chain.Repository, txPool *txpool.TxPool) *Communicator {
 		ctx:            ctx,
 		cancel:         cancel,
 		peerSet:        newPeerSet(),
+		bans:           newBanList(),
 		syncedCh:       make(chan struct{}),
 		announcementCh: make(chan *announcement),
 	}
@@ -94,11 +97,7 @@ func (c *Communicator) Sync(ctx context.Context, handler HandleBlockStream) {
 			logger.Debug("synchronization start")
 
 			best := c.repo.BestBlockSummary().Header
-			// choose peer which has the head block with higher total score
-			peer := c.peerSet.Slice().Find(func(peer *Peer) bool {
-				_, totalScore := peer.Head()
-				return totalScore >= best.TotalScore()
-			})
+			peer := c.pickSyncPeer(best.TotalScore())
 			if peer == nil {
 				if c.peerSet.Len() < 3 {
 					logger.Debug("no suitable peer to sync")
@@ -107,7 +106,7 @@ func (c *Communicator) Sync(ctx context.Context, handler HandleBlockStream) {
 				// if more than 3 peers connected, we are assumed to be the best
 				logger.Debug("synchronization done, best assumed")
 			} else {
-				if err := download(ctx, c.repo, peer, best.Number(), handler); err != nil {
+				if err := c.syncWith(ctx, peer, best.Number(), handler); err != nil {
 					peer.logger.Debug("synchronization failed", "err", err)
 					break
 				}
@@ -126,6 +125,42 @@ func (c *Communicator) Sync(ctx context.Context, handler HandleBlockStream) {
 	}
 }
 
+// pickSyncPeer chooses the peer to sync with, among the peers which have the head block with higher total score.
+// Peers not yet measured are tried first, so that every peer gets measured, then the ones with higher download
+// throughput are preferred.
+func (c *Communicator) pickSyncPeer(bestTotalScore uint64) *Peer {
+	peers := c.peerSet.Slice().Filter(func(peer *Peer) bool {
+		if c.bans.IsBanned(peer.ID()) {
+			return false
+		}
+		_, totalScore := peer.Head()
+		return totalScore >= bestTotalScore
+	})
+	if len(peers) == 0 {
+		return nil
+	}
+	// peers are randomly permuted, so the ties are broken randomly
+	sort.SliceStable(peers, func(i, j int) bool {
+		ti, tj := peers[i].score.Throughput(), peers[j].score.Throughput()
+		if ti == 0 || tj == 0 {
+			return ti == 0 && tj != 0
+		}
+		return ti > tj
+	})
+	return peers[0]
+}
+
+// syncWith downloads blocks from the peer, and bans the peer if it keeps serving invalid data or timing out.
+func (c *Communicator) syncWith(ctx context.Context, peer *Peer, headNum uint32, handler HandleBlockStream) error {
+	err := download(ctx, c.repo, peer, headNum, handler)
+	if err != nil && peer.score.recordFailure(err) {
+		duration := c.bans.Ban(peer.ID())
+		peer.logger.Debug("peer banned", "duration", duration, "err", err)
+		peer.Disconnect(p2p.DiscUselessPeer)
+	}
+	return err
+}
+
 // Protocols returns all supported protocols.
 func (c *Communicator) Protocols() []*p2p.Protocol {
 	return []*p2p.Protocol{
@@ -170,6 +205,7 @@ func (c *Communicator) servePeer(p *p2p.Peer, rw p2p.MsgReadWriter) error {
 	var txsToSync txsToSync
 
 	return peer.Serve(func(msg *p2p.Msg, w func(interface{})) error {
+		peer.markSeen()
 		return c.handleRPC(peer, msg, w, &txsToSync)
 	}, proto.MaxMsgSize)
 }
@@ -186,6 +222,10 @@ func (c *Communicator) runPeer(peer *Peer) {
 		peer.logger.Debug("failed to get status", "err", err)
 		return
 	}
+	if c.bans.IsBanned(peer.ID()) {
+		peer.logger.Debug("failed to handshake", "err", "peer banned")
+		return
+	}
 	if status.GenesisBlockID != c.repo.GenesisBlock().Header().ID() {
 		peer.logger.Debug("failed to handshake", "err", "genesis id mismatch")
 		return
@@ -243,7 +283,9 @@ func (c *Communicator) BroadcastBlock(blk *block.Block) {
 		c.goes.Go(func() {
 			if err := proto.NotifyNewBlock(c.ctx, peer, blk); err != nil {
 				peer.logger.Debug("failed to broadcast new block", "err", err)
+				return
 			}
+			peer.countBlocks(1)
 		})
 	}
 
@@ -275,10 +317,43 @@ func (c *Communicator) PeersStats() []*PeerStats {
 			NetAddr:     peer.RemoteAddr().String(),
 			Inbound:     peer.Inbound(),
 			Duration:    uint64(time.Duration(peer.Duration()) / time.Second),
+			Throughput:  uint64(peer.score.Throughput()),
+			Violations:  peer.score.Violations(),
+			Timeouts:    peer.score.Timeouts(),
 		})
 	}
+	// the peer set is unordered, break ties by ID to keep the order stable
 	sort.Slice(stats, func(i, j int) bool {
-		return stats[i].Duration < stats[j].Duration
+		if stats[i].Duration != stats[j].Duration {
+			return stats[i].Duration < stats[j].Duration
+		}
+		return stats[i].PeerID < stats[j].PeerID
 	})
 	return stats
 }
+
+// PeersByLatency returns connection quality of all peers, sorted by average round-trip time.
+// Peers without round-trip time sampled are put at the end.
+func (c *Communicator) PeersByLatency() []*PeerInfo {
+	var infos []*PeerInfo
+	for _, peer := range c.peerSet.Slice() {
+		infos = append(infos, &PeerInfo{
+			ID:              peer.ID(),
+			Enode:           peer.Enode(),
+			AvgRTT:          peer.AvgRTT(),
+			LastSeen:        peer.LastSeen(),
+			BlocksExchanged: peer.BlocksExchanged(),
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].AvgRTT != infos[j].AvgRTT {
+			if infos[i].AvgRTT == 0 || infos[j].AvgRTT == 0 {
+				return infos[j].AvgRTT == 0
+			}
+			return infos[i].AvgRTT < infos[j].AvgRTT
+		}
+		// the peer set is unordered, break ties by ID to keep the order stable
+		return bytes.Compare(infos[i].ID[:], infos[j].ID[:]) < 0
+	})
+	return infos
+}
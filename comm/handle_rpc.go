@@ -49,6 +49,7 @@ func (c *Communicator) handleRPC(peer *Peer, msg *p2p.Msg, write func(interface{
 
 		peer.MarkBlock(newBlock.Header().ID())
 		peer.UpdateHead(newBlock.Header().ID(), newBlock.Header().TotalScore())
+		peer.countBlocks(1)
 		c.newBlockFeed.Send(&NewBlockEvent{Block: newBlock})
 		write(&struct{}{})
 	case proto.MsgNewBlockID:
@@ -85,6 +86,7 @@ func (c *Communicator) handleRPC(peer *Peer, msg *p2p.Msg, write func(interface{
 			raw, _ := rlp.EncodeToBytes(b)
 			result = append(result, rlp.RawValue(raw))
 		}
+		peer.countBlocks(len(result))
 		write(result)
 	case proto.MsgGetBlockIDByNumber:
 		var num uint32
@@ -125,6 +127,7 @@ func (c *Communicator) handleRPC(peer *Peer, msg *p2p.Msg, write func(interface{
 			num++
 			size += thor.StorageSize(len(raw))
 		}
+		peer.countBlocks(len(result))
 		write(result)
 	case proto.MsgGetTxs:
 		const maxTxSyncSize = 100 * 1024
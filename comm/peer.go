@@ -6,7 +6,9 @@
 package comm
 
 import (
+	"context"
 	"math/rand/v2"
+	"net"
 	"sync"
 	"time"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/p2p/discover"
 	lru "github.com/hashicorp/golang-lru"
+	"github.com/vechain/thor/v2/comm/proto"
 	"github.com/vechain/thor/v2/log"
 	"github.com/vechain/thor/v2/p2psrv/rpc"
 	"github.com/vechain/thor/v2/thor"
@@ -22,6 +25,7 @@ import (
 const (
 	maxKnownTxs    = 65536 // Maximum transactions IDs to keep in the known list (prevent DOS)
 	maxKnownBlocks = 1024  // Maximum block IDs to keep in the known list (prevent DOS)
+	maxRTTSamples  = 10    // Maximum round-trip time samples to keep for the rolling average
 )
 
 // Peer extends p2p.Peer with RPC integrated.
@@ -33,11 +37,18 @@ type Peer struct {
 	createdTime mclock.AbsTime
 	knownTxs    *lru.Cache
 	knownBlocks *lru.Cache
+	score       peerScore
 	head        struct {
 		sync.Mutex
 		id         thor.Bytes32
 		totalScore uint64
 	}
+	traffic struct {
+		sync.Mutex
+		rtts     []time.Duration
+		lastSeen time.Time
+		blocks   uint64
+	}
 }
 
 func newPeer(peer *p2p.Peer, rw p2p.MsgReadWriter) *Peer {
@@ -77,6 +88,76 @@ func (p *Peer) UpdateHead(id thor.Bytes32, totalScore uint64) {
 	}
 }
 
+// Call sends a call to the peer and waits for result. The round-trip time of calls
+// with small fixed-size results is sampled.
+func (p *Peer) Call(ctx context.Context, msgCode uint64, arg interface{}, result interface{}) error {
+	start := time.Now()
+	if err := p.RPC.Call(ctx, msgCode, arg, result); err != nil {
+		return err
+	}
+	p.markSeen()
+
+	switch msgCode {
+	case proto.MsgGetStatus, proto.MsgGetBlockIDByNumber:
+		p.addRTT(time.Since(start))
+	}
+	return nil
+}
+
+// addRTT adds a round-trip time sample, the oldest sample is dropped if the window is full.
+func (p *Peer) addRTT(rtt time.Duration) {
+	p.traffic.Lock()
+	defer p.traffic.Unlock()
+
+	if len(p.traffic.rtts) >= maxRTTSamples {
+		p.traffic.rtts = p.traffic.rtts[1:]
+	}
+	p.traffic.rtts = append(p.traffic.rtts, rtt)
+}
+
+// AvgRTT returns the average of recent round-trip time samples.
+func (p *Peer) AvgRTT() time.Duration {
+	p.traffic.Lock()
+	defer p.traffic.Unlock()
+
+	if len(p.traffic.rtts) == 0 {
+		return 0
+	}
+	var sum time.Duration
+	for _, rtt := range p.traffic.rtts {
+		sum += rtt
+	}
+	return sum / time.Duration(len(p.traffic.rtts))
+}
+
+// LastSeen returns the time of the latest message received from the peer.
+func (p *Peer) LastSeen() time.Time {
+	p.traffic.Lock()
+	defer p.traffic.Unlock()
+	return p.traffic.lastSeen
+}
+
+// BlocksExchanged returns count of blocks sent to and received from the peer.
+func (p *Peer) BlocksExchanged() uint64 {
+	p.traffic.Lock()
+	defer p.traffic.Unlock()
+	return p.traffic.blocks
+}
+
+// markSeen records a message received from the peer.
+func (p *Peer) markSeen() {
+	p.traffic.Lock()
+	defer p.traffic.Unlock()
+	p.traffic.lastSeen = time.Now()
+}
+
+// countBlocks adds n blocks to the count of blocks exchanged with the peer.
+func (p *Peer) countBlocks(n int) {
+	p.traffic.Lock()
+	defer p.traffic.Unlock()
+	p.traffic.blocks += uint64(n)
+}
+
 // MarkTransaction marks a transaction to known.
 func (p *Peer) MarkTransaction(hash thor.Bytes32) {
 	// that's 10~100 block intervals
@@ -105,6 +186,14 @@ func (p *Peer) IsBlockKnown(id thor.Bytes32) bool {
 	return p.knownBlocks.Contains(id)
 }
 
+// Enode returns the enode URL of the peer with its remote address.
+func (p *Peer) Enode() string {
+	if addr, ok := p.RemoteAddr().(*net.TCPAddr); ok {
+		return discover.NewNode(p.ID(), addr.IP, uint16(addr.Port), uint16(addr.Port)).String()
+	}
+	return discover.NewNode(p.ID(), nil, 0, 0).String()
+}
+
 // Duration returns duration of connection.
 func (p *Peer) Duration() mclock.AbsTime {
 	return mclock.Now() - p.createdTime
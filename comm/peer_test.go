@@ -0,0 +1,72 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeersByLatency(t *testing.T) {
+	c := New(newScoreTestRepo(t, 0), nil)
+	defer c.Stop()
+
+	rtts := map[byte][]time.Duration{
+		1: {30 * time.Millisecond, 50 * time.Millisecond}, // avg 40ms
+		2: {10 * time.Millisecond},
+		3: nil, // not sampled
+		4: {20 * time.Millisecond, 20 * time.Millisecond, 20 * time.Millisecond},
+		5: {5 * time.Millisecond, 500 * time.Millisecond},
+		// ties broken by ID
+		6: nil,
+		7: {10 * time.Millisecond},
+	}
+	for b, samples := range rtts {
+		rw, _ := p2p.MsgPipe()
+		defer rw.Close()
+
+		peer := newPeer(p2p.NewPeer(discover.NodeID{b}, "peer", nil), rw)
+		peer.traffic.rtts = samples
+		peer.countBlocks(int(b))
+		c.peerSet.Add(peer)
+	}
+
+	infos := c.PeersByLatency()
+	var ids []discover.NodeID
+	for _, info := range infos {
+		ids = append(ids, info.ID)
+		assert.Equal(t, uint64(info.ID[0]), info.BlocksExchanged)
+	}
+	assert.Equal(t, []discover.NodeID{{2}, {7}, {4}, {1}, {5}, {3}, {6}}, ids)
+	assert.Equal(t, 40*time.Millisecond, infos[3].AvgRTT)
+	assert.Equal(t, time.Duration(0), infos[6].AvgRTT)
+}
+
+func TestPeerAvgRTT(t *testing.T) {
+	rw, _ := p2p.MsgPipe()
+	defer rw.Close()
+	peer := newPeer(p2p.NewPeer(discover.NodeID{1}, "peer", nil), rw)
+
+	assert.Equal(t, time.Duration(0), peer.AvgRTT())
+	assert.True(t, peer.LastSeen().IsZero())
+
+	peer.markSeen()
+	assert.False(t, peer.LastSeen().IsZero())
+
+	peer.addRTT(time.Second)
+	assert.Equal(t, time.Second, peer.AvgRTT())
+
+	// the first sample is dropped from the rolling window
+	for i := 0; i < maxRTTSamples; i++ {
+		peer.addRTT(100 * time.Millisecond)
+	}
+	assert.Len(t, peer.traffic.rtts, maxRTTSamples)
+	assert.Equal(t, 100*time.Millisecond, peer.AvgRTT())
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2024 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/pkg/errors"
+)
+
+const (
+	maxPeerViolations = 3 // count of invalid responses before a peer gets banned
+	maxPeerTimeouts   = 3 // count of consecutive timeouts before a peer gets banned
+
+	initialBanDuration = time.Minute
+	maxBanDuration     = time.Hour
+	banMemory          = maxBanDuration // time after a ban expires before it's forgotten, and the duration restarts
+
+	throughputWeight = 0.2 // weight of the latest sample in the throughput moving average
+)
+
+// errInvalidData is the cause of errors due to invalid data served by the peer.
+var errInvalidData = errors.New("invalid data")
+
+// peerScore tracks how well a peer serves blocks.
+type peerScore struct {
+	lock       sync.Mutex
+	throughput float64 // in bytes per second
+	violations int
+	timeouts   int
+}
+
+// Throughput returns the moving average of the download throughput, in bytes per second.
+func (s *peerScore) Throughput() float64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.throughput
+}
+
+// Violations returns the count of invalid responses.
+func (s *peerScore) Violations() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.violations
+}
+
+// Timeouts returns the count of consecutive timeouts.
+func (s *peerScore) Timeouts() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.timeouts
+}
+
+// recordDownload records a successful download of size bytes in duration d.
+func (s *peerScore) recordDownload(size int, d time.Duration) {
+	if d <= 0 {
+		d = time.Millisecond
+	}
+	sample := float64(size) / d.Seconds()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.throughput == 0 {
+		s.throughput = sample
+	} else {
+		s.throughput = s.throughput*(1-throughputWeight) + sample*throughputWeight
+	}
+	s.timeouts = 0
+}
+
+// recordFailure records a failed download and returns whether the peer should be banned.
+func (s *peerScore) recordFailure(err error) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	switch errors.Cause(err) {
+	case errInvalidData:
+		s.violations++
+		return s.violations >= maxPeerViolations
+	case context.DeadlineExceeded:
+		s.timeouts++
+		return s.timeouts >= maxPeerTimeouts
+	}
+	return false
+}
+
+// banList records temporarily banned nodes. The ban duration doubles every time the node gets banned again,
+// until the node has not been banned for banMemory.
+type banList struct {
+	lock sync.Mutex
+	m    map[discover.NodeID]*ban
+}
+
+type ban struct {
+	until time.Time
+	count int
+}
+
+func newBanList() *banList {
+	return &banList{
+		m: make(map[discover.NodeID]*ban),
+	}
+}
+
+// Ban bans the node and returns the ban duration.
+func (b *banList) Ban(id discover.NodeID) time.Duration {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := time.Now()
+	for bannedID, entry := range b.m {
+		if now.After(entry.until.Add(banMemory)) {
+			delete(b.m, bannedID)
+		}
+	}
+
+	entry, ok := b.m[id]
+	if !ok {
+		entry = &ban{}
+		b.m[id] = entry
+	}
+	duration := initialBanDuration << entry.count
+	if duration > maxBanDuration || duration <= 0 {
+		duration = maxBanDuration
+	} else {
+		entry.count++
+	}
+	entry.until = now.Add(duration)
+	return duration
+}
+
+// IsBanned returns whether the node is banned currently.
+func (b *banList) IsBanned(id discover.NodeID) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	entry, ok := b.m[id]
+	return ok && time.Now().Before(entry.until)
+}
@@ -0,0 +1,265 @@
+// Copyright (c) 2024 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/comm/proto"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/tx"
+)
+
+func newScoreTestRepo(t *testing.T, n int) *chain.Repository {
+	db := muxdb.NewMem()
+	b0, _, _, err := genesis.NewDevnet().Build(state.NewStater(db))
+	require.NoError(t, err)
+	repo, err := chain.NewRepository(db, b0)
+	require.NoError(t, err)
+
+	parent := b0
+	for i := 1; i <= n; i++ {
+		b := new(block.Builder).
+			ParentID(parent.Header().ID()).
+			Timestamp(parent.Header().Timestamp() + 10).
+			TotalScore(parent.Header().TotalScore() + 1).
+			Build()
+		pk, _ := crypto.GenerateKey()
+		sig, _ := crypto.Sign(b.Header().SigningHash().Bytes(), pk)
+		b = b.WithSignature(sig)
+
+		require.NoError(t, repo.AddBlock(b, nil, 0))
+		parent = b
+	}
+	require.NoError(t, repo.SetBestBlockID(parent.Header().ID()))
+	return repo
+}
+
+// pipeRW buffers the payload of messages read from a message pipe. As on real connections, the
+// payload has to be a byte reader for the rpc layer to decode it in parts.
+type pipeRW struct {
+	p2p.MsgReadWriter
+}
+
+func (rw pipeRW) ReadMsg() (p2p.Msg, error) {
+	msg, err := rw.MsgReadWriter.ReadMsg()
+	if err != nil {
+		return msg, err
+	}
+	data, err := io.ReadAll(msg.Payload)
+	if err != nil {
+		return msg, err
+	}
+	msg.Payload = bytes.NewReader(data)
+	return msg, nil
+}
+
+// connectPeer connects local to a peer served by remote, and returns the function to disconnect it.
+// The corrupt peer serves blocks whose transactions don't match the txs root of the header.
+func connectPeer(local, remote *Communicator, id discover.NodeID, corrupt bool) func() {
+	localRW, remoteRW := p2p.MsgPipe()
+
+	remotePeer := newPeer(p2p.NewPeer(discover.NodeID{}, "local", nil), pipeRW{remoteRW})
+	go func() {
+		var txsToSync txsToSync
+		_ = remotePeer.Serve(func(msg *p2p.Msg, write func(interface{})) error {
+			if corrupt && msg.Code == proto.MsgGetBlocksFromNumber {
+				var num uint32
+				if err := msg.Decode(&num); err != nil {
+					return err
+				}
+				junk := new(tx.Builder).Nonce(1).Build()
+				var result []rlp.RawValue
+				chain := remote.repo.NewBestChain()
+				for ; len(result) < 16; num++ {
+					b, err := chain.GetBlock(num)
+					if err != nil {
+						break
+					}
+					raw, _ := rlp.EncodeToBytes(block.Compose(b.Header(), tx.Transactions{junk}))
+					result = append(result, raw)
+				}
+				write(result)
+				return nil
+			}
+			return remote.handleRPC(remotePeer, msg, write, &txsToSync)
+		}, proto.MaxMsgSize)
+	}()
+	go func() {
+		_ = local.servePeer(p2p.NewPeer(id, "remote", nil), pipeRW{localRW})
+	}()
+	return func() { localRW.Close() }
+}
+
+func newNodeID(t *testing.T) discover.NodeID {
+	var id discover.NodeID
+	_, err := rand.Read(id[:])
+	require.NoError(t, err)
+	return id
+}
+
+func TestSyncBansCorruptPeer(t *testing.T) {
+	const n = 100
+	remote := New(newScoreTestRepo(t, n), nil)
+	defer remote.Stop()
+	local := New(newScoreTestRepo(t, 0), nil)
+	defer local.Stop()
+
+	var received int
+	handler := func(ctx context.Context, stream <-chan *block.Block) error {
+		for blk := range stream {
+			if blk == nil {
+				continue
+			}
+			if err := local.repo.AddBlock(blk, nil, 0); err != nil {
+				return err
+			}
+			if err := local.repo.SetBestBlockID(blk.Header().ID()); err != nil {
+				return err
+			}
+			received++
+		}
+		return nil
+	}
+	syncOnce := func() *Peer {
+		best := local.repo.BestBlockSummary().Header
+		peer := local.pickSyncPeer(best.TotalScore())
+		if peer != nil {
+			_ = local.syncWith(context.Background(), peer, best.Number(), handler)
+		}
+		return peer
+	}
+	isConnected := func(id discover.NodeID) func() bool {
+		return func() bool { return local.peerSet.Find(id) != nil }
+	}
+
+	// the corrupt peer is the only peer, it must be tried until banned without blocking
+	corruptID := newNodeID(t)
+	disconnect := connectPeer(local, remote, corruptID, true)
+	require.Eventually(t, isConnected(corruptID), time.Second, 10*time.Millisecond)
+
+	for i := 0; i < maxPeerViolations; i++ {
+		peer := syncOnce()
+		require.NotNil(t, peer)
+		assert.Equal(t, corruptID, peer.ID())
+		assert.Equal(t, i+1, peer.score.Violations())
+	}
+	assert.True(t, local.bans.IsBanned(corruptID))
+	assert.Nil(t, syncOnce())
+	assert.Equal(t, 0, received)
+
+	// sync completes via the honest peer
+	honestID := newNodeID(t)
+	defer connectPeer(local, remote, honestID, false)()
+	require.Eventually(t, isConnected(honestID), time.Second, 10*time.Millisecond)
+
+	peer := syncOnce()
+	require.NotNil(t, peer)
+	assert.Equal(t, honestID, peer.ID())
+	assert.Equal(t, n, received)
+	assert.Equal(t, remote.repo.BestBlockSummary().Header.ID(), local.repo.BestBlockSummary().Header.ID())
+	assert.Equal(t, 0, peer.score.Violations())
+	assert.True(t, peer.score.Throughput() > 0)
+
+	for _, stats := range local.PeersStats() {
+		if stats.PeerID == corruptID.String() {
+			assert.Equal(t, maxPeerViolations, stats.Violations)
+		} else {
+			assert.Equal(t, 0, stats.Violations)
+			assert.NotZero(t, stats.Throughput)
+		}
+	}
+
+	// a peer not yet measured is tried before the measured ones
+	newID := newNodeID(t)
+	defer connectPeer(local, remote, newID, false)()
+	require.Eventually(t, isConnected(newID), time.Second, 10*time.Millisecond)
+	assert.Equal(t, newID, local.pickSyncPeer(0).ID())
+
+	// the banned peer can't reconnect until the ban expires
+	disconnect()
+	require.Eventually(t, func() bool { return !isConnected(corruptID)() }, time.Second, 10*time.Millisecond)
+
+	disconnect = connectPeer(local, remote, corruptID, true)
+	assert.Never(t, isConnected(corruptID), 200*time.Millisecond, 10*time.Millisecond)
+	disconnect()
+
+	local.bans.lock.Lock()
+	local.bans.m[corruptID].until = time.Now()
+	local.bans.lock.Unlock()
+
+	defer connectPeer(local, remote, corruptID, true)()
+	require.Eventually(t, isConnected(corruptID), time.Second, 10*time.Millisecond)
+}
+
+func TestBanList(t *testing.T) {
+	bans := newBanList()
+	id := discover.NodeID{1}
+
+	assert.False(t, bans.IsBanned(id))
+	assert.Equal(t, initialBanDuration, bans.Ban(id))
+	assert.True(t, bans.IsBanned(id))
+	assert.False(t, bans.IsBanned(discover.NodeID{2}))
+
+	// exponential ban duration
+	assert.Equal(t, 2*initialBanDuration, bans.Ban(id))
+	assert.Equal(t, 4*initialBanDuration, bans.Ban(id))
+	for i := 0; i < 10; i++ {
+		bans.Ban(id)
+	}
+	assert.Equal(t, maxBanDuration, bans.Ban(id))
+
+	bans.m[id].until = time.Now()
+	assert.False(t, bans.IsBanned(id))
+
+	// forgotten once not banned for banMemory, and banned from the initial duration again
+	bans.m[id].until = time.Now().Add(-banMemory - time.Second)
+	bans.Ban(discover.NodeID{2})
+	assert.NotContains(t, bans.m, id)
+	assert.Equal(t, initialBanDuration, bans.Ban(id))
+}
+
+func TestPeerScore(t *testing.T) {
+	var s peerScore
+
+	s.recordDownload(1000, time.Second)
+	assert.InDelta(t, 1000, s.Throughput(), 0.001)
+	s.recordDownload(2000, time.Second)
+	assert.InDelta(t, 1200, s.Throughput(), 0.001)
+
+	assert.False(t, s.recordFailure(context.Canceled))
+	for i := 1; i < maxPeerTimeouts; i++ {
+		assert.False(t, s.recordFailure(context.DeadlineExceeded))
+	}
+	// a successful download resets timeouts
+	s.recordDownload(1200, time.Second)
+	assert.Equal(t, 0, s.Timeouts())
+	for i := 1; i < maxPeerTimeouts; i++ {
+		assert.False(t, s.recordFailure(context.DeadlineExceeded))
+	}
+	assert.True(t, s.recordFailure(context.DeadlineExceeded))
+
+	for i := 1; i < maxPeerViolations; i++ {
+		assert.False(t, s.recordFailure(errInvalidData))
+	}
+	assert.True(t, s.recordFailure(errInvalidData))
+	assert.Equal(t, maxPeerViolations, s.Violations())
+}
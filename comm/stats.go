@@ -6,6 +6,9 @@
 package comm
 
 import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/discover"
 	"github.com/vechain/thor/v2/thor"
 )
 
@@ -24,4 +27,16 @@ type PeerStats struct {
 	NetAddr     string
 	Inbound     bool
 	Duration    uint64 // in seconds
+	Throughput  uint64 // block download throughput, in bytes per second
+	Violations  int    // count of invalid responses
+	Timeouts    int    // count of consecutive timeouts
+}
+
+// PeerInfo records connection quality of a peer.
+type PeerInfo struct {
+	ID              discover.NodeID
+	Enode           string
+	AvgRTT          time.Duration
+	LastSeen        time.Time
+	BlocksExchanged uint64
 }
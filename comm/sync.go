@@ -8,6 +8,7 @@ package comm
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/pkg/errors"
@@ -48,6 +49,7 @@ func download(_ctx context.Context, repo *chain.Repository, peer *Peer, headNum
 
 func fetchBlocks(ctx context.Context, peer *Peer, fromBlockNum uint32, fetched chan<- []*block.Block) error {
 	for {
+		start := time.Now()
 		result, err := proto.GetBlocksFromNumber(ctx, peer, fromBlockNum)
 		if err != nil {
 			return err
@@ -56,18 +58,25 @@ func fetchBlocks(ctx context.Context, peer *Peer, fromBlockNum uint32, fetched c
 			return nil
 		}
 
+		size := 0
 		blocks := make([]*block.Block, 0, len(result))
 		for _, raw := range result {
 			var blk block.Block
 			if err := rlp.DecodeBytes(raw, &blk); err != nil {
-				return errors.Wrap(err, "invalid block")
+				return errors.WithMessage(errInvalidData, "invalid block: "+err.Error())
 			}
 			if blk.Header().Number() != fromBlockNum {
-				return errors.New("broken sequence")
+				return errors.WithMessage(errInvalidData, "broken sequence")
+			}
+			if blk.Header().TxsRoot() != blk.Transactions().RootHash() {
+				return errors.WithMessage(errInvalidData, "txs root mismatch")
 			}
 			fromBlockNum++
+			size += len(raw)
 			blocks = append(blocks, &blk)
 		}
+		peer.score.recordDownload(size, time.Since(start))
+		peer.countBlocks(len(blocks))
 
 		select {
 		case fetched <- blocks:
@@ -716,7 +716,7 @@ func TestValidateBlockBody(t *testing.T) {
 				}
 
 				err = tc.consent(blk)
-				assert.Equal(t, "intrinsic gas exceeds provided gas", err.Error())
+				assert.Equal(t, "intrinsic gas exceeds provided gas: required 21000, provided 0, clauses 1", err.Error())
 			},
 		},
 	}
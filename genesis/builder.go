@@ -7,6 +7,7 @@ package genesis
 
 import (
 	"math"
+	"math/big"
 
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/v2/block"
@@ -53,6 +54,44 @@ func (b *Builder) State(proc func(state *state.State) error) *Builder {
 	return b
 }
 
+// SetCode add a state process to set the code of the account.
+func (b *Builder) SetCode(addr thor.Address, code []byte) *Builder {
+	return b.State(func(state *state.State) error {
+		return state.SetCode(addr, code)
+	})
+}
+
+// SetBalance add a state process to set the balance of the account.
+// The energy of the account is touched at the genesis timestamp, with the amount unchanged.
+func (b *Builder) SetBalance(addr thor.Address, balance *big.Int) *Builder {
+	return b.State(func(state *state.State) error {
+		energy, err := state.GetEnergy(addr, b.timestamp)
+		if err != nil {
+			return err
+		}
+		if err := state.SetBalance(addr, balance); err != nil {
+			return err
+		}
+		return state.SetEnergy(addr, energy, b.timestamp)
+	})
+}
+
+// SetStorage add a state process to set the storage value of the account.
+func (b *Builder) SetStorage(addr thor.Address, key, value thor.Bytes32) *Builder {
+	return b.State(func(state *state.State) error {
+		state.SetStorage(addr, key, value)
+		return nil
+	})
+}
+
+// SetMasterKey add a state process to set the master of the account, which is
+// returned by the master method of the Prototype contract.
+func (b *Builder) SetMasterKey(addr thor.Address, master thor.Address) *Builder {
+	return b.State(func(state *state.State) error {
+		return state.SetMaster(addr, master)
+	})
+}
+
 // Call add a contract call.
 func (b *Builder) Call(clause *tx.Clause, caller thor.Address) *Builder {
 	b.calls = append(b.calls, call{clause, caller})
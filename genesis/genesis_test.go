@@ -6,9 +6,11 @@
 package genesis_test
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/vechain/thor/v2/genesis"
 	"github.com/vechain/thor/v2/muxdb"
 	"github.com/vechain/thor/v2/state"
@@ -34,3 +36,50 @@ func TestTestnetGenesis(t *testing.T) {
 	assert.Nil(t, err)
 	assert.True(t, v)
 }
+
+func TestBuilderSetters(t *testing.T) {
+	db := muxdb.NewMem()
+	addr := thor.BytesToAddress([]byte("acc"))
+	key := thor.BytesToBytes32([]byte("key"))
+	value := thor.BytesToBytes32([]byte("value"))
+	balance := big.NewInt(1e18)
+	energy := big.NewInt(1e17)
+	code := []byte{0x60, 0x00}
+	master := thor.BytesToAddress([]byte("master"))
+
+	b0, _, _, err := new(genesis.Builder).
+		Timestamp(1000).
+		GasLimit(thor.InitialGasLimit).
+		State(func(st *state.State) error {
+			return st.SetEnergy(addr, energy, 1000)
+		}).
+		SetBalance(addr, balance).
+		SetCode(addr, code).
+		SetStorage(addr, key, value).
+		SetMasterKey(addr, master).
+		Build(state.NewStater(db))
+	require.NoError(t, err)
+
+	st := state.New(db, b0.Header().StateRoot(), 0, 0, 0)
+
+	got, err := st.GetBalance(addr)
+	require.NoError(t, err)
+	assert.Equal(t, balance, got)
+
+	// the energy is kept
+	got, err = st.GetEnergy(addr, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, energy, got)
+
+	gotCode, err := st.GetCode(addr)
+	require.NoError(t, err)
+	assert.Equal(t, code, gotCode)
+
+	gotValue, err := st.GetStorage(addr, key)
+	require.NoError(t, err)
+	assert.Equal(t, value, gotValue)
+
+	gotMaster, err := st.GetMaster(addr)
+	require.NoError(t, err)
+	assert.Equal(t, master, gotMaster)
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2024 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logdb
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// export formats supported by ExportRange.
+const (
+	ExportJSONL = "jsonl"
+	ExportCSV   = "csv"
+)
+
+// exportCSVHeader is the header of csv export. Events and transfers share the same columns,
+// columns not applicable to the log type are left empty.
+var exportCSVHeader = []string{
+	"type",
+	"blockNumber",
+	"index",
+	"blockID",
+	"blockTime",
+	"txID",
+	"txOrigin",
+	"clauseIndex",
+	"address",
+	"topic0",
+	"topic1",
+	"topic2",
+	"topic3",
+	"topic4",
+	"data",
+	"sender",
+	"recipient",
+	"amount",
+}
+
+// ExportedLog is the record of a log in jsonl export.
+type ExportedLog struct {
+	Type        string                `json:"type"`
+	BlockNumber uint32                `json:"blockNumber"`
+	Index       uint32                `json:"index"`
+	BlockID     thor.Bytes32          `json:"blockID"`
+	BlockTime   uint64                `json:"blockTime"`
+	TxID        thor.Bytes32          `json:"txID"`
+	TxOrigin    thor.Address          `json:"txOrigin"`
+	ClauseIndex uint32                `json:"clauseIndex"`
+	Address     *thor.Address         `json:"address,omitempty"`
+	Topics      []thor.Bytes32        `json:"topics,omitempty"`
+	Data        *hexutil.Bytes        `json:"data,omitempty"`
+	Sender      *thor.Address         `json:"sender,omitempty"`
+	Recipient   *thor.Address         `json:"recipient,omitempty"`
+	Amount      *math.HexOrDecimal256 `json:"amount,omitempty"`
+}
+
+// ExportRange writes all events and then all transfers of blocks in range [from, to] to w,
+// in the given format. Logs are streamed, so the range is never entirely loaded in memory.
+func (db *LogDB) ExportRange(from, to uint32, format string, w io.Writer) error {
+	if from > to {
+		return errors.New("invalid range")
+	}
+
+	var (
+		write func(*ExportedLog) error
+		flush func() error
+	)
+	switch format {
+	case ExportJSONL:
+		bw := bufio.NewWriter(w)
+		enc := json.NewEncoder(bw)
+		write = func(l *ExportedLog) error { return enc.Encode(l) }
+		flush = bw.Flush
+	case ExportCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(exportCSVHeader); err != nil {
+			return err
+		}
+		write = func(l *ExportedLog) error { return cw.Write(l.csvRecord()) }
+		flush = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	default:
+		return fmt.Errorf("unsupported export format: %v", format)
+	}
+
+	ctx := context.Background()
+	rng := &Range{From: from, To: to}
+
+	if err := db.IterateEvents(ctx, &EventFilter{Range: rng}, func(ev *Event) error {
+		data := hexutil.Bytes(ev.Data)
+		l := &ExportedLog{
+			Type:        "event",
+			BlockNumber: ev.BlockNumber,
+			Index:       ev.Index,
+			BlockID:     ev.BlockID,
+			BlockTime:   ev.BlockTime,
+			TxID:        ev.TxID,
+			TxOrigin:    ev.TxOrigin,
+			ClauseIndex: ev.ClauseIndex,
+			Address:     &ev.Address,
+			Topics:      make([]thor.Bytes32, 0, len(ev.Topics)),
+			Data:        &data,
+		}
+		for _, topic := range ev.Topics {
+			if topic != nil {
+				l.Topics = append(l.Topics, *topic)
+			}
+		}
+		return write(l)
+	}); err != nil {
+		return err
+	}
+
+	if err := db.IterateTransfers(ctx, &TransferFilter{Range: rng}, func(tr *Transfer) error {
+		return write(&ExportedLog{
+			Type:        "transfer",
+			BlockNumber: tr.BlockNumber,
+			Index:       tr.Index,
+			BlockID:     tr.BlockID,
+			BlockTime:   tr.BlockTime,
+			TxID:        tr.TxID,
+			TxOrigin:    tr.TxOrigin,
+			ClauseIndex: tr.ClauseIndex,
+			Sender:      &tr.Sender,
+			Recipient:   &tr.Recipient,
+			Amount:      (*math.HexOrDecimal256)(tr.Amount),
+		})
+	}); err != nil {
+		return err
+	}
+	return flush()
+}
+
+func (l *ExportedLog) csvRecord() []string {
+	record := []string{
+		l.Type,
+		strconv.FormatUint(uint64(l.BlockNumber), 10),
+		strconv.FormatUint(uint64(l.Index), 10),
+		l.BlockID.String(),
+		strconv.FormatUint(l.BlockTime, 10),
+		l.TxID.String(),
+		l.TxOrigin.String(),
+		strconv.FormatUint(uint64(l.ClauseIndex), 10),
+	}
+
+	addrString := func(addr *thor.Address) string {
+		if addr == nil {
+			return ""
+		}
+		return addr.String()
+	}
+
+	record = append(record, addrString(l.Address))
+	for i := 0; i < 5; i++ {
+		if i < len(l.Topics) {
+			record = append(record, l.Topics[i].String())
+		} else {
+			record = append(record, "")
+		}
+	}
+	if l.Data != nil {
+		record = append(record, l.Data.String())
+	} else {
+		record = append(record, "")
+	}
+	record = append(record, addrString(l.Sender), addrString(l.Recipient))
+	if l.Amount != nil {
+		record = append(record, (*hexutil.Big)(l.Amount).String())
+	} else {
+		record = append(record, "")
+	}
+	return record
+}
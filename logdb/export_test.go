@@ -0,0 +1,136 @@
+// Copyright (c) 2024 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logdb_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/block"
+	logdb "github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/tx"
+)
+
+func TestExportRange(t *testing.T) {
+	db, err := logdb.NewMem()
+	require.NoError(t, err)
+	defer db.Close()
+
+	// 100 blocks from #2 with 10 events each, and a transfer in every 10th block
+	b := new(block.Builder).Build()
+	w := db.NewWriter()
+	for i := 0; i < 100; i++ {
+		builder := new(block.Builder).ParentID(b.Header().ID())
+		var receipts tx.Receipts
+		for j := 0; j < 10; j++ {
+			builder.Transaction(newTx())
+			if j == 0 && i%10 == 0 {
+				receipts = append(receipts, newTransferOnlyReceipt())
+				builder.Transaction(newTx())
+			}
+			receipts = append(receipts, newEventOnlyReceipt())
+		}
+		b = builder.Build()
+		require.NoError(t, w.Write(b, receipts))
+	}
+	require.NoError(t, w.Commit())
+	last := b.Header().Number()
+
+	events, err := db.FilterEvents(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, events, 1000)
+	transfers, err := db.FilterTransfers(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, transfers, 10)
+
+	t.Run("jsonl", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, db.ExportRange(0, last, logdb.ExportJSONL, &buf))
+
+		var logs []*logdb.ExportedLog
+		scanner := bufio.NewScanner(&buf)
+		for scanner.Scan() {
+			var l logdb.ExportedLog
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &l))
+			logs = append(logs, &l)
+		}
+		require.NoError(t, scanner.Err())
+		require.Len(t, logs, 1010)
+
+		for i, ev := range events {
+			l := logs[i]
+			assert.Equal(t, "event", l.Type)
+			assert.Equal(t, ev.BlockNumber, l.BlockNumber)
+			assert.Equal(t, ev.Index, l.Index)
+			assert.Equal(t, ev.BlockID, l.BlockID)
+			assert.Equal(t, ev.BlockTime, l.BlockTime)
+			assert.Equal(t, ev.TxID, l.TxID)
+			assert.Equal(t, ev.TxOrigin, l.TxOrigin)
+			assert.Equal(t, ev.ClauseIndex, l.ClauseIndex)
+			assert.Equal(t, ev.Address, *l.Address)
+			assert.Equal(t, *ev.Topics[0], l.Topics[0])
+			assert.Len(t, l.Topics, 1)
+			assert.Equal(t, ev.Data, []byte(*l.Data))
+		}
+		for i, tr := range transfers {
+			l := logs[len(events)+i]
+			assert.Equal(t, "transfer", l.Type)
+			assert.Equal(t, tr.BlockNumber, l.BlockNumber)
+			assert.Equal(t, tr.Index, l.Index)
+			assert.Equal(t, tr.TxID, l.TxID)
+			assert.Equal(t, tr.Sender, *l.Sender)
+			assert.Equal(t, tr.Recipient, *l.Recipient)
+			assert.Zero(t, tr.Amount.Cmp((*big.Int)(l.Amount)))
+		}
+	})
+
+	t.Run("sub range", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, db.ExportRange(11, 20, logdb.ExportJSONL, &buf))
+
+		count := 0
+		scanner := bufio.NewScanner(&buf)
+		for scanner.Scan() {
+			var l logdb.ExportedLog
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &l))
+			assert.True(t, l.BlockNumber >= 11 && l.BlockNumber <= 20)
+			count++
+		}
+		// 10 blocks of events and the transfer in block 12
+		assert.Equal(t, 101, count)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, db.ExportRange(0, last, logdb.ExportCSV, &buf))
+
+		records, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 1011)
+
+		header := records[0]
+		assert.Equal(t, "type", header[0])
+		for _, record := range records[1:] {
+			assert.Len(t, record, len(header))
+		}
+		assert.Equal(t, "event", records[1][0])
+		assert.Equal(t, events[0].TxID.String(), records[1][5])
+		assert.Equal(t, "transfer", records[1001][0])
+		assert.Equal(t, transfers[0].Sender.String(), records[1001][15])
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		assert.Error(t, db.ExportRange(0, 100, "xml", &bytes.Buffer{}))
+		assert.Error(t, db.ExportRange(2, 1, logdb.ExportJSONL, &bytes.Buffer{}))
+	})
+}
@@ -8,12 +8,16 @@ package logdb
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"math"
 	"math/big"
+	"sync"
+	"time"
 
 	sqlite3 "github.com/mattn/go-sqlite3"
 	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/log"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/tx"
 )
@@ -22,13 +26,26 @@ const (
 	refIDQuery = "(SELECT id FROM ref WHERE data=?)"
 )
 
+var logger = log.WithContext("pkg", "logdb")
+
 type LogDB struct {
-	path          string
-	driverVersion string
-	db            *sql.DB
-	wconn         *sql.Conn
-	wconnSyncOff  *sql.Conn
-	stmtCache     *stmtCache
+	path               string
+	driverVersion      string
+	db                 *sql.DB
+	wconn              *sql.Conn
+	wconnSyncOff       *sql.Conn
+	stmtCache          *stmtCache
+	slowQueryThreshold time.Duration
+	txLock             sync.RWMutex // held for reading by the open write txs, and for writing by Compact
+	counts             logCounts
+}
+
+// logCounts is the running counts of events and transfers. They're counted once on first use, and
+// then kept by the writers as their txs are committed.
+type logCounts struct {
+	lock              sync.Mutex // held while loading, and by the writers across committing and updating
+	loaded            bool
+	events, transfers int64
 }
 
 // New create or open log db at given path.
@@ -94,7 +111,46 @@ func (db *LogDB) Path() string {
 	return db.path
 }
 
+// SetSlowQueryThreshold sets the duration above which filter queries are logged
+// along with their criteria. Zero disables it.
+func (db *LogDB) SetSlowQueryThreshold(threshold time.Duration) {
+	db.slowQueryThreshold = threshold
+}
+
+// checkSlowQuery logs the filter and its execution time if the query is slower than the threshold.
+func (db *LogDB) checkSlowQuery(typ string, filter interface{}, startTime time.Time) {
+	if db.slowQueryThreshold <= 0 {
+		return
+	}
+	elapsed := time.Since(startTime)
+	if elapsed < db.slowQueryThreshold {
+		return
+	}
+
+	labels := map[string]string{"type": typ}
+	metricSlowQueryCount().AddWithLabel(1, labels)
+	metricSlowQueryDuration().ObserveWithLabels(elapsed.Milliseconds(), labels)
+
+	criteria, _ := json.Marshal(filter)
+	logger.Warn("slow logs query", "type", typ, "filter", string(criteria), "elapsed", elapsed)
+}
+
 func (db *LogDB) FilterEvents(ctx context.Context, filter *EventFilter) ([]*Event, error) {
+	defer db.checkSlowQuery("event", filter, time.Now())
+
+	var events []*Event
+	if err := db.IterateEvents(ctx, filter, func(event *Event) error {
+		events = append(events, event)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// IterateEvents calls fn for each event matching the filter, without loading them all in memory.
+// It stops at the first error returned by fn.
+func (db *LogDB) IterateEvents(ctx context.Context, filter *EventFilter, fn func(*Event) error) error {
 	const query = `SELECT e.seq, r0.data, e.blockTime, r1.data, r2.data, e.clauseIndex, r3.data, r4.data, r5.data, r6.data, r7.data, r8.data, e.data
 FROM (%v) e
 	LEFT JOIN ref r0 ON e.blockID = r0.id
@@ -108,7 +164,7 @@ FROM (%v) e
 	LEFT JOIN ref r8 ON e.topic4 = r8.id`
 
 	if filter == nil {
-		return db.queryEvents(ctx, fmt.Sprintf(query, "event"))
+		return db.queryEvents(ctx, fn, fmt.Sprintf(query, "event"))
 	}
 
 	var (
@@ -161,10 +217,25 @@ FROM (%v) e
 			eventQuery += " ORDER BY seq ASC "
 		}
 	}
-	return db.queryEvents(ctx, eventQuery, args...)
+	return db.queryEvents(ctx, fn, eventQuery, args...)
 }
 
 func (db *LogDB) FilterTransfers(ctx context.Context, filter *TransferFilter) ([]*Transfer, error) {
+	defer db.checkSlowQuery("transfer", filter, time.Now())
+
+	var transfers []*Transfer
+	if err := db.IterateTransfers(ctx, filter, func(transfer *Transfer) error {
+		transfers = append(transfers, transfer)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
+
+// IterateTransfers calls fn for each transfer matching the filter, without loading them all in memory.
+// It stops at the first error returned by fn.
+func (db *LogDB) IterateTransfers(ctx context.Context, filter *TransferFilter, fn func(*Transfer) error) error {
 	const query = `SELECT t.seq, r0.data, t.blockTime, r1.data, r2.data, t.clauseIndex, r3.data, r4.data, t.amount
 FROM (%v) t 
 	LEFT JOIN ref r0 ON t.blockID = r0.id
@@ -174,7 +245,7 @@ FROM (%v) t
 	LEFT JOIN ref r4 ON t.recipient = r4.id`
 
 	if filter == nil {
-		return db.queryTransfers(ctx, fmt.Sprintf(query, "transfer"))
+		return db.queryTransfers(ctx, fn, fmt.Sprintf(query, "transfer"))
 	}
 
 	var (
@@ -225,21 +296,20 @@ FROM (%v) t
 			transferQuery += " ORDER BY seq ASC "
 		}
 	}
-	return db.queryTransfers(ctx, transferQuery, args...)
+	return db.queryTransfers(ctx, fn, transferQuery, args...)
 }
 
-func (db *LogDB) queryEvents(ctx context.Context, query string, args ...interface{}) ([]*Event, error) {
+func (db *LogDB) queryEvents(ctx context.Context, fn func(*Event) error, query string, args ...interface{}) error {
 	rows, err := db.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer func() { _ = rows.Close() }()
 
-	var events []*Event
 	for rows.Next() {
 		select {
 		case <-ctx.Done():
-			return nil, ctx.Err()
+			return ctx.Err()
 		default:
 		}
 		var (
@@ -268,7 +338,7 @@ func (db *LogDB) queryEvents(ctx context.Context, query string, args ...interfac
 			&topics[4],
 			&data,
 		); err != nil {
-			return nil, err
+			return err
 		}
 		event := &Event{
 			BlockNumber: seq.BlockNumber(),
@@ -287,25 +357,23 @@ func (db *LogDB) queryEvents(ctx context.Context, query string, args ...interfac
 				event.Topics[i] = &h
 			}
 		}
-		events = append(events, event)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+		if err := fn(event); err != nil {
+			return err
+		}
 	}
-	return events, nil
+	return rows.Err()
 }
 
-func (db *LogDB) queryTransfers(ctx context.Context, query string, args ...interface{}) ([]*Transfer, error) {
+func (db *LogDB) queryTransfers(ctx context.Context, fn func(*Transfer) error, query string, args ...interface{}) error {
 	rows, err := db.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer func() { _ = rows.Close() }()
-	var transfers []*Transfer
 	for rows.Next() {
 		select {
 		case <-ctx.Done():
-			return nil, ctx.Err()
+			return ctx.Err()
 		default:
 		}
 		var (
@@ -330,7 +398,7 @@ func (db *LogDB) queryTransfers(ctx context.Context, query string, args ...inter
 			&recipient,
 			&amount,
 		); err != nil {
-			return nil, err
+			return err
 		}
 		trans := &Transfer{
 			BlockNumber: seq.BlockNumber(),
@@ -344,12 +412,11 @@ func (db *LogDB) queryTransfers(ctx context.Context, query string, args ...inter
 			Recipient:   thor.BytesToAddress(recipient),
 			Amount:      new(big.Int).SetBytes(amount),
 		}
-		transfers = append(transfers, trans)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+		if err := fn(trans); err != nil {
+			return err
+		}
 	}
-	return transfers, nil
+	return rows.Err()
 }
 
 // NewestBlockID query newest written block id.
@@ -369,6 +436,94 @@ func (db *LogDB) NewestBlockID() (thor.Bytes32, error) {
 	return thor.BytesToBytes32(data), nil
 }
 
+// Statistics returns the counts of events and transfers, and the range of blocks they are in.
+// SQLite keeps no row counts, so the tables are counted on the first call only, which takes a scan
+// of their narrowest indexes. The counts are then kept by the writers.
+func (db *LogDB) Statistics() (LogDBStats, error) {
+	const (
+		countQuery = `SELECT (SELECT COUNT(*) FROM event), (SELECT COUNT(*) FROM transfer)`
+		rangeQuery = `SELECT
+			(SELECT MIN(seq) FROM (SELECT MIN(seq) AS seq FROM event UNION ALL SELECT MIN(seq) FROM transfer)),
+			(SELECT MAX(seq) FROM (SELECT MAX(seq) AS seq FROM event UNION ALL SELECT MAX(seq) FROM transfer))`
+	)
+
+	var (
+		stats          LogDBStats
+		oldest, newest sql.NullInt64
+	)
+
+	// the lock keeps the commits out, so that the counts match the range
+	db.counts.lock.Lock()
+	defer db.counts.lock.Unlock()
+
+	if !db.counts.loaded {
+		row := db.stmtCache.MustPrepare(countQuery).QueryRow()
+		if err := row.Scan(&db.counts.events, &db.counts.transfers); err != nil {
+			return LogDBStats{}, err
+		}
+		db.counts.loaded = true
+	}
+	stats.TotalEvents = uint64(db.counts.events)
+	stats.TotalTransfers = uint64(db.counts.transfers)
+
+	row := db.stmtCache.MustPrepare(rangeQuery).QueryRow()
+	if err := row.Scan(&oldest, &newest); err != nil {
+		return LogDBStats{}, err
+	}
+	if !oldest.Valid {
+		// no logs written
+		return stats, nil
+	}
+
+	stats.OldestBlock = sequence(oldest.Int64).BlockNumber()
+	stats.NewestBlock = sequence(newest.Int64).BlockNumber()
+	blocks := float64(stats.NewestBlock-stats.OldestBlock) + 1
+	stats.EventsPerBlock = float64(stats.TotalEvents) / blocks
+	stats.TransfersPerBlock = float64(stats.TotalTransfers) / blocks
+	return stats, nil
+}
+
+// Compact rebuilds the database to reclaim the space left by the deleted logs, and returns the sizes of
+// the database in bytes before and after. It waits for the open write txs to end, and the writers then
+// wait until it's done, which may take minutes for a large database. The rebuilt copy of the database
+// takes up to its size of extra disk space meanwhile.
+func (db *LogDB) Compact(ctx context.Context) (LogDBCompaction, error) {
+	var compaction LogDBCompaction
+
+	db.txLock.Lock()
+	defer db.txLock.Unlock()
+
+	conn, err := db.db.Conn(ctx)
+	if err != nil {
+		return compaction, err
+	}
+	defer conn.Close()
+
+	if compaction.SizeBefore, err = dbSize(ctx, conn); err != nil {
+		return compaction, err
+	}
+	start := time.Now()
+	if _, err := conn.ExecContext(ctx, "VACUUM"); err != nil {
+		return compaction, err
+	}
+	// the rebuilt pages are all in the WAL, which keeps its size on disk until truncated
+	if _, err := conn.ExecContext(ctx, "PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return compaction, err
+	}
+	if compaction.SizeAfter, err = dbSize(ctx, conn); err != nil {
+		return compaction, err
+	}
+
+	logger.Info("logdb compacted", "sizeBefore", compaction.SizeBefore, "sizeAfter", compaction.SizeAfter, "elapsed", time.Since(start))
+	return compaction, nil
+}
+
+// dbSize returns the size of the database in bytes, including the free pages.
+func dbSize(ctx context.Context, conn *sql.Conn) (size uint64, err error) {
+	err = conn.QueryRowContext(ctx, "SELECT page_count * page_size FROM pragma_page_count(), pragma_page_size()").Scan(&size)
+	return
+}
+
 // HasBlockID query whether given block id related logs were written.
 func (db *LogDB) HasBlockID(id thor.Bytes32) (bool, error) {
 	const query = `SELECT COUNT(*) FROM (
@@ -388,12 +543,12 @@ func (db *LogDB) HasBlockID(id thor.Bytes32) (bool, error) {
 
 // NewWriter creates a log writer.
 func (db *LogDB) NewWriter() *Writer {
-	return &Writer{conn: db.wconn, stmtCache: db.stmtCache}
+	return &Writer{conn: db.wconn, stmtCache: db.stmtCache, txLock: &db.txLock, counts: &db.counts}
 }
 
 // NewWriterSyncOff creates a log writer which applied 'pragma synchronous = off'.
 func (db *LogDB) NewWriterSyncOff() *Writer {
-	return &Writer{conn: db.wconnSyncOff, stmtCache: db.stmtCache}
+	return &Writer{conn: db.wconnSyncOff, stmtCache: db.stmtCache, txLock: &db.txLock, counts: &db.counts}
 }
 
 func topicValue(topics []thor.Bytes32, i int) []byte {
@@ -403,24 +558,32 @@ func topicValue(topics []thor.Bytes32, i int) []byte {
 	return nil
 }
 
-// Writer is the transactional log writer.
+// Writer is the transactional log writer. The tx opened by a write must be ended by Commit or Rollback,
+// as a compaction waits for it.
 type Writer struct {
 	conn      *sql.Conn
 	stmtCache *stmtCache
+	txLock    *sync.RWMutex
+	counts    *logCounts
 
 	tx               *sql.Tx
 	uncommittedCount int
+	// changes to the counts of events and transfers made by the tx
+	eventsDelta, transfersDelta int64
 }
 
 // Truncate truncates the database by deleting logs after blockNum (included).
 func (w *Writer) Truncate(blockNum uint32) error {
 	seq := newSequence(blockNum, 0)
-	if err := w.exec("DELETE FROM event WHERE seq >= ?", seq); err != nil {
+	deleted, err := w.execCount("DELETE FROM event WHERE seq >= ?", seq)
+	if err != nil {
 		return err
 	}
-	if err := w.exec("DELETE FROM transfer WHERE seq >= ?", seq); err != nil {
+	w.eventsDelta -= deleted
+	if deleted, err = w.execCount("DELETE FROM transfer WHERE seq >= ?", seq); err != nil {
 		return err
 	}
+	w.transfersDelta -= deleted
 	return nil
 }
 
@@ -502,7 +665,7 @@ func (w *Writer) Write(b *block.Block, receipts tx.Receipts) error {
 					eventData = ev.Data
 				}
 
-				if err := w.exec(
+				inserted, err := w.execCount(
 					query,
 					newSequence(blockNum, eventCount),
 					blockTimestamp,
@@ -516,9 +679,11 @@ func (w *Writer) Write(b *block.Block, receipts tx.Receipts) error {
 					topicValue(ev.Topics, 1),
 					topicValue(ev.Topics, 2),
 					topicValue(ev.Topics, 3),
-					topicValue(ev.Topics, 4)); err != nil {
+					topicValue(ev.Topics, 4))
+				if err != nil {
 					return err
 				}
+				w.eventsDelta += inserted
 				eventCount++
 			}
 
@@ -537,7 +702,7 @@ func (w *Writer) Write(b *block.Block, receipts tx.Receipts) error {
 					refIDQuery + "," +
 					refIDQuery + ")"
 
-				if err := w.exec(
+				inserted, err := w.execCount(
 					query,
 					newSequence(blockNum, transferCount),
 					blockTimestamp,
@@ -547,9 +712,11 @@ func (w *Writer) Write(b *block.Block, receipts tx.Receipts) error {
 					txID[:],
 					txOrigin[:],
 					tr.Sender[:],
-					tr.Recipient[:]); err != nil {
+					tr.Recipient[:])
+				if err != nil {
 					return err
 				}
+				w.transfersDelta += inserted
 				transferCount++
 			}
 		}
@@ -563,13 +730,19 @@ func (w *Writer) Commit() (err error) {
 		return nil
 	}
 
-	defer func() {
-		if err == nil {
-			w.tx = nil
-			w.uncommittedCount = 0
-		}
-	}()
-	return w.tx.Commit()
+	defer w.endTx()
+
+	// committed and counted at once, so that the counts are never loaded in between
+	w.counts.lock.Lock()
+	defer w.counts.lock.Unlock()
+	if err := w.tx.Commit(); err != nil {
+		return err
+	}
+	if w.counts.loaded {
+		w.counts.events += w.eventsDelta
+		w.counts.transfers += w.transfersDelta
+	}
+	return nil
 }
 
 // Rollback rollback all uncommitted logs.
@@ -577,29 +750,43 @@ func (w *Writer) Rollback() (err error) {
 	if w.tx == nil {
 		return nil
 	}
-	defer func() {
-		if err == nil {
-			w.tx = nil
-			w.uncommittedCount = 0
-		}
-	}()
+	defer w.endTx()
 	return w.tx.Rollback()
 }
 
+// endTx ends the tx, which is done once committed or rolled back, even on error.
+func (w *Writer) endTx() {
+	w.tx = nil
+	w.uncommittedCount = 0
+	w.eventsDelta, w.transfersDelta = 0, 0
+	w.txLock.RUnlock()
+}
+
 // UncommittedCount returns the count of uncommitted logs.
 func (w *Writer) UncommittedCount() int {
 	return w.uncommittedCount
 }
 
-func (w *Writer) exec(query string, args ...interface{}) (err error) {
+func (w *Writer) exec(query string, args ...interface{}) error {
+	_, err := w.execCount(query, args...)
+	return err
+}
+
+// execCount executes the query and returns the count of rows it changed.
+func (w *Writer) execCount(query string, args ...interface{}) (int64, error) {
 	if w.tx == nil {
-		if w.tx, err = w.conn.BeginTx(context.Background(), nil); err != nil {
-			return
+		w.txLock.RLock()
+		tx, err := w.conn.BeginTx(context.Background(), nil)
+		if err != nil {
+			w.txLock.RUnlock()
+			return 0, err
 		}
+		w.tx = tx
 	}
-	if _, err = w.tx.Stmt(w.stmtCache.MustPrepare(query)).Exec(args...); err != nil {
-		return
+	res, err := w.tx.Stmt(w.stmtCache.MustPrepare(query)).Exec(args...)
+	if err != nil {
+		return 0, err
 	}
 	w.uncommittedCount++
-	return nil
+	return res.RowsAffected()
 }
@@ -9,12 +9,17 @@ import (
 	"context"
 	"crypto/rand"
 	"math/big"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/vechain/thor/v2/block"
 	logdb "github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/metrics"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/tx"
 )
@@ -431,3 +436,174 @@ func TestLogDB_HasBlockID(t *testing.T) {
 	}
 	assert.True(t, has)
 }
+
+func TestLogDB_Statistics(t *testing.T) {
+	db, err := logdb.NewMem()
+	require.NoError(t, err)
+	defer db.Close()
+
+	stats, err := db.Statistics()
+	require.NoError(t, err)
+	assert.Equal(t, logdb.LogDBStats{}, stats)
+
+	var (
+		w                 = db.NewWriter()
+		parentID          thor.Bytes32 // of block 0
+		events, transfers int
+	)
+	for i := 1; i <= 100; i++ {
+		b := new(block.Builder).ParentID(parentID)
+		var receipts tx.Receipts
+		// a receipt with both an event and a transfer, and i%3 receipts with an event only
+		for j := range 1 + i%3 {
+			b.Transaction(newTx())
+			if j == 0 {
+				receipts = append(receipts, newReceipt())
+				events++
+				transfers++
+			} else {
+				receipts = append(receipts, newEventOnlyReceipt())
+				events++
+			}
+		}
+		blk := b.Build()
+		require.NoError(t, w.Write(blk, receipts))
+		parentID = blk.Header().ID()
+	}
+	require.NoError(t, w.Commit())
+
+	stats, err = db.Statistics()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(events), stats.TotalEvents)
+	assert.Equal(t, uint64(transfers), stats.TotalTransfers)
+	assert.InEpsilon(t, float64(events)/100, stats.EventsPerBlock, 0.1)
+	assert.InEpsilon(t, float64(transfers)/100, stats.TransfersPerBlock, 0.1)
+	assert.Equal(t, uint32(1), stats.OldestBlock)
+	assert.Equal(t, uint32(100), stats.NewestBlock)
+
+	// the counts are kept by the writers
+	require.NoError(t, w.Truncate(51))
+	require.NoError(t, w.Rollback())
+	stats, err = db.Statistics()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(events), stats.TotalEvents)
+	assert.Equal(t, uint64(transfers), stats.TotalTransfers)
+
+	require.NoError(t, w.Truncate(51))
+	require.NoError(t, w.Commit())
+	stats, err = db.Statistics()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(50), stats.NewestBlock)
+
+	// same as counted from scratch
+	counted, err := logdb.New(db.Path())
+	require.NoError(t, err)
+	defer counted.Close()
+	expected, err := counted.Statistics()
+	require.NoError(t, err)
+	assert.Equal(t, expected, stats)
+	assert.Less(t, stats.TotalEvents, uint64(events))
+	assert.Less(t, stats.TotalTransfers, uint64(transfers))
+}
+
+func TestLogDB_SlowQuery(t *testing.T) {
+	metrics.InitializePrometheusMetrics()
+
+	slowQueries := func(typ string) float64 {
+		families, err := prometheus.DefaultGatherer.Gather()
+		require.NoError(t, err)
+		for _, mf := range families {
+			if mf.GetName() != "thor_metrics_logdb_slow_query_count" {
+				continue
+			}
+			for _, m := range mf.GetMetric() {
+				for _, l := range m.GetLabel() {
+					if l.GetName() == "type" && l.GetValue() == typ {
+						return m.GetCounter().GetValue()
+					}
+				}
+			}
+		}
+		return 0
+	}
+
+	db, err := logdb.NewMem()
+	require.NoError(t, err)
+	defer db.Close()
+
+	events, transfers := slowQueries("event"), slowQueries("transfer")
+
+	// disabled by default
+	_, err = db.FilterEvents(context.Background(), &logdb.EventFilter{})
+	require.NoError(t, err)
+	_, err = db.FilterTransfers(context.Background(), &logdb.TransferFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, events, slowQueries("event"))
+	assert.Equal(t, transfers, slowQueries("transfer"))
+
+	// every query is slow
+	db.SetSlowQueryThreshold(time.Nanosecond)
+	_, err = db.FilterEvents(context.Background(), &logdb.EventFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, events+1, slowQueries("event"))
+	assert.Equal(t, transfers, slowQueries("transfer"))
+
+	_, err = db.FilterTransfers(context.Background(), &logdb.TransferFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, transfers+1, slowQueries("transfer"))
+
+	// no query is slow
+	db.SetSlowQueryThreshold(time.Hour)
+	_, err = db.FilterEvents(context.Background(), &logdb.EventFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, events+1, slowQueries("event"))
+}
+
+func TestLogDB_Compact(t *testing.T) {
+	db, err := logdb.New(filepath.Join(t.TempDir(), "logs.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	w := db.NewWriter()
+	var parentID thor.Bytes32
+	for range 100 {
+		blk := new(block.Builder).ParentID(parentID).Transaction(newTx()).Build()
+		require.NoError(t, w.Write(blk, tx.Receipts{newReceipt()}))
+		parentID = blk.Header().ID()
+	}
+	require.NoError(t, w.Commit())
+
+	// nothing to reclaim
+	compaction, err := db.Compact(context.Background())
+	require.NoError(t, err)
+	assert.NotZero(t, compaction.SizeBefore)
+	size := compaction.SizeAfter
+
+	// the space of the deleted logs is reclaimed
+	require.NoError(t, w.Truncate(10))
+	require.NoError(t, w.Commit())
+	compaction, err = db.Compact(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, size, compaction.SizeBefore)
+	assert.Less(t, compaction.SizeAfter, compaction.SizeBefore)
+
+	stats, err := db.Statistics()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(9), stats.NewestBlock)
+
+	// waits for the open write tx, and the writes wait for it instead of failing
+	blk := new(block.Builder).ParentID(parentID).Transaction(newTx()).Build()
+	require.NoError(t, w.Write(blk, tx.Receipts{newReceipt()}))
+	done := make(chan error, 1)
+	go func() {
+		_, err := db.Compact(context.Background())
+		done <- err
+	}()
+	select {
+	case <-done:
+		t.Fatal("compacted with an open write tx")
+	case <-time.After(50 * time.Millisecond):
+	}
+	require.NoError(t, w.Commit())
+	require.NoError(t, <-done)
+}
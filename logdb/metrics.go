@@ -0,0 +1,13 @@
+// Copyright (c) 2024 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logdb
+
+import "github.com/vechain/thor/v2/metrics"
+
+var (
+	metricSlowQueryCount    = metrics.LazyLoadCounterVec("logdb_slow_query_count", []string{"type"})
+	metricSlowQueryDuration = metrics.LazyLoadHistogramVec("logdb_slow_query_duration_ms", []string{"type"}, metrics.Bucket10s)
+)
@@ -40,6 +40,23 @@ type Transfer struct {
 	Amount      *big.Int
 }
 
+// LogDBStats contains the counts of events and transfers, and the range of blocks they are in.
+// The per block counts are averaged over the range, zero if no logs written.
+type LogDBStats struct {
+	TotalEvents       uint64
+	TotalTransfers    uint64
+	EventsPerBlock    float64
+	TransfersPerBlock float64
+	OldestBlock       uint32
+	NewestBlock       uint32
+}
+
+// LogDBCompaction contains the sizes of the database in bytes before and after a compaction.
+type LogDBCompaction struct {
+	SizeBefore uint64
+	SizeAfter  uint64
+}
+
 type Order string
 
 const (
@@ -14,20 +14,22 @@ type noopMetrics struct{}
 
 func defaultNoopMetrics() Metrics { return &noopMetrics{} }
 
-func (n *noopMetrics) GetOrCreateHistogramMeter(string, []int64) HistogramMeter { return &noopMetric }
-func (n *noopMetrics) GetOrCreateHistogramVecMeter(string, []string, []int64) HistogramVecMeter {
+func (n *noopMetrics) GetOrCreateHistogramMeter(string, []int64, ...Option) HistogramMeter {
 	return &noopMetric
 }
-func (n *noopMetrics) GetOrCreateCountMeter(string) CountMeter { return &noopMetric }
+func (n *noopMetrics) GetOrCreateHistogramVecMeter(string, []string, []int64, ...Option) HistogramVecMeter {
+	return &noopMetric
+}
+func (n *noopMetrics) GetOrCreateCountMeter(string, ...Option) CountMeter { return &noopMetric }
 
-func (n *noopMetrics) GetOrCreateCountVecMeter(string, []string) CountVecMeter {
+func (n *noopMetrics) GetOrCreateCountVecMeter(string, []string, ...Option) CountVecMeter {
 	return &noopMetric
 }
 
-func (n *noopMetrics) GetOrCreateGaugeMeter(string) GaugeMeter {
+func (n *noopMetrics) GetOrCreateGaugeMeter(string, ...Option) GaugeMeter {
 	return &noopMetric
 }
-func (n *noopMetrics) GetOrCreateGaugeVecMeter(string, []string) GaugeVecMeter {
+func (n *noopMetrics) GetOrCreateGaugeVecMeter(string, []string, ...Option) GaugeVecMeter {
 	return &noopMetric
 }
 
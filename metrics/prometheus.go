@@ -25,11 +25,16 @@ var logger = log.WithContext("pkg", "metrics")
 
 const namespace = "thor_metrics"
 
+// fullName returns the name the meter is exported as, to key the meters created.
+func fullName(namespace, name string) string {
+	return namespace + "_" + name
+}
+
 // InitializePrometheusMetrics creates a new instance of the Prometheus service and
-// sets the implementation as the default metrics services
+// sets the implementation as the default metrics services, unless another one is set by SetBackend.
 func InitializePrometheusMetrics() {
 	// don't allow for reset
-	if _, ok := metrics.(*prometheusMetrics); !ok {
+	if _, ok := metrics.(*noopMetrics); ok {
 		metrics = newPrometheusMetrics()
 		// collection disk io metrics every 5 seconds
 		go metrics.(*prometheusMetrics).collectDiskIO(5 * time.Second)
@@ -56,24 +61,32 @@ func newPrometheusMetrics() Metrics {
 	}
 }
 
-func (o *prometheusMetrics) GetOrCreateCountMeter(name string) CountMeter {
-	var meter CountMeter
-	mapItem, ok := o.counters.Load(name)
+func (o *prometheusMetrics) GetOrCreateCountMeter(name string, opts ...Option) CountMeter {
+	var (
+		meter CountMeter
+		ns    = newOptions(opts).namespace
+		key   = fullName(ns, name)
+	)
+	mapItem, ok := o.counters.Load(key)
 	if !ok {
-		meter = o.newCountMeter(name)
-		o.counters.Store(name, meter)
+		meter = o.newCountMeter(ns, name)
+		o.counters.Store(key, meter)
 	} else {
 		meter = mapItem.(CountMeter)
 	}
 	return meter
 }
 
-func (o *prometheusMetrics) GetOrCreateCountVecMeter(name string, labels []string) CountVecMeter {
-	var meter CountVecMeter
-	mapItem, ok := o.counterVecs.Load(name)
+func (o *prometheusMetrics) GetOrCreateCountVecMeter(name string, labels []string, opts ...Option) CountVecMeter {
+	var (
+		meter CountVecMeter
+		ns    = newOptions(opts).namespace
+		key   = fullName(ns, name)
+	)
+	mapItem, ok := o.counterVecs.Load(key)
 	if !ok {
-		meter = o.newCountVecMeter(name, labels)
-		o.counterVecs.Store(name, meter)
+		meter = o.newCountVecMeter(ns, name, labels)
+		o.counterVecs.Store(key, meter)
 	} else {
 		meter = mapItem.(CountVecMeter)
 	}
@@ -84,48 +97,64 @@ func (o *prometheusMetrics) GetOrCreateHandler() http.Handler {
 	return promhttp.Handler()
 }
 
-func (o *prometheusMetrics) GetOrCreateHistogramMeter(name string, buckets []int64) HistogramMeter {
-	var meter HistogramMeter
-	mapItem, ok := o.histograms.Load(name)
+func (o *prometheusMetrics) GetOrCreateHistogramMeter(name string, buckets []int64, opts ...Option) HistogramMeter {
+	var (
+		meter HistogramMeter
+		ns    = newOptions(opts).namespace
+		key   = fullName(ns, name)
+	)
+	mapItem, ok := o.histograms.Load(key)
 	if !ok {
-		meter = o.newHistogramMeter(name, buckets)
-		o.histograms.Store(name, meter)
+		meter = o.newHistogramMeter(ns, name, buckets)
+		o.histograms.Store(key, meter)
 	} else {
 		meter = mapItem.(HistogramMeter)
 	}
 	return meter
 }
 
-func (o *prometheusMetrics) GetOrCreateHistogramVecMeter(name string, labels []string, buckets []int64) HistogramVecMeter {
-	var meter HistogramVecMeter
-	mapItem, ok := o.histogramVecs.Load(name)
+func (o *prometheusMetrics) GetOrCreateHistogramVecMeter(name string, labels []string, buckets []int64, opts ...Option) HistogramVecMeter {
+	var (
+		meter HistogramVecMeter
+		ns    = newOptions(opts).namespace
+		key   = fullName(ns, name)
+	)
+	mapItem, ok := o.histogramVecs.Load(key)
 	if !ok {
-		meter = o.newHistogramVecMeter(name, labels, buckets)
-		o.histogramVecs.Store(name, meter)
+		meter = o.newHistogramVecMeter(ns, name, labels, buckets)
+		o.histogramVecs.Store(key, meter)
 	} else {
 		meter = mapItem.(HistogramVecMeter)
 	}
 	return meter
 }
 
-func (o *prometheusMetrics) GetOrCreateGaugeMeter(name string) GaugeMeter {
-	var meter GaugeMeter
-	mapItem, ok := o.gauges.Load(name)
+func (o *prometheusMetrics) GetOrCreateGaugeMeter(name string, opts ...Option) GaugeMeter {
+	var (
+		meter GaugeMeter
+		ns    = newOptions(opts).namespace
+		key   = fullName(ns, name)
+	)
+	mapItem, ok := o.gauges.Load(key)
 	if !ok {
-		meter = o.newGaugeMeter(name)
-		o.gauges.Store(name, meter)
+		meter = o.newGaugeMeter(ns, name)
+		o.gauges.Store(key, meter)
 	} else {
 		meter = mapItem.(GaugeMeter)
 	}
 	return meter
 }
 
-func (o *prometheusMetrics) GetOrCreateGaugeVecMeter(name string, labels []string) GaugeVecMeter {
-	var meter GaugeVecMeter
-	mapItem, ok := o.gaugeVecs.Load(name)
+func (o *prometheusMetrics) GetOrCreateGaugeVecMeter(name string, labels []string, opts ...Option) GaugeVecMeter {
+	var (
+		meter GaugeVecMeter
+		ns    = newOptions(opts).namespace
+		key   = fullName(ns, name)
+	)
+	mapItem, ok := o.gaugeVecs.Load(key)
 	if !ok {
-		meter = o.newGaugeVecMeter(name, labels)
-		o.gaugeVecs.Store(name, meter)
+		meter = o.newGaugeVecMeter(ns, name, labels)
+		o.gaugeVecs.Store(key, meter)
 	} else {
 		meter = mapItem.(GaugeVecMeter)
 	}
@@ -188,7 +217,7 @@ func (o *prometheusMetrics) collectDiskIO(refresh time.Duration) {
 	}
 }
 
-func (o *prometheusMetrics) newHistogramMeter(name string, buckets []int64) HistogramMeter {
+func (o *prometheusMetrics) newHistogramMeter(namespace, name string, buckets []int64) HistogramMeter {
 	var floatBuckets []float64
 	for _, bucket := range buckets {
 		floatBuckets = append(floatBuckets, float64(bucket))
@@ -220,7 +249,7 @@ func (c *promHistogramMeter) Observe(i int64) {
 	c.histogram.Observe(float64(i))
 }
 
-func (o *prometheusMetrics) newHistogramVecMeter(name string, labels []string, buckets []int64) HistogramVecMeter {
+func (o *prometheusMetrics) newHistogramVecMeter(namespace, name string, labels []string, buckets []int64) HistogramVecMeter {
 	var floatBuckets []float64
 	for _, bucket := range buckets {
 		floatBuckets = append(floatBuckets, float64(bucket))
@@ -253,7 +282,7 @@ func (c *promHistogramVecMeter) ObserveWithLabels(i int64, labels map[string]str
 	c.histogram.With(labels).Observe(float64(i))
 }
 
-func (o *prometheusMetrics) newCountMeter(name string) CountMeter {
+func (o *prometheusMetrics) newCountMeter(namespace, name string) CountMeter {
 	meter := prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Namespace: namespace,
@@ -270,7 +299,7 @@ func (o *prometheusMetrics) newCountMeter(name string) CountMeter {
 	}
 }
 
-func (o *prometheusMetrics) newCountVecMeter(name string, labels []string) CountVecMeter {
+func (o *prometheusMetrics) newCountVecMeter(namespace, name string, labels []string) CountVecMeter {
 	meter := prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: namespace,
@@ -288,7 +317,7 @@ func (o *prometheusMetrics) newCountVecMeter(name string, labels []string) Count
 	}
 }
 
-func (o *prometheusMetrics) newGaugeMeter(name string) GaugeMeter {
+func (o *prometheusMetrics) newGaugeMeter(namespace, name string) GaugeMeter {
 	meter := prometheus.NewGauge(
 		prometheus.GaugeOpts{
 			Namespace: namespace,
@@ -305,7 +334,7 @@ func (o *prometheusMetrics) newGaugeMeter(name string) GaugeMeter {
 	}
 }
 
-func (o *prometheusMetrics) newGaugeVecMeter(name string, labels []string) GaugeVecMeter {
+func (o *prometheusMetrics) newGaugeVecMeter(namespace, name string, labels []string) GaugeVecMeter {
 	meter := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: namespace,
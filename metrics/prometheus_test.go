@@ -31,6 +31,10 @@ func TestPromMetrics(t *testing.T) {
 	gauge1 := Gauge("gauge1")
 	gaugeVec := GaugeVec("gaugeVec1", []string{"zeroOrOne"})
 
+	// exported under the given namespace
+	Counter("consensus_count1", WithNamespace("thor")).Add(1)
+	Counter("consensus_count1").Add(2)
+
 	count1.Add(1)
 	randCount2 := rand.N(100) + 1
 	for i := 0; i < randCount2; i++ {
@@ -76,6 +80,8 @@ func TestPromMetrics(t *testing.T) {
 	// Validate metrics
 	require.Equal(t, float64(1), metrics["thor_metrics_count1"].Metric[0].GetCounter().GetValue())
 	require.Equal(t, float64(randCount2), metrics["thor_metrics_count2"].Metric[0].GetCounter().GetValue())
+	require.Equal(t, float64(1), metrics["thor_consensus_count1"].Metric[0].GetCounter().GetValue())
+	require.Equal(t, float64(2), metrics["thor_metrics_consensus_count1"].Metric[0].GetCounter().GetValue())
 	require.Equal(t, float64(histTotal), metrics["thor_metrics_hist1"].Metric[0].GetHistogram().GetSampleSum())
 
 	sumHistVect := metrics["thor_metrics_hist2"].Metric[0].GetHistogram().GetSampleSum() +
@@ -16,15 +16,46 @@ var metrics = defaultNoopMetrics() // defaults to a Noop implementation of the m
 
 // Metrics defines the interface for metrics service implementations
 type Metrics interface {
-	GetOrCreateCountMeter(name string) CountMeter
-	GetOrCreateCountVecMeter(name string, labels []string) CountVecMeter
-	GetOrCreateGaugeMeter(name string) GaugeMeter
-	GetOrCreateGaugeVecMeter(name string, labels []string) GaugeVecMeter
-	GetOrCreateHistogramMeter(name string, buckets []int64) HistogramMeter
-	GetOrCreateHistogramVecMeter(name string, labels []string, buckets []int64) HistogramVecMeter
+	GetOrCreateCountMeter(name string, opts ...Option) CountMeter
+	GetOrCreateCountVecMeter(name string, labels []string, opts ...Option) CountVecMeter
+	GetOrCreateGaugeMeter(name string, opts ...Option) GaugeMeter
+	GetOrCreateGaugeVecMeter(name string, labels []string, opts ...Option) GaugeVecMeter
+	GetOrCreateHistogramMeter(name string, buckets []int64, opts ...Option) HistogramMeter
+	GetOrCreateHistogramVecMeter(name string, labels []string, buckets []int64, opts ...Option) HistogramVecMeter
 	GetOrCreateHandler() http.Handler
 }
 
+// Options are the settings of a meter, see Option.
+type Options struct {
+	namespace string
+}
+
+// Option sets an option of a meter.
+type Option func(*Options)
+
+// WithNamespace exports the meter under the given namespace, instead of thor_metrics.
+func WithNamespace(namespace string) Option {
+	return func(o *Options) {
+		o.namespace = namespace
+	}
+}
+
+func newOptions(opts []Option) Options {
+	o := Options{namespace: namespace}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// SetBackend sets the implementation of the metrics service, e.g. to report to OpenTelemetry or StatsD
+// instead of Prometheus. It must be called at startup before any meter is created, as meters stay bound
+// to the implementation creating them. GetOrCreateHandler may return nil if the implementation doesn't
+// serve metrics over http.
+func SetBackend(m Metrics) {
+	metrics = m
+}
+
 // HTTPHandler returns the http handler for retrieving metrics
 func HTTPHandler() http.Handler {
 	return metrics.GetOrCreateHandler()
@@ -46,8 +77,8 @@ type HistogramMeter interface {
 	Observe(int64)
 }
 
-func Histogram(name string, buckets []int64) HistogramMeter {
-	return metrics.GetOrCreateHistogramMeter(name, buckets)
+func Histogram(name string, buckets []int64, opts ...Option) HistogramMeter {
+	return metrics.GetOrCreateHistogramMeter(name, buckets, opts...)
 }
 
 // HistogramVecMeter same as the Histogram but with labels
@@ -55,8 +86,8 @@ type HistogramVecMeter interface {
 	ObserveWithLabels(int64, map[string]string)
 }
 
-func HistogramVec(name string, labels []string, buckets []int64) HistogramVecMeter {
-	return metrics.GetOrCreateHistogramVecMeter(name, labels, buckets)
+func HistogramVec(name string, labels []string, buckets []int64, opts ...Option) HistogramVecMeter {
+	return metrics.GetOrCreateHistogramVecMeter(name, labels, buckets, opts...)
 }
 
 // CountMeter is a cumulative metric that represents a single monotonically increasing counter
@@ -65,7 +96,9 @@ type CountMeter interface {
 	Add(int64)
 }
 
-func Counter(name string) CountMeter { return metrics.GetOrCreateCountMeter(name) }
+func Counter(name string, opts ...Option) CountMeter {
+	return metrics.GetOrCreateCountMeter(name, opts...)
+}
 
 // CountVecMeter is a cumulative metric that represents a single monotonically increasing counter
 // whose value can only increase or be reset to zero on restart with a vector of values.
@@ -73,8 +106,8 @@ type CountVecMeter interface {
 	AddWithLabel(int64, map[string]string)
 }
 
-func CounterVec(name string, labels []string) CountVecMeter {
-	return metrics.GetOrCreateCountVecMeter(name, labels)
+func CounterVec(name string, labels []string, opts ...Option) CountVecMeter {
+	return metrics.GetOrCreateCountVecMeter(name, labels, opts...)
 }
 
 // GaugeMeter is a metric that represents a single numeric value, which can arbitrarily go up and down.
@@ -83,8 +116,8 @@ type GaugeMeter interface {
 	Set(int64)
 }
 
-func Gauge(name string) GaugeMeter {
-	return metrics.GetOrCreateGaugeMeter(name)
+func Gauge(name string, opts ...Option) GaugeMeter {
+	return metrics.GetOrCreateGaugeMeter(name, opts...)
 }
 
 // GaugeVecMeter is a metric that represents a single numeric value, which can arbitrarily go up and down
@@ -94,8 +127,8 @@ type GaugeVecMeter interface {
 	SetWithLabel(int64, map[string]string)
 }
 
-func GaugeVec(name string, labels []string) GaugeVecMeter {
-	return metrics.GetOrCreateGaugeVecMeter(name, labels)
+func GaugeVec(name string, labels []string, opts ...Option) GaugeVecMeter {
+	return metrics.GetOrCreateGaugeVecMeter(name, labels, opts...)
 }
 
 // LazyLoad allows to defer the instantiation of the metric while allowing its definition. More clearly:
@@ -112,37 +145,37 @@ func LazyLoad[T any](f func() T) func() T {
 	}
 }
 
-func LazyLoadHistogram(name string, buckets []int64) func() HistogramMeter {
+func LazyLoadHistogram(name string, buckets []int64, opts ...Option) func() HistogramMeter {
 	return LazyLoad(func() HistogramMeter {
-		return Histogram(name, buckets)
+		return Histogram(name, buckets, opts...)
 	})
 }
-func LazyLoadHistogramVec(name string, labels []string, buckets []int64) func() HistogramVecMeter {
+func LazyLoadHistogramVec(name string, labels []string, buckets []int64, opts ...Option) func() HistogramVecMeter {
 	return LazyLoad(func() HistogramVecMeter {
-		return HistogramVec(name, labels, buckets)
+		return HistogramVec(name, labels, buckets, opts...)
 	})
 }
 
-func LazyLoadCounter(name string) func() CountMeter {
+func LazyLoadCounter(name string, opts ...Option) func() CountMeter {
 	return LazyLoad(func() CountMeter {
-		return Counter(name)
+		return Counter(name, opts...)
 	})
 }
 
-func LazyLoadCounterVec(name string, labels []string) func() CountVecMeter {
+func LazyLoadCounterVec(name string, labels []string, opts ...Option) func() CountVecMeter {
 	return LazyLoad(func() CountVecMeter {
-		return CounterVec(name, labels)
+		return CounterVec(name, labels, opts...)
 	})
 }
 
-func LazyLoadGaugeVec(name string, labels []string) func() GaugeVecMeter {
+func LazyLoadGaugeVec(name string, labels []string, opts ...Option) func() GaugeVecMeter {
 	return LazyLoad(func() GaugeVecMeter {
-		return GaugeVec(name, labels)
+		return GaugeVec(name, labels, opts...)
 	})
 }
 
-func LazyLoadGauge(name string) func() GaugeMeter {
+func LazyLoadGauge(name string, opts ...Option) func() GaugeMeter {
 	return LazyLoad(func() GaugeMeter {
-		return Gauge(name)
+		return Gauge(name, opts...)
 	})
 }
@@ -0,0 +1,91 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package metrics
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingMetrics is a backend keeping the sum of the values reported to each meter.
+type recordingMetrics struct {
+	lock   sync.Mutex
+	values map[string]int64
+}
+
+func (r *recordingMetrics) meter(name string) *recordingMeter {
+	return &recordingMeter{backend: r, name: name}
+}
+
+func (r *recordingMetrics) GetOrCreateCountMeter(name string, _ ...Option) CountMeter {
+	return r.meter(name)
+}
+func (r *recordingMetrics) GetOrCreateCountVecMeter(name string, _ []string, _ ...Option) CountVecMeter {
+	return r.meter(name)
+}
+func (r *recordingMetrics) GetOrCreateGaugeMeter(name string, _ ...Option) GaugeMeter {
+	return r.meter(name)
+}
+func (r *recordingMetrics) GetOrCreateGaugeVecMeter(name string, _ []string, _ ...Option) GaugeVecMeter {
+	return r.meter(name)
+}
+func (r *recordingMetrics) GetOrCreateHistogramMeter(name string, _ []int64, _ ...Option) HistogramMeter {
+	return r.meter(name)
+}
+func (r *recordingMetrics) GetOrCreateHistogramVecMeter(name string, _ []string, _ []int64, _ ...Option) HistogramVecMeter {
+	return r.meter(name)
+}
+func (r *recordingMetrics) GetOrCreateHandler() http.Handler { return nil }
+
+type recordingMeter struct {
+	backend *recordingMetrics
+	name    string
+}
+
+func (m *recordingMeter) add(i int64) {
+	m.backend.lock.Lock()
+	defer m.backend.lock.Unlock()
+	m.backend.values[m.name] += i
+}
+
+func (m *recordingMeter) Add(i int64)                                    { m.add(i) }
+func (m *recordingMeter) Set(i int64)                                    { m.add(i) }
+func (m *recordingMeter) Observe(i int64)                                { m.add(i) }
+func (m *recordingMeter) AddWithLabel(i int64, _ map[string]string)      { m.add(i) }
+func (m *recordingMeter) SetWithLabel(i int64, _ map[string]string)      { m.add(i) }
+func (m *recordingMeter) ObserveWithLabels(i int64, _ map[string]string) { m.add(i) }
+
+func TestSetBackend(t *testing.T) {
+	prev := metrics
+	t.Cleanup(func() { SetBackend(prev) })
+
+	backend := &recordingMetrics{values: make(map[string]int64)}
+	SetBackend(backend)
+	// kept once metrics are enabled
+	InitializePrometheusMetrics()
+	assert.Nil(t, HTTPHandler())
+
+	Counter("backend_count").Add(1)
+	CounterVec("backend_count_vec", []string{"label"}).AddWithLabel(2, map[string]string{"label": "a"})
+	Gauge("backend_gauge").Set(3)
+	GaugeVec("backend_gauge_vec", []string{"label"}).SetWithLabel(4, map[string]string{"label": "a"})
+	Histogram("backend_hist", Bucket10s).Observe(5)
+	HistogramVec("backend_hist_vec", []string{"label"}, Bucket10s).ObserveWithLabels(6, map[string]string{"label": "a"})
+	LazyLoadCounter("backend_lazy_count")().Add(7)
+
+	assert.Equal(t, map[string]int64{
+		"backend_count":      1,
+		"backend_count_vec":  2,
+		"backend_gauge":      3,
+		"backend_gauge_vec":  4,
+		"backend_hist":       5,
+		"backend_hist_vec":   6,
+		"backend_lazy_count": 7,
+	}, backend.values)
+}
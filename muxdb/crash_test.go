@@ -0,0 +1,418 @@
+// Copyright (c) 2024 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package muxdb_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/kv"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/muxdb/internal/engine"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+)
+
+var errCrashed = errors.New("crashed")
+
+type op struct {
+	key, val []byte
+	del      bool
+}
+
+// crashEngine simulates a store that loses un-synced writes on crash.
+//
+// All writes are visible to reads immediately, but only become durable on Sync.
+// Each write is an atomic batch, and on crash a random prefix of un-synced batches
+// survives, as the journal of leveldb is append-only.
+type crashEngine struct {
+	engine.Engine                   // the live view
+	durable       map[string][]byte // the durable view
+	pending       [][]op            // un-synced batches
+	writes        int               // count of batches written
+	syncs         int               // count of syncs
+	crashAt       int               // the count of batches after which it crashes
+	rng           *rand.Rand
+}
+
+func newCrashEngine(durable map[string][]byte, crashAt int, rng *rand.Rand) *crashEngine {
+	ldb, _ := leveldb.Open(storage.NewMemStorage(), nil)
+	live := engine.NewLevelEngine(ldb)
+	for k, v := range durable {
+		live.Put([]byte(k), v)
+	}
+	return &crashEngine{
+		Engine:  live,
+		durable: durable,
+		crashAt: crashAt,
+		rng:     rng,
+	}
+}
+
+func (e *crashEngine) crashed() bool {
+	return e.writes >= e.crashAt
+}
+
+func (e *crashEngine) write(batch []op) error {
+	if e.crashed() {
+		return errCrashed
+	}
+	e.writes++
+
+	bulk := e.Engine.Bulk()
+	for _, o := range batch {
+		if o.del {
+			bulk.Delete(o.key)
+		} else {
+			bulk.Put(o.key, o.val)
+		}
+	}
+	if err := bulk.Write(); err != nil {
+		return err
+	}
+	e.pending = append(e.pending, batch)
+	return nil
+}
+
+func (e *crashEngine) Put(key, val []byte) error {
+	return e.write([]op{{key: append([]byte(nil), key...), val: append([]byte(nil), val...)}})
+}
+
+func (e *crashEngine) Delete(key []byte) error {
+	return e.write([]op{{key: append([]byte(nil), key...), del: true}})
+}
+
+func (e *crashEngine) Bulk() kv.Bulk {
+	var (
+		batch     []op
+		autoFlush bool
+	)
+	add := func(o op) error {
+		batch = append(batch, o)
+		if autoFlush {
+			// non-atomic, every write is a batch
+			b := batch
+			batch = nil
+			return e.write(b)
+		}
+		return nil
+	}
+	return &struct {
+		kv.PutFunc
+		kv.DeleteFunc
+		kv.EnableAutoFlushFunc
+		kv.WriteFunc
+	}{
+		func(key, val []byte) error {
+			return add(op{key: append([]byte(nil), key...), val: append([]byte(nil), val...)})
+		},
+		func(key []byte) error {
+			return add(op{key: append([]byte(nil), key...), del: true})
+		},
+		func() { autoFlush = true },
+		func() error {
+			if len(batch) == 0 {
+				return nil
+			}
+			b := batch
+			batch = nil
+			return e.write(b)
+		},
+	}
+}
+
+func (e *crashEngine) DeleteRange(ctx context.Context, r kv.Range) error {
+	iter := e.Iterate(r)
+	var keys [][]byte
+	for iter.Next() {
+		keys = append(keys, append([]byte(nil), iter.Key()...))
+	}
+	iter.Release()
+
+	bulk := e.Bulk()
+	bulk.EnableAutoFlush()
+	for _, key := range keys {
+		if err := bulk.Delete(key); err != nil {
+			return err
+		}
+	}
+	return bulk.Write()
+}
+
+func apply(m map[string][]byte, batch []op) {
+	for _, o := range batch {
+		if o.del {
+			delete(m, string(o.key))
+		} else {
+			m[string(o.key)] = o.val
+		}
+	}
+}
+
+func (e *crashEngine) Sync() error {
+	if e.crashed() {
+		return errCrashed
+	}
+	e.syncs++
+	for _, batch := range e.pending {
+		apply(e.durable, batch)
+	}
+	e.pending = nil
+	return nil
+}
+
+// crash returns the durable view after the crash.
+func (e *crashEngine) crash() map[string][]byte {
+	for _, batch := range e.pending[:e.rng.IntN(len(e.pending)+1)] {
+		apply(e.durable, batch)
+	}
+	e.pending = nil
+	return e.durable
+}
+
+var (
+	crashTestAccounts = func() (addrs []thor.Address) {
+		for i := 0; i < 4; i++ {
+			addrs = append(addrs, thor.BytesToAddress([]byte{0xac, byte(i)}))
+		}
+		return
+	}()
+	crashTestKey, _ = crypto.HexToECDSA("99f0500549792796c14fed62011a51081dc5b5e68fe8bd8a13b86be829c4fd36")
+)
+
+// commitBlock commits a block on top of parent the way the node does.
+func commitBlock(repo *chain.Repository, stater *state.Stater, parent *chain.BlockSummary, round int) error {
+	num := parent.Header.Number() + 1
+	conflicts, err := repo.ScanConflicts(num)
+	if err != nil {
+		return err
+	}
+
+	st := stater.NewState(parent.Header.StateRoot(), parent.Header.Number(), parent.Conflicts, parent.SteadyNum)
+	for i, addr := range crashTestAccounts {
+		if err := st.SetBalance(addr, big.NewInt(int64(num))); err != nil {
+			return err
+		}
+		st.SetStorage(addr, thor.BytesToBytes32([]byte{byte(num % 8)}), thor.BytesToBytes32([]byte{byte(i), byte(num)}))
+	}
+	stage, err := st.Stage(num, conflicts)
+	if err != nil {
+		return err
+	}
+	root, err := stage.Commit()
+	if err != nil {
+		return err
+	}
+
+	blk := new(block.Builder).
+		ParentID(parent.Header.ID()).
+		Timestamp(parent.Header.Timestamp() + thor.BlockInterval).
+		TotalScore(parent.Header.TotalScore() + 1).
+		GasLimit(parent.Header.GasLimit()).
+		Beneficiary(thor.BytesToAddress([]byte{byte(round)})).
+		StateRoot(root).
+		Build()
+	sig, err := crypto.Sign(blk.Header().SigningHash().Bytes(), crashTestKey)
+	if err != nil {
+		return err
+	}
+	blk = blk.WithSignature(sig)
+
+	if err := repo.AddBlock(blk, nil, conflicts); err != nil {
+		return err
+	}
+	return repo.SetBestBlockID(blk.Header().ID())
+}
+
+// iterateTrie visits all nodes of the trie.
+func iterateTrie(tr *muxdb.Trie) error {
+	it := tr.NodeIterator(nil, 0)
+	nodes := 0
+	for it.Next(true) {
+		nodes++
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	if nodes == 0 {
+		return errors.New("empty trie")
+	}
+	return nil
+}
+
+// verifyConsistency checks the best block and its ancestors are all there, and the state
+// trie of the best block is fully readable.
+func verifyConsistency(t *testing.T, db *muxdb.MuxDB, repo *chain.Repository) {
+	best := repo.BestBlockSummary()
+	bestChain := repo.NewBestChain()
+	for n := uint32(0); n <= best.Header.Number(); n++ {
+		id, err := bestChain.GetBlockID(n)
+		require.NoError(t, err)
+		_, err = repo.GetBlockSummary(id)
+		require.NoError(t, err)
+	}
+
+	root := best.Header.StateRoot()
+	require.NoError(t, iterateTrie(db.NewTrie(state.AccountTrieName, root, best.Header.Number(), best.Conflicts)),
+		"account trie of best block #%v", best.Header.Number())
+
+	num := best.Header.Number()
+	if num == 0 {
+		return
+	}
+	st := state.New(db, root, num, best.Conflicts, best.SteadyNum)
+	for i, addr := range crashTestAccounts {
+		balance, err := st.GetBalance(addr)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(int64(num)), balance)
+
+		v, err := st.GetStorage(addr, thor.BytesToBytes32([]byte{byte(num % 8)}))
+		require.NoError(t, err)
+		assert.Equal(t, thor.BytesToBytes32([]byte{byte(i), byte(num)}), v)
+
+		storage, err := st.BuildStorageTrie(addr)
+		require.NoError(t, err)
+		require.NoError(t, iterateTrie(storage), "storage trie of %v at best block #%v", addr, num)
+	}
+}
+
+func TestCrashConsistency(t *testing.T) {
+	rounds := 200
+	if testing.Short() {
+		rounds = 20
+	}
+	rng := rand.New(rand.NewPCG(1, 2)) //#nosec G404
+
+	gene := genesis.NewDevnet()
+	durable := make(map[string][]byte)
+
+	// init the db with the genesis
+	e := newCrashEngine(durable, int(^uint(0)>>1), rng)
+	db := muxdb.NewMemWithEngine(e)
+	b0, _, _, err := gene.Build(state.NewStater(db))
+	require.NoError(t, err)
+	_, err = chain.NewRepository(db, b0)
+	require.NoError(t, err)
+	require.NoError(t, db.CommitBarrier())
+
+	for round := 0; round < rounds; round++ {
+		// crash at some point of committing the next few blocks
+		e := newCrashEngine(durable, 1+rng.IntN(200), rng)
+		db := muxdb.NewMemWithEngine(e)
+
+		repo, err := chain.NewRepository(db, b0)
+		require.NoError(t, err, "round %v: reopen", round)
+		verifyConsistency(t, db, repo)
+
+		stater := state.NewStater(db)
+		for {
+			if err := commitBlock(repo, stater, repo.BestBlockSummary(), round); err != nil {
+				require.True(t, e.crashed(), "round %v: %v", round, err)
+				break
+			}
+		}
+		durable = e.crash()
+	}
+}
+
+func TestBulkImportCrashConsistency(t *testing.T) {
+	rounds := 100
+	if testing.Short() {
+		rounds = 20
+	}
+	rng := rand.New(rand.NewPCG(3, 4)) //#nosec G404
+
+	gene := genesis.NewDevnet()
+	durable := make(map[string][]byte)
+
+	e := newCrashEngine(durable, int(^uint(0)>>1), rng)
+	db := muxdb.NewMemWithEngine(e)
+	b0, _, _, err := gene.Build(state.NewStater(db))
+	require.NoError(t, err)
+	_, err = chain.NewRepository(db, b0)
+	require.NoError(t, err)
+	require.NoError(t, db.CommitBarrier())
+
+	var lastBest uint32
+	for round := 0; round < rounds; round++ {
+		e := newCrashEngine(durable, 1+rng.IntN(300), rng)
+		db := muxdb.NewMemWithEngine(e)
+
+		repo, err := chain.NewRepository(db, b0)
+		if err != nil {
+			// crashed on discarding the uncommitted blocks
+			require.True(t, e.crashed(), "round %v: reopen: %v", round, err)
+			durable = e.crash()
+			continue
+		}
+		verifyConsistency(t, db, repo)
+
+		// reopened to the last committed batch, blocks of the uncommitted batch discarded
+		best := repo.BestBlockSummary().Header.Number()
+		assert.GreaterOrEqual(t, best, lastBest, "round %v", round)
+		maxNum, err := repo.GetMaxBlockNum()
+		require.NoError(t, err)
+		assert.Equal(t, best, maxNum, "round %v", round)
+		lastBest = best
+
+		if err := repo.BeginBulkImport(1+rng.IntN(8), 1<<20); err != nil {
+			require.True(t, e.crashed(), "round %v: %v", round, err)
+		} else {
+			for {
+				if err := commitBlock(repo, state.NewStater(db), repo.BestBlockSummary(), round); err != nil {
+					require.True(t, e.crashed(), "round %v: %v", round, err)
+					break
+				}
+			}
+		}
+		durable = e.crash()
+	}
+}
+
+func BenchmarkImport(b *testing.B) {
+	const blocks = 10000
+
+	for _, bulk := range []bool{false, true} {
+		name := "per-block"
+		if bulk {
+			name = "bulk"
+		}
+		b.Run(name, func(b *testing.B) {
+			syncs := 0
+			for i := 0; i < b.N; i++ {
+				e := newCrashEngine(make(map[string][]byte), int(^uint(0)>>1), nil)
+				db := muxdb.NewMemWithEngine(e)
+				b0, _, _, err := genesis.NewDevnet().Build(state.NewStater(db))
+				require.NoError(b, err)
+				repo, err := chain.NewRepository(db, b0)
+				require.NoError(b, err)
+
+				e.syncs = 0
+				if bulk {
+					require.NoError(b, repo.BeginBulkImport(256, 16*1024*1024))
+				}
+				stater := state.NewStater(db)
+				for n := 0; n < blocks; n++ {
+					require.NoError(b, commitBlock(repo, stater, repo.BestBlockSummary(), 0))
+				}
+				require.NoError(b, repo.EndBulkImport())
+				syncs += e.syncs
+			}
+			b.ReportMetric(float64(syncs)/float64(b.N), "syncs/op")
+		})
+	}
+}
@@ -0,0 +1,11 @@
+// Copyright (c) 2024 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package muxdb
+
+import "github.com/vechain/thor/v2/muxdb/internal/engine"
+
+// NewMemWithEngine creates a DB with memory DB settings on top of the given engine.
+var NewMemWithEngine = func(e engine.Engine) *MuxDB { return newMem(e) }
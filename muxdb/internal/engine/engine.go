@@ -15,4 +15,7 @@ import (
 type Engine interface {
 	kv.Store
 	io.Closer
+
+	// Sync makes all previous writes durable.
+	Sync() error
 }
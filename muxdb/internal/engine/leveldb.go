@@ -8,6 +8,7 @@ package engine
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/opt"
@@ -17,20 +18,27 @@ import (
 
 var (
 	writeOpt = opt.WriteOptions{}
+	syncOpt  = opt.WriteOptions{Sync: true}
 	readOpt  = opt.ReadOptions{}
 	scanOpt  = opt.ReadOptions{DontFillCache: true}
+
+	// the key written synchronously by Sync, since leveldb skips empty writes.
+	// it's out of all key spaces used by muxdb.
+	syncMarkerKey = []byte("\xffengine.sync")
 )
 
 type levelEngine struct {
 	db        *leveldb.DB
 	batchPool *sync.Pool
+	dirty     atomic.Bool // whether written since last sync
+	syncLock  sync.Mutex  // serializes syncs, so Sync never returns before a sync covering previous writes
 }
 
 // NewLevelEngine creates leveldb instance which implements the Engine interface.
 func NewLevelEngine(db *leveldb.DB) Engine {
 	return &levelEngine{
-		db,
-		&sync.Pool{
+		db: db,
+		batchPool: &sync.Pool{
 			New: func() interface{} {
 				return &leveldb.Batch{}
 			},
@@ -60,11 +68,38 @@ func (ldb *levelEngine) Has(key []byte) (bool, error) {
 }
 
 func (ldb *levelEngine) Put(key, val []byte) error {
-	return ldb.db.Put(key, val, &writeOpt)
+	if err := ldb.db.Put(key, val, &writeOpt); err != nil {
+		return err
+	}
+	ldb.dirty.Store(true)
+	return nil
 }
 
 func (ldb *levelEngine) Delete(key []byte) error {
-	return ldb.db.Delete(key, &writeOpt)
+	if err := ldb.db.Delete(key, &writeOpt); err != nil {
+		return err
+	}
+	ldb.dirty.Store(true)
+	return nil
+}
+
+// Sync syncs the write-ahead log of leveldb, which is append-only, so all previous
+// writes become durable. It's a no-op if nothing written since last sync.
+//
+// The dirty flag is set after a write is done, and cleared before syncing, so a write
+// done before Sync is called is either covered by the sync or leaves the flag set.
+func (ldb *levelEngine) Sync() error {
+	ldb.syncLock.Lock()
+	defer ldb.syncLock.Unlock()
+
+	if !ldb.dirty.Swap(false) {
+		return nil
+	}
+	if err := ldb.db.Put(syncMarkerKey, nil, &syncOpt); err != nil {
+		ldb.dirty.Store(true)
+		return err
+	}
+	return nil
 }
 
 func (ldb *levelEngine) Snapshot() kv.Snapshot {
@@ -117,6 +152,7 @@ func (ldb *levelEngine) Bulk() kv.Bulk {
 				if err := ldb.db.Write(batch, &writeOpt); err != nil {
 					return err
 				}
+				ldb.dirty.Store(true)
 			}
 			ldb.batchPool.Put(batch)
 			batch = nil
@@ -0,0 +1,139 @@
+// Copyright (c) 2024 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package engine
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+)
+
+// journalStorage tracks the bytes written to and synced of the leveldb journal.
+type journalStorage struct {
+	storage.Storage
+	written atomic.Int64 // bytes written to the journal
+	synced  atomic.Int64 // bytes written to the journal before its last sync
+	syncs   atomic.Int64 // count of journal syncs
+}
+
+type journalWriter struct {
+	storage.Writer
+	s *journalStorage
+}
+
+func (w *journalWriter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	w.s.written.Add(int64(n))
+	return n, err
+}
+
+func (w *journalWriter) Sync() error {
+	written := w.s.written.Load()
+	// as slow as a disk, so syncs overlap with other writes and syncs
+	time.Sleep(time.Millisecond)
+	if err := w.Writer.Sync(); err != nil {
+		return err
+	}
+	for {
+		synced := w.s.synced.Load()
+		if synced >= written || w.s.synced.CompareAndSwap(synced, written) {
+			break
+		}
+	}
+	w.s.syncs.Add(1)
+	return nil
+}
+
+func (s *journalStorage) Create(fd storage.FileDesc) (storage.Writer, error) {
+	w, err := s.Storage.Create(fd)
+	if err != nil || fd.Type != storage.TypeJournal {
+		return w, err
+	}
+	return &journalWriter{w, s}, nil
+}
+
+func newTestEngine(t *testing.T) (Engine, *journalStorage) {
+	stor := &journalStorage{Storage: storage.NewMemStorage()}
+	db, err := leveldb.Open(stor, nil)
+	require.NoError(t, err)
+	e := NewLevelEngine(db)
+	t.Cleanup(func() { e.Close() })
+	return e, stor
+}
+
+func TestLevelEngineSync(t *testing.T) {
+	e, stor := newTestEngine(t)
+
+	// nothing written
+	require.NoError(t, e.Sync())
+	assert.Equal(t, int64(0), stor.syncs.Load())
+
+	require.NoError(t, e.Put([]byte("k1"), []byte("v1")))
+	require.NoError(t, e.Sync())
+	assert.Equal(t, int64(1), stor.syncs.Load())
+	assert.Equal(t, stor.written.Load(), stor.synced.Load())
+
+	// nothing written since last sync
+	require.NoError(t, e.Sync())
+	assert.Equal(t, int64(1), stor.syncs.Load())
+
+	require.NoError(t, e.Delete([]byte("k1")))
+	require.NoError(t, e.Sync())
+	assert.Equal(t, int64(2), stor.syncs.Load())
+
+	// empty bulk writes nothing
+	require.NoError(t, e.Bulk().Write())
+	require.NoError(t, e.Sync())
+	assert.Equal(t, int64(2), stor.syncs.Load())
+
+	bulk := e.Bulk()
+	require.NoError(t, bulk.Put([]byte("k2"), []byte("v2")))
+	require.NoError(t, bulk.Write())
+	require.NoError(t, e.Sync())
+	assert.Equal(t, int64(3), stor.syncs.Load())
+	assert.Equal(t, stor.written.Load(), stor.synced.Load())
+
+	val, err := e.Get([]byte("k2"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("v2"), val)
+}
+
+func TestLevelEngineSyncConcurrent(t *testing.T) {
+	e, stor := newTestEngine(t)
+
+	// every Sync returns after a sync covering the writes done before it's called
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				key := []byte(fmt.Sprintf("%v-%v", i, j))
+				if j%2 == 0 {
+					assert.NoError(t, e.Put(key, key))
+				} else {
+					bulk := e.Bulk()
+					assert.NoError(t, bulk.Put(key, key))
+					assert.NoError(t, bulk.Write())
+				}
+				written := stor.written.Load()
+				assert.NoError(t, e.Sync())
+				if synced := stor.synced.Load(); synced < written {
+					assert.Fail(t, "write not synced", "synced %v < written %v", synced, written)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -7,6 +7,9 @@ package trie
 
 import (
 	"context"
+	"runtime"
+	"sync/atomic"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/v2/kv"
@@ -23,10 +26,17 @@ type Backend struct {
 	Cache    *Cache
 	LeafBank *LeafBank
 	HistSpace,
-	DedupedSpace byte
+	DedupedSpace,
+	RetainedSpace byte
 	HistPtnFactor,
 	DedupedPtnFactor uint32
 	CachedNodeTTL uint16
+	// CommitBatchSize is the max count of nodes written in a batch when committing a trie.
+	// Batches are written in turn, yielding the processor between them. 0 means no limit.
+	CommitBatchSize int
+	// CleanIOLimit is the max bytes per second of nodes deleted when cleaning history, 0 means no limit.
+	// It can be changed while cleaning.
+	CleanIOLimit atomic.Int64
 }
 
 // sequence helps convert sequence number from/to commitNum & distinctNum.
@@ -100,6 +110,15 @@ func (t *Trie) makeDedupedNodeKey(dst []byte, seq sequence, path []byte) []byte
 	return dst
 }
 
+func (t *Trie) makeRetainedNodeKey(dst []byte, seq sequence, path []byte) []byte {
+	dst = append(dst, t.back.RetainedSpace)    // space
+	dst = append(dst, t.name...)               // trie name
+	dst = encodePath(dst, path)                // path
+	dst = appendUint32(dst, seq.CommitNum())   // commit num
+	dst = appendUint32(dst, seq.DistinctNum()) // distinct num
+	return dst
+}
+
 // newDatabase creates a database instance for low-level trie construction.
 func (t *Trie) newDatabase() trie.Database {
 	var (
@@ -136,8 +155,9 @@ func (t *Trie) newDatabase() trie.Database {
 				}
 			}
 
+			var verified bool
 			defer func() {
-				if err == nil && !t.ext.IsNonCrypto() {
+				if err == nil && !verified && !t.ext.IsNonCrypto() {
 					// to ensure the node is correct, we need to verify the node hash.
 					// TODO: later can skip this step
 					if ok, err1 := trie.VerifyNodeHash(blob[len(dst):], thisHash); err1 != nil {
@@ -166,10 +186,32 @@ func (t *Trie) newDatabase() trie.Database {
 
 			// then from deduped space
 			keyBuf = t.makeDedupedNodeKey(keyBuf[:0], thisSeq, thisPath)
-			if val, err := snapshot.Get(keyBuf); err == nil {
-				return append(dst, val...), nil
+			val, err := snapshot.Get(keyBuf)
+			if err == nil {
+				if t.ext.IsNonCrypto() {
+					return append(dst, val...), nil
+				}
+				// the deduped node at the path is replaced once newer nodes dumped
+				if ok, _ := trie.VerifyNodeHash(val, thisHash); ok {
+					verified = true
+					return append(dst, val...), nil
+				}
+			} else if !snapshot.IsNotFound(err) {
+				return nil, err
 			}
-			return nil, err
+
+			// finally from retained space, where nodes of retained states are never replaced
+			keyBuf = t.makeRetainedNodeKey(keyBuf[:0], thisSeq, thisPath)
+			if retained, rerr := snapshot.Get(keyBuf); rerr == nil {
+				return append(dst, retained...), nil
+			} else if !snapshot.IsNotFound(rerr) {
+				return nil, rerr
+			}
+			if err != nil {
+				return nil, err
+			}
+			// the replaced deduped node, to fail the checksum
+			return append(dst, val...), nil
 		}),
 		databaseKeyEncodeFunc(func(hash []byte, seq uint64, path []byte) []byte {
 			thisHash = hash
@@ -272,6 +314,8 @@ func (t *Trie) Stage(newCommitNum, newDistinctNum uint32) (root thor.Bytes32, co
 		thisPath []byte
 		bulk     = t.back.Store.Bulk()
 		buf      []byte
+		bulks    []kv.Bulk // filled bulks, written before the current one
+		n        int       // count of nodes in the current bulk
 	)
 
 	// make a copy of the original trie to perform commit.
@@ -285,10 +329,16 @@ func (t *Trie) Stage(newCommitNum, newDistinctNum uint32) (root thor.Bytes32, co
 		trie.DatabaseKeyEncoder
 	}{
 		kv.PutFunc(func(_, blob []byte) error {
+			if t.back.CommitBatchSize > 0 && n >= t.back.CommitBatchSize {
+				bulks = append(bulks, bulk)
+				bulk = t.back.Store.Bulk()
+				n = 0
+			}
 			buf = t.makeHistNodeKey(buf[:0], newSeq, thisPath)
 			if err := bulk.Put(buf, blob); err != nil {
 				return err
 			}
+			n++
 			if !t.noFillCache {
 				t.back.Cache.AddNodeBlob(t.name, newSeq, thisPath, blob, true)
 			}
@@ -312,7 +362,14 @@ func (t *Trie) Stage(newCommitNum, newDistinctNum uint32) (root thor.Bytes32, co
 				return err
 			}
 		}
-		// real-commit, flush to db
+		// real-commit, flush to db.
+		// the trie root is referred only after the commit, so it's safe to write nodes in several batches.
+		for _, b := range bulks {
+			if err := b.Write(); err != nil {
+				return err
+			}
+			runtime.Gosched()
+		}
 		if err := bulk.Write(); err != nil {
 			return err
 		}
@@ -417,6 +474,43 @@ func (t *Trie) DumpNodes(ctx context.Context, baseCommitNum uint32, handleLeaf f
 	return bulk.Write()
 }
 
+// DumpRetainedNodes dumps all nodes of the trie into the retained space, which is never cleaned.
+// The trie keeps readable after its nodes in the hist space cleaned and in the deduped space replaced.
+func (t *Trie) DumpRetainedNodes(ctx context.Context, handleLeaf func(*trie.Leaf)) error {
+	if t.dirty {
+		return errors.New("dirty trie")
+	}
+	var (
+		checkContext = newContextChecker(ctx, 5000)
+		bulk         = t.back.Store.Bulk()
+		iter         = t.NodeIterator(nil, 0)
+		buf          []byte
+	)
+	bulk.EnableAutoFlush()
+
+	for iter.Next(true) {
+		if err := checkContext(); err != nil {
+			return err
+		}
+
+		if err := iter.Node(func(blob []byte) error {
+			buf = t.makeRetainedNodeKey(buf[:0], sequence(iter.SeqNum()), iter.Path())
+			return bulk.Put(buf, blob)
+		}); err != nil {
+			return err
+		}
+		if handleLeaf != nil {
+			if leaf := iter.Leaf(); leaf != nil {
+				handleLeaf(leaf)
+			}
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return bulk.Write()
+}
+
 // CleanHistory cleans history nodes within [startCommitNum, limitCommitNum).
 func CleanHistory(ctx context.Context, back *Backend, startCommitNum, limitCommitNum uint32) error {
 	startPtn := startCommitNum / back.HistPtnFactor
@@ -426,12 +520,78 @@ func CleanHistory(ctx context.Context, back *Backend, startCommitNum, limitCommi
 		startPtn = 1
 	}
 
-	return back.Store.DeleteRange(ctx, kv.Range{
+	return deleteRange(ctx, back, kv.Range{
 		Start: appendUint32([]byte{back.HistSpace}, startPtn),
 		Limit: appendUint32([]byte{back.HistSpace}, limitPtn),
 	})
 }
 
+// deleteRange deletes the keys in the range, backing off to keep the bytes of keys and values deleted
+// per second under back.CleanIOLimit. The limit is rechecked every cleanCheckInterval keys.
+// The rate is measured over a window of at most rateWindow, so that an idle or unlimited period
+// doesn't build up a budget for a burst afterwards.
+func deleteRange(ctx context.Context, back *Backend, r kv.Range) error {
+	const (
+		cleanCheckInterval = 1000
+		rateWindow         = time.Second
+	)
+
+	iter := back.Store.Iterate(r)
+	defer iter.Release()
+
+	bulk := back.Store.Bulk()
+	bulk.EnableAutoFlush()
+
+	var (
+		cnt         int
+		limit       int64
+		size        int64 // bytes deleted since windowStart
+		windowStart = time.Now()
+	)
+	for iter.Next() {
+		if err := bulk.Delete(iter.Key()); err != nil {
+			return err
+		}
+		size += int64(len(iter.Key()) + len(iter.Value()))
+
+		if cnt++; cnt%cleanCheckInterval != 0 {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		// restart the window once the limit changes
+		if newLimit := back.CleanIOLimit.Load(); newLimit != limit {
+			limit, size, windowStart = newLimit, 0, time.Now()
+			continue
+		}
+		if limit <= 0 {
+			continue
+		}
+		elapsed := time.Since(windowStart)
+		if wait := time.Duration(float64(size)/float64(limit)*float64(time.Second)) - elapsed; wait > 0 {
+			// the deletes held in the bulk are written before the pause, not in a burst after it
+			if err := bulk.Write(); err != nil {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+			size, windowStart = 0, time.Now()
+		} else if elapsed >= rateWindow {
+			size, windowStart = 0, time.Now()
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return bulk.Write()
+}
+
 // individual functions of trie database interface.
 type (
 	databaseKeyEncodeFunc func(hash []byte, seq uint64, path []byte) []byte
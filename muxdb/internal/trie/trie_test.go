@@ -7,12 +7,16 @@ package trie
 
 import (
 	"context"
+	"math"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/storage"
+	"github.com/vechain/thor/v2/kv"
 	"github.com/vechain/thor/v2/muxdb/internal/engine"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/trie"
@@ -31,6 +35,7 @@ func newBackend() *Backend {
 		LeafBank:         NewLeafBank(engine, 2, 100),
 		HistSpace:        0,
 		DedupedSpace:     1,
+		RetainedSpace:    3,
 		HistPtnFactor:    1,
 		DedupedPtnFactor: 1,
 		CachedNodeTTL:    100,
@@ -74,6 +79,38 @@ func TestTrie(t *testing.T) {
 		}
 	})
 
+	t.Run("batched commit", func(t *testing.T) {
+		back := newBackend()
+		store := &writeCountingStore{Store: back.Store}
+		back.Store = store
+		back.CommitBatchSize = 10
+
+		_back := newBackend()
+		tr := New(back, name, thor.Bytes32{}, 0, 0, false)
+		_tr := New(_back, name, thor.Bytes32{}, 0, 0, false)
+
+		for i := 0; i < 1000; i++ {
+			key := []byte(strconv.Itoa(i))
+			val := []byte("v" + strconv.Itoa(i))
+			tr.Update(key, val, nil)
+			_tr.Update(key, val, nil)
+		}
+		root, commit := tr.Stage(1, 0)
+		_root, _commit := _tr.Stage(1, 0)
+		assert.Equal(t, _root, root)
+
+		assert.Nil(t, commit())
+		assert.Nil(t, _commit())
+		assert.True(t, store.writes > 1)
+
+		tr = New(back, name, root, 1, 0, false)
+		for i := 0; i < 1000; i++ {
+			val, _, err := tr.Get([]byte(strconv.Itoa(i)))
+			assert.Nil(t, err)
+			assert.Equal(t, []byte("v"+strconv.Itoa(i)), val)
+		}
+	})
+
 	t.Run("fast get", func(t *testing.T) {
 		back := newBackend()
 		tr := New(back, name, thor.Bytes32{}, 0, 0, false)
@@ -119,4 +156,112 @@ func TestTrie(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("retained nodes", func(t *testing.T) {
+		back := newBackend()
+		back.DedupedPtnFactor = math.MaxUint32
+		tr := New(back, name, thor.Bytes32{}, 0, 0, false)
+
+		var roots []thor.Bytes32
+		for i := 0; i < 4; i++ {
+			for j := 0; j < 100; j++ {
+				tr.Update([]byte(strconv.Itoa(j)), []byte("v"+strconv.Itoa(i)), nil)
+			}
+			root, commit := tr.Stage(uint32(i), 0)
+			require.NoError(t, commit())
+			roots = append(roots, root)
+		}
+		require.NoError(t, New(back, name, roots[1], 1, 0, false).DumpRetainedNodes(context.Background(), nil))
+
+		// the deduped nodes of state 2 are replaced by the ones of state 3
+		for i := 2; i < 4; i++ {
+			require.NoError(t, New(back, name, roots[i], uint32(i), 0, false).DumpNodes(context.Background(), 0, nil))
+		}
+		require.NoError(t, CleanHistory(context.Background(), back, 1, 4))
+
+		for i, want := range []string{"v0", "v1", "", "v3"} {
+			val, _, err := New(back, name, roots[i], uint32(i), 0, false).Get([]byte("0"))
+			if want == "" {
+				assert.Error(t, err, "state %d", i)
+			} else {
+				assert.NoError(t, err, "state %d", i)
+				assert.Equal(t, []byte(want), val, "state %d", i)
+			}
+		}
+	})
+}
+
+// writeCountingStore counts writes of bulks.
+type writeCountingStore struct {
+	kv.Store
+	writes int
+}
+
+func (s *writeCountingStore) Bulk() kv.Bulk {
+	bulk := s.Store.Bulk()
+	return &struct {
+		kv.PutFunc
+		kv.DeleteFunc
+		kv.EnableAutoFlushFunc
+		kv.WriteFunc
+	}{
+		bulk.Put,
+		bulk.Delete,
+		bulk.EnableAutoFlush,
+		func() error {
+			s.writes++
+			return bulk.Write()
+		},
+	}
+}
+
+func TestCleanHistoryIOLimit(t *testing.T) {
+	fill := func(back *Backend) {
+		bulk := back.Store.Bulk()
+		val := make([]byte, 100)
+		for ptn := uint32(1); ptn < 4; ptn++ {
+			for i := uint32(0); i < 1000; i++ {
+				key := appendUint32(appendUint32([]byte{back.HistSpace}, ptn), i)
+				require.NoError(t, bulk.Put(key, val))
+			}
+		}
+		// out of the range to clean
+		require.NoError(t, bulk.Put(appendUint32([]byte{back.HistSpace}, 4), val))
+		require.NoError(t, bulk.Write())
+	}
+	count := func(back *Backend) int {
+		iter := back.Store.Iterate(kv.Range{})
+		defer iter.Release()
+		n := 0
+		for iter.Next() {
+			n++
+		}
+		return n
+	}
+
+	back := newBackend()
+	fill(back)
+	require.NoError(t, CleanHistory(context.Background(), back, 1, 4))
+	assert.Equal(t, 1, count(back))
+
+	// ~109 bytes per key, the limit applies after the first 1000 keys
+	back = newBackend()
+	back.CleanIOLimit.Store(1 << 20)
+	fill(back)
+	store := &writeCountingStore{Store: back.Store}
+	back.Store = store
+	start := time.Now()
+	require.NoError(t, CleanHistory(context.Background(), back, 1, 4))
+	assert.True(t, time.Since(start) >= 150*time.Millisecond, "elapsed %v", time.Since(start))
+	assert.Equal(t, 1, count(back))
+	// written before each of the 2 pauses, and at the end
+	assert.Equal(t, 3, store.writes)
+
+	// interrupted while backing off
+	back = newBackend()
+	back.CleanIOLimit.Store(1)
+	fill(back)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, CleanHistory(ctx, back, 1, 4))
 }
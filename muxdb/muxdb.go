@@ -27,6 +27,7 @@ const (
 	trieDedupedSpace  = byte(1) // the key space for deduped trie nodes.
 	trieLeafBankSpace = byte(2) // the key space for the trie leaf bank.
 	namedStoreSpace   = byte(3) // the key space for named store.
+	trieRetainedSpace = byte(4) // the key space for trie nodes of retained states.
 )
 
 const (
@@ -53,6 +54,9 @@ type Options struct {
 	TrieDedupedPartitionFactor uint32
 	// TrieWillCleanHistory is the hint to tell if historical nodes will be cleaned.
 	TrieWillCleanHistory bool
+	// TrieCommitBatchSize is the max count of trie nodes written in a batch on commit, 0 means no limit.
+	// Smaller batches bound the write latency of large commits, at a small cost of throughput.
+	TrieCommitBatchSize int
 
 	// OpenFilesCacheCapacity is the capacity of open files caching for underlying database.
 	OpenFilesCacheCapacity int
@@ -126,9 +130,11 @@ func Open(path string, options *Options) (*MuxDB, error) {
 			LeafBank:         trieLeafBank,
 			HistSpace:        trieHistSpace,
 			DedupedSpace:     trieDedupedSpace,
+			RetainedSpace:    trieRetainedSpace,
 			HistPtnFactor:    cfg.HistPtnFactor,
 			DedupedPtnFactor: cfg.DedupedPtnFactor,
 			CachedNodeTTL:    options.TrieCachedNodeTTL,
+			CommitBatchSize:  options.TrieCommitBatchSize,
 		},
 	}, nil
 }
@@ -138,7 +144,11 @@ func NewMem() *MuxDB {
 	storage := storage.NewMemStorage()
 	ldb, _ := leveldb.Open(storage, nil)
 
-	engine := engine.NewLevelEngine(ldb)
+	return newMem(engine.NewLevelEngine(ldb))
+}
+
+// newMem creates a DB with memory DB settings on top of the given engine.
+func newMem(engine engine.Engine) *MuxDB {
 	return &MuxDB{
 		engine: engine,
 		trieBackend: &trie.Backend{
@@ -147,6 +157,7 @@ func NewMem() *MuxDB {
 			LeafBank:         nil,
 			HistSpace:        trieHistSpace,
 			DedupedSpace:     trieDedupedSpace,
+			RetainedSpace:    trieRetainedSpace,
 			HistPtnFactor:    1,
 			DedupedPtnFactor: 1,
 			CachedNodeTTL:    32,
@@ -159,6 +170,16 @@ func (db *MuxDB) Close() error {
 	return db.engine.Close()
 }
 
+// CommitBarrier makes all previous writes durable before it returns.
+//
+// Writes go to the append-only journal of leveldb, so they survive a crash in the order
+// they were written, and a record never survives without the data written before it,
+// e.g. a block without the nodes of its state trie. The barrier is needed only when the
+// writes must be durable, e.g. before they are referred by another store.
+func (db *MuxDB) CommitBarrier() error {
+	return db.engine.Sync()
+}
+
 // NewTrie creates trie with existing root node.
 //
 // If root is zero or blake2b hash of an empty string, the trie is
@@ -194,6 +215,17 @@ func (db *MuxDB) CleanTrieHistory(ctx context.Context, startCommitNum, limitComm
 	return trie.CleanHistory(ctx, db.trieBackend, startCommitNum, limitCommitNum)
 }
 
+// SetTrieCleanIOLimit sets the max bytes per second of trie nodes deleted by CleanTrieHistory, 0 means no limit.
+// It takes effect on the cleaning in progress.
+func (db *MuxDB) SetTrieCleanIOLimit(bytesPerSec int64) {
+	db.trieBackend.CleanIOLimit.Store(bytesPerSec)
+}
+
+// TrieCleanIOLimit returns the max bytes per second of trie nodes deleted by CleanTrieHistory.
+func (db *MuxDB) TrieCleanIOLimit() int64 {
+	return db.trieBackend.CleanIOLimit.Load()
+}
+
 // NewStore creates named kv-store.
 func (db *MuxDB) NewStore(name string) kv.Store {
 	return kv.Bucket(string(namedStoreSpace) + name).NewStore(db.engine)
@@ -11,4 +11,6 @@ var (
 	metricConnectedPeers  = metrics.LazyLoadGauge("p2p_connected_peers_gauge")
 	metricDiscoveredNodes = metrics.LazyLoadCounter("p2p_discovered_node_count")
 	metricDialingNewNode  = metrics.LazyLoadGauge("p2p_dialing_new_node_count")
+
+	metricNATRenewalFailures = metrics.LazyLoadCounter("p2p_nat_renewal_failure_count")
 )
@@ -0,0 +1,217 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package p2psrv
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/nat"
+	"github.com/pkg/errors"
+)
+
+const (
+	natRenewInterval = 5 * time.Minute
+	natMapLifetime   = 20 * time.Minute
+)
+
+// NAT states reported by NATStatus.
+const (
+	NATUnknown  = "unknown"  // no NAT configured, or not verified yet
+	NATMapped   = "mapped"   // ports mapped on the gateway
+	NATUnmapped = "unmapped" // gateway unreachable or mapping lost
+	NATStatic   = "static"   // external address given by extip, no mapping needed
+)
+
+// NATStatus is the status of the port mapping of the p2p server.
+type NATStatus struct {
+	State        string
+	Mechanism    string
+	ExternalIP   net.IP
+	ExternalPort int
+	LastRenewal  time.Time // zero if never renewed
+}
+
+// ParseNAT parses a NAT spec as nat.Parse does. In addition, "extip:<IP>:<port>" pins the external port
+// as well as the IP, for nodes behind a manual port forwarding with a different external port.
+func ParseNAT(spec string) (nat.Interface, error) {
+	parts := strings.SplitN(spec, ":", 2)
+	if mech := strings.ToLower(parts[0]); len(parts) < 2 || (mech != "extip" && mech != "ip") {
+		return nat.Parse(spec)
+	}
+
+	if ip := net.ParseIP(parts[1]); ip != nil {
+		return &staticNAT{ip: ip}, nil
+	}
+	host, portStr, err := net.SplitHostPort(parts[1])
+	if err != nil {
+		return nil, errors.New("invalid IP address")
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return nil, errors.New("invalid IP address")
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil || port == 0 {
+		return nil, errors.New("invalid port")
+	}
+	return &staticNAT{ip: ip, port: int(port)}, nil
+}
+
+// staticNAT assumes the local machine is reachable on the given external IP, and optionally port,
+// with any required ports mapped manually.
+type staticNAT struct {
+	ip   net.IP
+	port int // zero if same as the listening port
+}
+
+func (n *staticNAT) ExternalIP() (net.IP, error) { return n.ip, nil }
+func (n *staticNAT) String() string {
+	if n.port == 0 {
+		return fmt.Sprintf("ExtIP(%v)", n.ip)
+	}
+	return fmt.Sprintf("ExtIP(%v)", net.JoinHostPort(n.ip.String(), strconv.Itoa(n.port)))
+}
+
+// These do nothing.
+func (*staticNAT) AddMapping(string, int, int, string, time.Duration) error { return nil }
+func (*staticNAT) DeleteMapping(string, int, int) error                     { return nil }
+
+type natMapping struct {
+	protocol string
+	port     int
+}
+
+// natMapper maps the listening ports on the gateway, and periodically verifies the external address
+// and re-establishes the mappings, which may be lost e.g. when the router reboots.
+type natMapper struct {
+	nat        nat.Interface
+	lock       sync.Mutex
+	mappings   []natMapping
+	advertised net.IP // external IP when first verified, which is advertised by discovery
+	status     NATStatus
+}
+
+func newNATMapper(n nat.Interface) *natMapper {
+	m := &natMapper{
+		nat:    n,
+		status: NATStatus{State: NATUnknown, Mechanism: n.String()},
+	}
+	if static, ok := n.(*staticNAT); ok {
+		m.status.State = NATStatic
+		m.status.ExternalIP = static.ip
+		m.status.ExternalPort = static.port
+	}
+	return m
+}
+
+// Status returns a copy of the current status.
+func (m *natMapper) Status() NATStatus {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.status
+}
+
+// ExternalAddr returns the external address of the local port, or nil if unknown.
+func (m *natMapper) ExternalAddr(port int) *net.UDPAddr {
+	status := m.Status()
+	if status.ExternalIP == nil {
+		return nil
+	}
+	if status.ExternalPort != 0 {
+		port = status.ExternalPort
+	}
+	return &net.UDPAddr{IP: status.ExternalIP, Port: port}
+}
+
+// Add maps the local port for the protocol on the gateway. The mapping is then kept by Renew.
+func (m *natMapper) Add(protocol string, port int) {
+	m.lock.Lock()
+	m.mappings = append(m.mappings, natMapping{protocol, port})
+	m.lock.Unlock()
+
+	m.Renew()
+}
+
+// Renew re-queries the external IP of the gateway, compares it with the advertised one, and
+// re-establishes the port mappings.
+func (m *natMapper) Renew() {
+	if m.Status().State == NATStatic {
+		return
+	}
+
+	m.lock.Lock()
+	mappings := append([]natMapping(nil), m.mappings...)
+	m.lock.Unlock()
+
+	ip, err := m.nat.ExternalIP()
+	if err != nil {
+		m.fail(errors.WithMessage(err, "query external IP"))
+		return
+	}
+	for _, mapping := range mappings {
+		if err := m.nat.AddMapping(mapping.protocol, mapping.port, mapping.port, "vechain thor", natMapLifetime); err != nil {
+			m.fail(errors.WithMessage(err, "add "+mapping.protocol+" mapping"))
+			return
+		}
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.advertised == nil {
+		m.advertised = ip
+	} else if !m.advertised.Equal(ip) {
+		logger.Warn("external IP changed, advertised node address is stale", "advertised", m.advertised, "external", ip)
+	}
+	if m.status.State == NATUnmapped {
+		logger.Info("port mapping re-established", "interface", m.nat, "external", ip)
+	}
+	m.status.State = NATMapped
+	m.status.ExternalIP = ip
+	if len(mappings) > 0 {
+		m.status.ExternalPort = mappings[0].port
+	}
+	m.status.LastRenewal = time.Now()
+}
+
+func (m *natMapper) fail(err error) {
+	metricNATRenewalFailures().Add(1)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.status.State != NATUnmapped {
+		logger.Warn("port mapping lost, node may be unreachable from the internet", "interface", m.nat, "err", err)
+	}
+	m.status.State = NATUnmapped
+}
+
+// Run renews the port mappings periodically until done is closed, then deletes them.
+func (m *natMapper) Run(done <-chan struct{}) {
+	ticker := time.NewTicker(natRenewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			m.lock.Lock()
+			mappings := m.mappings
+			m.lock.Unlock()
+			for _, mapping := range mappings {
+				m.nat.DeleteMapping(mapping.protocol, mapping.port, mapping.port)
+			}
+			return
+		case <-ticker.C:
+			m.Renew()
+		}
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package p2psrv
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNAT simulates a gateway which may reboot and lose the mappings.
+type fakeNAT struct {
+	lock     sync.Mutex
+	ip       net.IP
+	down     bool
+	mappings map[string]int
+}
+
+func newFakeNAT(ip string) *fakeNAT {
+	return &fakeNAT{ip: net.ParseIP(ip), mappings: make(map[string]int)}
+}
+
+func (n *fakeNAT) AddMapping(protocol string, extport, _ int, _ string, _ time.Duration) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.down {
+		return errors.New("gateway unreachable")
+	}
+	n.mappings[protocol] = extport
+	return nil
+}
+
+func (n *fakeNAT) DeleteMapping(protocol string, _, _ int) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	delete(n.mappings, protocol)
+	return nil
+}
+
+func (n *fakeNAT) ExternalIP() (net.IP, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.down {
+		return nil, errors.New("gateway unreachable")
+	}
+	return n.ip, nil
+}
+
+func (n *fakeNAT) String() string { return "fake" }
+
+// reboot drops all mappings, and the gateway stays unreachable until up.
+func (n *fakeNAT) reboot() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.down = true
+	n.mappings = make(map[string]int)
+}
+
+func (n *fakeNAT) up(ip string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.down = false
+	n.ip = net.ParseIP(ip)
+}
+
+func (n *fakeNAT) mapped() map[string]int {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	mappings := make(map[string]int)
+	for k, v := range n.mappings {
+		mappings[k] = v
+	}
+	return mappings
+}
+
+func TestNATMapper(t *testing.T) {
+	gateway := newFakeNAT("1.2.3.4")
+	m := newNATMapper(gateway)
+	assert.Equal(t, NATStatus{State: NATUnknown, Mechanism: "fake"}, m.Status())
+	assert.Nil(t, m.ExternalAddr(11235))
+
+	m.Add("tcp", 11235)
+	m.Add("udp", 11235)
+	assert.Equal(t, map[string]int{"tcp": 11235, "udp": 11235}, gateway.mapped())
+
+	status := m.Status()
+	assert.Equal(t, NATMapped, status.State)
+	assert.Equal(t, "1.2.3.4", status.ExternalIP.String())
+	assert.Equal(t, 11235, status.ExternalPort)
+	assert.False(t, status.LastRenewal.IsZero())
+	assert.Equal(t, &net.UDPAddr{IP: net.ParseIP("1.2.3.4"), Port: 11235}, m.ExternalAddr(11235))
+
+	// mapping lost while the router reboots
+	gateway.reboot()
+	m.Renew()
+	assert.Equal(t, NATUnmapped, m.Status().State)
+	assert.Equal(t, status.LastRenewal, m.Status().LastRenewal)
+	assert.Empty(t, gateway.mapped())
+
+	// recovered with a new external IP
+	gateway.up("5.6.7.8")
+	m.Renew()
+	recovered := m.Status()
+	assert.Equal(t, NATMapped, recovered.State)
+	assert.Equal(t, "5.6.7.8", recovered.ExternalIP.String())
+	assert.False(t, recovered.LastRenewal.Before(status.LastRenewal))
+	assert.Equal(t, map[string]int{"tcp": 11235, "udp": 11235}, gateway.mapped())
+
+	// mappings deleted when done
+	done := make(chan struct{})
+	close(done)
+	m.Run(done)
+	assert.Empty(t, gateway.mapped())
+}
+
+func TestNATMapperStatic(t *testing.T) {
+	n, err := ParseNAT("extip:1.2.3.4:30303")
+	require.NoError(t, err)
+
+	m := newNATMapper(n)
+	m.Add("tcp", 11235)
+	status := m.Status()
+	assert.Equal(t, NATStatic, status.State)
+	assert.Equal(t, "1.2.3.4", status.ExternalIP.String())
+	assert.Equal(t, 30303, status.ExternalPort)
+	assert.True(t, status.LastRenewal.IsZero())
+	assert.Equal(t, &net.UDPAddr{IP: net.ParseIP("1.2.3.4"), Port: 30303}, m.ExternalAddr(11235))
+
+	// port not pinned
+	n, err = ParseNAT("extip:1.2.3.4")
+	require.NoError(t, err)
+	m = newNATMapper(n)
+	assert.Equal(t, NATStatic, m.Status().State)
+	assert.Equal(t, &net.UDPAddr{IP: net.ParseIP("1.2.3.4"), Port: 11235}, m.ExternalAddr(11235))
+}
+
+func TestParseNAT(t *testing.T) {
+	for _, tc := range []struct {
+		spec string
+		want string
+	}{
+		{"extip:1.2.3.4", "ExtIP(1.2.3.4)"},
+		{"EXTIP:1.2.3.4:30303", "ExtIP(1.2.3.4:30303)"},
+		{"ip:::1", "ExtIP(::1)"},
+		{"extip:[::1]:30303", "ExtIP([::1]:30303)"},
+		{"any", "UPnP or NAT-PMP"},
+		{"upnp", "UPnP"},
+	} {
+		n, err := ParseNAT(tc.spec)
+		require.NoError(t, err, tc.spec)
+		assert.Equal(t, tc.want, n.String(), tc.spec)
+	}
+
+	n, err := ParseNAT("none")
+	assert.NoError(t, err)
+	assert.Nil(t, n)
+
+	for _, spec := range []string{"extip", "extip:", "extip:foo", "extip:1.2.3.4:0", "extip:1.2.3.4:65536", "extip:1.2.3.4:port", "foo"} {
+		_, err := ParseNAT(spec)
+		assert.Error(t, err, spec)
+	}
+}
@@ -16,7 +16,6 @@ import (
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/p2p/discover"
 	"github.com/ethereum/go-ethereum/p2p/discv5"
-	"github.com/ethereum/go-ethereum/p2p/nat"
 	"github.com/vechain/thor/v2/cache"
 	"github.com/vechain/thor/v2/co"
 	"github.com/vechain/thor/v2/log"
@@ -35,6 +34,7 @@ type Server struct {
 	knownNodes      *cache.PrioCache
 	discoveredNodes *cache.RandCache
 	dialingNodes    *nodeMap
+	nat             *natMapper
 }
 
 // New create a p2p server.
@@ -57,9 +57,9 @@ func New(opts *Options) *Server {
 				DiscoveryV5: false, // disable discovery inside p2p.Server instance(we use our own)
 				ListenAddr:  opts.ListenAddr,
 				NetRestrict: opts.NetRestrict,
-				NAT:         opts.NAT,
-				NoDial:      opts.NoDial,
-				DialRatio:   int(math.Sqrt(float64(opts.MaxPeers))),
+				// ports are mapped by the server itself, to keep track of the mapping status
+				NoDial:    opts.NoDial,
+				DialRatio: int(math.Sqrt(float64(opts.MaxPeers))),
 			},
 		},
 		done:            make(chan struct{}),
@@ -107,6 +107,13 @@ func (s *Server) Start(protocols []*p2p.Protocol, topic discv5.Topic) error {
 	if err := s.srv.Start(); err != nil {
 		return err
 	}
+	if s.opts.NAT != nil {
+		s.nat = newNATMapper(s.opts.NAT)
+		if laddr, err := net.ResolveTCPAddr("tcp", s.srv.ListenAddr); err == nil && !laddr.IP.IsLoopback() {
+			s.nat.Add("tcp", laddr.Port)
+		}
+		s.goes.Go(func() { s.nat.Run(s.done) })
+	}
 	if !s.opts.NoDiscovery {
 		if err := s.listenDiscV5(); err != nil {
 			return err
@@ -162,6 +169,14 @@ func (s *Server) RemoveStatic(node *discover.Node) {
 	s.srv.RemovePeer(node)
 }
 
+// NATStatus returns the status of the port mapping.
+func (s *Server) NATStatus() NATStatus {
+	if s.nat == nil {
+		return NATStatus{State: NATUnknown}
+	}
+	return s.nat.Status()
+}
+
 // NodeInfo gathers and returns a collection of metadata known about the host.
 func (s *Server) NodeInfo() *p2p.NodeInfo {
 	return s.srv.NodeInfo()
@@ -179,13 +194,13 @@ func (s *Server) listenDiscV5() (err error) {
 	}
 
 	realaddr := conn.LocalAddr().(*net.UDPAddr)
-	if s.opts.NAT != nil {
+	if s.nat != nil {
 		if !realaddr.IP.IsLoopback() {
-			s.goes.Go(func() { nat.Map(s.opts.NAT, s.done, "udp", realaddr.Port, realaddr.Port, "vechain discovery") })
+			s.nat.Add("udp", realaddr.Port)
 		}
-		// TODO: react to external IP changes over time.
-		if ext, err := s.opts.NAT.ExternalIP(); err == nil {
-			realaddr = &net.UDPAddr{IP: ext, Port: realaddr.Port}
+		// external IP changes are reported by the mapper, but not re-advertised
+		if ext := s.nat.ExternalAddr(realaddr.Port); ext != nil {
+			realaddr = ext
 		}
 	}
 
@@ -65,6 +65,11 @@ func (f *Flow) TotalScore() uint64 {
 	return f.runtime.Context().TotalScore
 }
 
+// IsEmpty returns whether no tx is adopted.
+func (f *Flow) IsEmpty() bool {
+	return len(f.txs) == 0
+}
+
 func (f *Flow) findDep(txID thor.Bytes32) (found bool, reverted bool, err error) {
 	if reverted, ok := f.processedTxs[txID]; ok {
 		return true, reverted, nil
@@ -0,0 +1,166 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package packer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// GasLimitAlgorithm decides the gas limit of blocks to be packed.
+type GasLimitAlgorithm interface {
+	// GasLimit returns the desired gas limit of the block on top of the parent. The target gas limit
+	// is 0 if not set. The packer moves the gas limit from the parent's one by at most the bound
+	// allowed by the consensus.
+	GasLimit(parent *block.Header, target uint64) uint64
+}
+
+// SimpleGasLimit heads to the target gas limit, or keeps the parent's one if no target.
+type SimpleGasLimit struct{}
+
+func (SimpleGasLimit) GasLimit(parent *block.Header, target uint64) uint64 {
+	if target != 0 {
+		return target
+	}
+	return parent.GasLimit()
+}
+
+// PIDParams are the gains of the PID controller.
+type PIDParams struct {
+	Kp, Ki, Kd float64
+}
+
+// DefaultPIDParams are the gains tuned for the gas limit change bound of the consensus.
+var DefaultPIDParams = PIDParams{Kp: 0.5, Ki: 0.01, Kd: 0.05}
+
+// PIDTargetUtilization is the ratio of gas used to gas limit the PID controller heads to.
+const PIDTargetUtilization = 0.8
+
+// pidState is the state of the controller after processing the parent block.
+type pidState struct {
+	ParentID  thor.Bytes32 `json:"parentID"`
+	GasLimit  uint64       `json:"gasLimit"`
+	Integral  float64      `json:"integral"`
+	LastError float64      `json:"lastError"`
+}
+
+// PIDGasLimit adjusts the gas limit with a PID controller, heading to PIDTargetUtilization of blocks.
+// The target gas limit, if set, caps the gas limit.
+type PIDGasLimit struct {
+	params    PIDParams
+	statePath string
+
+	lock  sync.Mutex
+	state *pidState
+}
+
+// NewPIDGasLimit creates the PID gas limit algorithm. The controller state is persisted in the file
+// at statePath between blocks, and restored from it if exists. The state is not persisted if statePath is empty.
+// An undecodable state file is logged and discarded, the controller then starts over from the next parent.
+func NewPIDGasLimit(params PIDParams, statePath string) (*PIDGasLimit, error) {
+	p := &PIDGasLimit{params: params, statePath: statePath}
+	if statePath == "" {
+		return p, nil
+	}
+
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return p, nil
+		}
+		return nil, err
+	}
+	var state pidState
+	if err := json.Unmarshal(data, &state); err != nil {
+		logger.Warn("failed to decode pid state, reset", "path", statePath, "err", err)
+		return p, nil
+	}
+	p.state = &state
+	return p, nil
+}
+
+func (p *PIDGasLimit) GasLimit(parent *block.Header, target uint64) uint64 {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	// the parent may be scheduled on more than once
+	if p.state == nil || p.state.ParentID != parent.ID() {
+		p.update(parent)
+	}
+
+	if target != 0 && p.state.GasLimit > target {
+		return target
+	}
+	return p.state.GasLimit
+}
+
+// update feeds the utilization of the parent block to the controller.
+func (p *PIDGasLimit) update(parent *block.Header) {
+	var (
+		parentGasLimit = parent.GasLimit()
+		e              = float64(parent.GasUsed())/float64(parentGasLimit) - PIDTargetUtilization
+		integral       float64
+		derivative     float64
+	)
+	if p.state != nil {
+		integral = p.state.Integral
+		derivative = e - p.state.LastError
+	}
+
+	output := p.params.Kp*e + p.params.Ki*(integral+e) + p.params.Kd*derivative
+	delta := output * float64(parentGasLimit)
+
+	// integrate only if the output is not bounded by the consensus, to avoid windup
+	maxDelta := float64(parentGasLimit / thor.GasLimitBoundDivisor)
+	if delta >= -maxDelta && delta <= maxDelta {
+		integral += e
+	}
+
+	p.state = &pidState{
+		ParentID:  parent.ID(),
+		GasLimit:  block.GasLimit(parentGasLimit).Adjust(int64(delta)),
+		Integral:  integral,
+		LastError: e,
+	}
+	if err := p.save(); err != nil {
+		logger.Warn("failed to save pid state", "err", err)
+	}
+}
+
+// save writes the state to a temp file and then renames it, so that a crash never leaves a truncated state file.
+func (p *PIDGasLimit) save() error {
+	if p.statePath == "" {
+		return nil
+	}
+	data, err := json.Marshal(p.state)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(p.statePath), filepath.Base(p.statePath)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name()) // no-op once renamed
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), p.statePath)
+}
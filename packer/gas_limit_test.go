@@ -0,0 +1,110 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package packer_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/packer"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// simulateBlocks packs n blocks upon parent with the algorithm. Each block uses the constant demand of gas, up to its gas limit.
+func simulateBlocks(t *testing.T, alg packer.GasLimitAlgorithm, parent *block.Header, demand uint64, n int) *block.Header {
+	for i := 0; i < n; i++ {
+		gasLimit := block.GasLimit(alg.GasLimit(parent, 0)).Qualify(parent.GasLimit())
+		require.True(t, block.GasLimit(gasLimit).IsValid(parent.GasLimit()))
+
+		parent = new(block.Builder).
+			ParentID(parent.ID()).
+			Timestamp(parent.Timestamp() + thor.BlockInterval).
+			GasLimit(gasLimit).
+			GasUsed(min(demand, gasLimit)).
+			Build().Header()
+	}
+	return parent
+}
+
+func utilization(h *block.Header) float64 {
+	return float64(h.GasUsed()) / float64(h.GasLimit())
+}
+
+func TestPIDGasLimitConverges(t *testing.T) {
+	const initial = 40_000_000
+
+	for _, demandRatio := range []float64{0.84, 0.82, 0.8, 0.78, 0.76} {
+		pid, err := packer.NewPIDGasLimit(packer.DefaultPIDParams, "")
+		require.NoError(t, err)
+
+		demand := uint64(demandRatio * initial)
+		genesis := new(block.Builder).GasLimit(initial).GasUsed(demand).Build().Header()
+
+		last := simulateBlocks(t, pid, genesis, demand, 100)
+		assert.InDelta(t, packer.PIDTargetUtilization, utilization(last), 0.001, "demand ratio %v", demandRatio)
+	}
+}
+
+func TestPIDGasLimitState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pid.json")
+	pid, err := packer.NewPIDGasLimit(packer.DefaultPIDParams, path)
+	require.NoError(t, err)
+
+	genesis := new(block.Builder).GasLimit(40_000_000).GasUsed(36_000_000).Build().Header()
+	parent := simulateBlocks(t, pid, genesis, 36_000_000, 10)
+
+	// scheduling on the same parent doesn't feed the controller again
+	gasLimit := pid.GasLimit(parent, 0)
+	assert.Equal(t, gasLimit, pid.GasLimit(parent, 0))
+	assert.True(t, gasLimit > parent.GasLimit())
+	// capped by the target
+	assert.Equal(t, parent.GasLimit(), pid.GasLimit(parent, parent.GasLimit()))
+
+	// restored from the persisted state
+	restored, err := packer.NewPIDGasLimit(packer.DefaultPIDParams, path)
+	require.NoError(t, err)
+	assert.Equal(t, gasLimit, restored.GasLimit(parent, 0))
+
+	// the restored controller goes on like the original one
+	next := simulateBlocks(t, pid, parent, 36_000_000, 10)
+	assert.Equal(t, next.GasLimit(), simulateBlocks(t, restored, parent, 36_000_000, 10).GasLimit())
+
+	// no temp file is left behind
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestPIDGasLimitCorruptState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pid.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"parentID":`), 0o600))
+
+	// the corrupt state is discarded, and the controller starts over
+	pid, err := packer.NewPIDGasLimit(packer.DefaultPIDParams, path)
+	require.NoError(t, err)
+	fresh, err := packer.NewPIDGasLimit(packer.DefaultPIDParams, "")
+	require.NoError(t, err)
+
+	genesis := new(block.Builder).GasLimit(40_000_000).GasUsed(36_000_000).Build().Header()
+	assert.Equal(t, fresh.GasLimit(genesis, 0), pid.GasLimit(genesis, 0))
+
+	// and the state file is overwritten
+	restored, err := packer.NewPIDGasLimit(packer.DefaultPIDParams, path)
+	require.NoError(t, err)
+	assert.Equal(t, pid.GasLimit(genesis, 0), restored.GasLimit(genesis, 0))
+}
+
+func TestSimpleGasLimit(t *testing.T) {
+	parent := new(block.Builder).GasLimit(thor.InitialGasLimit).Build().Header()
+
+	var alg packer.SimpleGasLimit
+	assert.Equal(t, parent.GasLimit(), alg.GasLimit(parent, 0))
+	assert.Equal(t, uint64(thor.InitialGasLimit*2), alg.GasLimit(parent, thor.InitialGasLimit*2))
+}
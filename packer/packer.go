@@ -9,6 +9,7 @@ import (
 	"github.com/vechain/thor/v2/block"
 	"github.com/vechain/thor/v2/builtin"
 	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/log"
 	"github.com/vechain/thor/v2/poa"
 	"github.com/vechain/thor/v2/runtime"
 	"github.com/vechain/thor/v2/state"
@@ -17,15 +18,18 @@ import (
 	"github.com/vechain/thor/v2/xenv"
 )
 
+var logger = log.WithContext("pkg", "packer")
+
 // Packer to pack txs and build new blocks.
 type Packer struct {
-	repo           *chain.Repository
-	stater         *state.Stater
-	nodeMaster     thor.Address
-	beneficiary    *thor.Address
-	targetGasLimit uint64
-	forkConfig     thor.ForkConfig
-	seeder         *poa.Seeder
+	repo              *chain.Repository
+	stater            *state.Stater
+	nodeMaster        thor.Address
+	beneficiary       *thor.Address
+	targetGasLimit    uint64
+	gasLimitAlgorithm GasLimitAlgorithm
+	forkConfig        thor.ForkConfig
+	seeder            *poa.Seeder
 }
 
 // New create a new Packer instance.
@@ -43,6 +47,7 @@ func New(
 		nodeMaster,
 		beneficiary,
 		0,
+		SimpleGasLimit{},
 		forkConfig,
 		poa.NewSeeder(repo),
 	}
@@ -129,7 +134,7 @@ func (p *Packer) Schedule(parent *chain.BlockSummary, nowTimestamp uint64) (flow
 			Signer:      p.nodeMaster,
 			Number:      parent.Header.Number() + 1,
 			Time:        newBlockTime,
-			GasLimit:    p.gasLimit(parent.Header.GasLimit()),
+			GasLimit:    p.gasLimit(parent.Header),
 			TotalScore:  parent.Header.TotalScore() + score,
 		},
 		p.forkConfig)
@@ -150,7 +155,7 @@ func (p *Packer) Mock(parent *chain.BlockSummary, targetTime uint64, gasLimit ui
 
 	gl := gasLimit
 	if gasLimit == 0 {
-		gl = p.gasLimit(parent.Header.GasLimit())
+		gl = p.gasLimit(parent.Header)
 	}
 
 	rt := runtime.New(
@@ -169,11 +174,12 @@ func (p *Packer) Mock(parent *chain.BlockSummary, targetTime uint64, gasLimit ui
 	return newFlow(p, parent.Header, rt, features), nil
 }
 
-func (p *Packer) gasLimit(parentGasLimit uint64) uint64 {
-	if p.targetGasLimit != 0 {
-		return block.GasLimit(p.targetGasLimit).Qualify(parentGasLimit)
+func (p *Packer) gasLimit(parent *block.Header) uint64 {
+	gl := p.gasLimitAlgorithm.GasLimit(parent, p.targetGasLimit)
+	if gl == parent.GasLimit() {
+		return gl
 	}
-	return parentGasLimit
+	return block.GasLimit(gl).Qualify(parent.GasLimit())
 }
 
 // SetTargetGasLimit set target gas limit, the Packer will adjust block gas limit close to
@@ -181,3 +187,8 @@ func (p *Packer) gasLimit(parentGasLimit uint64) uint64 {
 func (p *Packer) SetTargetGasLimit(gl uint64) {
 	p.targetGasLimit = gl
 }
+
+// SetGasLimitAlgorithm sets the algorithm deciding the block gas limit, SimpleGasLimit by default.
+func (p *Packer) SetGasLimitAlgorithm(alg GasLimitAlgorithm) {
+	p.gasLimitAlgorithm = alg
+}
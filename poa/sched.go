@@ -17,6 +17,7 @@ type Scheduler interface {
 	Schedule(nowTime uint64) (newBlockTime uint64)
 	IsTheTime(newBlockTime uint64) bool
 	Updates(newBlockTime uint64) (updates []Proposer, score uint64)
+	WhoseTurn(blockTime uint64) thor.Address
 }
 
 // SchedulerV1 to schedule the time when a proposer to produce a block.
@@ -68,6 +69,11 @@ func (s *SchedulerV1) whoseTurn(t uint64) Proposer {
 	return s.actives[index]
 }
 
+// WhoseTurn returns the proposer scheduled at the blockTime, which should be T aligned and > parentBlockTime.
+func (s *SchedulerV1) WhoseTurn(blockTime uint64) thor.Address {
+	return s.whoseTurn(blockTime).Address
+}
+
 // Schedule to determine time of the proposer to produce a block, according to `nowTime`.
 // `newBlockTime` is promised to be >= nowTime and > parentBlockTime
 func (s *SchedulerV1) Schedule(nowTime uint64) (newBlockTime uint64) {
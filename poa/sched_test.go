@@ -170,3 +170,30 @@ func TestActivateInV2(t *testing.T) {
 		assert.Equal(t, tt.want, score)
 	}
 }
+
+func TestWhoseTurn(t *testing.T) {
+	all := []poa.Proposer{{p1, true}, {p2, true}, {p3, true}, {p4, false}, {p5, true}}
+
+	for _, newSched := range []func(addr thor.Address) (poa.Scheduler, error){
+		func(addr thor.Address) (poa.Scheduler, error) {
+			return poa.NewSchedulerV1(addr, all, 1, parentTime)
+		},
+		func(addr thor.Address) (poa.Scheduler, error) {
+			return poa.NewSchedulerV2(addr, all, 1, parentTime, []byte("seed"))
+		},
+	} {
+		sched, err := newSched(p1)
+		assert.Nil(t, err)
+
+		for i := uint64(1); i <= 20; i++ {
+			blockTime := parentTime + i*thor.BlockInterval
+			addr := sched.WhoseTurn(blockTime)
+			assert.NotEqual(t, p4, addr, "inactive proposer should not be scheduled")
+
+			// the proposer of the turn is scheduled at the time
+			p, err := newSched(addr)
+			assert.Nil(t, err)
+			assert.True(t, p.IsTheTime(blockTime))
+		}
+	}
+}
@@ -121,8 +121,14 @@ func (s *SchedulerV2) IsScheduled(blockTime uint64, proposer thor.Address) bool
 		return false
 	}
 
+	return s.WhoseTurn(blockTime) == proposer
+}
+
+// WhoseTurn returns the proposer scheduled at the blockTime, which should be T aligned and > parentBlockTime.
+func (s *SchedulerV2) WhoseTurn(blockTime uint64) thor.Address {
+	T := thor.BlockInterval
 	index := (blockTime - s.parentBlockTime - T) / T % uint64(len(s.shuffled))
-	return s.shuffled[index] == proposer
+	return s.shuffled[index]
 }
 
 // Updates returns proposers whose status are changed, and the score when new block time is assumed to be newBlockTime.
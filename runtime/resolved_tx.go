@@ -6,17 +6,30 @@
 package runtime
 
 import (
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/builtin/prototype"
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/tx"
 	"github.com/vechain/thor/v2/xenv"
 )
 
+// IntrinsicGasError is returned when the gas provided by a tx is below its intrinsic gas.
+type IntrinsicGasError struct {
+	Required uint64 // intrinsic gas of the tx
+	Provided uint64 // gas provided by the tx
+	Clauses  int    // count of clauses
+}
+
+func (e *IntrinsicGasError) Error() string {
+	return fmt.Sprintf("intrinsic gas exceeds provided gas: required %d, provided %d, clauses %d", e.Required, e.Provided, e.Clauses)
+}
+
 // ResolvedTransaction resolve the transaction according to given state.
 type ResolvedTransaction struct {
 	tx           *tx.Transaction
@@ -37,7 +50,11 @@ func ResolveTransaction(tx *tx.Transaction) (*ResolvedTransaction, error) {
 		return nil, err
 	}
 	if tx.Gas() < intrinsicGas {
-		return nil, errors.New("intrinsic gas exceeds provided gas")
+		return nil, &IntrinsicGasError{
+			Required: intrinsicGas,
+			Provided: tx.Gas(),
+			Clauses:  len(tx.Clauses()),
+		}
 	}
 	delegator, err := tx.Delegator()
 	if err != nil {
@@ -99,6 +116,18 @@ func (r *ResolvedTransaction) BuyGas(state *state.State, blockTime uint64) (
 	prepaid *big.Int,
 	returnGas func(uint64) error,
 	err error,
+) {
+	return r.buyGas(state, blockTime, nil)
+}
+
+// buyGas buys gas, decoding the credit plan and user objects through the cache if not nil.
+func (r *ResolvedTransaction) buyGas(state *state.State, blockTime uint64, creditCache *prototype.Cache) (
+	baseGasPrice *big.Int,
+	gasPrice *big.Int,
+	payer thor.Address,
+	prepaid *big.Int,
+	returnGas func(uint64) error,
+	err error,
 ) {
 	if baseGasPrice, err = builtin.Params.Native(state).Get(thor.KeyBaseGasPrice); err != nil {
 		return
@@ -132,7 +161,7 @@ func (r *ResolvedTransaction) BuyGas(state *state.State, blockTime uint64) (
 
 	commonTo := r.CommonTo()
 	if commonTo != nil {
-		binding := builtin.Prototype.Native(state).Bind(*commonTo)
+		binding := builtin.Prototype.Native(state).Bind(*commonTo).WithCache(creditCache)
 		var credit *big.Int
 		if credit, err = binding.UserCredit(r.Origin, blockTime); err != nil {
 			return
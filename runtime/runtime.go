@@ -16,6 +16,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/v2/abi"
 	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/builtin/prototype"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/runtime/statedb"
 	"github.com/vechain/thor/v2/state"
@@ -86,6 +87,7 @@ type Runtime struct {
 	state       *state.State
 	ctx         *xenv.BlockContext
 	chainConfig vm.ChainConfig
+	creditCache *prototype.Cache // decoded credit plans of the sponsored txs, scoped to the block
 }
 
 // New create a Runtime object.
@@ -131,6 +133,7 @@ func New(
 		state:       state,
 		ctx:         ctx,
 		chainConfig: currentChainConfig,
+		creditCache: prototype.NewCache(),
 	}
 	return &rt
 }
@@ -371,6 +374,33 @@ func (rt *Runtime) PrepareClause(
 	return
 }
 
+// SimulateClause executes the clause at clauseIndex of the tx alone, in the context of the tx, without
+// executing the other clauses or making a receipt. Gas is bought the same way as executing the tx, and the
+// gas available to the clause is the gas of the tx net of the intrinsic gas, so the gas used by the clause
+// is that gas minus Output.LeftOverGas. The state of the runtime is changed, a disposable one is expected.
+func (rt *Runtime) SimulateClause(tx *tx.Transaction, clauseIndex uint32) (*Output, error) {
+	resolvedTx, err := ResolveTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+	if int(clauseIndex) >= len(resolvedTx.Clauses) {
+		return nil, fmt.Errorf("clause index out of range: %d of %d clauses", clauseIndex, len(resolvedTx.Clauses))
+	}
+
+	_, gasPrice, payer, _, _, err := resolvedTx.buyGas(rt.state, rt.ctx.Time, rt.creditCache)
+	if err != nil {
+		return nil, err
+	}
+	txCtx, err := resolvedTx.ToContext(gasPrice, payer, rt.ctx.Number, rt.chain.GetBlockID)
+	if err != nil {
+		return nil, err
+	}
+
+	exec, _ := rt.PrepareClause(resolvedTx.Clauses[clauseIndex], clauseIndex, tx.Gas()-resolvedTx.IntrinsicGas, txCtx)
+	output, _, err := exec()
+	return output, err
+}
+
 // ExecuteTransaction executes a transaction.
 // If some clause failed, receipt.Outputs will be nil and vmOutputs may shorter than clause count.
 func (rt *Runtime) ExecuteTransaction(tx *tx.Transaction) (receipt *tx.Receipt, err error) {
@@ -394,7 +424,7 @@ func (rt *Runtime) PrepareTransaction(tx *tx.Transaction) (*TransactionExecutor,
 		return nil, err
 	}
 
-	baseGasPrice, gasPrice, payer, _, returnGas, err := resolvedTx.BuyGas(rt.state, rt.ctx.Time)
+	baseGasPrice, gasPrice, payer, _, returnGas, err := resolvedTx.buyGas(rt.state, rt.ctx.Time, rt.creditCache)
 	if err != nil {
 		return nil, err
 	}
@@ -6,6 +6,7 @@
 package runtime_test
 
 import (
+	"crypto/ecdsa"
 	"encoding/hex"
 	"math"
 	"math/big"
@@ -478,6 +479,60 @@ func TestExecuteTransaction(t *testing.T) {
 	_ = receipt
 }
 
+func TestSimulateClause(t *testing.T) {
+	origin := genesis.DevAccounts()[0]
+	to := genesis.DevAccounts()[1].Address
+
+	db := muxdb.NewMem()
+	b0, _, _, err := genesis.NewDevnet().Build(state.NewStater(db))
+	assert.Nil(t, err)
+	repo, _ := chain.NewRepository(db, b0)
+
+	transfer, _ := builtin.Energy.ABI.MethodByName("transfer")
+	data, err := transfer.EncodeInput(to, big.NewInt(1000))
+	assert.Nil(t, err)
+	trx := tx.MustSign(new(tx.Builder).
+		ChainTag(repo.ChainTag()).
+		Expiration(10).
+		Clause(tx.NewClause(&to).WithValue(big.NewInt(1))).
+		Clause(tx.NewClause(&builtin.Energy.Address).WithData(data)).
+		Gas(200000).
+		Build(), origin.PrivateKey)
+
+	newRuntime := func() *runtime.Runtime {
+		st := state.New(db, b0.Header().StateRoot(), 0, 0, 0)
+		return runtime.New(repo.NewChain(b0.Header().ID()), st, &xenv.BlockContext{Time: b0.Header().Timestamp()}, thor.NoFork)
+	}
+
+	// the energy transfer of clause 1, without the VET transfer of clause 0
+	output, err := newRuntime().SimulateClause(trx, 1)
+	assert.Nil(t, err)
+	assert.Nil(t, output.VMErr)
+	assert.Empty(t, output.Transfers)
+	if assert.Len(t, output.Events, 1) {
+		event := output.Events[0]
+		transferEvent, _ := builtin.Energy.ABI.EventByName("Transfer")
+		assert.Equal(t, builtin.Energy.Address, event.Address)
+		assert.Equal(t, []thor.Bytes32{
+			transferEvent.ID(),
+			thor.BytesToBytes32(origin.Address.Bytes()),
+			thor.BytesToBytes32(to.Bytes()),
+		}, event.Topics)
+		assert.Equal(t, thor.BytesToBytes32(big.NewInt(1000).Bytes()).Bytes(), event.Data)
+	}
+	intrinsicGas, err := trx.IntrinsicGas()
+	assert.Nil(t, err)
+	assert.Less(t, output.LeftOverGas, trx.Gas()-intrinsicGas)
+
+	output, err = newRuntime().SimulateClause(trx, 0)
+	assert.Nil(t, err)
+	assert.Empty(t, output.Events)
+	assert.Len(t, output.Transfers, 1)
+
+	_, err = newRuntime().SimulateClause(trx, 2)
+	assert.EqualError(t, err, "clause index out of range: 2 of 2 clauses")
+}
+
 func TestExecuteTransactionFailure(t *testing.T) {
 	origin := genesis.DevAccounts()[0]
 
@@ -503,3 +558,83 @@ func TestExecuteTransactionFailure(t *testing.T) {
 
 	assert.NotNil(t, err)
 }
+
+// BenchmarkExecuteSponsoredTxs executes a block of txs to the same contract, either sponsored by its credit
+// plan or paid by the origins, to measure the cost of the credit computations against the execution.
+func BenchmarkExecuteSponsoredTxs(b *testing.B) {
+	const (
+		users       = 10
+		txsPerBlock = 500
+	)
+
+	db := muxdb.NewMem()
+	b0, _, _, err := genesis.NewDevnet().Build(state.NewStater(db))
+	if err != nil {
+		b.Fatal(err)
+	}
+	repo, _ := chain.NewRepository(db, b0)
+
+	contract := thor.BytesToAddress([]byte("contract"))
+	keys := make([]*ecdsa.PrivateKey, users)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+	}
+	// signers are recovered before the execution, and cached by the txs
+	txs := make([]*tx.Transaction, txsPerBlock)
+	for i := range txs {
+		txs[i] = tx.MustSign(new(tx.Builder).
+			ChainTag(repo.ChainTag()).
+			Gas(21000).
+			Expiration(100).
+			Nonce(uint64(i)).
+			Clause(tx.NewClause(&contract)).
+			Build(), keys[i%users])
+		if _, err := txs[i].Origin(); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	for _, sponsored := range []bool{true, false} {
+		name := "origin"
+		if sponsored {
+			name = "sponsored"
+		}
+		b.Run(name, func(b *testing.B) {
+			base := state.New(db, b0.Header().StateRoot(), 0, 0, 0)
+			bind := builtin.Prototype.Native(base).Bind(contract)
+			if sponsored {
+				base.SetEnergy(contract, new(big.Int).Lsh(big.NewInt(1), 200), 0)
+				bind.SetCreditPlan(new(big.Int).Lsh(big.NewInt(1), 100), big.NewInt(1000))
+			}
+			for _, key := range keys {
+				origin := thor.Address(crypto.PubkeyToAddress(key.PublicKey))
+				base.SetEnergy(origin, new(big.Int).Lsh(big.NewInt(1), 100), 0)
+				if sponsored {
+					bind.AddUser(origin, 1)
+				}
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for range b.N {
+				b.StopTimer()
+				st, err := base.Copy()
+				if err != nil {
+					b.Fatal(err)
+				}
+				rt := runtime.New(repo.NewChain(b0.Header().ID()), st, &xenv.BlockContext{Time: 10}, thor.NoFork)
+				b.StartTimer()
+
+				for _, trx := range txs {
+					receipt, err := rt.ExecuteTransaction(trx)
+					if err != nil {
+						b.Fatal(err)
+					}
+					if sponsored != (receipt.GasPayer == contract) {
+						b.Fatalf("unexpected gas payer %v", receipt.GasPayer)
+					}
+				}
+			}
+		})
+	}
+}
@@ -6,10 +6,13 @@
 package state
 
 import (
+	"runtime"
+
 	"github.com/ethereum/go-ethereum/rlp"
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/vechain/thor/v2/muxdb"
 	"github.com/vechain/thor/v2/thor"
+	"golang.org/x/sync/errgroup"
 )
 
 var codeCache, _ = lru.NewARC(512)
@@ -37,18 +40,66 @@ func (co *cachedObject) getOrCreateStorageTrie() *muxdb.Trie {
 		return co.cache.storageTrie
 	}
 
+	trie := co.newStorageTrie()
+	co.cache.storageTrie = trie
+	return trie
+}
+
+// newStorageTrie creates a storage trie reader, or returns nil if the account has no storage.
+// Unlike the cached one, the returned trie is not shared.
+func (co *cachedObject) newStorageTrie() *muxdb.Trie {
 	if len(co.data.StorageRoot) == 0 {
 		return nil
 	}
 
-	trie := co.db.NewTrie(
+	return co.db.NewTrie(
 		StorageTrieName(co.meta.StorageID),
 		thor.BytesToBytes32(co.data.StorageRoot),
 		co.meta.StorageCommitNum,
 		co.meta.StorageDistinctNum)
+}
 
-	co.cache.storageTrie = trie
-	return trie
+// PreloadStorage loads values of the given keys into the storage cache.
+// Keys absent from the cache are read concurrently, each with its own trie reader.
+func (co *cachedObject) PreloadStorage(keys []thor.Bytes32, steadyBlockNum uint32) error {
+	if len(co.data.StorageRoot) == 0 {
+		return nil
+	}
+	cache := &co.cache
+	if cache.storage == nil {
+		cache.storage = make(map[thor.Bytes32]rlp.RawValue)
+	}
+
+	missing := make([]thor.Bytes32, 0, len(keys))
+	seen := make(map[thor.Bytes32]struct{}, len(keys))
+	for _, key := range keys {
+		if _, ok := cache.storage[key]; ok {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		missing = append(missing, key)
+	}
+
+	values := make([]rlp.RawValue, len(missing))
+	var g errgroup.Group
+	g.SetLimit(runtime.NumCPU())
+	for i, key := range missing {
+		g.Go(func() (err error) {
+			values[i], err = loadStorage(co.newStorageTrie(), key, steadyBlockNum)
+			return
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	for i, key := range missing {
+		cache.storage[key] = values[i]
+	}
+	return nil
 }
 
 // GetStorage returns storage value for given key.
@@ -0,0 +1,73 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/v2/kv"
+	"github.com/vechain/thor/v2/thor"
+)
+
+const changesetStoreName = "state.changeset"
+
+// ChangedAccount is an account changed by a block.
+type ChangedAccount struct {
+	Address      thor.Address
+	StorageSlots uint32 // count of storage slots written
+}
+
+// changesetKey makes the key of the changeset produced by a block.
+// The state root alone is not unique, since a block may leave the state of its parent unchanged.
+func changesetKey(blockNum uint32, root thor.Bytes32) []byte {
+	key := make([]byte, 4+len(root))
+	binary.BigEndian.PutUint32(key, blockNum)
+	copy(key[4:], root[:])
+	return key
+}
+
+func sortChangeset(cs []ChangedAccount) {
+	sort.Slice(cs, func(i, j int) bool {
+		return bytes.Compare(cs[i].Address[:], cs[j].Address[:]) < 0
+	})
+}
+
+// SetChangesetRecording enables or disables recording of the accounts changed by a block, by the stages
+// of the states created by the stater. See Stage.RecordChangeset.
+func (s *Stater) SetChangesetRecording(enabled bool) {
+	s.recordChangesets = enabled
+}
+
+// GetChangeset returns the accounts changed by the block of the given number and state root,
+// sorted by address. The changeset is only available if recorded when the block was added to the chain,
+// and not pruned yet.
+func (s *Stater) GetChangeset(blockNum uint32, root thor.Bytes32) ([]ChangedAccount, error) {
+	data, err := s.db.NewStore(changesetStoreName).Get(changesetKey(blockNum, root))
+	if err != nil {
+		return nil, err
+	}
+	var cs []ChangedAccount
+	if err := rlp.DecodeBytes(data, &cs); err != nil {
+		return nil, err
+	}
+	return cs, nil
+}
+
+// PruneChangesets deletes the changesets of the blocks numbered below limit.
+func (s *Stater) PruneChangesets(ctx context.Context, limit uint32) error {
+	var end [4]byte
+	binary.BigEndian.PutUint32(end[:], limit)
+	return s.db.NewStore(changesetStoreName).DeleteRange(ctx, kv.Range{Limit: end[:]})
+}
+
+// IsNotFound returns if the error indicates the changeset not found.
+func (s *Stater) IsNotFound(err error) bool {
+	return s.db.IsNotFound(err)
+}
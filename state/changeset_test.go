@@ -0,0 +1,88 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/thor"
+)
+
+func TestChangeset(t *testing.T) {
+	db := muxdb.NewMem()
+	stater := NewStater(db)
+
+	acc1 := thor.BytesToAddress([]byte("acc1"))
+	acc2 := thor.BytesToAddress([]byte("acc2"))
+
+	// not recorded by default
+	st := stater.NewState(thor.Bytes32{}, 0, 0, 0)
+	st.SetBalance(acc1, big.NewInt(10))
+	stage, err := st.Stage(1, 0)
+	require.NoError(t, err)
+	root1, err := stage.Commit()
+	require.NoError(t, err)
+	require.NoError(t, stage.RecordChangeset())
+
+	_, err = stater.GetChangeset(1, root1)
+	assert.True(t, stater.IsNotFound(err))
+
+	stater.SetChangesetRecording(true)
+	st = stater.NewState(root1, 1, 0, 0)
+	st.SetBalance(acc2, big.NewInt(20))
+	st.SetStorage(acc1, thor.BytesToBytes32([]byte("s1")), thor.BytesToBytes32([]byte("v1")))
+	st.SetStorage(acc1, thor.BytesToBytes32([]byte("s2")), thor.BytesToBytes32([]byte("v2")))
+	stage, err = st.Stage(2, 0)
+	require.NoError(t, err)
+	root2, err := stage.Commit()
+	require.NoError(t, err)
+
+	// not recorded until the block is added to the chain
+	_, err = stater.GetChangeset(2, root2)
+	assert.True(t, stater.IsNotFound(err))
+	require.NoError(t, stage.RecordChangeset())
+
+	cs, err := stater.GetChangeset(2, root2)
+	require.NoError(t, err)
+	expected := []ChangedAccount{
+		{Address: acc1, StorageSlots: 2},
+		{Address: acc2, StorageSlots: 0},
+	}
+	if bytes.Compare(acc2[:], acc1[:]) < 0 {
+		expected[0], expected[1] = expected[1], expected[0]
+	}
+	assert.Equal(t, expected, cs)
+
+	// a block leaving the state unchanged has an empty changeset
+	st = stater.NewState(root2, 2, 0, 0)
+	stage, err = st.Stage(3, 0)
+	require.NoError(t, err)
+	root3, err := stage.Commit()
+	require.NoError(t, err)
+	require.NoError(t, stage.RecordChangeset())
+	assert.Equal(t, root2, root3)
+
+	cs, err = stater.GetChangeset(3, root3)
+	require.NoError(t, err)
+	assert.Empty(t, cs)
+
+	cs, err = stater.GetChangeset(2, root2)
+	require.NoError(t, err)
+	assert.Len(t, cs, 2)
+
+	// pruned below the limit
+	require.NoError(t, stater.PruneChangesets(context.Background(), 3))
+	_, err = stater.GetChangeset(2, root2)
+	assert.True(t, stater.IsNotFound(err))
+	_, err = stater.GetChangeset(3, root3)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"github.com/vechain/thor/v2/thor"
+)
+
+const preimageStoreName = "state.preimage"
+
+// SetPreimageRecording enables or disables recording of the addresses of the accounts changed by the stages
+// of the states created by the stater, keyed by their hashes in the accounts trie. See GetAddressPreimage.
+func (s *Stater) SetPreimageRecording(enabled bool) {
+	s.recordPreimages = enabled
+}
+
+// GetAddressPreimage returns the address of the given hash in the accounts trie. The address is only
+// available if an account at the address was changed while recording, since the accounts trie keeps no preimages.
+// Recorded preimages are never pruned.
+func (s *Stater) GetAddressPreimage(hashedAddr thor.Bytes32) (thor.Address, error) {
+	data, err := s.db.NewStore(preimageStoreName).Get(hashedAddr[:])
+	if err != nil {
+		return thor.Address{}, err
+	}
+	return thor.BytesToAddress(data), nil
+}
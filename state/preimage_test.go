@@ -0,0 +1,56 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/thor"
+)
+
+func TestAddressPreimage(t *testing.T) {
+	db := muxdb.NewMem()
+	stater := NewStater(db)
+
+	acc1 := thor.BytesToAddress([]byte("acc1"))
+	acc2 := thor.BytesToAddress([]byte("acc2"))
+
+	// not recorded by default
+	st := stater.NewState(thor.Bytes32{}, 0, 0, 0)
+	st.SetBalance(acc1, big.NewInt(10))
+	stage, err := st.Stage(1, 0)
+	require.NoError(t, err)
+	root1, err := stage.Commit()
+	require.NoError(t, err)
+
+	_, err = stater.GetAddressPreimage(thor.Blake2b(acc1[:]))
+	assert.True(t, stater.IsNotFound(err))
+
+	stater.SetPreimageRecording(true)
+	st = stater.NewState(root1, 1, 0, 0)
+	st.SetBalance(acc2, big.NewInt(20))
+	// empty accounts are not recorded
+	st.SetBalance(thor.BytesToAddress([]byte("empty")), big.NewInt(0))
+	stage, err = st.Stage(2, 0)
+	require.NoError(t, err)
+	_, err = stage.Commit()
+	require.NoError(t, err)
+
+	addr, err := stater.GetAddressPreimage(thor.Blake2b(acc2[:]))
+	require.NoError(t, err)
+	assert.Equal(t, acc2, addr)
+
+	// unchanged since recording
+	_, err = stater.GetAddressPreimage(thor.Blake2b(acc1[:]))
+	assert.True(t, stater.IsNotFound(err))
+	empty := thor.BytesToAddress([]byte("empty"))
+	_, err = stater.GetAddressPreimage(thor.Blake2b(empty[:]))
+	assert.True(t, stater.IsNotFound(err))
+}
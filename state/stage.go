@@ -9,8 +9,9 @@ import "github.com/vechain/thor/v2/thor"
 
 // Stage abstracts changes on the main accounts trie.
 type Stage struct {
-	root    thor.Bytes32
-	commits []func() error
+	root            thor.Bytes32
+	commits         []func() error
+	recordChangeset func() error // nil if changesets are not recorded
 }
 
 // Hash computes hash of the main accounts trie.
@@ -28,3 +29,15 @@ func (s *Stage) Commit() (root thor.Bytes32, err error) {
 	}
 	return s.root, nil
 }
+
+// RecordChangeset records the accounts changed by the stage, once the block producing the stage is added to
+// the chain. It does nothing unless the stater records changesets.
+func (s *Stage) RecordChangeset() error {
+	if s.recordChangeset == nil {
+		return nil
+	}
+	if err := s.recordChangeset(); err != nil {
+		return &Error{err}
+	}
+	return nil
+}
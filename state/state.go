@@ -7,14 +7,18 @@ package state
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"math/big"
+	"runtime"
 
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/vechain/thor/v2/lowrlp"
 	"github.com/vechain/thor/v2/muxdb"
 	"github.com/vechain/thor/v2/stackedmap"
 	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+	"golang.org/x/sync/errgroup"
 )
 
 const (
@@ -43,13 +47,29 @@ func (e *Error) Error() string {
 	return fmt.Sprintf("state: %v", e.cause)
 }
 
+// ErrStatePruned is the error caused by accessing the state which is no longer available,
+// since the trie nodes have been pruned.
+type ErrStatePruned struct {
+	Root     thor.Bytes32
+	BlockNum uint32
+}
+
+func (e *ErrStatePruned) Error() string {
+	return fmt.Sprintf("state: root %v of block %v is pruned", e.Root, e.BlockNum)
+}
+
 // State manages the world state.
 type State struct {
 	db             *muxdb.MuxDB
 	trie           *muxdb.Trie                    // the accounts trie reader
+	root           thor.Bytes32                   // the root of the accounts trie
+	blockNum       uint32                         // the number of the block the state is of
 	cache          map[thor.Address]*cachedObject // cache of accounts trie
 	sm             *stackedmap.StackedMap         // keeps revisions of accounts state
 	steadyBlockNum uint32
+
+	recordChangeset bool // whether stages record the changed accounts
+	recordPreimages bool // whether stages record the addresses of the changed accounts
 }
 
 // New create state object.
@@ -57,6 +77,8 @@ func New(db *muxdb.MuxDB, root thor.Bytes32, blockNum, blockConflicts, steadyBlo
 	state := State{
 		db:             db,
 		trie:           db.NewTrie(AccountTrieName, root, blockNum, blockConflicts),
+		root:           root,
+		blockNum:       blockNum,
 		cache:          make(map[thor.Address]*cachedObject),
 		steadyBlockNum: steadyBlockNum,
 	}
@@ -72,6 +94,46 @@ func (s *State) Checkout(root thor.Bytes32, blockNum, blockConflicts, steadyBloc
 	return New(s.db, root, blockNum, blockConflicts, steadyBlockNum)
 }
 
+// Copy returns an isolated copy of the state, including uncommitted changes, for speculative execution.
+// Changes made to either of them do not affect the other, and both can be staged and committed independently.
+// Revision history is not copied, so checkpoints taken before the copy are invalid in the new state.
+func (s *State) Copy() (*State, error) {
+	cpy := State{
+		db:              s.db,
+		trie:            s.trie.Copy(),
+		root:            s.root,
+		blockNum:        s.blockNum,
+		cache:           make(map[thor.Address]*cachedObject),
+		steadyBlockNum:  s.steadyBlockNum,
+		recordChangeset: s.recordChangeset,
+		recordPreimages: s.recordPreimages,
+	}
+	cpy.sm = stackedmap.New(func(key interface{}) (interface{}, bool, error) {
+		return cpy.cacheGetter(key)
+	})
+
+	// values in the journal are never modified in place, it's safe to share them
+	s.sm.Journal(func(k, v interface{}) bool {
+		cpy.sm.Put(k, v)
+		return true
+	})
+	return &cpy, nil
+}
+
+// newError wraps the cause of the state access failure. A missing trie node means the state has been pruned.
+// Errors already made by newError are returned as is.
+func (s *State) newError(cause error) error {
+	switch cause.(type) {
+	case *Error, *ErrStatePruned:
+		return cause
+	}
+	var missing *trie.MissingNodeError
+	if errors.As(cause, &missing) {
+		return &ErrStatePruned{Root: s.root, BlockNum: s.blockNum}
+	}
+	return &Error{cause}
+}
+
 // cacheGetter implements stackedmap.MapGetter.
 func (s *State) cacheGetter(key interface{}) (value interface{}, exist bool, err error) {
 	switch k := key.(type) {
@@ -126,6 +188,52 @@ func (s *State) getCachedObject(addr thor.Address) (*cachedObject, error) {
 	return co, nil
 }
 
+// Preload loads the accounts of the given addresses into the cache, so that later reads of them,
+// e.g. GetBalance and GetEnergy, need no trie access.
+// Accounts absent from the cache are read by runtime.NumCPU() workers concurrently, each with its own trie reader.
+func (s *State) Preload(addrs []thor.Address) error {
+	missing := make([]thor.Address, 0, len(addrs))
+	seen := make(map[thor.Address]struct{}, len(addrs))
+	for _, addr := range addrs {
+		if _, ok := s.cache[addr]; ok {
+			continue
+		}
+		if _, ok := seen[addr]; ok {
+			continue
+		}
+		seen[addr] = struct{}{}
+		missing = append(missing, addr)
+	}
+
+	var (
+		objs    = make([]*cachedObject, len(missing))
+		workers = min(runtime.NumCPU(), len(missing))
+		g       errgroup.Group
+	)
+	for w := 0; w < workers; w++ {
+		// resolved trie nodes are kept by the reader, and shared by reads of the worker
+		trie := s.trie.Copy()
+		g.Go(func() error {
+			for i := w; i < len(missing); i += workers {
+				a, am, err := loadAccount(trie, missing[i], s.steadyBlockNum)
+				if err != nil {
+					return err
+				}
+				objs[i] = newCachedObject(s.db, missing[i], a, am)
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return s.newError(err)
+	}
+
+	for _, obj := range objs {
+		s.cache[obj.addr] = obj
+	}
+	return nil
+}
+
 // getAccount gets account by address. the returned account should not be modified.
 func (s *State) getAccount(addr thor.Address) (*Account, error) {
 	v, _, err := s.sm.Get(addr)
@@ -161,7 +269,7 @@ func (s *State) setStorageBarrier(addr thor.Address, barrier int) {
 func (s *State) GetBalance(addr thor.Address) (*big.Int, error) {
 	acc, err := s.getAccount(addr)
 	if err != nil {
-		return nil, &Error{err}
+		return nil, s.newError(err)
 	}
 	return acc.Balance, nil
 }
@@ -170,7 +278,7 @@ func (s *State) GetBalance(addr thor.Address) (*big.Int, error) {
 func (s *State) SetBalance(addr thor.Address, balance *big.Int) error {
 	cpy, err := s.getAccountCopy(addr)
 	if err != nil {
-		return &Error{err}
+		return s.newError(err)
 	}
 	cpy.Balance = balance
 	s.updateAccount(addr, &cpy)
@@ -181,7 +289,7 @@ func (s *State) SetBalance(addr thor.Address, balance *big.Int) error {
 func (s *State) GetEnergy(addr thor.Address, blockTime uint64) (*big.Int, error) {
 	acc, err := s.getAccount(addr)
 	if err != nil {
-		return nil, &Error{err}
+		return nil, s.newError(err)
 	}
 	return acc.CalcEnergy(blockTime), nil
 }
@@ -190,7 +298,7 @@ func (s *State) GetEnergy(addr thor.Address, blockTime uint64) (*big.Int, error)
 func (s *State) SetEnergy(addr thor.Address, energy *big.Int, blockTime uint64) error {
 	cpy, err := s.getAccountCopy(addr)
 	if err != nil {
-		return &Error{err}
+		return s.newError(err)
 	}
 	cpy.Energy, cpy.BlockTime = energy, blockTime
 	s.updateAccount(addr, &cpy)
@@ -202,7 +310,7 @@ func (s *State) SetEnergy(addr thor.Address, energy *big.Int, blockTime uint64)
 func (s *State) GetMaster(addr thor.Address) (thor.Address, error) {
 	acc, err := s.getAccount(addr)
 	if err != nil {
-		return thor.Address{}, &Error{err}
+		return thor.Address{}, s.newError(err)
 	}
 	return thor.BytesToAddress(acc.Master), nil
 }
@@ -211,7 +319,7 @@ func (s *State) GetMaster(addr thor.Address) (thor.Address, error) {
 func (s *State) SetMaster(addr thor.Address, master thor.Address) error {
 	cpy, err := s.getAccountCopy(addr)
 	if err != nil {
-		return &Error{err}
+		return s.newError(err)
 	}
 	if master.IsZero() {
 		cpy.Master = nil
@@ -226,14 +334,14 @@ func (s *State) SetMaster(addr thor.Address, master thor.Address) error {
 func (s *State) GetStorage(addr thor.Address, key thor.Bytes32) (thor.Bytes32, error) {
 	raw, err := s.GetRawStorage(addr, key)
 	if err != nil {
-		return thor.Bytes32{}, &Error{err}
+		return thor.Bytes32{}, s.newError(err)
 	}
 	if len(raw) == 0 {
 		return thor.Bytes32{}, nil
 	}
 	kind, content, _, err := rlp.Split(raw)
 	if err != nil {
-		return thor.Bytes32{}, &Error{err}
+		return thor.Bytes32{}, s.newError(err)
 	}
 	if kind == rlp.List {
 		// special case for rlp list, it should be customized storage value
@@ -257,7 +365,7 @@ func (s *State) SetStorage(addr thor.Address, key, value thor.Bytes32) {
 func (s *State) GetRawStorage(addr thor.Address, key thor.Bytes32) (rlp.RawValue, error) {
 	data, _, err := s.sm.Get(storageKey{addr, s.getStorageBarrier(addr), key})
 	if err != nil {
-		return nil, &Error{err}
+		return nil, s.newError(err)
 	}
 	return data.(rlp.RawValue), nil
 }
@@ -272,7 +380,7 @@ func (s *State) SetRawStorage(addr thor.Address, key thor.Bytes32, raw rlp.RawVa
 func (s *State) EncodeStorage(addr thor.Address, key thor.Bytes32, enc func() ([]byte, error)) error {
 	raw, err := enc()
 	if err != nil {
-		return &Error{err}
+		return s.newError(err)
 	}
 	s.SetRawStorage(addr, key, raw)
 	return nil
@@ -283,19 +391,65 @@ func (s *State) EncodeStorage(addr thor.Address, key thor.Bytes32, enc func() ([
 func (s *State) DecodeStorage(addr thor.Address, key thor.Bytes32, dec func([]byte) error) error {
 	raw, err := s.GetRawStorage(addr, key)
 	if err != nil {
-		return &Error{err}
+		return s.newError(err)
 	}
 	if err := dec(raw); err != nil {
-		return &Error{err}
+		return s.newError(err)
 	}
 	return nil
 }
 
+// AccountWithStorage bundles the account info and a set of its storage values.
+type AccountWithStorage struct {
+	Balance *big.Int
+	// Energy is the energy settled at the account's last update.
+	// Use GetEnergy for the value at a given block time.
+	Energy  *big.Int
+	HasCode bool
+	Storage map[thor.Bytes32]thor.Bytes32
+}
+
+// GetAccountWithStorage returns the account info along with storage values for the given keys.
+// Storage values not yet cached are loaded from the storage trie in parallel.
+func (s *State) GetAccountWithStorage(addr thor.Address, keys []thor.Bytes32) (*AccountWithStorage, error) {
+	acc, err := s.getAccount(addr)
+	if err != nil {
+		return nil, s.newError(err)
+	}
+
+	// storage of a deleted account is served from the journal only
+	if s.getStorageBarrier(addr) == 0 {
+		obj, err := s.getCachedObject(addr)
+		if err != nil {
+			return nil, s.newError(err)
+		}
+		if err := obj.PreloadStorage(keys, s.steadyBlockNum); err != nil {
+			return nil, s.newError(err)
+		}
+	}
+
+	storage := make(map[thor.Bytes32]thor.Bytes32, len(keys))
+	for _, key := range keys {
+		v, err := s.GetStorage(addr, key)
+		if err != nil {
+			return nil, err
+		}
+		storage[key] = v
+	}
+
+	return &AccountWithStorage{
+		Balance: acc.Balance,
+		Energy:  acc.Energy,
+		HasCode: len(acc.CodeHash) > 0,
+		Storage: storage,
+	}, nil
+}
+
 // GetCode returns code for the given address.
 func (s *State) GetCode(addr thor.Address) ([]byte, error) {
 	v, _, err := s.sm.Get(codeKey(addr))
 	if err != nil {
-		return nil, &Error{err}
+		return nil, s.newError(err)
 	}
 	return v.([]byte), nil
 }
@@ -304,7 +458,7 @@ func (s *State) GetCode(addr thor.Address) ([]byte, error) {
 func (s *State) GetCodeHash(addr thor.Address) (thor.Bytes32, error) {
 	acc, err := s.getAccount(addr)
 	if err != nil {
-		return thor.Bytes32{}, &Error{err}
+		return thor.Bytes32{}, s.newError(err)
 	}
 	return thor.BytesToBytes32(acc.CodeHash), nil
 }
@@ -321,7 +475,7 @@ func (s *State) SetCode(addr thor.Address, code []byte) error {
 	}
 	cpy, err := s.getAccountCopy(addr)
 	if err != nil {
-		return &Error{err}
+		return s.newError(err)
 	}
 	cpy.CodeHash = codeHash
 	s.updateAccount(addr, &cpy)
@@ -333,7 +487,7 @@ func (s *State) SetCode(addr thor.Address, code []byte) error {
 func (s *State) Exists(addr thor.Address) (bool, error) {
 	acc, err := s.getAccount(addr)
 	if err != nil {
-		return false, &Error{err}
+		return false, s.newError(err)
 	}
 	return !acc.IsEmpty(), nil
 }
@@ -362,13 +516,13 @@ func (s *State) RevertTo(revision int) {
 func (s *State) BuildStorageTrie(addr thor.Address) (trie *muxdb.Trie, err error) {
 	acc, err := s.getAccount(addr)
 	if err != nil {
-		return nil, &Error{err}
+		return nil, s.newError(err)
 	}
 
 	if len(acc.StorageRoot) > 0 {
 		obj, err := s.getCachedObject(addr)
 		if err != nil {
-			return nil, &Error{err}
+			return nil, s.newError(err)
 		}
 		trie = s.db.NewTrie(
 			StorageTrieName(obj.meta.StorageID),
@@ -400,11 +554,31 @@ func (s *State) BuildStorageTrie(addr thor.Address) (trie *muxdb.Trie, err error
 		return true
 	})
 	if err != nil {
-		return nil, &Error{err}
+		return nil, s.newError(err)
 	}
 	return trie, nil
 }
 
+// IterateAccounts iterates the accounts of the committed state in the order of their hashed addresses,
+// starting at the hashed address start. Changes not yet committed are not visited. The accounts trie
+// keeps no address preimages, see Stater.GetAddressPreimage. The iteration stops once cb returns false.
+func (s *State) IterateAccounts(start []byte, cb func(hashedAddr thor.Bytes32, acc *Account) bool) error {
+	it := trie.NewIterator(s.trie.NodeIterator(start, 0))
+	for it.Next() {
+		var acc Account
+		if err := rlp.DecodeBytes(it.Value, &acc); err != nil {
+			return s.newError(err)
+		}
+		if !cb(thor.BytesToBytes32(it.Key), &acc) {
+			return nil
+		}
+	}
+	if it.Err != nil {
+		return s.newError(it.Err)
+	}
+	return nil
+}
+
 // Stage makes a stage object to compute hash of trie or commit all changes.
 func (s *State) Stage(newBlockNum, newBlockConflicts uint32) (*Stage, error) {
 	type changed struct {
@@ -428,7 +602,7 @@ func (s *State) Stage(newBlockNum, newBlockConflicts uint32) (*Stage, error) {
 		}
 		co, err := s.getCachedObject(addr)
 		if err != nil {
-			return nil, &Error{err}
+			return nil, s.newError(err)
 		}
 
 		c := &changed{data: co.data, meta: co.meta, baseStorageTrie: co.cache.storageTrie}
@@ -480,7 +654,7 @@ func (s *State) Stage(newBlockNum, newBlockConflicts uint32) (*Stage, error) {
 		return true
 	})
 	if jerr != nil {
-		return nil, &Error{jerr}
+		return nil, s.newError(jerr)
 	}
 
 	trieCpy := s.trie.Copy()
@@ -502,7 +676,7 @@ func (s *State) Stage(newBlockNum, newBlockConflicts uint32) (*Stage, error) {
 				}
 				for k, v := range c.storage {
 					if err := saveStorage(sTrie, k, v); err != nil {
-						return nil, &Error{err}
+						return nil, s.newError(err)
 					}
 				}
 				sRoot, commit := sTrie.Stage(newBlockNum, newBlockConflicts)
@@ -513,7 +687,7 @@ func (s *State) Stage(newBlockNum, newBlockConflicts uint32) (*Stage, error) {
 			}
 		}
 		if err := saveAccount(trieCpy, addr, &c.data, &c.meta); err != nil {
-			return nil, &Error{err}
+			return nil, s.newError(err)
 		}
 	}
 	root, commitAcc := trieCpy.Stage(newBlockNum, newBlockConflicts)
@@ -531,9 +705,42 @@ func (s *State) Stage(newBlockNum, newBlockConflicts uint32) (*Stage, error) {
 	}
 	commits = append(commits, commitAcc, commitCodes)
 
+	if s.recordPreimages && len(changes) > 0 {
+		commits = append(commits, func() error {
+			bulk := s.db.NewStore(preimageStoreName).Bulk()
+			for addr, c := range changes {
+				if c.data.IsEmpty() {
+					continue
+				}
+				hashedAddr := thor.Blake2b(addr[:])
+				if err := bulk.Put(hashedAddr[:], addr[:]); err != nil {
+					return err
+				}
+			}
+			return bulk.Write()
+		})
+	}
+
+	var recordChangeset func() error
+	if s.recordChangeset {
+		cs := make([]ChangedAccount, 0, len(changes))
+		for addr, c := range changes {
+			cs = append(cs, ChangedAccount{Address: addr, StorageSlots: uint32(len(c.storage))})
+		}
+		sortChangeset(cs)
+		recordChangeset = func() error {
+			data, err := rlp.EncodeToBytes(cs)
+			if err != nil {
+				return err
+			}
+			return s.db.NewStore(changesetStoreName).Put(changesetKey(newBlockNum, root), data)
+		}
+	}
+
 	return &Stage{
-		root:    root,
-		commits: commits,
+		root:            root,
+		commits:         commits,
+		recordChangeset: recordChangeset,
 	}, nil
 }
 
@@ -226,3 +226,274 @@ func TestStorageBarrier(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, 0, len(acc.StorageRoot), "should skip storage writes when account deleteed then recreated")
 }
+
+func TestGetAccountWithStorage(t *testing.T) {
+	db := muxdb.NewMem()
+	st := New(db, thor.Bytes32{}, 0, 0, 0)
+
+	addr := thor.BytesToAddress([]byte("addr"))
+	st.SetBalance(addr, big.NewInt(10))
+	st.SetEnergy(addr, big.NewInt(20), 0)
+	st.SetCode(addr, []byte("code"))
+
+	expected := make(map[thor.Bytes32]thor.Bytes32)
+	keys := make([]thor.Bytes32, 0, 5)
+	for i := 1; i <= 5; i++ {
+		key := thor.BytesToBytes32([]byte{byte(i)})
+		value := thor.BytesToBytes32([]byte{byte(i * 10)})
+		st.SetStorage(addr, key, value)
+		keys = append(keys, key)
+		expected[key] = value
+	}
+
+	stage, err := st.Stage(1, 0)
+	assert.Nil(t, err)
+	root, err := stage.Commit()
+	assert.Nil(t, err)
+
+	st = New(db, root, 1, 0, 0)
+	acc, err := st.GetAccountWithStorage(addr, keys)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(10), acc.Balance)
+	assert.Equal(t, big.NewInt(20), acc.Energy)
+	assert.True(t, acc.HasCode)
+	assert.Equal(t, expected, acc.Storage)
+
+	// uncommitted changes and absent keys
+	st.SetStorage(addr, keys[0], thor.BytesToBytes32([]byte("new")))
+	absent := thor.BytesToBytes32([]byte("absent"))
+	acc, err = st.GetAccountWithStorage(addr, []thor.Bytes32{keys[0], keys[1], absent})
+	assert.Nil(t, err)
+	assert.Equal(t, map[thor.Bytes32]thor.Bytes32{
+		keys[0]: thor.BytesToBytes32([]byte("new")),
+		keys[1]: expected[keys[1]],
+		absent:  {},
+	}, acc.Storage)
+
+	// deleted account
+	st.Delete(addr)
+	acc, err = st.GetAccountWithStorage(addr, keys)
+	assert.Nil(t, err)
+	assert.False(t, acc.HasCode)
+	for _, key := range keys {
+		assert.True(t, acc.Storage[key].IsZero())
+	}
+}
+
+func TestPreload(t *testing.T) {
+	db := muxdb.NewMem()
+	st := New(db, thor.Bytes32{}, 0, 0, 0)
+
+	addrs := make([]thor.Address, 0, 10)
+	for i := 0; i < 10; i++ {
+		addr := thor.BytesToAddress([]byte{byte(i + 1)})
+		st.SetBalance(addr, big.NewInt(int64(i+1)))
+		st.SetEnergy(addr, big.NewInt(int64(i+10)), 0)
+		addrs = append(addrs, addr)
+	}
+	stage, err := st.Stage(1, 0)
+	assert.Nil(t, err)
+	root, err := stage.Commit()
+	assert.Nil(t, err)
+
+	st = New(db, root, 1, 0, 0)
+	// uncommitted changes are not overridden by preloading
+	st.SetBalance(addrs[0], big.NewInt(100))
+	absent := thor.BytesToAddress([]byte("absent"))
+	assert.Nil(t, st.Preload(append(addrs, addrs[1], absent)))
+	assert.Len(t, st.cache, len(addrs)+1)
+
+	assert.Equal(t, M(big.NewInt(100), nil), M(st.GetBalance(addrs[0])))
+	for i, addr := range addrs[1:] {
+		assert.Equal(t, M(big.NewInt(int64(i+2)), nil), M(st.GetBalance(addr)))
+		assert.Equal(t, M(big.NewInt(int64(i+11)), nil), M(st.GetEnergy(addr, 0)))
+	}
+	assert.Equal(t, M(false, nil), M(st.Exists(absent)))
+}
+
+func BenchmarkPreload(b *testing.B) {
+	const accounts = 100_000
+
+	// disk backed, for the I/O latency of trie reads
+	db, err := muxdb.Open(b.TempDir(), &muxdb.Options{
+		TrieRootCacheCapacity:      16,
+		TrieCachedNodeTTL:          32,
+		TrieHistPartitionFactor:    524288,
+		TrieDedupedPartitionFactor: 8192,
+		OpenFilesCacheCapacity:     16,
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer db.Close()
+
+	st := New(db, thor.Bytes32{}, 0, 0, 0)
+	addrs := make([]thor.Address, 0, accounts)
+	for i := 0; i < accounts; i++ {
+		addr := thor.BytesToAddress(thor.Blake2b(big.NewInt(int64(i)).Bytes()).Bytes())
+		st.SetBalance(addr, big.NewInt(int64(i+1)))
+		addrs = append(addrs, addr)
+	}
+	stage, err := st.Stage(1, 0)
+	if err != nil {
+		b.Fatal(err)
+	}
+	root, err := stage.Commit()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	// 50 accounts spread over the trie
+	set := make([]thor.Address, 0, 50)
+	for i := 0; i < 50; i++ {
+		set = append(set, addrs[i*accounts/50])
+	}
+
+	for _, preload := range []bool{false, true} {
+		name := "sequential"
+		if preload {
+			name = "preload"
+		}
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				st := New(db, root, 1, 0, 0)
+				if preload {
+					if err := st.Preload(set); err != nil {
+						b.Fatal(err)
+					}
+				}
+				for _, addr := range set {
+					if _, err := st.GetBalance(addr); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestStateCopy(t *testing.T) {
+	db := muxdb.NewMem()
+	st := New(db, thor.Bytes32{}, 0, 0, 0)
+
+	addr1 := thor.BytesToAddress([]byte("addr1"))
+	addr2 := thor.BytesToAddress([]byte("addr2"))
+	key := thor.BytesToBytes32([]byte("key"))
+
+	st.SetBalance(addr1, big.NewInt(10))
+	st.SetStorage(addr1, key, thor.BytesToBytes32([]byte("value")))
+	stage, err := st.Stage(1, 0)
+	assert.Nil(t, err)
+	root, err := stage.Commit()
+	assert.Nil(t, err)
+
+	st = New(db, root, 1, 0, 0)
+	// uncommitted changes are copied
+	st.SetBalance(addr2, big.NewInt(20))
+
+	cpy, err := st.Copy()
+	assert.Nil(t, err)
+	assert.Equal(t, M(big.NewInt(10), nil), M(cpy.GetBalance(addr1)))
+	assert.Equal(t, M(big.NewInt(20), nil), M(cpy.GetBalance(addr2)))
+	assert.Equal(t, M(thor.BytesToBytes32([]byte("value")), nil), M(cpy.GetStorage(addr1, key)))
+
+	// writes to both independently
+	st.SetBalance(addr1, big.NewInt(11))
+	cpy.SetBalance(addr1, big.NewInt(12))
+	cpy.SetStorage(addr1, key, thor.BytesToBytes32([]byte("new value")))
+	cpy.Delete(addr2)
+
+	assert.Equal(t, M(big.NewInt(11), nil), M(st.GetBalance(addr1)))
+	assert.Equal(t, M(big.NewInt(20), nil), M(st.GetBalance(addr2)))
+	assert.Equal(t, M(thor.BytesToBytes32([]byte("value")), nil), M(st.GetStorage(addr1, key)))
+	assert.Equal(t, M(big.NewInt(12), nil), M(cpy.GetBalance(addr1)))
+	assert.Equal(t, M(false, nil), M(cpy.Exists(addr2)))
+
+	// commits both
+	stage, err = st.Stage(2, 0)
+	assert.Nil(t, err)
+	root1, err := stage.Commit()
+	assert.Nil(t, err)
+
+	stage, err = cpy.Stage(2, 1)
+	assert.Nil(t, err)
+	root2, err := stage.Commit()
+	assert.Nil(t, err)
+	assert.NotEqual(t, root1, root2)
+
+	st = New(db, root1, 2, 0, 0)
+	assert.Equal(t, M(big.NewInt(11), nil), M(st.GetBalance(addr1)))
+	assert.Equal(t, M(big.NewInt(20), nil), M(st.GetBalance(addr2)))
+	assert.Equal(t, M(thor.BytesToBytes32([]byte("value")), nil), M(st.GetStorage(addr1, key)))
+
+	cpy = New(db, root2, 2, 1, 0)
+	assert.Equal(t, M(big.NewInt(12), nil), M(cpy.GetBalance(addr1)))
+	assert.Equal(t, M(false, nil), M(cpy.Exists(addr2)))
+	assert.Equal(t, M(thor.BytesToBytes32([]byte("new value")), nil), M(cpy.GetStorage(addr1, key)))
+}
+
+func TestIterateAccounts(t *testing.T) {
+	db := muxdb.NewMem()
+	st := New(db, thor.Bytes32{}, 0, 0, 0)
+
+	balances := make(map[thor.Bytes32]int64)
+	for i := 1; i <= 10; i++ {
+		addr := thor.BytesToAddress([]byte{byte(i)})
+		st.SetBalance(addr, big.NewInt(int64(i)))
+		balances[thor.Blake2b(addr[:])] = int64(i)
+	}
+	stage, err := st.Stage(1, 0)
+	assert.Nil(t, err)
+	root, err := stage.Commit()
+	assert.Nil(t, err)
+
+	// uncommitted changes are not visited
+	st = New(db, root, 1, 0, 0)
+	st.SetBalance(thor.BytesToAddress([]byte("uncommitted")), big.NewInt(1))
+
+	visited := make(map[thor.Bytes32]int64)
+	var keys []thor.Bytes32
+	assert.Nil(t, st.IterateAccounts(nil, func(hashedAddr thor.Bytes32, acc *Account) bool {
+		visited[hashedAddr] = acc.Balance.Int64()
+		keys = append(keys, hashedAddr)
+		return true
+	}))
+	assert.Equal(t, balances, visited)
+
+	// resumed at the key, and stopped by the callback
+	var resumed []thor.Bytes32
+	assert.Nil(t, st.IterateAccounts(keys[5].Bytes(), func(hashedAddr thor.Bytes32, _ *Account) bool {
+		resumed = append(resumed, hashedAddr)
+		return len(resumed) < 3
+	}))
+	assert.Equal(t, keys[5:8], resumed)
+}
+
+func TestStatePruned(t *testing.T) {
+	db := muxdb.NewMem()
+
+	addr := thor.BytesToAddress([]byte("account1"))
+	st := New(db, thor.Bytes32{}, 0, 0, 0)
+	st.SetBalance(addr, big.NewInt(1))
+	stage, err := st.Stage(1, 0)
+	assert.Nil(t, err)
+	root, err := stage.Commit()
+	assert.Nil(t, err)
+
+	assert.Equal(t, M(big.NewInt(1), nil), M(New(db, root, 1, 0, 0).GetBalance(addr)))
+
+	// the nodes of an unknown root are missing, as if they were pruned
+	unknown := thor.BytesToBytes32([]byte("unknown"))
+	_, err = New(db, unknown, 2, 0, 0).GetBalance(addr)
+	assert.Equal(t, &ErrStatePruned{Root: unknown, BlockNum: 2}, err)
+
+	_, err = New(db, unknown, 2, 0, 0).Exists(addr)
+	assert.IsType(t, &ErrStatePruned{}, err)
+
+	// storage reads wrap the error of GetRawStorage, which must not be wrapped again
+	_, err = New(db, unknown, 2, 0, 0).GetStorage(addr, thor.Bytes32{})
+	assert.Equal(t, &ErrStatePruned{Root: unknown, BlockNum: 2}, err)
+
+	err = New(db, unknown, 2, 0, 0).DecodeStorage(addr, thor.Bytes32{}, func([]byte) error { return nil })
+	assert.Equal(t, &ErrStatePruned{Root: unknown, BlockNum: 2}, err)
+}
@@ -12,15 +12,20 @@ import (
 
 // Stater is the state creator.
 type Stater struct {
-	db *muxdb.MuxDB
+	db               *muxdb.MuxDB
+	recordChangesets bool
+	recordPreimages  bool
 }
 
 // NewStater create a new stater.
 func NewStater(db *muxdb.MuxDB) *Stater {
-	return &Stater{db}
+	return &Stater{db: db}
 }
 
 // NewState create a new state object.
 func (s *Stater) NewState(root thor.Bytes32, blockNum, blockConflicts, steadyBlockNum uint32) *State {
-	return New(s.db, root, blockNum, blockConflicts, steadyBlockNum)
+	st := New(s.db, root, blockNum, blockConflicts, steadyBlockNum)
+	st.recordChangeset = s.recordChangesets
+	st.recordPreimages = s.recordPreimages
+	return st
 }
@@ -169,6 +169,9 @@ func (c *Chain) MintBlock(account genesis.DevAccount, transactions ...*tx.Transa
 	if err := c.Repo().AddBlock(newBlk, receipts, 0); err != nil {
 		return fmt.Errorf("unable to add tx to repo: %w", err)
 	}
+	if err := stage.RecordChangeset(); err != nil {
+		return fmt.Errorf("unable to record changeset: %w", err)
+	}
 
 	// Set the new block as the best (latest) block in the repository.
 	if err := c.Repo().SetBestBlockID(newBlk.Header().ID()); err != nil {
@@ -11,6 +11,7 @@ import (
 	"encoding/json"
 	"errors"
 	"strings"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -28,11 +29,38 @@ var (
 	_ json.Unmarshaler = (*Address)(nil)
 )
 
+// checksumJSON indicates whether addresses are in checksum form in JSON, and parsed strictly from JSON.
+var checksumJSON atomic.Bool
+
+// SetChecksumAddressJSON sets whether addresses are encoded to JSON in EIP-55 checksum form,
+// and whether mixed-case addresses with invalid checksums are rejected when decoding JSON.
+// It applies process-wide.
+func SetChecksumAddressJSON(enabled bool) {
+	checksumJSON.Store(enabled)
+}
+
 // String implements the stringer interface
 func (a Address) String() string {
 	return "0x" + hex.EncodeToString(a[:])
 }
 
+// Checksumed returns the EIP-55 mixed-case checksum form of the address.
+func (a Address) Checksumed() string {
+	buf := []byte(hex.EncodeToString(a[:]))
+	hash := Keccak256(buf)
+	for i, c := range buf {
+		// uppercase the letter if the corresponding nibble of the hash >= 8
+		nibble := hash[i/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+		if c >= 'a' && nibble&0xf >= 8 {
+			buf[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(buf)
+}
+
 // Bytes returns byte slice form of address.
 func (a Address) Bytes() []byte {
 	return a[:]
@@ -48,6 +76,9 @@ func (a *Address) MarshalJSON() ([]byte, error) {
 	if a == nil {
 		return json.Marshal(nil)
 	}
+	if checksumJSON.Load() {
+		return json.Marshal(a.Checksumed())
+	}
 	return json.Marshal(a.String())
 }
 
@@ -57,7 +88,11 @@ func (a *Address) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &hex); err != nil {
 		return err
 	}
-	parsed, err := ParseAddress(hex)
+	parse := ParseAddress
+	if checksumJSON.Load() {
+		parse = ParseAddressStrict
+	}
+	parsed, err := parse(hex)
 	if err != nil {
 		return err
 	}
@@ -85,6 +120,20 @@ func ParseAddress(s string) (Address, error) {
 	return addr, nil
 }
 
+// ParseAddressStrict is like ParseAddress, but rejects mixed-case addresses with invalid EIP-55 checksums.
+// All lowercase or all uppercase addresses are accepted.
+func ParseAddressStrict(s string) (Address, error) {
+	addr, err := ParseAddress(s)
+	if err != nil {
+		return Address{}, err
+	}
+	body := s[len(s)-AddressLength*2:]
+	if body != strings.ToLower(body) && body != strings.ToUpper(body) && body != addr.Checksumed()[2:] {
+		return Address{}, errors.New("invalid checksum")
+	}
+	return addr, nil
+}
+
 // MustParseAddress convert string presented address into Address type, panic on error.
 func MustParseAddress(s string) Address {
 	addr, err := ParseAddress(s)
@@ -0,0 +1,90 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package thor
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// test vectors from EIP-55
+var checksumVectors = []string{
+	// all caps
+	"0x52908400098527886E0F7030069857D2E4169EE7",
+	"0x8617E340B3D01FA5F11F306F4090FD50E238070D",
+	// all lower
+	"0xde709f2102306220921060314715629080e2fb77",
+	"0x27b1fdb04752bbc536007a920d24acb045561c26",
+	// normal
+	"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+	"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+	"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+	"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+}
+
+func TestAddressChecksumed(t *testing.T) {
+	for _, v := range checksumVectors {
+		assert.Equal(t, v, MustParseAddress(v).Checksumed())
+	}
+}
+
+func TestParseAddressStrict(t *testing.T) {
+	for _, v := range checksumVectors {
+		addr, err := ParseAddressStrict(v)
+		assert.NoError(t, err)
+		assert.Equal(t, MustParseAddress(v), addr)
+
+		// all lowercase or uppercase is always accepted
+		_, err = ParseAddressStrict(strings.ToLower(v))
+		assert.NoError(t, err)
+		_, err = ParseAddressStrict("0x" + strings.ToUpper(v[2:]))
+		assert.NoError(t, err)
+		_, err = ParseAddressStrict(v[2:])
+		assert.NoError(t, err)
+	}
+
+	// invalid checksum
+	_, err := ParseAddressStrict("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD")
+	assert.EqualError(t, err, "invalid checksum")
+	_, err = ParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD")
+	assert.NoError(t, err)
+
+	// errors of ParseAddress
+	_, err = ParseAddressStrict("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1Be")
+	assert.EqualError(t, err, "invalid length")
+}
+
+func TestAddressJSONChecksum(t *testing.T) {
+	type obj struct {
+		Addr  Address  `json:"addr"`
+		Ptr   *Address `json:"ptr"`
+		Slice []Address
+	}
+	addr := MustParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	o := &obj{Addr: addr, Ptr: &addr, Slice: []Address{addr}}
+
+	data, err := json.Marshal(o)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"addr":"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed","ptr":"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed","Slice":["0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"]}`, string(data))
+
+	invalid := []byte(`{"addr":"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD"}`)
+	assert.NoError(t, json.Unmarshal(invalid, &obj{}))
+
+	SetChecksumAddressJSON(true)
+	defer SetChecksumAddressJSON(false)
+
+	data, err = json.Marshal(o)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"addr":"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed","ptr":"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed","Slice":["0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"]}`, string(data))
+
+	var decoded obj
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, *o, decoded)
+	assert.EqualError(t, json.Unmarshal(invalid, &obj{}), "invalid checksum")
+}
@@ -34,7 +34,7 @@ func TestMockBlocklist(t *testing.T) {
 		"0xdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
 		"0xfeedbeeffeedbeeffeedbeeffeedbeeffeedbeef",
 	}
-	MockBlocklist(mockAddresses)
+	restore := MockBlocklist(mockAddresses)
 
 	// Test to ensure the mock blocklist is now in effect
 	tests := []struct {
@@ -53,4 +53,10 @@ func TestMockBlocklist(t *testing.T) {
 			t.Errorf("MockBlocklist failed for %v: expected blocked=%v, got blocked=%v", tt.address, tt.blocked, !tt.blocked)
 		}
 	}
+
+	// the original blocklist is back after restored
+	restore()
+	if !IsOriginBlocked(MustParseAddress("0x4427be8010dd870395975a8fbaa7afa9439b5332")) || IsOriginBlocked(MustParseAddress(mockAddresses[0])) {
+		t.Errorf("MockBlocklist failed to restore the original blocklist")
+	}
 }
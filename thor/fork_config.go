@@ -40,6 +40,29 @@ func (fc ForkConfig) String() string {
 	return strings.Join(strs, ", ")
 }
 
+// nolint: revive
+// ForkFlags indicates which forks are active at a block.
+type ForkFlags struct {
+	VIP191    bool
+	ETH_CONST bool
+	BLOCKLIST bool
+	ETH_IST   bool
+	VIP214    bool
+	FINALITY  bool
+}
+
+// FlagsAt returns the forks active at the given block number.
+func (fc ForkConfig) FlagsAt(blockNum uint32) ForkFlags {
+	return ForkFlags{
+		VIP191:    blockNum >= fc.VIP191,
+		ETH_CONST: blockNum >= fc.ETH_CONST,
+		BLOCKLIST: blockNum >= fc.BLOCKLIST,
+		ETH_IST:   blockNum >= fc.ETH_IST,
+		VIP214:    blockNum >= fc.VIP214,
+		FINALITY:  blockNum >= fc.FINALITY,
+	}
+}
+
 // NoFork a special config without any forks.
 var NoFork = ForkConfig{
 	VIP191:    math.MaxUint32,
@@ -72,6 +95,12 @@ var forkConfigs = map[Bytes32]ForkConfig{
 	},
 }
 
+// IsPrivateNetwork returns whether the genesis ID is not of a well-known public network.
+func IsPrivateNetwork(genesisID Bytes32) bool {
+	_, ok := forkConfigs[genesisID]
+	return !ok
+}
+
 // GetForkConfig get fork config for given genesis ID.
 func GetForkConfig(genesisID Bytes32) ForkConfig {
 	return forkConfigs[genesisID]
@@ -57,3 +57,26 @@ func TestGetForkConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestForkConfigFlagsAt(t *testing.T) {
+	fc := NoFork
+	fc.VIP191 = 10
+	fc.BLOCKLIST = 0
+	fc.VIP214 = 20
+
+	tests := []struct {
+		blockNum uint32
+		want     ForkFlags
+	}{
+		{0, ForkFlags{BLOCKLIST: true}},
+		{9, ForkFlags{BLOCKLIST: true}},
+		{10, ForkFlags{VIP191: true, BLOCKLIST: true}},
+		{20, ForkFlags{VIP191: true, BLOCKLIST: true, VIP214: true}},
+		{math.MaxUint32 - 1, ForkFlags{VIP191: true, BLOCKLIST: true, VIP214: true}},
+	}
+	for _, tt := range tests {
+		if got := fc.FlagsAt(tt.blockNum); got != tt.want {
+			t.Errorf("ForkConfig.FlagsAt(%v) = %+v, want %+v", tt.blockNum, got, tt.want)
+		}
+	}
+}
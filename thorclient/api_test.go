@@ -6,6 +6,8 @@
 package thorclient
 
 import (
+	"encoding/json"
+	"errors"
 	"math/big"
 	"net/http"
 	"net/http/httptest"
@@ -17,6 +19,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/vechain/thor/v2/api/accounts"
 	"github.com/vechain/thor/v2/api/blocks"
@@ -24,18 +27,22 @@ import (
 	"github.com/vechain/thor/v2/api/events"
 	"github.com/vechain/thor/v2/api/node"
 	"github.com/vechain/thor/v2/api/transactions"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/builtin"
 	"github.com/vechain/thor/v2/comm"
 	"github.com/vechain/thor/v2/genesis"
 	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/p2psrv"
 	"github.com/vechain/thor/v2/test/datagen"
 	"github.com/vechain/thor/v2/test/testchain"
 	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/thorclient/common"
+	"github.com/vechain/thor/v2/tracers/logger"
 	"github.com/vechain/thor/v2/tx"
 	"github.com/vechain/thor/v2/txpool"
 
 	// Force-load the tracer native engines to trigger registration
 	_ "github.com/vechain/thor/v2/tracers/js"
-	_ "github.com/vechain/thor/v2/tracers/logger"
 )
 
 const (
@@ -56,20 +63,20 @@ func initAPIServer(t *testing.T) (*testchain.Chain, *httptest.Server) {
 
 	router := mux.NewRouter()
 
-	accounts.New(thorChain.Repo(), thorChain.Stater(), uint64(gasLimit), thor.NoFork, thorChain.Engine(), true).
+	accounts.New(thorChain.Repo(), thorChain.Stater(), nil, uint64(gasLimit), thor.NoFork, thorChain.Engine(), true).
 		Mount(router, "/accounts")
 
 	mempool := txpool.New(thorChain.Repo(), thorChain.Stater(), txpool.Options{Limit: 10000, LimitPerAccount: 16, MaxLifetime: 10 * time.Minute})
-	transactions.New(thorChain.Repo(), mempool).Mount(router, "/transactions")
+	transactions.New(thorChain.Repo(), mempool, thorChain.Engine()).Mount(router, "/transactions")
 
-	blocks.New(thorChain.Repo(), thorChain.Engine()).Mount(router, "/blocks")
+	blocks.New(thorChain.Repo(), thorChain.Engine(), thorChain.GetForkConfig()).Mount(router, "/blocks")
 
-	debug.New(thorChain.Repo(), thorChain.Stater(), thorChain.GetForkConfig(), gasLimit, true, thorChain.Engine(), []string{"all"}, false).
+	debug.New(thorChain.Repo(), thorChain.Stater(), thorChain.GetForkConfig(), gasLimit, true, thorChain.Engine(), []string{"all"}, false, debug.JobOptions{}, false).
 		Mount(router, "/debug")
 
 	logDb, err := logdb.NewMem()
 	require.NoError(t, err)
-	events.New(thorChain.Repo(), logDb, logDBLimit).Mount(router, "/logs/event")
+	events.New(thorChain.Repo(), logDb, logDBLimit, thorChain.Engine()).Mount(router, "/logs/event")
 
 	communicator := comm.New(
 		thorChain.Repo(),
@@ -79,11 +86,20 @@ func initAPIServer(t *testing.T) (*testchain.Chain, *httptest.Server) {
 			MaxLifetime:     10 * time.Minute,
 		}),
 	)
-	node.New(communicator).Mount(router, "/node")
+	node.New(&network{communicator}, mempool, true, thorChain.Repo(), thorChain.Stater(), thorChain.GetForkConfig(), nil).Mount(router, "/node")
 
 	return thorChain, httptest.NewServer(router)
 }
 
+// network serves the communicator with no port mapping.
+type network struct {
+	*comm.Communicator
+}
+
+func (n *network) NATStatus() p2psrv.NATStatus {
+	return p2psrv.NATStatus{State: p2psrv.NATUnknown}
+}
+
 func mintTransactions(t *testing.T, thorChain *testchain.Chain) {
 	toAddr := datagen.RandAddress()
 
@@ -139,7 +155,7 @@ func TestAPIs(t *testing.T) {
 	}
 }
 
-func testAccountEndpoint(t *testing.T, _ *testchain.Chain, ts *httptest.Server) {
+func testAccountEndpoint(t *testing.T, thorChain *testchain.Chain, ts *httptest.Server) {
 	// Example storage key
 	storageKey := thor.MustParseBytes32("0x0000000000000000000000000000000000000000000000000000000000000000")
 
@@ -200,6 +216,28 @@ func testAccountEndpoint(t *testing.T, _ *testchain.Chain, ts *httptest.Server)
 		_, err = c.InspectClauses(payload, Revision("best"))
 		require.NoError(t, err)
 	})
+
+	// 5. Test energy supply
+	t.Run("EnergySupply", func(t *testing.T) {
+		c := New(ts.URL)
+		supply, err := c.EnergySupply()
+		require.NoError(t, err)
+
+		best := thorChain.Repo().BestBlockSummary()
+		st := thorChain.Stater().NewState(best.Header.StateRoot(), best.Header.Number(), best.Conflicts, best.SteadyNum)
+		expected, err := builtin.Energy.Native(st, best.Header.Timestamp()).SupplyBreakdown()
+		require.NoError(t, err)
+		require.Zero(t, expected.Initial.Cmp(supply.Initial))
+		require.Zero(t, expected.Growth.Cmp(supply.Growth))
+		require.Zero(t, expected.Burned.Cmp(supply.Burned))
+		require.Zero(t, expected.Total.Cmp(supply.Total))
+
+		genesisSupply, err := c.EnergySupply(Revision("0"))
+		require.NoError(t, err)
+		require.Zero(t, supply.Initial.Cmp(genesisSupply.Initial))
+		require.Zero(t, genesisSupply.Growth.Sign())
+		require.Zero(t, genesisSupply.Initial.Cmp(genesisSupply.Total))
+	})
 }
 
 func testTransactionsEndpoint(t *testing.T, thorChain *testchain.Chain, ts *httptest.Server) {
@@ -214,6 +252,19 @@ func testTransactionsEndpoint(t *testing.T, thorChain *testchain.Chain, ts *http
 		require.Equal(t, id.String(), trx.ID.String())
 	})
 
+	t.Run("TransactionBlock", func(t *testing.T) {
+		id := preMintedTx01.ID()
+		blk, err := c.TransactionBlock(id)
+		require.NoError(t, err)
+		trx, err := c.Transaction(&id)
+		require.NoError(t, err)
+		require.Equal(t, trx.Meta.BlockID, blk.ID)
+		require.Contains(t, blk.Transactions, id)
+
+		_, err = c.TransactionBlock(thor.Bytes32{})
+		require.ErrorIs(t, err, common.ErrNotFound)
+	})
+
 	// 2. Test sending a new transaction
 	t.Run("SendTransaction", func(t *testing.T) {
 		toAddr := thor.MustParseAddress("0x0123456789abcdef0123456789abcdef01234567")
@@ -230,6 +281,7 @@ func testTransactionsEndpoint(t *testing.T, thorChain *testchain.Chain, ts *http
 		require.NoError(t, err)
 		require.NotNil(t, sendResult)
 		require.Equal(t, trx.ID().String(), sendResult.ID.String()) // Ensure transaction was successful
+		require.Equal(t, genesis.DevAccounts()[0].Address, sendResult.Origin)
 	})
 
 	// 3. Test retrieving the transaction receipt
@@ -254,7 +306,7 @@ func testTransactionsEndpoint(t *testing.T, thorChain *testchain.Chain, ts *http
 	})
 }
 
-func testBlocksEndpoint(t *testing.T, _ *testchain.Chain, ts *httptest.Server) {
+func testBlocksEndpoint(t *testing.T, thorChain *testchain.Chain, ts *httptest.Server) {
 	c := New(ts.URL)
 	// Example revision (this could be a block number or block ID)
 	revision := "best"
@@ -272,6 +324,40 @@ func testBlocksEndpoint(t *testing.T, _ *testchain.Chain, ts *httptest.Server) {
 		require.NoError(t, err)
 		// TODO validate the response body here
 	})
+
+	t.Run("IsCanonical", func(t *testing.T) {
+		repo := thorChain.Repo()
+		best := repo.BestBlockSummary().Header
+
+		status, err := c.IsCanonical(best.ID())
+		require.NoError(t, err)
+		require.Equal(t, Canonical, status)
+
+		status, err = c.IsCanonical(repo.GenesisBlock().Header().ID())
+		require.NoError(t, err)
+		require.Equal(t, Canonical, status)
+
+		// a sibling of the best block, known but not on the best chain
+		orphan := new(block.Builder).
+			ParentID(best.ParentID()).
+			Timestamp(best.Timestamp()).
+			TotalScore(best.TotalScore()).
+			Build()
+		sig, err := crypto.Sign(orphan.Header().SigningHash().Bytes(), genesis.DevAccounts()[1].PrivateKey)
+		require.NoError(t, err)
+		orphan = orphan.WithSignature(sig)
+		// it conflicts with the best block
+		require.NoError(t, repo.AddBlock(orphan, nil, 1))
+
+		status, err = c.IsCanonical(orphan.Header().ID())
+		require.NoError(t, err)
+		require.Equal(t, Orphaned, status)
+
+		status, err = c.IsCanonical(datagen.RandomHash())
+		require.NoError(t, err)
+		require.Equal(t, CanonicalUnknown, status)
+		require.Equal(t, "unknown", status.String())
+	})
 }
 
 func testDebugEndpoint(t *testing.T, thorChain *testchain.Chain, ts *httptest.Server) {
@@ -331,6 +417,42 @@ func testDebugEndpoint(t *testing.T, thorChain *testchain.Chain, ts *httptest.Se
 		// Optionally, you can unmarshal and validate the response body here
 	})
 
+	// Test POST /debug/tracers/call?stream=true
+	t.Run("StreamTraceCall", func(t *testing.T) {
+		c := New(ts.URL)
+		opt := &debug.TraceCallOption{
+			Name: "structLogger",
+			// PUSH1 1 PUSH1 1 ADD POP STOP
+			Data: "0x60016001015000",
+			Gas:  1000000,
+		}
+
+		var ops []string
+		result, err := c.StreamTraceCall(opt, func(frame json.RawMessage) error {
+			var log logger.StructLogRes
+			if err := json.Unmarshal(frame, &log); err != nil {
+				return err
+			}
+			ops = append(ops, log.Op)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"PUSH1", "PUSH1", "ADD", "POP", "STOP"}, ops)
+
+		var res logger.ExecutionResult
+		require.NoError(t, json.Unmarshal(result, &res))
+		require.False(t, res.Failed)
+
+		// the consumer stops the stream
+		errStop := errors.New("stop")
+		_, err = c.StreamTraceCall(opt, func(json.RawMessage) error { return errStop })
+		require.ErrorIs(t, err, errStop)
+
+		// not supported by the tracer
+		_, err = c.StreamTraceCall(&debug.TraceCallOption{Name: "4byteTracer"}, func(json.RawMessage) error { return nil })
+		require.Error(t, err)
+	})
+
 	// 3. Test POST /debug/storage-range (Debug storage for a contract)
 	t.Run("DebugStorage", func(t *testing.T) {
 		payload := `{
@@ -389,11 +511,24 @@ func testEventsEndpoint(t *testing.T, _ *testchain.Chain, ts *httptest.Server) {
 	})
 }
 
-func testNodeEndpoint(t *testing.T, _ *testchain.Chain, ts *httptest.Server) {
+func testNodeEndpoint(t *testing.T, thorChain *testchain.Chain, ts *httptest.Server) {
 	c := New(ts.URL)
 	// 1. Test GET /node/network/peers
 	t.Run("GetPeersStats", func(t *testing.T) {
 		_, err := c.Peers()
 		require.NoError(t, err)
 	})
+
+	// 2. Test GET /node/schedule
+	t.Run("Schedule", func(t *testing.T) {
+		schedule, err := c.Schedule(10)
+		require.NoError(t, err)
+		assert.Equal(t, thorChain.Repo().BestBlockSummary().Header.ID(), schedule.ParentID)
+		require.Len(t, schedule.Slots, 10)
+		for _, slot := range schedule.Slots {
+			// the solo block signer is the only proposer
+			assert.Equal(t, genesis.DevAccounts()[0].Address, slot.Proposer)
+			assert.False(t, slot.IsMaster)
+		}
+	})
 }
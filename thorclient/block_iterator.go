@@ -0,0 +1,334 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package thorclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/vechain/thor/v2/api/blocks"
+	"github.com/vechain/thor/v2/api/subscriptions"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/thorclient/common"
+	"github.com/vechain/thor/v2/thorclient/wsclient"
+)
+
+// BlockIteratorOptions configures a block iterator. The zero value fetches one block at a time, with no retry
+// and no rate limit.
+type BlockIteratorOptions struct {
+	Concurrency int           // max blocks fetched in parallel, 1 if not positive
+	Retries     int           // max retries of a failed request
+	RetryDelay  time.Duration // delay before the first retry, doubled for each next retry
+	RateLimit   float64       // max requests per second, 0 for unlimited
+	// Follow ends the range at the finalized block in place of the given end, and then follows the best chain
+	// via the websocket subscription of blocks. It requires a websocket typed client.
+	Follow bool
+}
+
+// BlockIterator delivers the blocks of a range in order, while fetching the ones ahead concurrently.
+//
+//	it := client.BlockIterator(1, 1000, &thorclient.BlockIteratorOptions{Concurrency: 8, Retries: 3})
+//	defer it.Close()
+//	for it.Next(ctx) {
+//		block := it.Block()
+//	}
+//	if err := it.Err(); err != nil {
+//		// Next can be called again to resume from the block after the last delivered one
+//	}
+//
+// The blocks of the range are fetched by number, so the ones above the finalized block may be replaced by a
+// chain reorganization during the iteration. The followed blocks are delivered as the subscription of blocks
+// does, i.e. a reorganization is delivered as the obsolete blocks followed by the blocks of the new branch.
+// It's not safe for concurrent use.
+type BlockIterator[T any] struct {
+	fetch   func(revision string) (T, error)
+	summary func(T) *blocks.JSONBlockSummary
+	ws      *wsclient.Client
+	opts    BlockIteratorOptions
+	limiter *rateLimiter
+
+	from     uint32
+	next, to uint64 // of the range, next is beyond to once the range is done
+	resolved bool   // whether the end of the range is resolved
+
+	inflight []chan fetchResult[T] // fetches of the blocks from next, in order
+	fetchCtx context.Context       // of the in-flight fetches
+	cancel   context.CancelFunc
+	sub      *common.Subscription[*subscriptions.BlockMessage]
+
+	block     T
+	obsolete  bool
+	lastID    thor.Bytes32
+	delivered bool
+	err       error
+}
+
+type fetchResult[T any] struct {
+	block T
+	err   error
+}
+
+// BlockIterator returns an iterator of the collapsed blocks numbered from from to to, both inclusive.
+func (c *Client) BlockIterator(from, to uint32, opts *BlockIteratorOptions) *BlockIterator[*blocks.JSONCollapsedBlock] {
+	return newBlockIterator(c, from, to, opts, c.httpConn.GetBlock, func(b *blocks.JSONCollapsedBlock) *blocks.JSONBlockSummary {
+		return b.JSONBlockSummary
+	})
+}
+
+// ExpandedBlockIterator returns an iterator of the expanded blocks numbered from from to to, both inclusive.
+func (c *Client) ExpandedBlockIterator(from, to uint32, opts *BlockIteratorOptions) *BlockIterator[*blocks.JSONExpandedBlock] {
+	return newBlockIterator(c, from, to, opts, c.httpConn.GetExpandedBlock, func(b *blocks.JSONExpandedBlock) *blocks.JSONBlockSummary {
+		return b.JSONBlockSummary
+	})
+}
+
+func newBlockIterator[T any](
+	c *Client,
+	from, to uint32,
+	opts *BlockIteratorOptions,
+	fetch func(revision string) (T, error),
+	summary func(T) *blocks.JSONBlockSummary,
+) *BlockIterator[T] {
+	it := &BlockIterator[T]{
+		fetch:   fetch,
+		summary: summary,
+		ws:      c.wsConn,
+		from:    from,
+		next:    uint64(from),
+		to:      uint64(to),
+	}
+	if opts != nil {
+		it.opts = *opts
+	}
+	if it.opts.Concurrency <= 0 {
+		it.opts.Concurrency = 1
+	}
+	if it.opts.RateLimit > 0 {
+		it.limiter = &rateLimiter{interval: time.Duration(float64(time.Second) / it.opts.RateLimit)}
+	}
+	// the end of the range is resolved by the first call of Next in follow mode
+	it.resolved = !it.opts.Follow
+	return it
+}
+
+// Next advances to the next block, which is then available by Block. It returns false when the range is done,
+// or on error. After an error, Next can be called again to resume from the block after the last delivered one.
+func (it *BlockIterator[T]) Next(ctx context.Context) bool {
+	it.err = nil
+	if it.opts.Follow && it.ws == nil {
+		it.err = errors.New("not a websocket typed client")
+		return false
+	}
+
+	if !it.resolved {
+		finalized, err := it.fetchWithRetry(ctx, common.FinalizedRevision)
+		if err != nil {
+			return it.fail(err)
+		}
+		it.to = uint64(it.summary(finalized).Number)
+		it.resolved = true
+	}
+
+	if it.next <= it.to {
+		it.fill()
+		var res fetchResult[T]
+		select {
+		case <-ctx.Done():
+			return it.fail(ctx.Err())
+		case res = <-it.inflight[0]:
+		}
+		if res.err != nil {
+			return it.fail(res.err)
+		}
+		it.inflight = it.inflight[1:]
+		it.next++
+		if it.next > it.to {
+			it.stop()
+		}
+		return it.deliver(res.block, false)
+	}
+
+	if !it.opts.Follow {
+		return false
+	}
+	if it.sub == nil {
+		if err := it.subscribe(ctx); err != nil {
+			return it.fail(err)
+		}
+	}
+	var msg common.EventWrapper[*subscriptions.BlockMessage]
+	select {
+	case <-ctx.Done():
+		return it.fail(ctx.Err())
+	case m, ok := <-it.sub.EventChan:
+		if !ok {
+			return it.fail(errors.New("subscription closed"))
+		}
+		msg = m
+	}
+	if msg.Error != nil {
+		return it.fail(msg.Error)
+	}
+	block, err := it.fetchWithRetry(ctx, msg.Data.ID.String())
+	if err != nil {
+		return it.fail(err)
+	}
+	return it.deliver(block, msg.Data.Obsolete)
+}
+
+// Block returns the block delivered by the last call of Next.
+func (it *BlockIterator[T]) Block() T {
+	return it.block
+}
+
+// Obsolete returns whether the block delivered by the last call of Next is no longer on the best chain.
+// It's only reported for the followed blocks.
+func (it *BlockIterator[T]) Obsolete() bool {
+	return it.obsolete
+}
+
+// Err returns the error that stopped the last call of Next, nil if the range is done.
+func (it *BlockIterator[T]) Err() error {
+	return it.err
+}
+
+// Close stops the in-flight fetches and the subscription.
+func (it *BlockIterator[T]) Close() error {
+	it.stop()
+	return nil
+}
+
+// fill starts fetching the blocks ahead of the next one, up to the concurrency.
+func (it *BlockIterator[T]) fill() {
+	if it.cancel == nil {
+		it.fetchCtx, it.cancel = context.WithCancel(context.Background())
+	}
+	for len(it.inflight) < it.opts.Concurrency {
+		num := it.next + uint64(len(it.inflight))
+		if num > it.to {
+			return
+		}
+		result := make(chan fetchResult[T], 1)
+		it.inflight = append(it.inflight, result)
+		go func(ctx context.Context) {
+			block, err := it.fetchWithRetry(ctx, strconv.FormatUint(num, 10))
+			result <- fetchResult[T]{block, err}
+		}(it.fetchCtx)
+	}
+}
+
+func (it *BlockIterator[T]) deliver(block T, obsolete bool) bool {
+	it.block = block
+	it.obsolete = obsolete
+	it.lastID = it.summary(block).ID
+	it.delivered = true
+	return true
+}
+
+func (it *BlockIterator[T]) fail(err error) bool {
+	it.stop()
+	it.err = err
+	return false
+}
+
+// stop discards the in-flight fetches and closes the subscription, to be restarted by the next call of Next.
+func (it *BlockIterator[T]) stop() {
+	if it.cancel != nil {
+		it.cancel()
+		it.fetchCtx, it.cancel = nil, nil
+	}
+	it.inflight = nil
+	if it.sub != nil {
+		_ = it.sub.Unsubscribe()
+		it.sub = nil
+	}
+}
+
+// subscribe follows the best chain after the last delivered block, or the block before the range if none.
+func (it *BlockIterator[T]) subscribe(ctx context.Context) error {
+	pos := it.lastID
+	if !it.delivered {
+		if it.from == 0 {
+			return errors.New("no block before the genesis block to follow")
+		}
+		parent, err := it.fetchWithRetry(ctx, strconv.FormatUint(uint64(it.from-1), 10))
+		if err != nil {
+			return err
+		}
+		pos = it.summary(parent).ID
+	}
+	if err := it.limiter.wait(ctx); err != nil {
+		return err
+	}
+	sub, err := it.ws.SubscribeBlocks(pos.String())
+	if err != nil {
+		return err
+	}
+	it.sub = sub
+	return nil
+}
+
+// fetchWithRetry fetches a block, retrying on any error as the block may be not yet available on the node.
+func (it *BlockIterator[T]) fetchWithRetry(ctx context.Context, revision string) (T, error) {
+	delay := it.opts.RetryDelay
+	for attempt := 0; ; attempt++ {
+		if err := it.limiter.wait(ctx); err != nil {
+			var zero T
+			return zero, err
+		}
+		block, err := it.fetch(revision)
+		if err == nil {
+			return block, nil
+		}
+		if attempt >= it.opts.Retries {
+			var zero T
+			return zero, fmt.Errorf("block %v: %w", revision, err)
+		}
+		select {
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// rateLimiter spaces the requests evenly. The nil limiter doesn't limit.
+type rateLimiter struct {
+	lock     sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// wait blocks until the next request is allowed.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	l.lock.Lock()
+	at := time.Now()
+	if at.Before(l.next) {
+		at = l.next
+	}
+	l.next = at.Add(l.interval)
+	l.lock.Unlock()
+
+	delay := time.Until(at)
+	if delay <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(delay):
+		return nil
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package thorclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/api/blocks"
+	"github.com/vechain/thor/v2/api/subscriptions"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/txpool"
+)
+
+const iteratedBlocks = 300
+
+// failingServer serves the blocks and subscriptions APIs of a solo chain, failing the block requests
+// picked by shouldFail.
+type failingServer struct {
+	*httptest.Server
+	chain *testchain.Chain
+
+	lock       sync.Mutex
+	shouldFail func(path string, count int) bool
+	requests   int
+}
+
+func newFailingServer(t *testing.T) *failingServer {
+	thorChain, err := testchain.NewIntegrationTestChain()
+	require.NoError(t, err)
+	for range iteratedBlocks {
+		require.NoError(t, thorChain.MintBlock(genesis.DevAccounts()[0]))
+	}
+
+	router := mux.NewRouter()
+	blocks.New(thorChain.Repo(), thorChain.Engine(), thorChain.GetForkConfig()).Mount(router, "/blocks")
+	sub := subscriptions.New(thorChain.Repo(), thorChain.Stater(), nil, []string{"*"}, 1000, txpool.New(thorChain.Repo(), thorChain.Stater(), txpool.Options{}), false, subscriptions.Options{})
+	sub.Mount(router, "/subscriptions")
+	t.Cleanup(sub.Close)
+
+	s := &failingServer{chain: thorChain}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/blocks/") {
+			s.lock.Lock()
+			s.requests++
+			fail := s.shouldFail != nil && s.shouldFail(r.URL.Path, s.requests)
+			s.lock.Unlock()
+			if fail {
+				http.Error(w, "injected failure", http.StatusInternalServerError)
+				return
+			}
+		}
+		router.ServeHTTP(w, r)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *failingServer) failWhen(shouldFail func(path string, count int) bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.shouldFail = shouldFail
+}
+
+// checkOrder checks the blocks are numbered from from, and each is the child of the one before.
+func checkOrder(t *testing.T, from uint32, blks []*blocks.JSONBlockSummary) {
+	for i, b := range blks {
+		require.Equal(t, from+uint32(i), b.Number)
+		if i > 0 {
+			require.Equal(t, blks[i-1].ID, b.ParentID)
+		}
+	}
+}
+
+func TestBlockIterator(t *testing.T) {
+	s := newFailingServer(t)
+	client := New(s.URL)
+
+	// every 7th request fails, and is retried
+	s.failWhen(func(_ string, count int) bool { return count%7 == 0 })
+
+	it := client.BlockIterator(1, iteratedBlocks, &BlockIteratorOptions{Concurrency: 8, Retries: 3, RetryDelay: time.Millisecond})
+	defer it.Close()
+
+	var blks []*blocks.JSONBlockSummary
+	for it.Next(context.Background()) {
+		blks = append(blks, it.Block().JSONBlockSummary)
+	}
+	require.NoError(t, it.Err())
+	assert.Len(t, blks, iteratedBlocks)
+	checkOrder(t, 1, blks)
+
+	// done
+	assert.False(t, it.Next(context.Background()))
+	assert.NoError(t, it.Err())
+}
+
+func TestBlockIterator_Resume(t *testing.T) {
+	s := newFailingServer(t)
+	client := New(s.URL)
+
+	// the first two requests of block 100 fail, and are not retried
+	failures := 0
+	s.failWhen(func(path string, _ int) bool {
+		if path == "/blocks/100" && failures < 2 {
+			failures++
+			return true
+		}
+		return false
+	})
+
+	it := client.ExpandedBlockIterator(1, iteratedBlocks, &BlockIteratorOptions{Concurrency: 16})
+	defer it.Close()
+
+	var (
+		blks []*blocks.JSONBlockSummary
+		errs int
+	)
+	for {
+		for it.Next(context.Background()) {
+			blks = append(blks, it.Block().JSONBlockSummary)
+		}
+		if it.Err() == nil {
+			break
+		}
+		errs++
+		// resumed from the failed block
+		assert.Len(t, blks, 99)
+		assert.Contains(t, it.Err().Error(), "block 100")
+	}
+	assert.Equal(t, 2, errs)
+	assert.Len(t, blks, iteratedBlocks)
+	checkOrder(t, 1, blks)
+}
+
+func TestBlockIterator_RateLimit(t *testing.T) {
+	s := newFailingServer(t)
+	client := New(s.URL)
+
+	start := time.Now()
+	it := client.BlockIterator(1, 20, &BlockIteratorOptions{Concurrency: 8, RateLimit: 200})
+	defer it.Close()
+
+	count := 0
+	for it.Next(context.Background()) {
+		count++
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, 20, count)
+	// 20 requests spaced by 5ms
+	assert.GreaterOrEqual(t, time.Since(start), 95*time.Millisecond)
+}
+
+func TestBlockIterator_Follow(t *testing.T) {
+	s := newFailingServer(t)
+
+	// follow requires a websocket typed client
+	it := New(s.URL).BlockIterator(0, 0, &BlockIteratorOptions{Follow: true})
+	assert.False(t, it.Next(context.Background()))
+	assert.Error(t, it.Err())
+
+	client, err := NewWithWS(s.URL)
+	require.NoError(t, err)
+
+	it = client.BlockIterator(0, 0, &BlockIteratorOptions{Concurrency: 4, Retries: 3, Follow: true})
+	defer it.Close()
+
+	next := func() *blocks.JSONBlockSummary {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		require.True(t, it.Next(ctx), "%v", it.Err())
+		assert.False(t, it.Obsolete())
+		return it.Block().JSONBlockSummary
+	}
+
+	// the solo chain is finalized at the genesis, the existing blocks are followed from it
+	var blks []*blocks.JSONBlockSummary
+	for range iteratedBlocks + 1 {
+		blks = append(blks, next())
+	}
+	assert.Equal(t, s.chain.GenesisBlock().Header().ID(), blks[0].ID)
+
+	// then the new blocks, resuming after an error drops the subscription
+	require.NoError(t, s.chain.MintBlock(genesis.DevAccounts()[0]))
+	blks = append(blks, next())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.False(t, it.Next(ctx))
+	assert.ErrorIs(t, it.Err(), context.DeadlineExceeded)
+
+	require.NoError(t, s.chain.MintBlock(genesis.DevAccounts()[0]))
+	blks = append(blks, next())
+
+	assert.Len(t, blks, iteratedBlocks+3)
+	checkOrder(t, 0, blks)
+}
@@ -13,9 +13,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/vechain/thor/v2/api/accounts"
 	"github.com/vechain/thor/v2/api/blocks"
+	"github.com/vechain/thor/v2/api/debug"
 	"github.com/vechain/thor/v2/api/events"
 	"github.com/vechain/thor/v2/api/node"
 	"github.com/vechain/thor/v2/api/transactions"
@@ -64,10 +67,14 @@ func (c *Client) GetAccount(addr *thor.Address, revision string) (*accounts.Acco
 }
 
 // InspectClauses performs a clause inspection on batch call data at the specified revision.
-func (c *Client) InspectClauses(calldata *accounts.BatchCallData, revision string) ([]*accounts.CallResult, error) {
-	url := c.url + "/accounts/*"
+// If pending is true, the executable txs in the tx pool are applied before the clauses, on top of the next block.
+func (c *Client) InspectClauses(calldata *accounts.BatchCallData, revision string, pending bool) ([]*accounts.CallResult, error) {
+	url := c.url + "/accounts/*?"
 	if revision != "" {
-		url += "?revision=" + revision
+		url += "revision=" + revision + "&"
+	}
+	if pending {
+		url += "pending=true"
 	}
 	body, err := c.httpPOST(url, calldata)
 	if err != nil {
@@ -102,6 +109,46 @@ func (c *Client) GetAccountCode(addr *thor.Address, revision string) (*accounts.
 	return &res, nil
 }
 
+// GetEnergyProjection retrieves the energy of the given address at the specified revision, projected to the timestamp.
+func (c *Client) GetEnergyProjection(addr *thor.Address, timestamp uint64, revision string) (*accounts.EnergyProjection, error) {
+	url := c.url + "/accounts/" + addr.String() + "/energy-projection?timestamp=" + fmt.Sprint(timestamp)
+	if revision != "" {
+		url += "&revision=" + revision
+	}
+
+	body, err := c.httpGET(url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve energy projection - %w", err)
+	}
+
+	var projection accounts.EnergyProjection
+	if err = json.Unmarshal(body, &projection); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal energy projection - %w", err)
+	}
+
+	return &projection, nil
+}
+
+// GetProposerStatus retrieves whether the given address is an eligible block proposer at the specified revision.
+func (c *Client) GetProposerStatus(addr *thor.Address, revision string) (*accounts.ProposerStatus, error) {
+	url := c.url + "/accounts/" + addr.String() + "/proposer"
+	if revision != "" {
+		url += "?revision=" + revision
+	}
+
+	body, err := c.httpGET(url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve proposer status - %w", err)
+	}
+
+	var status accounts.ProposerStatus
+	if err = json.Unmarshal(body, &status); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal proposer status - %w", err)
+	}
+
+	return &status, nil
+}
+
 // GetAccountStorage retrieves the storage value for the given address and key at the specified revision.
 func (c *Client) GetAccountStorage(addr *thor.Address, key *thor.Bytes32, revision string) (*accounts.GetStorageResult, error) {
 	url := c.url + "/accounts/" + addr.String() + "/storage/" + key.String()
@@ -122,6 +169,26 @@ func (c *Client) GetAccountStorage(addr *thor.Address, key *thor.Bytes32, revisi
 	return &res, nil
 }
 
+// GetAccountStorageBatch retrieves the storage values for the given address and keys at the specified revision.
+func (c *Client) GetAccountStorageBatch(addr *thor.Address, keys []thor.Bytes32, revision string) (*accounts.StorageBatchResult, error) {
+	url := c.url + "/accounts/" + addr.String() + "/storage/batch"
+	if revision != "" {
+		url += "?revision=" + revision
+	}
+
+	body, err := c.httpPOST(url, &accounts.StorageBatch{Keys: keys})
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve account storage batch - %w", err)
+	}
+
+	var res accounts.StorageBatchResult
+	if err = json.Unmarshal(body, &res); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal storage batch result - %w", err)
+	}
+
+	return &res, nil
+}
+
 // GetTransaction retrieves the transaction details by the transaction ID, along with options for head and pending status.
 func (c *Client) GetTransaction(txID *thor.Bytes32, head string, isPending bool) (*transactions.Transaction, error) {
 	url := c.url + "/transactions/" + txID.String() + "?"
@@ -192,6 +259,30 @@ func (c *Client) GetTransactionReceipt(txID *thor.Bytes32, head string) (*transa
 	return &receipt, nil
 }
 
+// GetTransactionPosition retrieves the block and the index of the transaction by its ID.
+func (c *Client) GetTransactionPosition(txID *thor.Bytes32, head string) (*transactions.TxPosition, error) {
+	url := c.url + "/transactions/" + txID.String() + "/position"
+	if head != "" {
+		url += "?head=" + head
+	}
+
+	body, err := c.httpGET(url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch transaction position - %w", err)
+	}
+
+	if len(body) == 0 || bytes.Equal(bytes.TrimSpace(body), []byte("null")) {
+		return nil, common.ErrNotFound
+	}
+
+	var position transactions.TxPosition
+	if err = json.Unmarshal(body, &position); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal transaction position - %w", err)
+	}
+
+	return &position, nil
+}
+
 // SendTransaction sends a raw transaction to the blockchain.
 func (c *Client) SendTransaction(obj *transactions.RawTx) (*transactions.SendTxResult, error) {
 	body, err := c.httpPOST(c.url+"/transactions", obj)
@@ -226,6 +317,25 @@ func (c *Client) GetBlock(blockID string) (*blocks.JSONCollapsedBlock, error) {
 	return &block, nil
 }
 
+// GetBlockByTimestamp retrieves the block of the best chain at or just before the given timestamp.
+func (c *Client) GetBlockByTimestamp(timestamp uint64) (*blocks.JSONCollapsedBlock, error) {
+	body, err := c.httpGET(c.url + "/blocks/timestamp/" + fmt.Sprint(timestamp))
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve block - %w", err)
+	}
+
+	if len(body) == 0 || bytes.Equal(bytes.TrimSpace(body), []byte("null")) {
+		return nil, common.ErrNotFound
+	}
+
+	var block blocks.JSONCollapsedBlock
+	if err = json.Unmarshal(body, &block); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal block - %w", err)
+	}
+
+	return &block, nil
+}
+
 // GetExpandedBlock retrieves an expanded block by its revision.
 func (c *Client) GetExpandedBlock(revision string) (*blocks.JSONExpandedBlock, error) {
 	body, err := c.httpGET(c.url + "/blocks/" + revision + "?expanded=true")
@@ -245,6 +355,44 @@ func (c *Client) GetExpandedBlock(revision string) (*blocks.JSONExpandedBlock, e
 	return &block, nil
 }
 
+// GetBlockForks retrieves the forks active at the block of the given revision.
+func (c *Client) GetBlockForks(revision string) (*blocks.JSONBlockForks, error) {
+	body, err := c.httpGET(c.url + "/blocks/" + revision + "/forks")
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve block forks - %w", err)
+	}
+
+	if len(body) == 0 || bytes.Equal(bytes.TrimSpace(body), []byte("null")) {
+		return nil, common.ErrNotFound
+	}
+
+	var forks blocks.JSONBlockForks
+	if err = json.Unmarshal(body, &forks); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal block forks - %w", err)
+	}
+
+	return &forks, nil
+}
+
+// GetBlockGasHistory retrieves the gas usage of the blocks up to the block of the given revision.
+func (c *Client) GetBlockGasHistory(revision string, blockCount uint32) (*blocks.JSONGasHistory, error) {
+	body, err := c.httpGET(c.url + "/blocks/" + revision + "/gas-history?blockCount=" + strconv.FormatUint(uint64(blockCount), 10))
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve gas history - %w", err)
+	}
+
+	if len(body) == 0 || bytes.Equal(bytes.TrimSpace(body), []byte("null")) {
+		return nil, common.ErrNotFound
+	}
+
+	var history blocks.JSONGasHistory
+	if err = json.Unmarshal(body, &history); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal gas history - %w", err)
+	}
+
+	return &history, nil
+}
+
 // FilterEvents filters events based on the provided event filter.
 func (c *Client) FilterEvents(req *events.EventFilter) ([]events.FilteredEvent, error) {
 	body, err := c.httpPOST(c.url+"/logs/event", req)
@@ -290,6 +438,131 @@ func (c *Client) GetPeers() ([]*node.PeerStats, error) {
 	return peers, nil
 }
 
+// GetNetworkStatus retrieves the network status of the node, including the status of the port mapping.
+func (c *Client) GetNetworkStatus() (*node.NetworkStatus, error) {
+	body, err := c.httpGET(c.url + "/node/network/status")
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve network status - %w", err)
+	}
+
+	var status node.NetworkStatus
+	if err = json.Unmarshal(body, &status); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal network status - %w", err)
+	}
+
+	return &status, nil
+}
+
+// GetSchedule retrieves the expected proposers of the slots following the best block, 0 slots for the default.
+func (c *Client) GetSchedule(slots int) (*node.Schedule, error) {
+	path := "/node/schedule"
+	if slots != 0 {
+		path += "?slots=" + strconv.Itoa(slots)
+	}
+	body, err := c.httpGET(c.url + path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve schedule - %w", err)
+	}
+
+	var schedule node.Schedule
+	if err = json.Unmarshal(body, &schedule); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal schedule - %w", err)
+	}
+
+	return &schedule, nil
+}
+
+// GetTxPool retrieves a page of the txs in the pool, requires the node to enable the txpool API.
+func (c *Client) GetTxPool(filter *node.TxPoolFilter) (*node.TxPool, error) {
+	body, err := c.httpGET(c.url + "/node/txpool" + txPoolQuery(filter, true))
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve txpool - %w", err)
+	}
+
+	var txs node.TxPool
+	if err = json.Unmarshal(body, &txs); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal txpool - %w", err)
+	}
+
+	return &txs, nil
+}
+
+// GetTxPoolIDs retrieves a page of the IDs of the txs in the pool.
+func (c *Client) GetTxPoolIDs(filter *node.TxPoolFilter) (*node.TxPoolIDs, error) {
+	body, err := c.httpGET(c.url + "/node/txpool" + txPoolQuery(filter, false))
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve txpool - %w", err)
+	}
+
+	var ids node.TxPoolIDs
+	if err = json.Unmarshal(body, &ids); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal txpool - %w", err)
+	}
+
+	return &ids, nil
+}
+
+func txPoolQuery(filter *node.TxPoolFilter, expanded bool) string {
+	query := url.Values{}
+	query.Set("expanded", strconv.FormatBool(expanded))
+	if filter != nil {
+		if filter.Origin != nil {
+			query.Set("origin", filter.Origin.String())
+		}
+		if filter.Executable != nil {
+			query.Set("executable", strconv.FormatBool(*filter.Executable))
+		}
+		if filter.Limit != 0 {
+			query.Set("limit", strconv.FormatUint(filter.Limit, 10))
+		}
+		if filter.Cursor != "" {
+			query.Set("cursor", filter.Cursor)
+		}
+	}
+	return "?" + query.Encode()
+}
+
+// StreamTraceClause traces the clause in streaming mode. fn is called with each frame as it arrives,
+// and the result of the tracer is returned at the end.
+func (c *Client) StreamTraceClause(opt *debug.TraceClauseOption, fn func(json.RawMessage) error) (json.RawMessage, error) {
+	return c.streamTrace(c.url+"/debug/tracers?stream=true", opt, fn)
+}
+
+// StreamTraceCall traces the call at the specified revision in streaming mode. fn is called with each
+// frame as it arrives, and the result of the tracer is returned at the end.
+func (c *Client) StreamTraceCall(opt *debug.TraceCallOption, revision string, fn func(json.RawMessage) error) (json.RawMessage, error) {
+	url := c.url + "/debug/tracers/call?stream=true"
+	if revision != "" {
+		url += "&revision=" + revision
+	}
+	return c.streamTrace(url, opt, fn)
+}
+
+func (c *Client) streamTrace(url string, opt interface{}, fn func(json.RawMessage) error) (json.RawMessage, error) {
+	var result json.RawMessage
+	if err := c.httpStreamPOST(url, opt, func(line []byte) error {
+		var frame debug.TraceFrame
+		if err := json.Unmarshal(line, &frame); err != nil {
+			return fmt.Errorf("unable to unmarshal trace frame - %w", err)
+		}
+		switch {
+		case frame.Error != "":
+			return fmt.Errorf("trace failed - %s", frame.Error)
+		case frame.Result != nil:
+			result = frame.Result
+			return nil
+		default:
+			return fn(frame.Frame)
+		}
+	}); err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, fmt.Errorf("trace stream ended without result")
+	}
+	return result, nil
+}
+
 // RawHTTPPost sends a raw HTTP POST request to the specified URL with the provided data.
 func (c *Client) RawHTTPPost(url string, calldata interface{}) ([]byte, int, error) {
 	var data []byte
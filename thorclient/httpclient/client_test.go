@@ -7,6 +7,7 @@ package httpclient
 
 import (
 	"encoding/json"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
@@ -54,6 +55,31 @@ func TestClient_GetTransactionReceipt(t *testing.T) {
 	assert.Equal(t, expectedReceipt, receipt)
 }
 
+func TestClient_GetTransactionPosition(t *testing.T) {
+	txID := thor.Bytes32{0x01}
+	expectedPosition := &transactions.TxPosition{
+		BlockID:     thor.Bytes32{0x00, 0x00, 0x00, 0x02},
+		BlockNumber: 2,
+		Index:       1,
+		ClauseCount: 3,
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/transactions/"+txID.String()+"/position", r.URL.Path)
+		assert.Equal(t, "head=best", r.URL.RawQuery)
+
+		positionBytes, _ := json.Marshal(expectedPosition)
+		w.Write(positionBytes)
+	}))
+	defer ts.Close()
+
+	client := New(ts.URL)
+	position, err := client.GetTransactionPosition(&txID, "best")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedPosition, position)
+}
+
 func TestClient_InspectClauses(t *testing.T) {
 	calldata := &accounts.BatchCallData{}
 	expectedResults := []*accounts.CallResult{{
@@ -73,12 +99,29 @@ func TestClient_InspectClauses(t *testing.T) {
 	defer ts.Close()
 
 	client := New(ts.URL)
-	results, err := client.InspectClauses(calldata, "")
+	results, err := client.InspectClauses(calldata, "", false)
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedResults, results)
 }
 
+func TestClient_InspectClausesPending(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/*", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("pending"))
+		assert.Equal(t, "next", r.URL.Query().Get("revision"))
+
+		w.Write([]byte("[]"))
+	}))
+	defer ts.Close()
+
+	client := New(ts.URL)
+	results, err := client.InspectClauses(&accounts.BatchCallData{}, "next", true)
+
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+}
+
 func TestClient_SendTransaction(t *testing.T) {
 	rawTx := &transactions.RawTx{}
 	expectedResult := &transactions.SendTxResult{ID: &thor.Bytes32{0x01}}
@@ -213,6 +256,85 @@ func TestClient_GetStorage(t *testing.T) {
 	assert.Equal(t, expectedStorageRsp.Value, data.Value)
 }
 
+func TestClient_GetStorageBatch(t *testing.T) {
+	addr := thor.Address{0x01}
+	keys := []thor.Bytes32{{0x01}, {0x02}}
+	expectedRsp := &accounts.StorageBatchResult{Storage: map[string]string{
+		keys[0].String(): thor.Bytes32{0x03}.String(),
+		keys[1].String(): thor.Bytes32{}.String(),
+	}}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/accounts/"+addr.String()+"/storage/batch?revision=best", r.URL.Path+"?"+r.URL.RawQuery)
+
+		var batch accounts.StorageBatch
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+		assert.Equal(t, keys, batch.Keys)
+
+		marshal, err := json.Marshal(expectedRsp)
+		require.NoError(t, err)
+
+		w.Write(marshal)
+	}))
+	defer ts.Close()
+
+	client := New(ts.URL)
+	data, err := client.GetAccountStorageBatch(&addr, keys, tccommon.BestRevision)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedRsp, data)
+}
+
+func TestClient_GetProposerStatus(t *testing.T) {
+	addr := thor.Address{0x01}
+	pos := uint64(2)
+	expectedStatus := &accounts.ProposerStatus{Proposer: true, Active: true, Position: &pos, Proposers: 3}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/"+addr.String()+"/proposer?revision=best", r.URL.Path+"?"+r.URL.RawQuery)
+
+		marshal, err := json.Marshal(expectedStatus)
+		require.NoError(t, err)
+
+		w.Write(marshal)
+	}))
+	defer ts.Close()
+
+	client := New(ts.URL)
+	status, err := client.GetProposerStatus(&addr, tccommon.BestRevision)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedStatus, status)
+}
+
+func TestClient_GetEnergyProjection(t *testing.T) {
+	addr := thor.Address{0x01}
+	expectedProjection := &accounts.EnergyProjection{
+		Energy:          math.HexOrDecimal256(*big.NewInt(100)),
+		GenerationRate:  math.HexOrDecimal256(*big.NewInt(5)),
+		RuleSet:         accounts.EnergyRuleSetGrowth,
+		Timestamp:       1000,
+		ProjectedEnergy: math.HexOrDecimal256(*big.NewInt(150)),
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/"+addr.String()+"/energy-projection?timestamp=1000&revision=best", r.URL.Path+"?"+r.URL.RawQuery)
+
+		marshal, err := json.Marshal(expectedProjection)
+		require.NoError(t, err)
+
+		w.Write(marshal)
+	}))
+	defer ts.Close()
+
+	client := New(ts.URL)
+	projection, err := client.GetEnergyProjection(&addr, 1000, tccommon.BestRevision)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedProjection, projection)
+}
+
 func TestClient_GetExpandedBlock(t *testing.T) {
 	blockID := "123"
 	expectedBlock := &blocks.JSONExpandedBlock{}
@@ -263,6 +385,54 @@ func TestClient_GetBlock(t *testing.T) {
 	assert.Equal(t, expectedBlock, block)
 }
 
+func TestClient_GetBlockForks(t *testing.T) {
+	revision := "123"
+	expectedForks := &blocks.JSONBlockForks{
+		Number:    123,
+		ID:        thor.Bytes32{0x01},
+		VIP191:    true,
+		Blocklist: true,
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/blocks/"+revision+"/forks", r.URL.Path)
+
+		forksBytes, _ := json.Marshal(expectedForks)
+		w.Write(forksBytes)
+	}))
+	defer ts.Close()
+
+	client := New(ts.URL)
+	forks, err := client.GetBlockForks(revision)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedForks, forks)
+}
+
+func TestClient_GetBlockGasHistory(t *testing.T) {
+	expectedHistory := &blocks.JSONGasHistory{
+		OldestBlock:  9,
+		GasUsed:      []uint64{21000, 0},
+		GasLimit:     []uint64{42000, 42000},
+		GasUsedRatio: []float64{0.5, 0},
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/blocks/best/gas-history", r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("blockCount"))
+
+		historyBytes, _ := json.Marshal(expectedHistory)
+		w.Write(historyBytes)
+	}))
+	defer ts.Close()
+
+	client := New(ts.URL)
+	history, err := client.GetBlockGasHistory("best", 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedHistory, history)
+}
+
 func TestClient_GetNilBlock(t *testing.T) {
 	blockID := "123"
 	var expectedBlock *blocks.JSONCollapsedBlock
@@ -412,7 +582,7 @@ func TestClient_Errors(t *testing.T) {
 			name: "InspectClauses",
 			path: "/accounts/*",
 			function: func(client *Client) ([]*accounts.CallResult, error) {
-				return client.InspectClauses(&accounts.BatchCallData{}, "")
+				return client.InspectClauses(&accounts.BatchCallData{}, "", false)
 			},
 		},
 		{
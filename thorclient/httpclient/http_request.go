@@ -6,6 +6,7 @@
 package httpclient
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"fmt"
@@ -73,3 +74,34 @@ func (c *Client) httpPOST(url string, payload interface{}) ([]byte, error) {
 
 	return c.httpRequest("POST", url, bytes.NewBuffer(data))
 }
+
+// httpStreamPOST sends a POST request and calls fn with each line of the response body as it arrives.
+func (c *Client) httpStreamPOST(url string, payload interface{}, fn func(line []byte) error) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("unable to marshal payload - %w", err)
+	}
+	resp, err := c.c.Post(url, "application/json", bytes.NewBuffer(data))
+	if err != nil {
+		return fmt.Errorf("error performing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if !statusCodeIs2xx(resp.StatusCode) {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("http error - Status Code %d - %s - %w", resp.StatusCode, body, common.ErrNot200Status)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	// a line may carry a large frame, e.g. with memory enabled
+	scanner.Buffer(nil, 64*1024*1024)
+	for scanner.Scan() {
+		if err := fn(scanner.Bytes()); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading response body: %w", err)
+	}
+	return nil
+}
@@ -10,7 +10,10 @@
 package thorclient
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
 	"net/http"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -18,11 +21,14 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/vechain/thor/v2/api/accounts"
 	"github.com/vechain/thor/v2/api/blocks"
+	"github.com/vechain/thor/v2/api/debug"
 	"github.com/vechain/thor/v2/api/events"
 	"github.com/vechain/thor/v2/api/node"
 	"github.com/vechain/thor/v2/api/subscriptions"
 	"github.com/vechain/thor/v2/api/transactions"
 	"github.com/vechain/thor/v2/api/transfers"
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/builtin/energy"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/thorclient/common"
 	"github.com/vechain/thor/v2/thorclient/httpclient"
@@ -130,9 +136,10 @@ func (c *Client) Account(addr *thor.Address, opts ...Option) (*accounts.Account,
 }
 
 // InspectClauses inspects the clauses of a batch call data and returns the call results.
+// With the Pending option, the executable txs in the tx pool are applied before the clauses, on top of the next block.
 func (c *Client) InspectClauses(calldata *accounts.BatchCallData, opts ...Option) ([]*accounts.CallResult, error) {
-	options := applyOptions(opts)
-	return c.httpConn.InspectClauses(calldata, options.revision)
+	options := applyHeadOptions(opts)
+	return c.httpConn.InspectClauses(calldata, options.revision, options.pending)
 }
 
 // InspectTxClauses inspects the clauses of a transaction and returns the call results.
@@ -148,12 +155,52 @@ func (c *Client) AccountCode(addr *thor.Address, opts ...Option) (*accounts.GetC
 	return c.httpConn.GetAccountCode(addr, options.revision)
 }
 
+// EnergyProjection retrieves the energy of an account projected to the given timestamp.
+func (c *Client) EnergyProjection(addr *thor.Address, timestamp uint64, opts ...Option) (*accounts.EnergyProjection, error) {
+	options := applyOptions(opts)
+	return c.httpConn.GetEnergyProjection(addr, timestamp, options.revision)
+}
+
+// ProposerStatus retrieves whether an address is an eligible block proposer, and its position in the proposers.
+// A false status is returned for addresses unknown to the authority.
+func (c *Client) ProposerStatus(addr *thor.Address, opts ...Option) (*accounts.ProposerStatus, error) {
+	options := applyOptions(opts)
+	return c.httpConn.GetProposerStatus(addr, options.revision)
+}
+
 // AccountStorage retrieves the storage value for a given address and key.
 func (c *Client) AccountStorage(addr *thor.Address, key *thor.Bytes32, opts ...Option) (*accounts.GetStorageResult, error) {
 	options := applyOptions(opts)
 	return c.httpConn.GetAccountStorage(addr, key, options.revision)
 }
 
+// AccountStorageBatch retrieves the storage values for a given address and keys, read from the same state.
+func (c *Client) AccountStorageBatch(addr *thor.Address, keys []*thor.Bytes32, opts ...Option) (map[thor.Bytes32]thor.Bytes32, error) {
+	options := applyOptions(opts)
+	reqKeys := make([]thor.Bytes32, 0, len(keys))
+	for _, key := range keys {
+		reqKeys = append(reqKeys, *key)
+	}
+	res, err := c.httpConn.GetAccountStorageBatch(addr, reqKeys, options.revision)
+	if err != nil {
+		return nil, err
+	}
+
+	storage := make(map[thor.Bytes32]thor.Bytes32, len(res.Storage))
+	for k, v := range res.Storage {
+		key, err := thor.ParseBytes32(k)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse storage key - %w", err)
+		}
+		value, err := thor.ParseBytes32(v)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse storage value - %w", err)
+		}
+		storage[key] = value
+	}
+	return storage, nil
+}
+
 // Transaction retrieves a transaction by its ID.
 func (c *Client) Transaction(id *thor.Bytes32, opts ...Option) (*transactions.Transaction, error) {
 	options := applyHeadOptions(opts)
@@ -172,14 +219,42 @@ func (c *Client) TransactionReceipt(id *thor.Bytes32, opts ...Option) (*transact
 	return c.httpConn.GetTransactionReceipt(id, options.revision)
 }
 
+// TransactionPosition retrieves the block and the index of a transaction by its ID, without the transaction itself.
+// It returns common.ErrNotFound if the transaction is not included in the chain of the head.
+func (c *Client) TransactionPosition(id *thor.Bytes32, opts ...Option) (*transactions.TxPosition, error) {
+	options := applyHeadOptions(opts)
+	return c.httpConn.GetTransactionPosition(id, options.revision)
+}
+
+// TransactionBlock retrieves the block of the best chain which includes the transaction.
+// It returns common.ErrNotFound if the transaction is not included in any block.
+func (c *Client) TransactionBlock(id thor.Bytes32) (*blocks.JSONCollapsedBlock, error) {
+	trx, err := c.httpConn.GetTransaction(&id, "", false)
+	if err != nil {
+		return nil, err
+	}
+	if trx.Meta == nil {
+		return nil, common.ErrNotFound
+	}
+	return c.httpConn.GetBlock(trx.Meta.BlockID.String())
+}
+
 // SendTransaction sends a signed transaction to the blockchain.
+// The locally computed tx id is sent along, so the node rejects the tx
+// if it decodes to a different one.
 func (c *Client) SendTransaction(tx *tx.Transaction) (*transactions.SendTxResult, error) {
 	rlpTx, err := rlp.EncodeToBytes(tx)
 	if err != nil {
 		return nil, fmt.Errorf("unable to encode transaction - %w", err)
 	}
 
-	return c.SendRawTransaction(rlpTx)
+	rawTx := &transactions.RawTx{Raw: hexutil.Encode(rlpTx)}
+	// tx id is only meaningful for signed txs
+	if _, err := tx.Origin(); err == nil {
+		id := tx.ID()
+		rawTx.ExpectedID = &id
+	}
+	return c.httpConn.SendTransaction(rawTx)
 }
 
 // SendRawTransaction sends a raw RLP-encoded transaction to the blockchain.
@@ -192,11 +267,80 @@ func (c *Client) Block(revision string) (blocks *blocks.JSONCollapsedBlock, err
 	return c.httpConn.GetBlock(revision)
 }
 
+// BlockByTimestamp retrieves the block of the best chain at or just before the given timestamp.
+// It returns common.ErrNotFound if the timestamp is before the genesis block.
+func (c *Client) BlockByTimestamp(timestamp uint64) (*blocks.JSONCollapsedBlock, error) {
+	return c.httpConn.GetBlockByTimestamp(timestamp)
+}
+
 // ExpandedBlock retrieves an expanded block by its revision.
 func (c *Client) ExpandedBlock(revision string) (blocks *blocks.JSONExpandedBlock, err error) {
 	return c.httpConn.GetExpandedBlock(revision)
 }
 
+// BlockForks retrieves the forks active at the block of the given revision.
+func (c *Client) BlockForks(revision string) (*blocks.JSONBlockForks, error) {
+	return c.httpConn.GetBlockForks(revision)
+}
+
+// BlockGasHistory retrieves the gas used, gas limit and fullness of at most blockCount blocks,
+// up to the block of the given revision.
+func (c *Client) BlockGasHistory(revision string, blockCount uint32) (*blocks.JSONGasHistory, error) {
+	return c.httpConn.GetBlockGasHistory(revision, blockCount)
+}
+
+// CanonicalStatus is the status of a block relative to the best chain.
+type CanonicalStatus int
+
+const (
+	// CanonicalUnknown indicates the block is not known by the node.
+	CanonicalUnknown CanonicalStatus = iota
+	// Canonical indicates the block is on the best chain.
+	Canonical
+	// Orphaned indicates the block is known by the node, but not on the best chain.
+	Orphaned
+)
+
+func (s CanonicalStatus) String() string {
+	switch s {
+	case Canonical:
+		return "canonical"
+	case Orphaned:
+		return "orphaned"
+	default:
+		return "unknown"
+	}
+}
+
+// IsCanonical checks whether the block of the given ID is on the best chain.
+// It is useful after a reorg, to tell whether data previously observed from the block is still valid.
+func (c *Client) IsCanonical(blockID thor.Bytes32) (CanonicalStatus, error) {
+	block, err := c.httpConn.GetBlock(blockID.String())
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return CanonicalUnknown, nil
+		}
+		return CanonicalUnknown, err
+	}
+	if block.IsTrunk {
+		return Canonical, nil
+	}
+	return Orphaned, nil
+}
+
+// StreamTraceClause traces an existing clause, calling fn with each trace frame as soon as it's produced.
+// It returns the result of the tracer. Only tracers supporting incremental output can be streamed.
+func (c *Client) StreamTraceClause(opt *debug.TraceClauseOption, fn func(frame json.RawMessage) error) (json.RawMessage, error) {
+	return c.httpConn.StreamTraceClause(opt, fn)
+}
+
+// StreamTraceCall traces a call, calling fn with each trace frame as soon as it's produced.
+// It returns the result of the tracer. Only tracers supporting incremental output can be streamed.
+func (c *Client) StreamTraceCall(opt *debug.TraceCallOption, fn func(frame json.RawMessage) error, opts ...Option) (json.RawMessage, error) {
+	options := applyOptions(opts)
+	return c.httpConn.StreamTraceCall(opt, options.revision, fn)
+}
+
 // FilterEvents filters events based on the provided filter request.
 func (c *Client) FilterEvents(req *events.EventFilter) ([]events.FilteredEvent, error) {
 	return c.httpConn.FilterEvents(req)
@@ -212,6 +356,28 @@ func (c *Client) Peers() ([]*node.PeerStats, error) {
 	return c.httpConn.GetPeers()
 }
 
+// NetworkStatus retrieves the network status of the node.
+func (c *Client) NetworkStatus() (*node.NetworkStatus, error) {
+	return c.httpConn.GetNetworkStatus()
+}
+
+// Schedule retrieves the expected proposers of the slots following the best block, 0 slots for the default.
+// Only the first slot is certain, the others are rescheduled once a block is produced.
+func (c *Client) Schedule(slots int) (*node.Schedule, error) {
+	return c.httpConn.GetSchedule(slots)
+}
+
+// TxPool retrieves a page of the txs in the pool. Pass the next cursor of the page in the filter
+// to fetch the following page.
+func (c *Client) TxPool(filter *node.TxPoolFilter) (*node.TxPool, error) {
+	return c.httpConn.GetTxPool(filter)
+}
+
+// TxPoolIDs retrieves a page of the IDs of the txs in the pool.
+func (c *Client) TxPoolIDs(filter *node.TxPoolFilter) (*node.TxPoolIDs, error) {
+	return c.httpConn.GetTxPoolIDs(filter)
+}
+
 // ChainTag retrieves the chain tag from the genesis block.
 func (c *Client) ChainTag() (byte, error) {
 	genesisBlock, err := c.Block("0")
@@ -221,6 +387,68 @@ func (c *Client) ChainTag() (byte, error) {
 	return genesisBlock.ID[31], nil
 }
 
+// EnergySupply retrieves the energy supply broken down by component, at the given revision.
+func (c *Client) EnergySupply(opts ...Option) (*energy.SupplyBreakdown, error) {
+	options := applyOptions(opts)
+
+	// nothing is grown or burned at genesis
+	initial, err := c.callEnergy("0", "totalSupply")
+	if err != nil {
+		return nil, err
+	}
+	results, err := c.callEnergy(options.revision, "totalSupply", "totalBurned")
+	if err != nil {
+		return nil, err
+	}
+	supply, burned := results[0], results[1]
+
+	return &energy.SupplyBreakdown{
+		Initial: initial[0],
+		Growth:  new(big.Int).Sub(supply, initial[0]),
+		Burned:  burned,
+		Total:   new(big.Int).Sub(supply, burned),
+	}, nil
+}
+
+// callEnergy calls the given uint256 getters of the energy contract.
+func (c *Client) callEnergy(revision string, names ...string) ([]*big.Int, error) {
+	var clauses accounts.Clauses
+	for _, name := range names {
+		method, _ := builtin.Energy.ABI.MethodByName(name)
+		data, err := method.EncodeInput()
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode %s call - %w", name, err)
+		}
+		clauses = append(clauses, accounts.Clause{To: &builtin.Energy.Address, Data: hexutil.Encode(data)})
+	}
+
+	results, err := c.httpConn.InspectClauses(&accounts.BatchCallData{Clauses: clauses}, revision, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != len(names) {
+		return nil, fmt.Errorf("unexpected count of call results")
+	}
+
+	values := make([]*big.Int, 0, len(names))
+	for i, name := range names {
+		if results[i].Reverted {
+			return nil, fmt.Errorf("%s call reverted - %s", name, results[i].VMError)
+		}
+		data, err := hexutil.Decode(results[i].Data)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode %s result - %w", name, err)
+		}
+		var value *big.Int
+		method, _ := builtin.Energy.ABI.MethodByName(name)
+		if err := method.DecodeOutput(data, &value); err != nil {
+			return nil, fmt.Errorf("unable to decode %s result - %w", name, err)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
 // SubscribeBlocks subscribes to block updates over WebSocket.
 func (c *Client) SubscribeBlocks(pos string) (*common.Subscription[*subscriptions.BlockMessage], error) {
 	if c.wsConn == nil {
@@ -237,6 +465,15 @@ func (c *Client) SubscribeEvents(pos string, filter *subscriptions.EventFilter)
 	return c.wsConn.SubscribeEvents(pos, filter)
 }
 
+// SubscribeEventsFromBlock subscribes to event updates over WebSocket, replaying the events since the given block number.
+// Resuming from the last seen block on reconnect gives at-least-once delivery.
+func (c *Client) SubscribeEventsFromBlock(fromBlock uint32, filter *subscriptions.EventFilter) (*common.Subscription[*subscriptions.EventMessage], error) {
+	if c.wsConn == nil {
+		return nil, fmt.Errorf("not a websocket typed client")
+	}
+	return c.wsConn.SubscribeEventsFromBlock(fromBlock, filter)
+}
+
 // SubscribeTransfers subscribes to transfer updates over WebSocket.
 func (c *Client) SubscribeTransfers(pos string, filter *subscriptions.TransferFilter) (*common.Subscription[*subscriptions.TransferMessage], error) {
 	if c.wsConn == nil {
@@ -245,6 +482,15 @@ func (c *Client) SubscribeTransfers(pos string, filter *subscriptions.TransferFi
 	return c.wsConn.SubscribeTransfers(pos, filter)
 }
 
+// SubscribeTransfersFromBlock subscribes to transfer updates over WebSocket, replaying the transfers since the given block number.
+// Resuming from the last seen block on reconnect gives at-least-once delivery.
+func (c *Client) SubscribeTransfersFromBlock(fromBlock uint32, filter *subscriptions.TransferFilter) (*common.Subscription[*subscriptions.TransferMessage], error) {
+	if c.wsConn == nil {
+		return nil, fmt.Errorf("not a websocket typed client")
+	}
+	return c.wsConn.SubscribeTransfersFromBlock(fromBlock, filter)
+}
+
 // SubscribeBeats2 subscribes to Beat2 message updates over WebSocket.
 func (c *Client) SubscribeBeats2(pos string) (*common.Subscription[*subscriptions.Beat2Message], error) {
 	if c.wsConn == nil {
@@ -253,6 +499,14 @@ func (c *Client) SubscribeBeats2(pos string) (*common.Subscription[*subscription
 	return c.wsConn.SubscribeBeats2(pos)
 }
 
+// SubscribeProposers subscribes to the changes of the active proposers over WebSocket.
+func (c *Client) SubscribeProposers(pos string) (*common.Subscription[*subscriptions.ProposerMessage], error) {
+	if c.wsConn == nil {
+		return nil, fmt.Errorf("not a websocket typed client")
+	}
+	return c.wsConn.SubscribeProposers(pos)
+}
+
 // SubscribeTxPool subscribes to pending transaction updates over WebSocket.
 func (c *Client) SubscribeTxPool(txID *thor.Bytes32) (*common.Subscription[*subscriptions.PendingTxIDMessage], error) {
 	if c.wsConn == nil {
@@ -0,0 +1,120 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package thorclient
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+
+	tccommon "github.com/vechain/thor/v2/thorclient/common"
+)
+
+// maxTxSize is the max size of tx accepted by the tx pool.
+const maxTxSize = 64 * 1024
+
+// TxIssueKind is the kind of problem found in a transaction.
+type TxIssueKind string
+
+const (
+	TxIssueMalformed     TxIssueKind = "malformed"     // not decodable, or signatures not recoverable
+	TxIssueChainTag      TxIssueKind = "chainTag"      // chain tag mismatch
+	TxIssueFeatures      TxIssueKind = "features"      // features not supported by the best block
+	TxIssueSize          TxIssueKind = "size"          // size too large
+	TxIssueIntrinsicGas  TxIssueKind = "intrinsicGas"  // gas below the intrinsic gas
+	TxIssueGasLimit      TxIssueKind = "gasLimit"      // gas above the gas limit of the best block
+	TxIssueExpired       TxIssueKind = "expired"       // expired on top of the best block
+	TxIssueBlockRefAhead TxIssueKind = "blockRefAhead" // block ref too far ahead of the best block
+)
+
+// TxIssue is a problem found in a transaction.
+type TxIssue struct {
+	Kind    TxIssueKind
+	Message string
+}
+
+// TxValidation is the diagnosis of a raw transaction against the best block.
+type TxValidation struct {
+	Tx        *tx.Transaction // nil if not decodable
+	Origin    *thor.Address   // nil if not recoverable
+	Delegator *thor.Address   // nil if not delegated or not recoverable
+	Issues    []TxIssue
+}
+
+// Valid returns whether no issue is found.
+func (v *TxValidation) Valid() bool {
+	return len(v.Issues) == 0
+}
+
+func (v *TxValidation) addIssue(kind TxIssueKind, format string, args ...interface{}) {
+	v.Issues = append(v.Issues, TxIssue{Kind: kind, Message: fmt.Sprintf(format, args...)})
+}
+
+// ValidateRawTransaction decodes the raw RLP-encoded transaction and validates it against the best block,
+// without submitting it. It checks the structure, signatures, chain tag, features, size and gas, and the
+// expiration and block ref as the tx pool does. Problems of the tx are reported as issues of the result,
+// while the returned error is about communicating with the node.
+// A valid tx may still be rejected by the node, e.g. for insufficient energy.
+func (c *Client) ValidateRawTransaction(raw []byte) (*TxValidation, error) {
+	v := &TxValidation{}
+
+	var trx *tx.Transaction
+	if err := rlp.DecodeBytes(raw, &trx); err != nil {
+		v.addIssue(TxIssueMalformed, "decode: %v", err)
+		return v, nil
+	}
+	v.Tx = trx
+
+	if origin, err := trx.Origin(); err != nil {
+		v.addIssue(TxIssueMalformed, "recover origin: %v", err)
+	} else {
+		v.Origin = &origin
+	}
+	if trx.Features().IsDelegated() {
+		if delegator, err := trx.Delegator(); err != nil {
+			v.addIssue(TxIssueMalformed, "recover delegator: %v", err)
+		} else {
+			v.Delegator = delegator
+		}
+	}
+
+	chainTag, err := c.ChainTag()
+	if err != nil {
+		return nil, err
+	}
+	best, err := c.Block(tccommon.BestRevision)
+	if err != nil {
+		return nil, err
+	}
+
+	if trx.ChainTag() != chainTag {
+		v.addIssue(TxIssueChainTag, "chain tag mismatch: want %d, have %d", chainTag, trx.ChainTag())
+	}
+	if err := trx.TestFeatures(tx.Features(best.TxsFeatures)); err != nil {
+		v.addIssue(TxIssueFeatures, "%v", err)
+	}
+	if size := trx.Size(); size > maxTxSize {
+		v.addIssue(TxIssueSize, "size too large: size %d, max %d", uint64(size), maxTxSize)
+	}
+	if intrinsicGas, err := trx.IntrinsicGas(); err != nil {
+		v.addIssue(TxIssueIntrinsicGas, "%v", err)
+	} else if trx.Gas() < intrinsicGas {
+		v.addIssue(TxIssueIntrinsicGas, "intrinsic gas exceeds provided gas: required %d, provided %d, clauses %d", intrinsicGas, trx.Gas(), len(trx.Clauses()))
+	}
+	if trx.Gas() > best.GasLimit {
+		v.addIssue(TxIssueGasLimit, "gas too large: max %d, have %d", best.GasLimit, trx.Gas())
+	}
+	// on top of the next block
+	if trx.IsExpired(best.Number + 1) {
+		v.addIssue(TxIssueExpired, "expired at block %d", trx.BlockRef().Number()+trx.Expiration())
+	}
+	if refNum, maxNum := trx.BlockRef().Number(), best.Number+uint32(5*60/thor.BlockInterval); refNum > maxNum {
+		v.addIssue(TxIssueBlockRefAhead, "block ref out of schedule: max %d, have %d", maxNum, refNum)
+	}
+	return v, nil
+}
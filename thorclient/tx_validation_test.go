@@ -0,0 +1,108 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package thorclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/api/blocks"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+func TestValidateRawTransaction(t *testing.T) {
+	const chainTag = 0x27
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		block := &blocks.JSONCollapsedBlock{JSONBlockSummary: &blocks.JSONBlockSummary{}}
+		switch r.URL.Path {
+		case "/blocks/0":
+			block.ID[31] = chainTag
+		case "/blocks/best":
+			block.Number = 100
+			block.GasLimit = 10_000_000
+			block.TxsFeatures = uint32(tx.DelegationFeature)
+		default:
+			t.Fatalf("unexpected path %v", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(block)
+	}))
+	defer ts.Close()
+	client := New(ts.URL)
+
+	to := thor.BytesToAddress([]byte("to"))
+	newTx := func(build func(b *tx.Builder)) []byte {
+		b := new(tx.Builder).
+			ChainTag(chainTag).
+			BlockRef(tx.NewBlockRef(100)).
+			Expiration(10).
+			Gas(21000).
+			Clause(tx.NewClause(&to))
+		build(b)
+		raw, err := rlp.EncodeToBytes(tx.MustSign(b.Build(), genesis.DevAccounts()[0].PrivateKey))
+		require.NoError(t, err)
+		return raw
+	}
+	kinds := func(v *TxValidation) []TxIssueKind {
+		var kinds []TxIssueKind
+		for _, issue := range v.Issues {
+			kinds = append(kinds, issue.Kind)
+		}
+		return kinds
+	}
+
+	v, err := client.ValidateRawTransaction(newTx(func(*tx.Builder) {}))
+	require.NoError(t, err)
+	assert.True(t, v.Valid())
+	assert.Equal(t, genesis.DevAccounts()[0].Address, *v.Origin)
+	assert.Nil(t, v.Delegator)
+
+	for _, tc := range []struct {
+		name  string
+		build func(b *tx.Builder)
+		want  []TxIssueKind
+	}{
+		{"chain tag", func(b *tx.Builder) { b.ChainTag(1) }, []TxIssueKind{TxIssueChainTag}},
+		{"intrinsic gas", func(b *tx.Builder) { b.Gas(20000) }, []TxIssueKind{TxIssueIntrinsicGas}},
+		{"gas limit", func(b *tx.Builder) { b.Gas(20_000_000) }, []TxIssueKind{TxIssueGasLimit}},
+		{"expired", func(b *tx.Builder) { b.BlockRef(tx.NewBlockRef(80)) }, []TxIssueKind{TxIssueExpired}},
+		{"block ref ahead", func(b *tx.Builder) { b.BlockRef(tx.NewBlockRef(200)) }, []TxIssueKind{TxIssueBlockRefAhead}},
+		{"features", func(b *tx.Builder) { b.Features(tx.Features(2)) }, []TxIssueKind{TxIssueFeatures}},
+		{"size", func(b *tx.Builder) {
+			b.Clause(tx.NewClause(&to).WithData(make([]byte, 70*1024))).Gas(5_000_000)
+		}, []TxIssueKind{TxIssueSize}},
+		{"many", func(b *tx.Builder) { b.ChainTag(1).Gas(20_000_000) }, []TxIssueKind{TxIssueChainTag, TxIssueGasLimit}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := client.ValidateRawTransaction(newTx(tc.build))
+			require.NoError(t, err)
+			assert.False(t, v.Valid())
+			assert.Equal(t, tc.want, kinds(v))
+		})
+	}
+
+	// delegated but not signed by the delegator, the signature length mismatches
+	var features tx.Features
+	features.SetDelegated(true)
+	v, err = client.ValidateRawTransaction(newTx(func(b *tx.Builder) { b.Features(features) }))
+	require.NoError(t, err)
+	assert.Equal(t, []TxIssueKind{TxIssueMalformed, TxIssueMalformed}, kinds(v))
+	assert.Nil(t, v.Origin)
+	assert.Nil(t, v.Delegator)
+
+	// not decodable
+	v, err = client.ValidateRawTransaction([]byte{0x01, 0x02})
+	require.NoError(t, err)
+	assert.Nil(t, v.Tx)
+	assert.Equal(t, []TxIssueKind{TxIssueMalformed}, kinds(v))
+}
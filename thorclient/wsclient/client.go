@@ -10,6 +10,7 @@ package wsclient
 import (
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -58,6 +59,30 @@ func NewClient(url string) (*Client, error) {
 func (c *Client) SubscribeEvents(pos string, filter *subscriptions.EventFilter) (*common.Subscription[*subscriptions.EventMessage], error) {
 	queryValues := &url.Values{}
 	queryValues.Add("pos", pos)
+	addEventFilter(queryValues, filter)
+	conn, err := c.connect("/subscriptions/event", queryValues)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect - %w", err)
+	}
+
+	return subscribe[subscriptions.EventMessage](conn), nil
+}
+
+// SubscribeEventsFromBlock subscribes to blockchain events, replaying the events since the given block number
+// before streaming live updates. It's used to resume a subscription without missing events across reconnects.
+func (c *Client) SubscribeEventsFromBlock(fromBlock uint32, filter *subscriptions.EventFilter) (*common.Subscription[*subscriptions.EventMessage], error) {
+	queryValues := &url.Values{}
+	queryValues.Add("fromBlock", strconv.FormatUint(uint64(fromBlock), 10))
+	addEventFilter(queryValues, filter)
+	conn, err := c.connect("/subscriptions/event", queryValues)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect - %w", err)
+	}
+
+	return subscribe[subscriptions.EventMessage](conn), nil
+}
+
+func addEventFilter(queryValues *url.Values, filter *subscriptions.EventFilter) {
 	if filter != nil {
 		if filter.Address != nil {
 			queryValues.Add("address", filter.Address.String())
@@ -78,12 +103,6 @@ func (c *Client) SubscribeEvents(pos string, filter *subscriptions.EventFilter)
 			queryValues.Add("topic4", filter.Topic4.String())
 		}
 	}
-	conn, err := c.connect("/subscriptions/event", queryValues)
-	if err != nil {
-		return nil, fmt.Errorf("unable to connect - %w", err)
-	}
-
-	return subscribe[subscriptions.EventMessage](conn), nil
 }
 
 // SubscribeBlocks subscribes to block updates based on the provided query.
@@ -104,6 +123,30 @@ func (c *Client) SubscribeBlocks(pos string) (*common.Subscription[*subscription
 func (c *Client) SubscribeTransfers(pos string, filter *subscriptions.TransferFilter) (*common.Subscription[*subscriptions.TransferMessage], error) {
 	queryValues := &url.Values{}
 	queryValues.Add("pos", pos)
+	addTransferFilter(queryValues, filter)
+	conn, err := c.connect("/subscriptions/transfer", queryValues)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect - %w", err)
+	}
+
+	return subscribe[subscriptions.TransferMessage](conn), nil
+}
+
+// SubscribeTransfersFromBlock subscribes to transfer events, replaying the transfers since the given block number
+// before streaming live updates. It's used to resume a subscription without missing transfers across reconnects.
+func (c *Client) SubscribeTransfersFromBlock(fromBlock uint32, filter *subscriptions.TransferFilter) (*common.Subscription[*subscriptions.TransferMessage], error) {
+	queryValues := &url.Values{}
+	queryValues.Add("fromBlock", strconv.FormatUint(uint64(fromBlock), 10))
+	addTransferFilter(queryValues, filter)
+	conn, err := c.connect("/subscriptions/transfer", queryValues)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect - %w", err)
+	}
+
+	return subscribe[subscriptions.TransferMessage](conn), nil
+}
+
+func addTransferFilter(queryValues *url.Values, filter *subscriptions.TransferFilter) {
 	if filter != nil {
 		if filter.TxOrigin != nil {
 			queryValues.Add("txOrigin", filter.TxOrigin.String())
@@ -115,12 +158,6 @@ func (c *Client) SubscribeTransfers(pos string, filter *subscriptions.TransferFi
 			queryValues.Add("recipient", filter.Recipient.String())
 		}
 	}
-	conn, err := c.connect("/subscriptions/transfer", queryValues)
-	if err != nil {
-		return nil, fmt.Errorf("unable to connect - %w", err)
-	}
-
-	return subscribe[subscriptions.TransferMessage](conn), nil
 }
 
 // SubscribeTxPool subscribes to pending transaction pool updates based on the provided query.
@@ -152,6 +189,19 @@ func (c *Client) SubscribeBeats2(pos string) (*common.Subscription[*subscription
 	return subscribe[subscriptions.Beat2Message](conn), nil
 }
 
+// SubscribeProposers subscribes to the changes of the active proposers based on the provided query.
+// It returns a Subscription that streams proposer messages or an error if the connection fails.
+func (c *Client) SubscribeProposers(pos string) (*common.Subscription[*subscriptions.ProposerMessage], error) {
+	queryValues := &url.Values{}
+	queryValues.Add("pos", pos)
+	conn, err := c.connect("/subscriptions/proposer", queryValues)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect - %w", err)
+	}
+
+	return subscribe[subscriptions.ProposerMessage](conn), nil
+}
+
 // subscribe starts a new subscription over the given WebSocket connection.
 // It returns a read-only channel that streams events of type T.
 func subscribe[T any](conn *websocket.Conn) *common.Subscription[*T] {
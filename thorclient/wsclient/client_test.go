@@ -97,6 +97,82 @@ func TestClient_SubscribeTransfers(t *testing.T) {
 	assert.Equal(t, expectedTransfer, derp)
 }
 
+func TestClient_SubscribeFromBlock(t *testing.T) {
+	expectedEvent := &subscriptions.EventMessage{}
+	expectedTransfer := &subscriptions.TransferMessage{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "fromBlock=10", r.URL.RawQuery)
+
+		upgrader := websocket.Upgrader{}
+
+		conn, _ := upgrader.Upgrade(w, r, nil)
+		defer conn.Close()
+
+		switch r.URL.Path {
+		case "/subscriptions/event":
+			conn.WriteJSON(expectedEvent)
+		case "/subscriptions/transfer":
+			conn.WriteJSON(expectedTransfer)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(ts.URL)
+	assert.NoError(t, err)
+
+	eventSub, err := client.SubscribeEventsFromBlock(10, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedEvent, (<-eventSub.EventChan).Data)
+
+	transferSub, err := client.SubscribeTransfersFromBlock(10, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedTransfer, (<-transferSub.EventChan).Data)
+}
+
+func TestClient_AnswersPings(t *testing.T) {
+	expectedBlock := &subscriptions.BlockMessage{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := websocket.Upgrader{}
+
+		conn, _ := upgrader.Upgrade(w, r, nil)
+		defer conn.Close()
+
+		pong := make(chan string, 1)
+		conn.SetPongHandler(func(payload string) error {
+			pong <- payload
+			return nil
+		})
+		go func() {
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}()
+
+		assert.NoError(t, conn.WriteControl(websocket.PingMessage, []byte("ping"), time.Now().Add(time.Second)))
+		select {
+		case payload := <-pong:
+			assert.Equal(t, "ping", payload)
+			conn.WriteJSON(expectedBlock)
+		case <-time.After(5 * time.Second):
+			t.Error("pong not received")
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(ts.URL)
+	assert.NoError(t, err)
+	sub, err := client.SubscribeBlocks("")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedBlock, (<-sub.EventChan).Data)
+}
+
 func TestClient_SubscribeTxPool(t *testing.T) {
 	txID := datagen.RandomHash()
 	expectedPendingTxID := &subscriptions.PendingTxIDMessage{}
@@ -146,6 +222,34 @@ func TestClient_SubscribeBeats2(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedBeat2, (<-sub.EventChan).Data)
 }
+
+func TestClient_SubscribeProposers(t *testing.T) {
+	pos := "best"
+	expectedProposer := &subscriptions.ProposerMessage{
+		Address: thor.BytesToAddress([]byte("proposer")),
+		Change:  subscriptions.ProposerDeactivated,
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/subscriptions/proposer", r.URL.Path)
+		assert.Equal(t, "pos="+pos, r.URL.RawQuery)
+
+		upgrader := websocket.Upgrader{}
+
+		conn, _ := upgrader.Upgrade(w, r, nil)
+		defer conn.Close()
+
+		conn.WriteJSON(expectedProposer)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(ts.URL)
+	assert.NoError(t, err)
+	sub, err := client.SubscribeProposers(pos)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedProposer, (<-sub.EventChan).Data)
+}
 func TestNewClient(t *testing.T) {
 	expectedHost := "example.com"
 
@@ -228,6 +332,11 @@ func TestClient_SubscribeError(t *testing.T) {
 			subscribeFunc: client.SubscribeBlocks,
 			args:          []interface{}{pos}, // only pos
 		},
+		{
+			name:          "SubscribeProposers",
+			subscribeFunc: client.SubscribeProposers,
+			args:          []interface{}{pos}, // only pos
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			fn := reflect.ValueOf(tc.subscribeFunc)
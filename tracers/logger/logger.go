@@ -120,6 +120,9 @@ type StructLogger struct {
 
 	interrupt atomic.Value // Atomic flag to signal execution interruption
 	reason    error        // Textual reason for the interruption
+
+	writeFrame func(json.RawMessage) error // set in streaming mode
+	count      int                         // count of captured logs
 }
 
 // NewStructLogger returns a new logger
@@ -143,6 +146,7 @@ func (l *StructLogger) Reset() {
 	l.storage = make(map[common.Address]Storage)
 	l.output = make([]byte, 0)
 	l.logs = l.logs[:0]
+	l.count = 0
 	l.err = nil
 }
 
@@ -160,7 +164,7 @@ func (l *StructLogger) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, m
 		return
 	}
 	// check if already accumulated the specified number of logs
-	if l.cfg.Limit != 0 && l.cfg.Limit <= len(l.logs) {
+	if l.cfg.Limit != 0 && l.cfg.Limit <= l.count {
 		return
 	}
 
@@ -213,6 +217,17 @@ func (l *StructLogger) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, m
 	}
 	// create a new snapshot of the EVM.
 	log := StructLog{pc, op, gas, cost, mem, memory.Len(), stck, rdata, storage, depth, l.env.StateDB.GetRefund(), err}
+	l.count++
+	if l.writeFrame != nil {
+		frame, err := json.Marshal(formatLogs([]StructLog{log})[0])
+		if err == nil {
+			err = l.writeFrame(frame)
+		}
+		if err != nil {
+			l.Stop(err)
+		}
+		return
+	}
 	l.logs = append(l.logs, log)
 }
 
@@ -262,6 +277,11 @@ func (l *StructLogger) GetResult() (json.RawMessage, error) {
 	})
 }
 
+// SetFrameWriter implements tracers.StreamingTracer, every struct log is a frame.
+func (l *StructLogger) SetFrameWriter(fn func(json.RawMessage) error) {
+	l.writeFrame = fn
+}
+
 // Stop terminates execution of the tracer at the first opportune moment.
 func (l *StructLogger) Stop(err error) {
 	l.reason = err
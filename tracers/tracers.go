@@ -48,6 +48,15 @@ type Tracer interface {
 	Stop(err error)
 }
 
+// StreamingTracer is a Tracer able to emit its output incrementally.
+type StreamingTracer interface {
+	Tracer
+	// SetFrameWriter sets the function receiving trace frames as soon as they are produced.
+	// Frames passed to it are not kept, so GetResult returns what remains, e.g. the summary.
+	// The tracer stops if the function returns an error.
+	SetFrameWriter(fn func(frame json.RawMessage) error)
+}
+
 type ctorFn func(json.RawMessage) (Tracer, error)
 type jsCtorFn func(string, json.RawMessage) (Tracer, error)
 
@@ -81,25 +90,30 @@ func (d *directory) RegisterJSEval(f jsCtorFn) {
 
 // Lookup returns true if the given tracer is registered.
 func (d *directory) Lookup(name string) bool {
+	_, ok := d.Resolve(name)
+	return ok
+}
+
+// Resolve returns the registered name of the given tracer, and false if not registered,
+// i.e. the name is arbitrary JS code.
+func (d *directory) Resolve(name string) (string, bool) {
 	if _, ok := d.elems[name]; ok {
-		return ok
+		return name, true
 	}
 
 	// backward compatible, allow users emit "Tracer" suffix
-	_, ok := d.elems[name+"Tracer"]
-	return ok
+	if _, ok := d.elems[name+"Tracer"]; ok {
+		return name + "Tracer", true
+	}
+	return "", false
 }
 
 // New returns a new instance of a tracer, by iterating through the
 // registered lookups. Name is either name of an existing tracer
 // or an arbitrary JS code.
 func (d *directory) New(name string, cfg json.RawMessage, allowCustom bool) (Tracer, error) {
-	if elem, ok := d.elems[name]; ok {
-		return elem.ctor(cfg)
-	}
-	// backward compatible, allow users emit "Tracer" suffix
-	if elem, ok := d.elems[name+"Tracer"]; ok {
-		return elem.ctor(cfg)
+	if registered, ok := d.Resolve(name); ok {
+		return d.elems[registered].ctor(cfg)
 	}
 
 	if allowCustom {
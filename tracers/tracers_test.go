@@ -185,6 +185,19 @@ func TestNewTracer(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestResolveTracer(t *testing.T) {
+	name, ok := tracers.DefaultDirectory.Resolve("callTracer")
+	assert.True(t, ok)
+	assert.Equal(t, "callTracer", name)
+
+	name, ok = tracers.DefaultDirectory.Resolve("call")
+	assert.True(t, ok)
+	assert.Equal(t, "callTracer", name)
+
+	_, ok = tracers.DefaultDirectory.Resolve("{step: function() {}, result: function() {}}")
+	assert.False(t, ok)
+}
+
 func TestAllTracers(t *testing.T) {
 	var testData callTest
 	if blob, err := os.ReadFile("testdata/calls.json"); err != nil {
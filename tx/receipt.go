@@ -37,9 +37,23 @@ type Output struct {
 	Transfers Transfers
 }
 
+// BurnedFee returns the energy burned by the tx, i.e. the energy paid net of the reward to the block proposer.
+func (r *Receipt) BurnedFee() *big.Int {
+	return new(big.Int).Sub(r.Paid, r.Reward)
+}
+
 // Receipts slice of receipts.
 type Receipts []*Receipt
 
+// BurnedFee returns the total energy burned by the txs.
+func (rs Receipts) BurnedFee() *big.Int {
+	burned := new(big.Int)
+	for _, r := range rs {
+		burned.Add(burned, r.BurnedFee())
+	}
+	return burned
+}
+
 // RootHash computes merkle root hash of receipts.
 func (rs Receipts) RootHash() thor.Bytes32 {
 	if len(rs) == 0 {
@@ -46,6 +46,18 @@ func TestReceiptStructure(t *testing.T) {
 	assert.Equal(t, []*tx.Output{}, receipt.Outputs)
 }
 
+func TestBurnedFee(t *testing.T) {
+	receipt1 := getMockReceipt()
+	receipt2 := getMockReceipt()
+	receipt2.Paid = big.NewInt(300)
+	receipt2.Reward = big.NewInt(90)
+
+	assert.Equal(t, big.NewInt(50), receipt1.BurnedFee())
+	assert.Equal(t, big.NewInt(210), receipt2.BurnedFee())
+	assert.Equal(t, big.NewInt(260), tx.Receipts{&receipt1, &receipt2}.BurnedFee())
+	assert.Equal(t, big.NewInt(0), tx.Receipts{}.BurnedFee())
+}
+
 func TestEmptyRootHash(t *testing.T) {
 	receipt1 := getMockReceipt()
 	receipt2 := getMockReceipt()
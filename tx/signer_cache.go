@@ -0,0 +1,77 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tx
+
+import (
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// DefaultSignerCacheSize is the default count of recovered signers cached.
+const DefaultSignerCacheSize = 16384
+
+// signerKey identifies a recovered signer. The tx hash covers the whole tx including signatures,
+// so a cached signer is always the result of recovering the very same signature, and entries
+// never go stale. Only successful recoveries are cached.
+type signerKey struct {
+	txHash    thor.Bytes32
+	delegator bool
+}
+
+var (
+	// signerCache is shared by all tx instances, so that the same tx decoded again,
+	// e.g. received by the pool and then in a block, doesn't need to be recovered again.
+	signerCache atomic.Pointer[lru.Cache]
+
+	signerCacheHits  atomic.Uint64
+	signerRecoveries atomic.Uint64
+)
+
+func init() {
+	SetSignerCacheSize(DefaultSignerCacheSize)
+}
+
+// SetSignerCacheSize sets the max count of recovered signers cached process-wide.
+// The cache is disabled if size is 0.
+func SetSignerCacheSize(size int) {
+	if size <= 0 {
+		signerCache.Store(nil)
+		return
+	}
+	cache, _ := lru.New(size)
+	signerCache.Store(cache)
+}
+
+// SignerStats returns the count of signers served by the cache, and the count of signature recoveries performed.
+func SignerStats() (cacheHits, recoveries uint64) {
+	return signerCacheHits.Load(), signerRecoveries.Load()
+}
+
+// recoverSigner recovers the signer of the hash from the signature, or takes it from the cache.
+func recoverSigner(key signerKey, hash thor.Bytes32, sig []byte) (thor.Address, error) {
+	cache := signerCache.Load()
+	if cache != nil {
+		if cached, ok := cache.Get(key); ok {
+			signerCacheHits.Add(1)
+			return cached.(thor.Address), nil
+		}
+	}
+
+	pub, err := crypto.SigToPub(hash.Bytes(), sig)
+	if err != nil {
+		return thor.Address{}, err
+	}
+	signerRecoveries.Add(1)
+
+	signer := thor.Address(crypto.PubkeyToAddress(*pub))
+	if cache != nil {
+		cache.Add(key, signer)
+	}
+	return signer, nil
+}
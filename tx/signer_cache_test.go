@@ -0,0 +1,129 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// replaySigners decodes the txs as received by the pool, then as received in a block,
+// and recovers the signers each time. It returns the count of signature recoveries performed.
+func replaySigners(t *testing.T, txs tx.Transactions) uint64 {
+	_, before := tx.SignerStats()
+
+	origins := make([]thor.Address, len(txs))
+	for i, trx := range txs {
+		data, err := rlp.EncodeToBytes(trx)
+		require.NoError(t, err)
+		var received tx.Transaction
+		require.NoError(t, rlp.DecodeBytes(data, &received))
+		origins[i], err = received.Origin()
+		require.NoError(t, err)
+		_, err = received.Delegator()
+		require.NoError(t, err)
+	}
+
+	data, err := rlp.EncodeToBytes(txs)
+	require.NoError(t, err)
+	var inBlock tx.Transactions
+	require.NoError(t, rlp.DecodeBytes(data, &inBlock))
+	for i, trx := range inBlock {
+		origin, err := trx.Origin()
+		require.NoError(t, err)
+		assert.Equal(t, origins[i], origin)
+		_, err = trx.Delegator()
+		require.NoError(t, err)
+	}
+
+	_, after := tx.SignerStats()
+	return after - before
+}
+
+func newSignedTxs(t *testing.T, n int) tx.Transactions {
+	origin, _ := crypto.GenerateKey()
+	delegator, _ := crypto.GenerateKey()
+
+	var txs tx.Transactions
+	for i := 0; i < n; i++ {
+		builder := new(tx.Builder).ChainTag(1).Gas(21000).Nonce(uint64(i))
+		if i%2 == 0 {
+			txs = append(txs, tx.MustSign(builder.Build(), origin))
+			continue
+		}
+		var features tx.Features
+		features.SetDelegated(true)
+		trx, err := tx.SignDelegated(builder.Features(features).Build(), origin, delegator)
+		require.NoError(t, err)
+		txs = append(txs, trx)
+	}
+	return txs
+}
+
+func TestSignerCacheReplay(t *testing.T) {
+	defer tx.SetSignerCacheSize(tx.DefaultSignerCacheSize)
+
+	// 50 txs, 25 of them delegated
+	const signatures = 50 + 25
+
+	tx.SetSignerCacheSize(0)
+	assert.Equal(t, uint64(2*signatures), replaySigners(t, newSignedTxs(t, 50)))
+
+	tx.SetSignerCacheSize(tx.DefaultSignerCacheSize)
+	hits, _ := tx.SignerStats()
+	assert.Equal(t, uint64(signatures), replaySigners(t, newSignedTxs(t, 50)))
+	newHits, _ := tx.SignerStats()
+	assert.Equal(t, uint64(signatures), newHits-hits)
+
+	// evicted signers are recovered again
+	tx.SetSignerCacheSize(10)
+	assert.True(t, replaySigners(t, newSignedTxs(t, 50)) > signatures)
+}
+
+func TestSignerCacheNotPoisoned(t *testing.T) {
+	defer tx.SetSignerCacheSize(tx.DefaultSignerCacheSize)
+	tx.SetSignerCacheSize(tx.DefaultSignerCacheSize)
+
+	key, _ := crypto.GenerateKey()
+	trx := tx.MustSign(new(tx.Builder).ChainTag(1).Gas(21000).Nonce(1).Build(), key)
+	origin, err := trx.Origin()
+	require.NoError(t, err)
+	assert.Equal(t, thor.Address(crypto.PubkeyToAddress(key.PublicKey)), origin)
+
+	// a tampered signature is a different tx, never served the signer of the original one
+	sig := trx.Signature()
+	sig[0] ^= 0xff
+	tampered := trx.WithSignature(sig)
+	if signer, err := tampered.Origin(); err == nil {
+		assert.NotEqual(t, origin, signer)
+	}
+
+	// failed recoveries are not cached
+	_, recoveries := tx.SignerStats()
+	for i := 0; i < 2; i++ {
+		_, err := trx.WithSignature(make([]byte, 65)).Origin()
+		assert.Error(t, err)
+	}
+	hits, newRecoveries := tx.SignerStats()
+	assert.Equal(t, recoveries, newRecoveries)
+
+	// the original tx decoded again is served from the cache
+	data, err := rlp.EncodeToBytes(trx)
+	require.NoError(t, err)
+	var decoded tx.Transaction
+	require.NoError(t, rlp.DecodeBytes(data, &decoded))
+	signer, err := decoded.Origin()
+	require.NoError(t, err)
+	assert.Equal(t, origin, signer)
+	newHits, _ := tx.SignerStats()
+	assert.Equal(t, hits+1, newHits)
+}
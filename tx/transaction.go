@@ -15,7 +15,6 @@ import (
 	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common/math"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/secp256k1"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -221,11 +220,10 @@ func (t *Transaction) Origin() (thor.Address, error) {
 		return cached.(thor.Address), nil
 	}
 
-	pub, err := crypto.SigToPub(t.SigningHash().Bytes(), t.body.Signature[:65])
+	origin, err := recoverSigner(signerKey{t.Hash(), false}, t.SigningHash(), t.body.Signature[:65])
 	if err != nil {
 		return thor.Address{}, err
 	}
-	origin := thor.Address(crypto.PubkeyToAddress(*pub))
 	t.cache.origin.Store(origin)
 	return origin, nil
 }
@@ -256,13 +254,11 @@ func (t *Transaction) Delegator() (*thor.Address, error) {
 		return nil, err
 	}
 
-	pub, err := crypto.SigToPub(t.DelegatorSigningHash(origin).Bytes(), t.body.Signature[65:])
+	delegator, err := recoverSigner(signerKey{t.Hash(), true}, t.DelegatorSigningHash(origin), t.body.Signature[65:])
 	if err != nil {
 		return nil, err
 	}
 
-	delegator := thor.Address(crypto.PubkeyToAddress(*pub))
-
 	t.cache.delegator.Store(delegator)
 	return &delegator, nil
 }
@@ -335,6 +331,45 @@ func (t *Transaction) IntrinsicGas() (uint64, error) {
 	return gas, nil
 }
 
+// GasCost is the intrinsic gas consumed by a clause.
+type GasCost struct {
+	ClauseIndex       uint32
+	DataCost          uint64 // of the zero and non-zero bytes of the clause data
+	BaseCost          uint64 // of the clause, including the tx gas for the first clause
+	ExecutionEstimate uint64 // the intrinsic gas of the clause, i.e. DataCost + BaseCost
+}
+
+// GasSchedule breaks down the intrinsic gas of tx by clause. The sum of the
+// execution estimates equals IntrinsicGas, except for the tx without clauses,
+// which has an empty schedule.
+func (t *Transaction) GasSchedule() ([]GasCost, error) {
+	schedule := make([]GasCost, 0, len(t.body.Clauses))
+	for i, c := range t.body.Clauses {
+		data, err := dataGas(c.body.Data)
+		if err != nil {
+			return nil, err
+		}
+		base := thor.ClauseGas
+		if c.IsCreatingContract() {
+			base = thor.ClauseGasContractCreation
+		}
+		if i == 0 {
+			base += thor.TxGas
+		}
+		total, overflow := math.SafeAdd(data, base)
+		if overflow {
+			return nil, errIntrinsicGasOverflow
+		}
+		schedule = append(schedule, GasCost{
+			ClauseIndex:       uint32(i),
+			DataCost:          data,
+			BaseCost:          base,
+			ExecutionEstimate: total,
+		})
+	}
+	return schedule, nil
+}
+
 // GasPrice returns gas price.
 // gasPrice = baseGasPrice + baseGasPrice * gasPriceCoef / 255
 func (t *Transaction) GasPrice(baseGasPrice *big.Int) *big.Int {
@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/stretchr/testify/assert"
 	"github.com/vechain/thor/v2/thor"
@@ -280,6 +281,32 @@ func TestIntrinsicGas(t *testing.T) {
 	assert.Equal(t, thor.TxGas+thor.ClauseGas*2, gas)
 }
 
+func TestGasSchedule(t *testing.T) {
+	to := thor.BytesToAddress([]byte("to"))
+	data := []byte{0, 0, 1, 2, 3}
+	trx := new(tx.Builder).
+		Clause(tx.NewClause(&to).WithData(data)).
+		Clause(tx.NewClause(nil)).
+		Build()
+
+	schedule, err := trx.GasSchedule()
+	assert.Nil(t, err)
+	// 2 zero bytes and 3 non-zero bytes
+	dataCost := 2*params.TxDataZeroGas + 3*params.TxDataNonZeroGas
+	assert.Equal(t, []tx.GasCost{
+		{ClauseIndex: 0, DataCost: dataCost, BaseCost: 21000, ExecutionEstimate: dataCost + 21000},
+		{ClauseIndex: 1, DataCost: 0, BaseCost: thor.ClauseGasContractCreation, ExecutionEstimate: thor.ClauseGasContractCreation},
+	}, schedule)
+
+	intrinsicGas, err := trx.IntrinsicGas()
+	assert.Nil(t, err)
+	assert.Equal(t, intrinsicGas, schedule[0].ExecutionEstimate+schedule[1].ExecutionEstimate)
+
+	schedule, err = new(tx.Builder).Build().GasSchedule()
+	assert.Nil(t, err)
+	assert.Empty(t, schedule)
+}
+
 func BenchmarkTxMining(b *testing.B) {
 	tx := new(tx.Builder).Build()
 	signer := thor.BytesToAddress([]byte("acc1"))
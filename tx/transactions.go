@@ -19,6 +19,9 @@ var (
 type Transactions []*Transaction
 
 // RootHash computes merkle root hash of transactions.
+// It's the txs root of a block including the txs in the same order, which is
+// what the block builder sets and consensus verifies, so it can be used to
+// verify a block's txs root, or to commit to a batch of txs in advance.
 func (txs Transactions) RootHash() thor.Bytes32 {
 	if len(txs) == 0 {
 		// optimized
@@ -8,7 +8,10 @@ package tx_test
 import (
 	"testing"
 
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/block"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/tx"
 )
@@ -31,3 +34,45 @@ func TestRootHash(t *testing.T) {
 	nonEmptyTxs := MockTransactions(2)
 	assert.Equal(t, nonEmptyTxs.RootHash(), thor.Bytes32{0x30, 0x9a, 0xd5, 0x4b, 0x28, 0x76, 0x65, 0x52, 0x66, 0x89, 0x7b, 0x19, 0x22, 0x24, 0x63, 0xd8, 0x27, 0xc8, 0x2a, 0xd6, 0x20, 0x17, 0x7a, 0xcf, 0x9a, 0xfa, 0xc, 0xce, 0xff, 0x12, 0x24, 0x48})
 }
+
+func TestRootHashOfBlock(t *testing.T) {
+	key, err := crypto.HexToECDSA("dce1443bd2ef0c2631adc1c67e5c93f13dc23a41c18b536effbbdcbcdb96fb65")
+	require.NoError(t, err)
+	to := thor.MustParseAddress("0x7567d83b7b8d80addcb281a71d54fc7b3364ffed")
+
+	newTx := func(nonce uint64, features tx.Features) *tx.Transaction {
+		return tx.MustSign(new(tx.Builder).
+			ChainTag(0x27).
+			BlockRef(tx.NewBlockRef(10)).
+			Expiration(32).
+			Clause(tx.NewClause(&to).WithData([]byte{0x01, 0x02})).
+			GasPriceCoef(128).
+			Gas(21000).
+			Features(features).
+			Nonce(nonce).
+			Build(), key)
+	}
+
+	tests := []struct {
+		name string
+		txs  tx.Transactions
+		root string
+	}{
+		{"empty", nil, "0x45b0cfc220ceec5b7c1c62c4d4193d38e4eba48e8815729ce75f9c0ab0e4c1c0"},
+		{"one tx", tx.Transactions{newTx(1, 0)}, "0xf1cecb12f03830f93b213bd2548fdac6cd959d84234f4a9e6cdd8e3308493d2e"},
+		{"two txs", tx.Transactions{newTx(1, 0), newTx(2, tx.DelegationFeature)}, "0x44acb0a1035c2821a0769cc2bf30750bc7718b73d8e0a375c29b054a619b83f3"},
+		{"reordered", tx.Transactions{newTx(2, tx.DelegationFeature), newTx(1, 0)}, "0x535a976a2552e3448a39bc49a116af4a621d937eb9f5c6bdb2036f5f3fa859c0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := new(block.Builder).ParentID(thor.Bytes32{}).Timestamp(10)
+			for _, trx := range tt.txs {
+				builder.Transaction(trx)
+			}
+			blk := builder.Build()
+
+			assert.Equal(t, blk.Header().TxsRoot(), tt.txs.RootHash())
+			assert.Equal(t, tt.root, tt.txs.RootHash().String())
+		})
+	}
+}
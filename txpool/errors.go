@@ -5,19 +5,53 @@
 
 package txpool
 
+import (
+	"errors"
+	"fmt"
+)
+
+// badTxError and txRejectedError may wrap a cause carrying details, e.g. TxSizeError or
+// runtime.IntrinsicGasError, which can be retrieved by errors.As.
 type (
-	badTxError      struct{ msg string }
-	txRejectedError struct{ msg string }
+	badTxError struct {
+		msg   string
+		cause error
+	}
+	txRejectedError struct {
+		msg   string
+		cause error
+	}
 )
 
 func (e badTxError) Error() string {
 	return "bad tx: " + e.msg
 }
 
+func (e badTxError) Unwrap() error {
+	return e.cause
+}
+
 func (e txRejectedError) Error() string {
 	return "tx rejected: " + e.msg
 }
 
+func (e txRejectedError) Unwrap() error {
+	return e.cause
+}
+
+// errTxNotFound is the cause of rejecting to cancel or replace a tx not in the pool.
+var errTxNotFound = errors.New("tx not found")
+
+// TxSizeError is the cause of rejecting a tx whose encoded size exceeds the max size.
+type TxSizeError struct {
+	Size    uint64 // encoded size of the tx
+	MaxSize uint64 // max size accepted by the pool
+}
+
+func (e *TxSizeError) Error() string {
+	return fmt.Sprintf("size too large: size %d, max %d", e.Size, e.MaxSize)
+}
+
 // IsBadTx returns whether the given error indicates that tx is bad.
 func IsBadTx(err error) bool {
 	_, ok := err.(badTxError)
@@ -29,3 +63,8 @@ func IsTxRejected(err error) bool {
 	_, ok := err.(txRejectedError)
 	return ok
 }
+
+// IsTxNotFound returns whether the given error indicates the tx to cancel or replace is not in the pool.
+func IsTxNotFound(err error) bool {
+	return errors.Is(err, errTxNotFound)
+}
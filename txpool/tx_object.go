@@ -27,9 +27,11 @@ type txObject struct {
 	localSubmitted bool          // tx is submitted locally on this node, or synced remotely from p2p.
 	payer          *thor.Address // payer of the tx, either origin, delegator, or on-chain delegation payer
 	cost           *big.Int      // total tx cost the payer needs to pay before execution(gas price * gas)
+	value          *big.Int      // total VET value the origin transfers out by clauses, transfers to itself excluded
 
-	executable      bool     // don't touch this value, will be updated by the pool
-	overallGasPrice *big.Int // don't touch this value, it's only be used in pool's housekeeping
+	executable      bool         // don't touch this value, will be updated by the pool
+	overallGasPrice *big.Int     // don't touch this value, it's only be used in pool's housekeeping
+	washedHead      thor.Bytes32 // the head on which the tx was found executable by the last wash
 }
 
 func resolveTx(tx *tx.Transaction, localSubmitted bool) (*txObject, error) {
@@ -38,11 +40,21 @@ func resolveTx(tx *tx.Transaction, localSubmitted bool) (*txObject, error) {
 		return nil, err
 	}
 
+	value := new(big.Int)
+	for _, clause := range tx.Clauses() {
+		// transfers to the origin itself don't decrease the origin's balance
+		if to := clause.To(); to != nil && *to == resolved.Origin {
+			continue
+		}
+		value.Add(value, clause.Value())
+	}
+
 	return &txObject{
 		Transaction:    tx,
 		resolved:       resolved,
 		timeAdded:      time.Now().UnixNano(),
 		localSubmitted: localSubmitted,
+		value:          value,
 	}, nil
 }
 
@@ -62,15 +74,27 @@ func (o *txObject) Payer() *thor.Address {
 	return o.payer
 }
 
-func (o *txObject) Executable(chain *chain.Chain, state *state.State, headBlock *block.Header) (bool, error) {
+func (o *txObject) Value() *big.Int {
+	return o.value
+}
+
+// checkHead checks the tx against the head block, without accessing the chain and the state.
+func (o *txObject) checkHead(headBlock *block.Header) error {
 	switch {
 	case o.Gas() > headBlock.GasLimit():
-		return false, errors.New("gas too large")
+		return errors.New("gas too large")
 	case o.IsExpired(headBlock.Number() + 1): // Check tx expiration on top of next block
-		return false, errors.New("expired")
+		return errors.New("expired")
 	case o.BlockRef().Number() > headBlock.Number()+uint32(5*60/thor.BlockInterval):
 		// reject deferred tx which will be applied after 5mins
-		return false, errors.New("block ref out of schedule")
+		return errors.New("block ref out of schedule")
+	}
+	return nil
+}
+
+func (o *txObject) Executable(chain *chain.Chain, state *state.State, headBlock *block.Header) (bool, error) {
+	if err := o.checkHead(headBlock); err != nil {
+		return false, err
 	}
 
 	if has, err := chain.HasTransaction(o.ID(), o.BlockRef().Number()); err != nil {
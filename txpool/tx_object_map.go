@@ -14,21 +14,25 @@ import (
 	"github.com/vechain/thor/v2/tx"
 )
 
-// txObjectMap to maintain mapping of tx hash to tx object, account quota and pending cost.
+// txObjectMap to maintain mapping of tx hash to tx object, account quota, pending cost and pending value.
 type txObjectMap struct {
 	lock      sync.RWMutex
 	mapByHash map[thor.Bytes32]*txObject
 	mapByID   map[thor.Bytes32]*txObject
+	byOrigin  map[thor.Address]map[thor.Bytes32]*txObject
 	quota     map[thor.Address]int
 	cost      map[thor.Address]*big.Int
+	value     map[thor.Address]*big.Int
 }
 
 func newTxObjectMap() *txObjectMap {
 	return &txObjectMap{
 		mapByHash: make(map[thor.Bytes32]*txObject),
 		mapByID:   make(map[thor.Bytes32]*txObject),
+		byOrigin:  make(map[thor.Address]map[thor.Bytes32]*txObject),
 		quota:     make(map[thor.Address]int),
 		cost:      make(map[thor.Address]*big.Int),
+		value:     make(map[thor.Address]*big.Int),
 	}
 }
 
@@ -39,10 +43,43 @@ func (m *txObjectMap) ContainsHash(txHash thor.Bytes32) bool {
 	return found
 }
 
-func (m *txObjectMap) Add(txObj *txObject, limitPerAccount int, validatePayer func(payer thor.Address, needs *big.Int) error) error {
+// balanceChecks validates the balances against the overall pending amounts of an account, a nil check passes.
+type balanceChecks struct {
+	payer  func(payer thor.Address, needs *big.Int) error  // energy of the payer for the overall pending cost
+	origin func(origin thor.Address, value *big.Int) error // VET of the origin for the overall pending value
+}
+
+func (m *txObjectMap) Add(txObj *txObject, limitPerAccount int, checks balanceChecks) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.add(txObj, limitPerAccount, checks)
+}
+
+// Replace removes the tx of the given hash and adds the new tx in one step. The removed tx is kept if the new
+// tx can't be added.
+func (m *txObjectMap) Replace(oldHash thor.Bytes32, txObj *txObject, limitPerAccount int, checks balanceChecks) error {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
+	old, ok := m.mapByHash[oldHash]
+	if !ok {
+		return errTxNotFound
+	}
+	if _, found := m.mapByHash[txObj.Hash()]; found {
+		return errors.New("replacement already in the pool")
+	}
+	m.removeByHash(oldHash)
+	if err := m.add(txObj, limitPerAccount, checks); err != nil {
+		// restored without checks, as it was added before
+		if restoreErr := m.add(old, limitPerAccount, balanceChecks{}); restoreErr != nil {
+			return restoreErr
+		}
+		return err
+	}
+	return nil
+}
+
+func (m *txObjectMap) add(txObj *txObject, limitPerAccount int, checks balanceChecks) error {
 	hash := txObj.Hash()
 	if _, found := m.mapByHash[hash]; found {
 		return nil
@@ -62,6 +99,7 @@ func (m *txObjectMap) Add(txObj *txObject, limitPerAccount int, validatePayer fu
 	var (
 		cost  *big.Int
 		payer thor.Address
+		value *big.Int
 	)
 
 	if txObj.Cost() != nil {
@@ -74,8 +112,25 @@ func (m *txObjectMap) Add(txObj *txObject, limitPerAccount int, validatePayer fu
 			cost = new(big.Int).Add(pending, txObj.Cost())
 		}
 
-		if err := validatePayer(payer, cost); err != nil {
-			return err
+		if checks.payer != nil {
+			if err := checks.payer(payer, cost); err != nil {
+				return err
+			}
+		}
+
+		// value is always paid by the origin, no matter who pays the fee
+		if txObj.Value().Sign() > 0 {
+			if pending := m.value[txObj.Origin()]; pending == nil {
+				value = new(big.Int).Set(txObj.Value())
+			} else {
+				value = new(big.Int).Add(pending, txObj.Value())
+			}
+
+			if checks.origin != nil {
+				if err := checks.origin(txObj.Origin(), value); err != nil {
+					return err
+				}
+			}
 		}
 	}
 
@@ -87,12 +142,25 @@ func (m *txObjectMap) Add(txObj *txObject, limitPerAccount int, validatePayer fu
 	if cost != nil {
 		m.cost[payer] = cost
 	}
+	if value != nil {
+		m.value[txObj.Origin()] = value
+	}
 
 	m.mapByHash[hash] = txObj
 	m.mapByID[txObj.ID()] = txObj
+	m.indexByOrigin(txObj)
 	return nil
 }
 
+func (m *txObjectMap) indexByOrigin(txObj *txObject) {
+	txObjs := m.byOrigin[txObj.Origin()]
+	if txObjs == nil {
+		txObjs = make(map[thor.Bytes32]*txObject)
+		m.byOrigin[txObj.Origin()] = txObjs
+	}
+	txObjs[txObj.Hash()] = txObj
+}
+
 func (m *txObjectMap) GetByID(id thor.Bytes32) *txObject {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
@@ -102,7 +170,10 @@ func (m *txObjectMap) GetByID(id thor.Bytes32) *txObject {
 func (m *txObjectMap) RemoveByHash(txHash thor.Bytes32) bool {
 	m.lock.Lock()
 	defer m.lock.Unlock()
+	return m.removeByHash(txHash)
+}
 
+func (m *txObjectMap) removeByHash(txHash thor.Bytes32) bool {
 	if txObj, ok := m.mapByHash[txHash]; ok {
 		if m.quota[txObj.Origin()] > 1 {
 			m.quota[txObj.Origin()]--
@@ -127,10 +198,24 @@ func (m *txObjectMap) RemoveByHash(txHash thor.Bytes32) bool {
 					m.cost[*payer] = new(big.Int).Sub(pending, txObj.Cost())
 				}
 			}
+
+			// update the pending value of origin
+			if pending := m.value[txObj.Origin()]; pending != nil && txObj.Value().Sign() > 0 {
+				if pending.Cmp(txObj.Value()) <= 0 {
+					delete(m.value, txObj.Origin())
+				} else {
+					m.value[txObj.Origin()] = new(big.Int).Sub(pending, txObj.Value())
+				}
+			}
 		}
 
 		delete(m.mapByHash, txHash)
 		delete(m.mapByID, txObj.ID())
+		if txObjs := m.byOrigin[txObj.Origin()]; len(txObjs) > 1 {
+			delete(txObjs, txHash)
+		} else {
+			delete(m.byOrigin, txObj.Origin())
+		}
 		return true
 	}
 	return false
@@ -145,6 +230,14 @@ func (m *txObjectMap) UpdatePendingCost(txObj *txObject) {
 	} else {
 		m.cost[*txObj.Payer()] = new(big.Int).Set(txObj.Cost())
 	}
+
+	if txObj.Value().Sign() > 0 {
+		if pending := m.value[txObj.Origin()]; pending != nil {
+			m.value[txObj.Origin()] = new(big.Int).Add(pending, txObj.Value())
+		} else {
+			m.value[txObj.Origin()] = new(big.Int).Set(txObj.Value())
+		}
+	}
 }
 
 func (m *txObjectMap) ToTxObjects() []*txObject {
@@ -158,6 +251,23 @@ func (m *txObjectMap) ToTxObjects() []*txObject {
 	return txObjs
 }
 
+// AccountSummary returns the count, the earliest time added and the total cost at the given base gas price
+// of the txs originated from the account.
+func (m *txObjectMap) AccountSummary(addr thor.Address, baseGasPrice *big.Int) (count int, oldest int64, cost *big.Int) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	cost = new(big.Int)
+	for _, txObj := range m.byOrigin[addr] {
+		if oldest == 0 || txObj.timeAdded < oldest {
+			oldest = txObj.timeAdded
+		}
+		gas := new(big.Int).SetUint64(txObj.Gas())
+		cost.Add(cost, gas.Mul(gas, txObj.GasPrice(baseGasPrice)))
+	}
+	return len(m.byOrigin[addr]), oldest, cost
+}
+
 func (m *txObjectMap) ToTxs() tx.Transactions {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
@@ -183,6 +293,7 @@ func (m *txObjectMap) Fill(txObjs []*txObject) {
 		}
 		m.mapByHash[txObj.Hash()] = txObj
 		m.mapByID[txObj.ID()] = txObj
+		m.indexByOrigin(txObj)
 		// skip cost check and accumulation
 	}
 }
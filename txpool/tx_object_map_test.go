@@ -32,8 +32,8 @@ func TestGetByID(t *testing.T) {
 
 	// Creating a new txObjectMap and adding transactions
 	m := newTxObjectMap()
-	assert.Nil(t, m.Add(txObj1, 1, func(_ thor.Address, _ *big.Int) error { return nil }))
-	assert.Nil(t, m.Add(txObj2, 1, func(_ thor.Address, _ *big.Int) error { return nil }))
+	assert.Nil(t, m.Add(txObj1, 1, balanceChecks{}))
+	assert.Nil(t, m.Add(txObj2, 1, balanceChecks{}))
 
 	// Testing GetByID
 	retrievedTxObj1 := m.GetByID(txObj1.ID())
@@ -97,14 +97,14 @@ func TestTxObjMap(t *testing.T) {
 	m := newTxObjectMap()
 	assert.Zero(t, m.Len())
 
-	assert.Nil(t, m.Add(txObj1, 1, func(_ thor.Address, _ *big.Int) error { return nil }))
-	assert.Nil(t, m.Add(txObj1, 1, func(_ thor.Address, _ *big.Int) error { return nil }), "should no error if exists")
+	assert.Nil(t, m.Add(txObj1, 1, balanceChecks{}))
+	assert.Nil(t, m.Add(txObj1, 1, balanceChecks{}), "should no error if exists")
 	assert.Equal(t, 1, m.Len())
 
-	assert.Equal(t, errors.New("account quota exceeded"), m.Add(txObj2, 1, func(_ thor.Address, _ *big.Int) error { return nil }))
+	assert.Equal(t, errors.New("account quota exceeded"), m.Add(txObj2, 1, balanceChecks{}))
 	assert.Equal(t, 1, m.Len())
 
-	assert.Nil(t, m.Add(txObj3, 1, func(_ thor.Address, _ *big.Int) error { return nil }))
+	assert.Nil(t, m.Add(txObj3, 1, balanceChecks{}))
 	assert.Equal(t, 2, m.Len())
 
 	assert.True(t, m.ContainsHash(tx1.Hash()))
@@ -132,13 +132,13 @@ func TestLimitByDelegator(t *testing.T) {
 	txObj3, _ := resolveTx(tx3, false)
 
 	m := newTxObjectMap()
-	assert.Nil(t, m.Add(txObj1, 1, func(_ thor.Address, _ *big.Int) error { return nil }))
-	assert.Nil(t, m.Add(txObj3, 1, func(_ thor.Address, _ *big.Int) error { return nil }))
+	assert.Nil(t, m.Add(txObj1, 1, balanceChecks{}))
+	assert.Nil(t, m.Add(txObj3, 1, balanceChecks{}))
 
 	m = newTxObjectMap()
-	assert.Nil(t, m.Add(txObj2, 1, func(_ thor.Address, _ *big.Int) error { return nil }))
-	assert.Equal(t, errors.New("delegator quota exceeded"), m.Add(txObj3, 1, func(_ thor.Address, _ *big.Int) error { return nil }))
-	assert.Equal(t, errors.New("account quota exceeded"), m.Add(txObj1, 1, func(_ thor.Address, _ *big.Int) error { return nil }))
+	assert.Nil(t, m.Add(txObj2, 1, balanceChecks{}))
+	assert.Equal(t, errors.New("delegator quota exceeded"), m.Add(txObj3, 1, balanceChecks{}))
+	assert.Equal(t, errors.New("account quota exceeded"), m.Add(txObj1, 1, balanceChecks{}))
 }
 
 func TestPendingCost(t *testing.T) {
@@ -176,9 +176,9 @@ func TestPendingCost(t *testing.T) {
 	// Creating a new txObjectMap
 	m := newTxObjectMap()
 
-	m.Add(txObj1, 10, func(_ thor.Address, _ *big.Int) error { return nil })
-	m.Add(txObj2, 10, func(_ thor.Address, _ *big.Int) error { return nil })
-	m.Add(txObj3, 10, func(_ thor.Address, _ *big.Int) error { return nil })
+	m.Add(txObj1, 10, balanceChecks{})
+	m.Add(txObj2, 10, balanceChecks{})
+	m.Add(txObj3, 10, balanceChecks{})
 
 	assert.Equal(t, txObj1.Cost(), m.cost[genesis.DevAccounts()[0].Address])
 	// No cost for txObj2's origin, should be counted on the delegator
@@ -194,3 +194,42 @@ func TestPendingCost(t *testing.T) {
 	m.RemoveByHash(txObj3.Hash())
 	assert.Nil(t, m.cost[genesis.DevAccounts()[2].Address])
 }
+
+func TestReplaceTxObject(t *testing.T) {
+	db := muxdb.NewMem()
+	repo := newChainRepo(db)
+	stater := state.NewStater(db)
+	chain := repo.NewBestChain()
+	best := repo.BestBlockSummary()
+	st := stater.NewState(best.Header.StateRoot(), best.Header.Number(), best.Conflicts, best.SteadyNum)
+
+	resolve := func(gas uint64) *txObject {
+		trx := newTx(repo.ChainTag(), nil, gas, tx.BlockRef{}, 100, nil, tx.Features(0), genesis.DevAccounts()[0])
+		txObj, err := resolveTx(trx, false)
+		assert.Nil(t, err)
+		txObj.executable, err = txObj.Executable(chain, st, best.Header)
+		assert.Nil(t, err)
+		return txObj
+	}
+	old, replacement := resolve(21000), resolve(30000)
+
+	m := newTxObjectMap()
+	assert.Nil(t, m.Add(old, 1, balanceChecks{}))
+
+	// the replacement fails the check, the old one is kept with its cost
+	errCheck := errors.New("insufficient energy")
+	err := m.Replace(old.Hash(), replacement, 1, balanceChecks{payer: func(thor.Address, *big.Int) error { return errCheck }})
+	assert.Equal(t, errCheck, err)
+	assert.Equal(t, 1, m.Len())
+	assert.Equal(t, old, m.GetByID(old.ID()))
+	assert.Equal(t, old.Cost(), m.cost[genesis.DevAccounts()[0].Address])
+
+	// replaced within the quota of the account
+	assert.Nil(t, m.Replace(old.Hash(), replacement, 1, balanceChecks{}))
+	assert.Equal(t, 1, m.Len())
+	assert.Nil(t, m.GetByID(old.ID()))
+	assert.Equal(t, replacement, m.GetByID(replacement.ID()))
+	assert.Equal(t, replacement.Cost(), m.cost[genesis.DevAccounts()[0].Address])
+
+	assert.Equal(t, errTxNotFound, m.Replace(old.Hash(), resolve(40000), 1, balanceChecks{}))
+}
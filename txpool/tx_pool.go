@@ -6,16 +6,22 @@
 package txpool
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"math"
 	"math/big"
 	"math/rand/v2"
 	"os"
+	"sort"
 	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/mclock"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/event"
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/v2/builtin"
 	"github.com/vechain/thor/v2/chain"
@@ -29,6 +35,8 @@ import (
 const (
 	// max size of tx allowed
 	maxTxSize = 64 * 1024
+	// max count of revoked tx ids remembered
+	revokedCacheSize = 4096
 )
 
 var (
@@ -42,12 +50,35 @@ type Options struct {
 	MaxLifetime            time.Duration
 	BlocklistCacheFilePath string
 	BlocklistFetchURL      string
+	PriceBumpPct           uint8 // min percentage of priority bump required to replace a pending tx
+	// treat the head block as synced however old it is, as it can be when empty blocks are not produced,
+	// so that txs are still washed and become executable
+	IgnoreStaleHead bool
 }
 
-// TxEvent will be posted when tx is added or status changed.
+// TxEvent will be posted when tx is added, removed or status changed.
 type TxEvent struct {
 	Tx         *tx.Transaction
 	Executable *bool
+	Removed    bool
+}
+
+// AccountTxSummary summarizes the pending txs originated from an account.
+type AccountTxSummary struct {
+	PendingCount int
+	// energy cost of the pending txs at the base gas price of the head block, whoever pays it
+	PendingEnergyCost *big.Int
+	OldestTxAge       time.Duration // time since the earliest pending tx was added
+	ExecutableCount   int
+}
+
+// TxEntry is a tx in a snapshot of the pool. The tx body is shared with the pool, not copied.
+type TxEntry struct {
+	*tx.Transaction
+	Origin     thor.Address
+	Delegator  *thor.Address
+	Executable bool
+	TimeAdded  int64 // unix nanoseconds
 }
 
 // TxPool maintains unprocessed transactions.
@@ -57,9 +88,11 @@ type TxPool struct {
 	stater    *state.Stater
 	blocklist blocklist
 
-	executables    atomic.Value
+	executables    atomic.Value // *executableTxs
 	all            *txObjectMap
 	addedAfterWash uint32
+	washCache      *washCache // owned by housekeeping
+	revoked        *lru.Cache // ids of the txs canceled or replaced by their origins, rejected if added again
 
 	ctx    context.Context
 	cancel func()
@@ -72,11 +105,13 @@ type TxPool struct {
 // Shutdown is required to be called at end.
 func New(repo *chain.Repository, stater *state.Stater, options Options) *TxPool {
 	ctx, cancel := context.WithCancel(context.Background())
+	revoked, _ := lru.New(revokedCacheSize)
 	pool := &TxPool{
 		options: options,
 		repo:    repo,
 		stater:  stater,
 		all:     newTxObjectMap(),
+		revoked: revoked,
 		ctx:     ctx,
 		cancel:  cancel,
 	}
@@ -105,7 +140,7 @@ func (p *TxPool) housekeeping() {
 				headSummary = newHeadSummary
 				headBlockChanged = true
 			}
-			if !isChainSynced(uint64(time.Now().Unix()), headSummary.Header.Timestamp()) {
+			if !p.isChainSynced(headSummary.Header.Timestamp()) {
 				// skip washing txs if not synced
 				continue
 			}
@@ -131,7 +166,7 @@ func (p *TxPool) housekeeping() {
 				if err != nil {
 					ctx = append(ctx, "err", err)
 				} else {
-					p.executables.Store(executables)
+					p.setExecutables(executables)
 				}
 
 				metricTxPoolGauge().AddWithLabel(0-int64(removed), map[string]string{"source": "washed", "total": "true"})
@@ -207,56 +242,79 @@ func (p *TxPool) SubscribeTxEvent(ch chan *TxEvent) event.Subscription {
 }
 
 func (p *TxPool) add(newTx *tx.Transaction, rejectNonExecutable bool, localSubmitted bool) error {
+	return p.insert(newTx, nil, rejectNonExecutable, localSubmitted)
+}
+
+// insert adds the new tx into the pool, in place of the replaced tx if not nil. Unlike adding, replacing fails
+// if the new tx is not added.
+func (p *TxPool) insert(newTx *tx.Transaction, replaced *txObject, rejectNonExecutable bool, localSubmitted bool) error {
 	if p.all.ContainsHash(newTx.Hash()) {
+		if replaced != nil {
+			return txRejectedError{msg: "replacement already in the pool"}
+		}
 		// tx already in the pool
 		return nil
 	}
+	if p.revoked.Contains(newTx.ID()) {
+		return txRejectedError{msg: "tx revoked"}
+	}
 
 	origin, _ := newTx.Origin()
 	if thor.IsOriginBlocked(origin) || p.blocklist.Contains(origin) {
+		if replaced != nil {
+			return txRejectedError{msg: "tx origin blocked"}
+		}
 		// tx origin blocked
 		return nil
 	}
 
+	addToAll := func(txObj *txObject, checks balanceChecks) error {
+		if replaced != nil {
+			return p.all.Replace(replaced.Hash(), txObj, p.options.LimitPerAccount, checks)
+		}
+		return p.all.Add(txObj, p.options.LimitPerAccount, checks)
+	}
+
 	headSummary := p.repo.BestBlockSummary()
 
 	// validation
 	switch {
 	case newTx.ChainTag() != p.repo.ChainTag():
-		return badTxError{"chain tag mismatch"}
+		return badTxError{msg: "chain tag mismatch"}
 	case newTx.Size() > maxTxSize:
-		return txRejectedError{"size too large"}
+		sizeErr := &TxSizeError{Size: uint64(newTx.Size()), MaxSize: maxTxSize}
+		return txRejectedError{msg: sizeErr.Error(), cause: sizeErr}
 	}
 
 	if err := newTx.TestFeatures(headSummary.Header.TxsFeatures()); err != nil {
-		return txRejectedError{err.Error()}
+		return txRejectedError{msg: err.Error()}
 	}
 
 	txObj, err := resolveTx(newTx, localSubmitted)
 	if err != nil {
-		return badTxError{err.Error()}
+		return badTxError{msg: err.Error(), cause: err}
 	}
 
-	if isChainSynced(uint64(time.Now().Unix()), headSummary.Header.Timestamp()) {
-		if !localSubmitted {
+	if p.isChainSynced(headSummary.Header.Timestamp()) {
+		if !localSubmitted && replaced == nil {
 			// reject when pool size exceeds 120% of limit
 			if p.all.Len() >= p.options.Limit*12/10 {
-				return txRejectedError{"pool is full"}
+				return txRejectedError{msg: "pool is full"}
 			}
 		}
 
 		state := p.stater.NewState(headSummary.Header.StateRoot(), headSummary.Header.Number(), headSummary.Conflicts, headSummary.SteadyNum)
 		executable, err := txObj.Executable(p.repo.NewChain(headSummary.Header.ID()), state, headSummary.Header)
 		if err != nil {
-			return txRejectedError{err.Error()}
+			return txRejectedError{msg: err.Error()}
 		}
 
 		if rejectNonExecutable && !executable {
-			return txRejectedError{"tx is not executable"}
+			return txRejectedError{msg: "tx is not executable"}
 		}
 
 		txObj.executable = executable
-		if err := p.all.Add(txObj, p.options.LimitPerAccount, func(payer thor.Address, needs *big.Int) error {
+		if err := addToAll(txObj, balanceChecks{payer: func(payer thor.Address, needs *big.Int) error {
 			// check payer's balance
 			balance, err := state.GetEnergy(payer, headSummary.Header.Timestamp()+thor.BlockInterval)
 			if err != nil {
@@ -268,28 +326,40 @@ func (p *TxPool) add(newTx *tx.Transaction, rejectNonExecutable bool, localSubmi
 			}
 
 			return nil
-		}); err != nil {
-			return txRejectedError{err.Error()}
+		}, origin: func(origin thor.Address, value *big.Int) error {
+			// check origin's balance
+			balance, err := state.GetBalance(origin)
+			if err != nil {
+				return err
+			}
+
+			if balance.Cmp(value) < 0 {
+				return errors.New("insufficient VET for overall pending value")
+			}
+
+			return nil
+		}}); err != nil {
+			return txRejectedError{msg: err.Error(), cause: err}
 		}
 
 		p.goes.Go(func() {
-			p.txFeed.Send(&TxEvent{newTx, &executable})
+			p.txFeed.Send(&TxEvent{Tx: newTx, Executable: &executable})
 		})
 		logger.Trace("tx added", "id", newTx.ID(), "executable", executable)
 	} else {
 		// we skip steps that rely on head block when chain is not synced,
 		// but check the pool's limit
-		if p.all.Len() >= p.options.Limit {
-			return txRejectedError{"pool is full"}
+		if replaced == nil && p.all.Len() >= p.options.Limit {
+			return txRejectedError{msg: "pool is full"}
 		}
 
-		// skip pending cost check when chain is not synced
-		if err := p.all.Add(txObj, p.options.LimitPerAccount, func(_ thor.Address, _ *big.Int) error { return nil }); err != nil {
-			return txRejectedError{err.Error()}
+		// skip pending cost and value check when chain is not synced
+		if err := addToAll(txObj, balanceChecks{}); err != nil {
+			return txRejectedError{msg: err.Error(), cause: err}
 		}
 		logger.Trace("tx added", "id", newTx.ID())
 		p.goes.Go(func() {
-			p.txFeed.Send(&TxEvent{newTx, nil})
+			p.txFeed.Send(&TxEvent{Tx: newTx})
 		})
 	}
 	atomic.AddUint32(&p.addedAfterWash, 1)
@@ -332,14 +402,179 @@ func (p *TxPool) Remove(txHash thor.Bytes32, txID thor.Bytes32) bool {
 	return false
 }
 
+// EvictByAddress removes all txs originated from the given address, both executable
+// and non-executable ones, and returns the count of removed txs.
+func (p *TxPool) EvictByAddress(addr thor.Address) int {
+	var evicted []*txObject
+	for _, txObj := range p.all.ToTxObjects() {
+		if txObj.Origin() != addr {
+			continue
+		}
+		if p.all.RemoveByHash(txObj.Hash()) {
+			evicted = append(evicted, txObj)
+		}
+	}
+
+	if len(evicted) > 0 {
+		p.afterRemoved(evicted)
+		metricTxPoolGauge().AddWithLabel(0-int64(len(evicted)), map[string]string{"source": "evicted", "total": "true"})
+		logger.Info("txs evicted", "origin", addr, "count", len(evicted))
+	}
+	return len(evicted)
+}
+
+// Replace replaces the pending tx of the given ID with a new tx from the same origin.
+// The priority of a tx is its gas price coef, i.e. the part of gas price paid above the base gas price.
+// The replacement is rejected, unless the new priority is bumped by at least Options.PriceBumpPct percent,
+// and is strictly higher in any case, e.g. when the old priority is 0. As the gas price coef is at most 255,
+// a tx whose priority can't be bumped within it, i.e. above 232 with the default bump of 10%, can't be replaced.
+// The replaced tx is removed like a canceled tx, and rejected if added again. Like canceling, it only affects
+// the local pool: as txs don't conflict by nonce, the replaced tx stays valid and may still be packed, along
+// with the replacement, if it has been propagated to other nodes.
+func (p *TxPool) Replace(txID thor.Bytes32, newTx *tx.Transaction) error {
+	txObj := p.all.GetByID(txID)
+	if txObj == nil {
+		if meta, err := p.repo.NewBestChain().GetTransactionMeta(txID); err == nil && meta != nil {
+			return txRejectedError{msg: "tx already included"}
+		}
+		return txRejectedError{msg: errTxNotFound.Error(), cause: errTxNotFound}
+	}
+	origin, err := newTx.Origin()
+	if err != nil {
+		return badTxError{msg: err.Error()}
+	}
+	if origin != txObj.Origin() {
+		return txRejectedError{msg: "replacement not from the same origin"}
+	}
+	if newTx.Hash() == txObj.Hash() {
+		return txRejectedError{msg: "replacement is the same tx"}
+	}
+
+	oldPriority, newPriority := uint64(txObj.GasPriceCoef()), uint64(newTx.GasPriceCoef())
+	minPriority := max(oldPriority*(100+uint64(p.options.PriceBumpPct))/100, oldPriority+1)
+	if minPriority > math.MaxUint8 {
+		return txRejectedError{msg: "tx priority too high to be bumped"}
+	}
+	if newPriority < minPriority {
+		return txRejectedError{msg: fmt.Sprintf("replacement priority too low, bump of %d%% required", p.options.PriceBumpPct)}
+	}
+
+	// the replaced tx is kept unless the new tx is added
+	if err := p.insert(newTx, txObj, false, true); err != nil {
+		return err
+	}
+	p.revoked.Add(txID, struct{}{})
+	p.afterRemoved([]*txObject{txObj})
+	logger.Debug("tx replaced", "id", txID, "by", newTx.ID())
+	return nil
+}
+
+// CancelSigningHash returns the hash to be signed by the origin to cancel its pending tx.
+func CancelSigningHash(txID thor.Bytes32) thor.Bytes32 {
+	return thor.Blake2b([]byte("txpool-cancel"), txID[:])
+}
+
+// Cancel removes the pending tx on behalf of its origin. The signature must be
+// signed by the tx origin over CancelSigningHash(txID).
+// Canceling only affects the local pool, the tx may still be packed if it has
+// been propagated to other nodes. The canceled tx is remembered and rejected if added again,
+// e.g. propagated back by peers, until it's evicted from the bounded set of revoked txs.
+func (p *TxPool) Cancel(txID thor.Bytes32, signature []byte) error {
+	txObj := p.all.GetByID(txID)
+	if txObj == nil {
+		return txRejectedError{msg: errTxNotFound.Error(), cause: errTxNotFound}
+	}
+
+	hash := CancelSigningHash(txID)
+	pub, err := crypto.SigToPub(hash[:], signature)
+	if err != nil {
+		return badTxError{msg: "invalid cancel signature: " + err.Error()}
+	}
+	if signer := thor.Address(crypto.PubkeyToAddress(*pub)); signer != txObj.Origin() {
+		return txRejectedError{msg: "cancel not signed by tx origin"}
+	}
+
+	if !p.all.RemoveByHash(txObj.Hash()) {
+		// removed meanwhile
+		return txRejectedError{msg: errTxNotFound.Error(), cause: errTxNotFound}
+	}
+	p.revoked.Add(txID, struct{}{})
+	p.afterRemoved([]*txObject{txObj})
+	metricTxPoolGauge().AddWithLabel(-1, map[string]string{"source": "canceled", "total": "true"})
+	logger.Debug("tx canceled", "id", txID)
+	return nil
+}
+
+// afterRemoved drops the removed txs from executables immediately rather than
+// waiting for the next wash, and notifies subscribers.
+func (p *TxPool) afterRemoved(txObjs []*txObject) {
+	removed := make(map[thor.Bytes32]bool, len(txObjs))
+	for _, txObj := range txObjs {
+		removed[txObj.Hash()] = true
+
+		trx := txObj.Transaction
+		p.goes.Go(func() {
+			p.txFeed.Send(&TxEvent{Tx: trx, Removed: true})
+		})
+	}
+
+	if executables := p.Executables(); len(executables) > 0 {
+		remaining := make(tx.Transactions, 0, len(executables))
+		for _, trx := range executables {
+			if !removed[trx.Hash()] {
+				remaining = append(remaining, trx)
+			}
+		}
+		p.setExecutables(remaining)
+	}
+}
+
+// executableTxs are the published executable txs, with the count of them by origin.
+type executableTxs struct {
+	txs      tx.Transactions
+	byOrigin map[thor.Address]int
+}
+
+func (p *TxPool) setExecutables(txs tx.Transactions) {
+	byOrigin := make(map[thor.Address]int)
+	for _, trx := range txs {
+		origin, _ := trx.Origin()
+		byOrigin[origin]++
+	}
+	p.executables.Store(&executableTxs{txs, byOrigin})
+}
+
 // Executables returns executable txs.
 func (p *TxPool) Executables() tx.Transactions {
-	if sorted := p.executables.Load(); sorted != nil {
-		return sorted.(tx.Transactions)
+	if executables := p.executables.Load(); executables != nil {
+		return executables.(*executableTxs).txs
 	}
 	return nil
 }
 
+// AccountSummary returns the summary of the pending txs originated from the given address.
+func (p *TxPool) AccountSummary(addr thor.Address) (*AccountTxSummary, error) {
+	head := p.repo.BestBlockSummary()
+	st := p.stater.NewState(head.Header.StateRoot(), head.Header.Number(), head.Conflicts, head.SteadyNum)
+	baseGasPrice, err := builtin.Params.Native(st).Get(thor.KeyBaseGasPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	count, oldest, cost := p.all.AccountSummary(addr, baseGasPrice)
+	summary := &AccountTxSummary{PendingCount: count, PendingEnergyCost: cost}
+	if count == 0 {
+		return summary, nil
+	}
+	summary.OldestTxAge = time.Duration(time.Now().UnixNano() - oldest)
+
+	// the executable flag of tx objects is owned by housekeeping, count on the published executables
+	if executables := p.executables.Load(); executables != nil {
+		summary.ExecutableCount = executables.(*executableTxs).byOrigin[addr]
+	}
+	return summary, nil
+}
+
 // Fill fills txs into pool.
 func (p *TxPool) Fill(txs tx.Transactions) {
 	txObjs := make([]*txObject, 0, len(txs))
@@ -356,18 +591,55 @@ func (p *TxPool) Fill(txs tx.Transactions) {
 	p.all.Fill(txObjs)
 }
 
+// Snapshot returns the txs in the pool, ordered by the time added and then by ID.
+// The order is stable while the pool churns, since txs added later come last.
+func (p *TxPool) Snapshot() []*TxEntry {
+	// the executable flag of tx objects is owned by housekeeping, count on the published executables
+	executables := make(map[thor.Bytes32]struct{})
+	for _, trx := range p.Executables() {
+		executables[trx.ID()] = struct{}{}
+	}
+
+	txObjs := p.all.ToTxObjects()
+	entries := make([]*TxEntry, 0, len(txObjs))
+	for _, txObj := range txObjs {
+		_, executable := executables[txObj.ID()]
+		entries = append(entries, &TxEntry{
+			Transaction: txObj.Transaction,
+			Origin:      txObj.Origin(),
+			Delegator:   txObj.Delegator(),
+			Executable:  executable,
+			TimeAdded:   txObj.timeAdded,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].TimeAdded != entries[j].TimeAdded {
+			return entries[i].TimeAdded < entries[j].TimeAdded
+		}
+		return bytes.Compare(entries[i].ID().Bytes(), entries[j].ID().Bytes()) < 0
+	})
+	return entries
+}
+
 // Dump dumps all txs in the pool.
 func (p *TxPool) Dump() tx.Transactions {
 	return p.all.ToTxs()
 }
 
-// wash to evict txs that are over limit, out of lifetime, out of energy, settled, expired or dep broken.
+// wash to evict txs that are over limit, out of lifetime, out of energy, out of balance, settled, expired or dep broken.
+// Txs found executable by the last wash are re-validated only if affected by the blocks since then, see diffSinceWash.
 // this method should only be called in housekeeping go routine
 func (p *TxPool) wash(headSummary *chain.BlockSummary) (executables tx.Transactions, removed int, err error) {
 	all := p.all.ToTxObjects()
+	// earlier added txs take precedence when the origin's balance can't cover all pending values
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].timeAdded < all[j].timeAdded
+	})
 	var toRemove []*txObject
 	var toUpdateCost []*txObject
+	var cache *washCache
 	defer func() {
+		p.washCache = cache
 		if err != nil {
 			// in case of error, simply cut pool size to limit
 			for i, txObj := range all {
@@ -398,12 +670,31 @@ func (p *TxPool) wash(headSummary *chain.BlockSummary) (executables tx.Transacti
 		return nil, 0, err
 	}
 
+	// re-validate only the txs affected since the last wash if possible
+	diff := p.diffSinceWash(headSummary, baseGasPrice)
+	headBalances := make(map[thor.Address]*big.Int)
+	originBalance := func(origin thor.Address) (balance *big.Int, err error) {
+		var cached bool
+		if diff != nil && !diff.dirty[origin] {
+			balance, cached = p.washCache.balances[origin]
+		}
+		if !cached {
+			if balance, err = newState().GetBalance(origin); err != nil {
+				return nil, err
+			}
+		}
+		headBalances[origin] = balance
+		return balance, nil
+	}
+
 	var (
 		chain               = p.repo.NewChain(headSummary.Header.ID())
 		executableObjs      = make([]*txObject, 0, len(all))
 		nonExecutableObjs   = make([]*txObject, 0, len(all))
 		localExecutableObjs = make([]*txObject, 0, len(all))
 		now                 = time.Now().UnixNano()
+		// remaining balance of origins after deducting values of executable txs
+		balances = make(map[thor.Address]*big.Int)
 	)
 	for _, txObj := range all {
 		if thor.IsOriginBlocked(txObj.Origin()) || p.blocklist.Contains(txObj.Origin()) {
@@ -419,14 +710,39 @@ func (p *TxPool) wash(headSummary *chain.BlockSummary) (executables tx.Transacti
 			continue
 		}
 		// settled, out of energy or dep broken
-		executable, err := txObj.Executable(chain, newState(), headSummary.Header)
-		if err != nil {
+		var (
+			executable bool
+			txErr      error // of the tx, which is washed out rather than failing the wash
+		)
+		if diff != nil && txObj.washedHead == p.washCache.headID && !diff.affects(txObj) {
+			// the tx stays executable, unless out of the head's bounds
+			executable, txErr = true, txObj.checkHead(headSummary.Header)
+		} else {
+			executable, txErr = txObj.Executable(chain, newState(), headSummary.Header)
+		}
+		if txErr != nil {
 			toRemove = append(toRemove, txObj)
-			logger.Trace("tx washed out", "id", txObj.ID(), "err", err)
+			logger.Trace("tx washed out", "id", txObj.ID(), "err", txErr)
 			continue
 		}
 
 		if executable {
+			txObj.washedHead = headSummary.Header.ID()
+			if txObj.Value().Sign() > 0 {
+				balance, ok := balances[txObj.Origin()]
+				if !ok {
+					if balance, err = originBalance(txObj.Origin()); err != nil {
+						return nil, 0, err
+					}
+				}
+				if balance.Cmp(txObj.Value()) < 0 {
+					toRemove = append(toRemove, txObj)
+					logger.Trace("tx washed out", "id", txObj.ID(), "err", "insufficient VET for overall pending value")
+					continue
+				}
+				balances[txObj.Origin()] = new(big.Int).Sub(balance, txObj.Value())
+			}
+
 			provedWork, err := txObj.ProvedWork(headSummary.Header.Number(), chain.GetBlockID)
 			if err != nil {
 				toRemove = append(toRemove, txObj)
@@ -494,12 +810,26 @@ func (p *TxPool) wash(headSummary *chain.BlockSummary) (executables tx.Transacti
 	p.goes.Go(func() {
 		executable := true
 		for _, tx := range toBroadcast {
-			p.txFeed.Send(&TxEvent{tx, &executable})
+			p.txFeed.Send(&TxEvent{Tx: tx, Executable: &executable})
 		}
 	})
+
+	cache = &washCache{
+		headID:       headSummary.Header.ID(),
+		baseGasPrice: baseGasPrice,
+		balances:     headBalances,
+	}
+	if diff != nil {
+		cache.blocks = diff.blocks
+	}
 	return executables, 0, nil
 }
 
+// isChainSynced returns whether the head block of the given timestamp is considered synced.
+func (p *TxPool) isChainSynced(headTimestamp uint64) bool {
+	return p.options.IgnoreStaleHead || isChainSynced(uint64(time.Now().Unix()), headTimestamp)
+}
+
 func isChainSynced(nowTimestamp, blockTimestamp uint64) bool {
 	timeDiff := nowTimestamp - blockTimestamp
 	if blockTimestamp > nowTimestamp {
@@ -8,6 +8,7 @@ package txpool
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
 	"net/http"
@@ -24,6 +25,7 @@ import (
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/genesis"
 	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/runtime"
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/tx"
@@ -206,6 +208,276 @@ func TestRemoveWithError(t *testing.T) {
 	assert.False(t, removed, "Transaction should not be successfully removed as it doesn't exist")
 }
 
+func TestEvictByAddress(t *testing.T) {
+	pool := newPool(LIMIT, 5)
+	defer pool.Close()
+
+	txCh := make(chan *TxEvent)
+	pool.SubscribeTxEvent(txCh)
+
+	evicted := make(map[thor.Bytes32]bool)
+	for i := 0; i < 5; i++ {
+		trx := newTx(pool.repo.ChainTag(), nil, 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[0])
+		assert.Nil(t, pool.Add(trx))
+		evicted[trx.ID()] = true
+	}
+	other := newTx(pool.repo.ChainTag(), nil, 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[1])
+	assert.Nil(t, pool.Add(other))
+
+	assert.Equal(t, 5, pool.EvictByAddress(devAccounts[0].Address))
+	assert.Equal(t, Tx.Transactions{other}, pool.Dump())
+	assert.Equal(t, 0, pool.EvictByAddress(devAccounts[0].Address))
+
+	// subscribers are notified about each evicted tx
+	for removed := 0; removed < 5; {
+		select {
+		case ev := <-txCh:
+			if ev.Removed {
+				assert.True(t, evicted[ev.Tx.ID()])
+				removed++
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for removed events")
+		}
+	}
+}
+
+func TestAccountSummary(t *testing.T) {
+	pool := newPool(LIMIT, 5)
+	defer pool.Close()
+
+	summary, err := pool.AccountSummary(devAccounts[0].Address)
+	assert.Nil(t, err)
+	assert.Equal(t, &AccountTxSummary{PendingEnergyCost: new(big.Int)}, summary)
+
+	chainTag := pool.repo.ChainTag()
+	assert.Nil(t, pool.Add(newTx(chainTag, nil, 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[0])))
+	assert.Nil(t, pool.Add(newTx(chainTag, nil, 30000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[0])))
+	// not executable until block 10
+	assert.Nil(t, pool.Add(newTx(chainTag, nil, 50000, tx.NewBlockRef(10), 100, nil, tx.Features(0), devAccounts[0])))
+	assert.Nil(t, pool.Add(newTx(chainTag, nil, 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[1])))
+
+	executables, _, err := pool.wash(pool.repo.BestBlockSummary())
+	assert.Nil(t, err)
+	pool.setExecutables(executables)
+
+	// gas price coef is 0, the gas price is the base gas price
+	best := pool.repo.BestBlockSummary()
+	st := pool.stater.NewState(best.Header.StateRoot(), best.Header.Number(), best.Conflicts, best.SteadyNum)
+	baseGasPrice, err := builtin.Params.Native(st).Get(thor.KeyBaseGasPrice)
+	assert.Nil(t, err)
+
+	summary, err = pool.AccountSummary(devAccounts[0].Address)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, summary.PendingCount)
+	assert.Equal(t, 2, summary.ExecutableCount)
+	// the cost of all the pending txs, the non-executable one included
+	assert.Equal(t, new(big.Int).Mul(big.NewInt(21000+30000+50000), baseGasPrice), summary.PendingEnergyCost)
+	assert.True(t, summary.OldestTxAge > 0)
+
+	summary, err = pool.AccountSummary(devAccounts[1].Address)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, summary.PendingCount)
+	assert.Equal(t, 1, summary.ExecutableCount)
+	assert.Equal(t, new(big.Int).Mul(big.NewInt(21000), baseGasPrice), summary.PendingEnergyCost)
+
+	// removed txs are no longer summarized
+	for _, trx := range pool.Dump() {
+		if origin, _ := trx.Origin(); origin == devAccounts[1].Address {
+			assert.True(t, pool.Remove(trx.Hash(), trx.ID()))
+		}
+	}
+	summary, err = pool.AccountSummary(devAccounts[1].Address)
+	assert.Nil(t, err)
+	assert.Equal(t, &AccountTxSummary{PendingEnergyCost: new(big.Int)}, summary)
+}
+
+func TestSnapshot(t *testing.T) {
+	pool := newPool(LIMIT, LIMIT_PER_ACCOUNT)
+	defer pool.Close()
+
+	chainTag := pool.repo.ChainTag()
+	added := []*tx.Transaction{
+		newTx(chainTag, nil, 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[0]),
+		// not executable until block 10
+		newTx(chainTag, nil, 21000, tx.NewBlockRef(10), 100, nil, tx.Features(0), devAccounts[1]),
+		newTx(chainTag, nil, 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[2]),
+	}
+	for _, trx := range added {
+		assert.Nil(t, pool.Add(trx))
+	}
+
+	executables, _, err := pool.wash(pool.repo.BestBlockSummary())
+	assert.Nil(t, err)
+	pool.setExecutables(executables)
+
+	entries := pool.Snapshot()
+	assert.Len(t, entries, 3)
+	for i, entry := range entries {
+		if i > 0 {
+			assert.True(t, entries[i-1].TimeAdded <= entry.TimeAdded)
+		}
+		origin, _ := entry.Transaction.Origin()
+		assert.Equal(t, origin, entry.Origin)
+		assert.Equal(t, entry.ID() != added[1].ID(), entry.Executable)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	pool := newPool(LIMIT, LIMIT_PER_ACCOUNT)
+	defer pool.Close()
+
+	txCh := make(chan *TxEvent)
+	pool.SubscribeTxEvent(txCh)
+
+	trx := newTx(pool.repo.ChainTag(), nil, 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[0])
+	assert.Nil(t, pool.Add(trx))
+
+	hash := CancelSigningHash(trx.ID())
+
+	// malformed signature
+	err := pool.Cancel(trx.ID(), []byte{1, 2, 3})
+	assert.True(t, IsBadTx(err))
+
+	// not signed by origin
+	sig, _ := crypto.Sign(hash[:], devAccounts[1].PrivateKey)
+	assert.Equal(t, "tx rejected: cancel not signed by tx origin", pool.Cancel(trx.ID(), sig).Error())
+	assert.NotNil(t, pool.Get(trx.ID()))
+
+	// signed over another tx
+	other := CancelSigningHash(thor.Bytes32{1})
+	sig, _ = crypto.Sign(other[:], devAccounts[0].PrivateKey)
+	assert.Equal(t, "tx rejected: cancel not signed by tx origin", pool.Cancel(trx.ID(), sig).Error())
+
+	sig, _ = crypto.Sign(hash[:], devAccounts[0].PrivateKey)
+	assert.Nil(t, pool.Cancel(trx.ID(), sig))
+	assert.Nil(t, pool.Get(trx.ID()))
+	err = pool.Cancel(trx.ID(), sig)
+	assert.Equal(t, "tx rejected: tx not found", err.Error())
+	assert.True(t, IsTxNotFound(err))
+
+	// the canceled tx can't be added back
+	assert.Equal(t, "tx rejected: tx revoked", pool.Add(trx).Error())
+	assert.Nil(t, pool.Get(trx.ID()))
+
+	for {
+		select {
+		case ev := <-txCh:
+			if ev.Removed {
+				assert.Equal(t, trx.ID(), ev.Tx.ID())
+				return
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for removed event")
+		}
+	}
+}
+
+func TestReplace(t *testing.T) {
+	pool := newPool(LIMIT, LIMIT_PER_ACCOUNT)
+	defer pool.Close()
+	pool.options.PriceBumpPct = 10
+
+	var nonce uint64
+	newTxWithCoef := func(coef uint8, from genesis.DevAccount) *tx.Transaction {
+		nonce++
+		return tx.MustSign(new(tx.Builder).
+			ChainTag(pool.repo.ChainTag()).
+			Expiration(100).
+			Gas(21000).
+			GasPriceCoef(coef).
+			Nonce(nonce).
+			Build(), from.PrivateKey)
+	}
+
+	txCh := make(chan *TxEvent)
+	sub := pool.SubscribeTxEvent(txCh)
+	defer sub.Unsubscribe()
+
+	old := newTxWithCoef(100, devAccounts[0])
+	assert.Nil(t, pool.Add(old))
+
+	// one less than the required bump
+	err := pool.Replace(old.ID(), newTxWithCoef(109, devAccounts[0]))
+	assert.Equal(t, "tx rejected: replacement priority too low, bump of 10% required", err.Error())
+	assert.NotNil(t, pool.Get(old.ID()))
+
+	// not from the same origin
+	err = pool.Replace(old.ID(), newTxWithCoef(200, devAccounts[1]))
+	assert.Equal(t, "tx rejected: replacement not from the same origin", err.Error())
+	assert.NotNil(t, pool.Get(old.ID()))
+
+	// exactly the required bump
+	replacement := newTxWithCoef(110, devAccounts[0])
+	assert.Nil(t, pool.Replace(old.ID(), replacement))
+	assert.Nil(t, pool.Get(old.ID()))
+	assert.NotNil(t, pool.Get(replacement.ID()))
+	assert.Equal(t, tx.Transactions{replacement}, pool.Dump())
+
+	// subscribers are notified the replaced tx is removed
+	for removed := false; !removed; {
+		select {
+		case ev := <-txCh:
+			if ev.Removed {
+				assert.Equal(t, old.ID(), ev.Tx.ID())
+				assert.Nil(t, ev.Executable)
+				removed = true
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for removed event")
+		}
+	}
+
+	err = pool.Replace(old.ID(), newTxWithCoef(200, devAccounts[0]))
+	assert.Equal(t, "tx rejected: tx not found", err.Error())
+	assert.True(t, IsTxNotFound(err))
+
+	// the replaced tx can't be added back
+	assert.Equal(t, "tx rejected: tx revoked", pool.Add(old).Error())
+
+	// a priority of 0 must be strictly bumped
+	zero := newTxWithCoef(0, devAccounts[1])
+	assert.Nil(t, pool.Add(zero))
+	err = pool.Replace(zero.ID(), newTxWithCoef(0, devAccounts[1]))
+	assert.Equal(t, "tx rejected: replacement priority too low, bump of 10% required", err.Error())
+	assert.Nil(t, pool.Replace(zero.ID(), newTxWithCoef(1, devAccounts[1])))
+
+	// no priority within the max coef is bumped enough
+	top := newTxWithCoef(233, devAccounts[2])
+	assert.Nil(t, pool.Add(top))
+	err = pool.Replace(top.ID(), newTxWithCoef(255, devAccounts[2]))
+	assert.Equal(t, "tx rejected: tx priority too high to be bumped", err.Error())
+
+	// the replacement is already in the pool
+	pending := newTxWithCoef(100, devAccounts[3])
+	dup := newTxWithCoef(200, devAccounts[3])
+	assert.Nil(t, pool.Add(pending))
+	assert.Nil(t, pool.Add(dup))
+	err = pool.Replace(pending.ID(), dup)
+	assert.Equal(t, "tx rejected: replacement already in the pool", err.Error())
+	assert.NotNil(t, pool.Get(pending.ID()))
+
+	// the origin is blocked since, the replacement is not added and the replaced tx is kept
+	pool.blocklist.lock.Lock()
+	pool.blocklist.list = map[thor.Address]bool{devAccounts[3].Address: true}
+	pool.blocklist.lock.Unlock()
+	err = pool.Replace(pending.ID(), newTxWithCoef(200, devAccounts[3]))
+	assert.Equal(t, "tx rejected: tx origin blocked", err.Error())
+	assert.NotNil(t, pool.Get(pending.ID()))
+	assert.False(t, pool.revoked.Contains(pending.ID()))
+
+	// the replaced tx is already included
+	included := newTxWithCoef(100, devAccounts[0])
+	b1 := new(block.Builder).
+		ParentID(pool.repo.GenesisBlock().Header().ID()).
+		Transaction(included).
+		Build()
+	assert.Nil(t, pool.repo.AddBlock(b1, tx.Receipts{{}}, 0))
+	assert.Nil(t, pool.repo.SetBestBlockID(b1.Header().ID()))
+	err = pool.Replace(included.ID(), newTxWithCoef(200, devAccounts[0]))
+	assert.Equal(t, "tx rejected: tx already included", err.Error())
+}
+
 func TestNewClose(t *testing.T) {
 	pool := newPool(LIMIT, LIMIT_PER_ACCOUNT)
 	defer pool.Close()
@@ -242,7 +514,7 @@ func TestSubscribeNewTx(t *testing.T) {
 	assert.Nil(t, pool.Add(tx))
 
 	v := true
-	assert.Equal(t, &TxEvent{tx, &v}, <-txCh)
+	assert.Equal(t, &TxEvent{Tx: tx, Executable: &v}, <-txCh)
 }
 
 func TestWashTxs(t *testing.T) {
@@ -279,11 +551,11 @@ func TestWashTxs(t *testing.T) {
 
 	tx2 := newTx(pool.repo.ChainTag(), nil, 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[1])
 	txObj2, _ := resolveTx(tx2, false)
-	assert.Nil(t, pool.all.Add(txObj2, LIMIT_PER_ACCOUNT, func(_ thor.Address, _ *big.Int) error { return nil })) // this tx will participate in the wash out.
+	assert.Nil(t, pool.all.Add(txObj2, LIMIT_PER_ACCOUNT, balanceChecks{})) // this tx will participate in the wash out.
 
 	tx3 := newTx(pool.repo.ChainTag(), nil, 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[2])
 	txObj3, _ := resolveTx(tx3, false)
-	assert.Nil(t, pool.all.Add(txObj3, LIMIT_PER_ACCOUNT, func(_ thor.Address, _ *big.Int) error { return nil })) // this tx will participate in the wash out.
+	assert.Nil(t, pool.all.Add(txObj3, LIMIT_PER_ACCOUNT, balanceChecks{})) // this tx will participate in the wash out.
 
 	txs, removedCount, err := pool.wash(pool.repo.BestBlockSummary())
 	assert.Nil(t, err)
@@ -317,7 +589,7 @@ func TestFillPool(t *testing.T) {
 
 	// Test executables after wash
 	executables, _, _ := pool.wash(pool.repo.BestBlockSummary())
-	pool.executables.Store(executables)
+	pool.setExecutables(executables)
 	assert.Equal(t, len(txs), len(pool.Executables()), "Number of transactions in the pool should match the number added")
 }
 
@@ -371,6 +643,7 @@ func TestAdd(t *testing.T) {
 
 	var data [64 * 1024]byte
 	rand.Read(data[:])
+	bigTx := newTx(pool.repo.ChainTag(), []*tx.Clause{tx.NewClause(nil).WithData(data[:])}, 21000, tx.BlockRef{}, 100, &thor.Bytes32{1}, Tx.Features(0), acc)
 
 	tests = []struct {
 		tx     *Tx.Transaction
@@ -380,7 +653,7 @@ func TestAdd(t *testing.T) {
 		{newTx(pool.repo.ChainTag(), nil, 21000, tx.NewBlockRef(100), 100, nil, Tx.Features(0), acc), "tx rejected: block ref out of schedule"},
 		{newTx(pool.repo.ChainTag(), nil, 21000, tx.BlockRef{}, 100, &thor.Bytes32{1}, Tx.Features(0), acc), "tx rejected: tx is not executable"},
 		{newTx(pool.repo.ChainTag(), nil, 21000, tx.BlockRef{}, 100, &thor.Bytes32{1}, Tx.Features(2), acc), "tx rejected: unsupported features"},
-		{newTx(pool.repo.ChainTag(), []*tx.Clause{tx.NewClause(nil).WithData(data[:])}, 21000, tx.BlockRef{}, 100, &thor.Bytes32{1}, Tx.Features(0), acc), "tx rejected: size too large"},
+		{bigTx, fmt.Sprintf("tx rejected: size too large: size %d, max %d", uint64(bigTx.Size()), maxTxSize)},
 		{badReserved, "tx rejected: unsupported features"},
 	}
 
@@ -394,6 +667,59 @@ func TestAdd(t *testing.T) {
 	}
 }
 
+func TestAddErrorDetails(t *testing.T) {
+	db := muxdb.NewMem()
+	defer db.Close()
+
+	pool := New(newChainRepo(db), state.NewStater(db), Options{
+		Limit:           10,
+		LimitPerAccount: 2,
+		MaxLifetime:     time.Hour,
+	})
+	defer pool.Close()
+
+	acc := devAccounts[0]
+	to := thor.BytesToAddress([]byte("to"))
+
+	// one byte over the size cap, the nonce is fixed to have a stable size
+	newBigTx := func(data []byte) *tx.Transaction {
+		return tx.MustSign(new(tx.Builder).
+			ChainTag(pool.repo.ChainTag()).
+			Clause(tx.NewClause(&to).WithData(data)).
+			Expiration(100).
+			Gas(1_000_000).
+			Nonce(1).
+			Build(), acc.PrivateKey)
+	}
+	data := make([]byte, maxTxSize-200)
+	data = append(data, make([]byte, maxTxSize+1-int(newBigTx(data).Size()))...)
+	bigTx := newBigTx(data)
+	assert.Equal(t, uint64(maxTxSize+1), uint64(bigTx.Size()))
+
+	err := pool.Add(bigTx)
+	assert.True(t, IsTxRejected(err))
+	var sizeErr *TxSizeError
+	if assert.True(t, errors.As(err, &sizeErr)) {
+		assert.Equal(t, uint64(maxTxSize+1), sizeErr.Size)
+		assert.Equal(t, uint64(maxTxSize), sizeErr.MaxSize)
+	}
+	assert.Equal(t, fmt.Sprintf("tx rejected: size too large: size %d, max %d", maxTxSize+1, maxTxSize), err.Error())
+
+	// one gas unit short
+	clauses := []*tx.Clause{tx.NewClause(&to), tx.NewClause(&to)}
+	intrinsicGas, err := tx.IntrinsicGas(clauses...)
+	assert.Nil(t, err)
+	err = pool.Add(newTx(pool.repo.ChainTag(), clauses, intrinsicGas-1, tx.BlockRef{}, 100, nil, Tx.Features(0), acc))
+	assert.True(t, IsBadTx(err))
+	var gasErr *runtime.IntrinsicGasError
+	if assert.True(t, errors.As(err, &gasErr)) {
+		assert.Equal(t, intrinsicGas, gasErr.Required)
+		assert.Equal(t, intrinsicGas-1, gasErr.Provided)
+		assert.Equal(t, 2, gasErr.Clauses)
+	}
+	assert.Equal(t, fmt.Sprintf("bad tx: intrinsic gas exceeds provided gas: required %d, provided %d, clauses 2", intrinsicGas, intrinsicGas-1), err.Error())
+}
+
 func TestBeforeVIP191Add(t *testing.T) {
 	db := muxdb.NewMem()
 	defer db.Close()
@@ -456,7 +782,7 @@ func TestBlocked(t *testing.T) {
 	// added into all, will be washed out
 	txObj, err := resolveTx(trx, false)
 	assert.Nil(t, err)
-	pool.all.Add(txObj, LIMIT_PER_ACCOUNT, func(_ thor.Address, _ *big.Int) error { return nil })
+	pool.all.Add(txObj, LIMIT_PER_ACCOUNT, balanceChecks{})
 
 	pool.wash(pool.repo.BestBlockSummary())
 	got := pool.Get(trx.ID())
@@ -500,7 +826,7 @@ func TestWash(t *testing.T) {
 
 				txObj, err := resolveTx(trx, false)
 				assert.Nil(t, err)
-				pool.all.Add(txObj, LIMIT_PER_ACCOUNT, func(_ thor.Address, _ *big.Int) error { return nil })
+				pool.all.Add(txObj, LIMIT_PER_ACCOUNT, balanceChecks{})
 
 				pool.wash(pool.repo.BestBlockSummary())
 				got := pool.Get(trx.ID())
@@ -527,11 +853,11 @@ func TestWash(t *testing.T) {
 
 				txObj, err := resolveTx(trx2, false)
 				assert.Nil(t, err)
-				pool.all.Add(txObj, LIMIT_PER_ACCOUNT, func(_ thor.Address, _ *big.Int) error { return nil })
+				pool.all.Add(txObj, LIMIT_PER_ACCOUNT, balanceChecks{})
 
 				txObj, err = resolveTx(trx3, false)
 				assert.Nil(t, err)
-				pool.all.Add(txObj, LIMIT_PER_ACCOUNT, func(_ thor.Address, _ *big.Int) error { return nil })
+				pool.all.Add(txObj, LIMIT_PER_ACCOUNT, balanceChecks{})
 
 				pool.wash(pool.repo.BestBlockSummary())
 				got := pool.Get(trx3.ID())
@@ -558,11 +884,11 @@ func TestWash(t *testing.T) {
 
 				txObj, err := resolveTx(trx2, false)
 				assert.Nil(t, err)
-				pool.all.Add(txObj, LIMIT_PER_ACCOUNT, func(_ thor.Address, _ *big.Int) error { return nil })
+				pool.all.Add(txObj, LIMIT_PER_ACCOUNT, balanceChecks{})
 
 				txObj, err = resolveTx(trx3, false)
 				assert.Nil(t, err)
-				pool.all.Add(txObj, LIMIT_PER_ACCOUNT, func(_ thor.Address, _ *big.Int) error { return nil })
+				pool.all.Add(txObj, LIMIT_PER_ACCOUNT, balanceChecks{})
 
 				pool.wash(pool.repo.BestBlockSummary())
 				// all non executable should be washed out
@@ -660,4 +986,105 @@ func TestAddOverPendingCost(t *testing.T) {
 	// delegated fee should also be counted
 	err = pool.Add(newDelegatedTx(pool.repo.ChainTag(), nil, 21000, tx.BlockRef{}, 100, nil, devAccounts[8], devAccounts[2]))
 	assert.EqualError(t, err, "tx rejected: insufficient energy for overall pending cost")
+
+	// the cost is summarized to the origin, whoever pays it
+	summary, err := pool.AccountSummary(devAccounts[1].Address)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, summary.PendingCount)
+	assert.Equal(t, new(big.Int).Mul(big.NewInt(21000), thor.InitialBaseGasPrice), summary.PendingEnergyCost)
+	summary, err = pool.AccountSummary(devAccounts[2].Address)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, summary.PendingCount)
+	assert.Equal(t, new(big.Int).Mul(big.NewInt(21000), thor.InitialBaseGasPrice), summary.PendingEnergyCost)
+}
+
+func TestAddOverPendingValue(t *testing.T) {
+	now := uint64(time.Now().Unix())
+	db := muxdb.NewMem()
+	oneHundredVET := new(big.Int).Mul(big.NewInt(100), big.NewInt(1e18))
+	sixtyVET := new(big.Int).Mul(big.NewInt(60), big.NewInt(1e18))
+
+	b0, _, _, err := new(genesis.Builder).
+		GasLimit(thor.InitialGasLimit).
+		Timestamp(now).
+		State(func(state *state.State) error {
+			bal, _ := new(big.Int).SetString("1000000000000000000000000000", 10)
+			for _, acc := range devAccounts {
+				state.SetBalance(acc.Address, bal)
+				state.SetEnergy(acc.Address, bal, now)
+			}
+			return state.SetBalance(devAccounts[0].Address, oneHundredVET)
+		}).
+		Build(state.NewStater(db))
+	assert.Nil(t, err)
+
+	repo, _ := chain.NewRepository(db, b0)
+	pool := New(repo, state.NewStater(db), Options{
+		Limit:           LIMIT,
+		LimitPerAccount: LIMIT,
+		MaxLifetime:     time.Hour,
+	})
+	defer pool.Close()
+
+	var feat tx.Features
+	feat.SetDelegated(true)
+	// mints a new best block with the given balance of devAccounts[0]
+	setBalance := func(balance *big.Int) {
+		best := pool.repo.BestBlockSummary()
+		st := pool.stater.NewState(best.Header.StateRoot(), best.Header.Number(), 0, 0)
+		assert.Nil(t, st.SetBalance(devAccounts[0].Address, balance))
+		stage, err := st.Stage(best.Header.Number()+1, 0)
+		assert.Nil(t, err)
+		root, err := stage.Commit()
+		assert.Nil(t, err)
+
+		blk := new(block.Builder).
+			ParentID(best.Header.ID()).
+			Timestamp(best.Header.Timestamp() + thor.BlockInterval).
+			TotalScore(best.Header.TotalScore() + 1).
+			GasLimit(thor.InitialGasLimit).
+			StateRoot(root).
+			TransactionFeatures(feat).
+			Build()
+		assert.Nil(t, pool.repo.AddBlock(blk, nil, 0))
+		assert.Nil(t, pool.repo.SetBestBlockID(blk.Header().ID()))
+	}
+
+	setBalance(oneHundredVET)
+
+	to := devAccounts[1].Address
+	self := devAccounts[0].Address
+	transfer := func(to thor.Address, value *big.Int) []*tx.Clause {
+		return []*tx.Clause{tx.NewClause(&to).WithValue(value)}
+	}
+
+	trx1 := newTx(pool.repo.ChainTag(), transfer(to, sixtyVET), 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[0])
+	assert.Nil(t, pool.Add(trx1))
+
+	// overall pending value exceeds the balance
+	err = pool.Add(newTx(pool.repo.ChainTag(), transfer(to, sixtyVET), 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[0]))
+	assert.EqualError(t, err, "tx rejected: insufficient VET for overall pending value")
+
+	// value of delegated tx should also be counted on the origin
+	err = pool.Add(newDelegatedTx(pool.repo.ChainTag(), transfer(to, sixtyVET), 21000, tx.BlockRef{}, 100, nil, devAccounts[0], devAccounts[2]))
+	assert.EqualError(t, err, "tx rejected: insufficient VET for overall pending value")
+
+	// transfers to self and zero-value clauses are not counted
+	assert.Nil(t, pool.Add(newTx(pool.repo.ChainTag(), transfer(self, oneHundredVET), 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[0])))
+	assert.Nil(t, pool.Add(newTx(pool.repo.ChainTag(), transfer(to, big.NewInt(0)), 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[0])))
+	assert.Equal(t, sixtyVET, pool.all.value[self])
+
+	// balance increased by incoming transfers, the tx can be added now
+	setBalance(new(big.Int).Add(oneHundredVET, oneHundredVET))
+	trx2 := newTx(pool.repo.ChainTag(), transfer(to, sixtyVET), 21000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[0])
+	assert.Nil(t, pool.Add(trx2))
+	assert.Equal(t, new(big.Int).Add(sixtyVET, sixtyVET), pool.all.value[self])
+
+	// balance decreased, the later added tx should be washed out
+	setBalance(oneHundredVET)
+	_, _, err = pool.wash(pool.repo.BestBlockSummary())
+	assert.Nil(t, err)
+	assert.NotNil(t, pool.Get(trx1.ID()))
+	assert.Nil(t, pool.Get(trx2.ID()))
+	assert.Equal(t, sixtyVET, pool.all.value[self])
 }
@@ -0,0 +1,129 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package txpool
+
+import (
+	"math/big"
+
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// fullWashInterval is the max count of blocks between full washes. Incremental washes find the
+// affected txs from the receipts, the periodic full wash is the safety net for state changes
+// not reflected by receipts.
+const fullWashInterval = 100
+
+// washCache is what the last wash learned at its head. The next wash on a descendant head
+// re-validates only the txs affected by the blocks in between.
+type washCache struct {
+	headID       thor.Bytes32
+	baseGasPrice *big.Int
+	balances     map[thor.Address]*big.Int // VET balances of origins at the head
+	blocks       uint32                    // count of blocks since the last full wash
+}
+
+// washDiff collects the changes made by the blocks since the last wash.
+type washDiff struct {
+	dirty    map[thor.Address]bool // accounts touched by the blocks
+	included map[thor.Bytes32]bool // ids of the txs included in the blocks
+	blocks   uint32
+}
+
+// diffSinceWash returns the changes made by the blocks between the head of the last wash and the given head.
+// It returns nil if a full wash is required, i.e. no wash before, the base gas price changed, the head is
+// not a descendant of the last washed head, or it's time for the periodic full wash.
+func (p *TxPool) diffSinceWash(headSummary *chain.BlockSummary, baseGasPrice *big.Int) *washDiff {
+	c := p.washCache
+	if c == nil || c.baseGasPrice.Cmp(baseGasPrice) != 0 {
+		return nil
+	}
+
+	headNum, cachedNum := headSummary.Header.Number(), block.Number(c.headID)
+	if headNum < cachedNum || c.blocks+(headNum-cachedNum) >= fullWashInterval {
+		return nil
+	}
+
+	diff := &washDiff{
+		dirty:    make(map[thor.Address]bool),
+		included: make(map[thor.Bytes32]bool),
+		blocks:   c.blocks + (headNum - cachedNum),
+	}
+	for id := headSummary.Header.ID(); id != c.headID; {
+		if block.Number(id) <= cachedNum {
+			// forked
+			return nil
+		}
+		b, err := p.repo.GetBlock(id)
+		if err != nil {
+			return nil
+		}
+		receipts, err := p.repo.GetBlockReceipts(id)
+		if err != nil {
+			return nil
+		}
+		if !diff.collect(b.Transactions(), receipts) {
+			return nil
+		}
+		id = b.Header().ParentID()
+	}
+	return diff
+}
+
+// collect marks the accounts touched by the txs of a block. It returns false if
+// the block changes params, which may affect all txs.
+func (d *washDiff) collect(txs tx.Transactions, receipts tx.Receipts) bool {
+	for _, trx := range txs {
+		d.included[trx.ID()] = true
+		if origin, err := trx.Origin(); err == nil {
+			d.dirty[origin] = true
+		}
+		if delegator, err := trx.Delegator(); err == nil && delegator != nil {
+			d.dirty[*delegator] = true
+		}
+	}
+	for _, receipt := range receipts {
+		d.dirty[receipt.GasPayer] = true
+		for _, output := range receipt.Outputs {
+			for _, transfer := range output.Transfers {
+				d.dirty[transfer.Sender] = true
+				d.dirty[transfer.Recipient] = true
+			}
+			for _, event := range output.Events {
+				if event.Address == builtin.Params.Address {
+					return false
+				}
+				d.dirty[event.Address] = true
+				// indexed args may be addresses, e.g. VTHO transfer parties, users and sponsors of prototype
+				for i := 1; i < len(event.Topics); i++ {
+					d.dirty[thor.BytesToAddress(event.Topics[i][12:])] = true
+				}
+			}
+		}
+	}
+	return true
+}
+
+// affects returns whether the tx may be affected by the changes, i.e. it's included, or any account
+// involved in buying its gas or transferring its value is touched.
+func (d *washDiff) affects(txObj *txObject) bool {
+	if d.included[txObj.ID()] || d.dirty[txObj.Origin()] {
+		return true
+	}
+	if delegator := txObj.Delegator(); delegator != nil && d.dirty[*delegator] {
+		return true
+	}
+	if payer := txObj.Payer(); payer != nil && d.dirty[*payer] {
+		return true
+	}
+	if to := txObj.resolved.CommonTo(); to != nil && d.dirty[*to] {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,236 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package txpool
+
+import (
+	"math/big"
+	"math/rand/v2"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/packer"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+type washTestChain struct {
+	t      testing.TB
+	repo   *chain.Repository
+	stater *state.Stater
+	packer *packer.Packer
+}
+
+func newWashTestChain(t testing.TB) *washTestChain {
+	db := muxdb.NewMem()
+	repo := newChainRepo(db)
+	stater := state.NewStater(db)
+	forkConfig := thor.NoFork
+	forkConfig.VIP191 = 0
+	return &washTestChain{
+		t:      t,
+		repo:   repo,
+		stater: stater,
+		packer: packer.New(repo, stater, devAccounts[0].Address, &devAccounts[0].Address, forkConfig),
+	}
+}
+
+func (c *washTestChain) newPool() *TxPool {
+	return New(c.repo, c.stater, Options{
+		Limit:           10000,
+		LimitPerAccount: 10000,
+		MaxLifetime:     time.Hour,
+	})
+}
+
+// mint packs the txs into a new block on the best block, the txs failed to be adopted are skipped.
+func (c *washTestChain) mint(txs ...*tx.Transaction) {
+	best := c.repo.BestBlockSummary()
+	flow, err := c.packer.Mock(best, best.Header.Timestamp()+thor.BlockInterval, best.Header.GasLimit())
+	require.NoError(c.t, err)
+	for _, trx := range txs {
+		_ = flow.Adopt(trx)
+	}
+	blk, stage, receipts, err := flow.Pack(devAccounts[0].PrivateKey, 0, false)
+	require.NoError(c.t, err)
+	_, err = stage.Commit()
+	require.NoError(c.t, err)
+	require.NoError(c.t, c.repo.AddBlock(blk, receipts, 0))
+	require.NoError(c.t, c.repo.SetBestBlockID(blk.Header().ID()))
+}
+
+// fillPools adds the txs to all the pools, with the same time added.
+func fillPools(t testing.TB, txs tx.Transactions, pools ...*TxPool) {
+	for _, trx := range txs {
+		timeAdded := time.Now().UnixNano()
+		for _, pool := range pools {
+			txObj, err := resolveTx(trx, false)
+			require.NoError(t, err)
+			txObj.timeAdded = timeAdded
+			pool.all.Add(txObj, pool.options.LimitPerAccount, balanceChecks{})
+		}
+	}
+}
+
+func vetClause(to thor.Address, vet int64) *tx.Clause {
+	return tx.NewClause(&to).WithValue(new(big.Int).Mul(big.NewInt(vet), big.NewInt(1e18)))
+}
+
+func vthoClause(to thor.Address, vtho int64) *tx.Clause {
+	method, _ := builtin.Energy.ABI.MethodByName("transfer")
+	data, _ := method.EncodeInput(to, new(big.Int).Mul(big.NewInt(vtho), big.NewInt(1e18)))
+	return tx.NewClause(&builtin.Energy.Address).WithData(data)
+}
+
+func TestIncrementalWash(t *testing.T) {
+	c := newWashTestChain(t)
+	rng := rand.New(rand.NewPCG(1, 2)) //#nosec G404
+
+	// accounts with little VET and VTHO, to be affected by blocks
+	accounts := make([]genesis.DevAccount, 4)
+	var funds []*tx.Clause
+	for i := range accounts {
+		priv, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		accounts[i] = genesis.DevAccount{Address: thor.Address(crypto.PubkeyToAddress(priv.PublicKey)), PrivateKey: priv}
+		funds = append(funds, vetClause(accounts[i].Address, 100), vthoClause(accounts[i].Address, 500))
+	}
+	c.mint(newTx(c.repo.ChainTag(), funds, 500000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[1]))
+
+	incremental, full := c.newPool(), c.newPool()
+	defer incremental.Close()
+	defer full.Close()
+
+	pick := func() genesis.DevAccount { return accounts[rng.IntN(len(accounts))] }
+	newRandomTx := func(deps tx.Transactions) *tx.Transaction {
+		var (
+			chainTag  = c.repo.ChainTag()
+			clauses   = []*tx.Clause{vetClause(pick().Address, rng.Int64N(40))}
+			blockRef  = tx.NewBlockRef(c.repo.BestBlockSummary().Header.Number())
+			dependsOn *thor.Bytes32
+		)
+		switch rng.IntN(5) {
+		case 0:
+			clauses = []*tx.Clause{vthoClause(pick().Address, rng.Int64N(200))}
+		case 1:
+			blockRef = tx.NewBlockRef(blockRef.Number() + uint32(rng.IntN(5)))
+		case 2:
+			if len(deps) > 0 {
+				id := deps[rng.IntN(len(deps))].ID()
+				dependsOn = &id
+			}
+		case 3:
+			return newDelegatedTx(chainTag, clauses, 100000, blockRef, 30, nil, pick(), pick())
+		}
+		return newTx(chainTag, clauses, 100000, blockRef, uint32(10+rng.IntN(30)), dependsOn, tx.Features(0), pick())
+	}
+
+	assertEqualPools := func(round int) {
+		best := c.repo.BestBlockSummary()
+		incrExecutables, _, err := incremental.wash(best)
+		require.NoError(t, err)
+		full.washCache = nil
+		fullExecutables, _, err := full.wash(best)
+		require.NoError(t, err)
+
+		assert.Equal(t, fullExecutables, incrExecutables, "round %d", round)
+		ids := func(txs tx.Transactions) []thor.Bytes32 {
+			ids := make([]thor.Bytes32, 0, len(txs))
+			for _, trx := range txs {
+				ids = append(ids, trx.ID())
+			}
+			sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+			return ids
+		}
+		assert.Equal(t, ids(full.Dump()), ids(incremental.Dump()), "round %d", round)
+	}
+
+	var incrementalWashes int
+	for round := 0; round < 60; round++ {
+		pooled := full.Dump()
+		var txs tx.Transactions
+		for i := 0; i < 10; i++ {
+			txs = append(txs, newRandomTx(pooled))
+		}
+		fillPools(t, txs, incremental, full)
+
+		var blockTxs tx.Transactions
+		// pack some of the pooled txs
+		for _, trx := range full.Executables() {
+			if rng.IntN(4) == 0 {
+				blockTxs = append(blockTxs, trx)
+			}
+		}
+		// and txs out of the pool
+		for i := 0; i < rng.IntN(3); i++ {
+			blockTxs = append(blockTxs, newRandomTx(nil))
+		}
+		if round%7 == 6 {
+			// top up from rich accounts
+			from := devAccounts[1+rng.IntN(len(devAccounts)-1)]
+			blockTxs = append(blockTxs, newTx(c.repo.ChainTag(), []*tx.Clause{vetClause(pick().Address, 50), vthoClause(pick().Address, 300)}, 100000, tx.BlockRef{}, 100, nil, tx.Features(0), from))
+		}
+		if round == 30 {
+			// change the base gas price
+			method, found := builtin.Params.ABI.MethodByName("set")
+			require.True(t, found)
+			input, _ := method.EncodeInput(thor.KeyBaseGasPrice, new(big.Int).Mul(thor.InitialBaseGasPrice, big.NewInt(2)))
+			clause := tx.NewClause(&builtin.Params.Address).WithData(input)
+			blockTxs = append(blockTxs, newTx(c.repo.ChainTag(), []*tx.Clause{clause}, 100000, tx.BlockRef{}, 100, nil, tx.Features(0), devAccounts[0]))
+		}
+		// blocks may arrive faster than washes
+		for i := 0; i <= rng.IntN(2); i++ {
+			c.mint(blockTxs...)
+			blockTxs = nil
+		}
+
+		if incremental.washCache != nil && incremental.diffSinceWash(c.repo.BestBlockSummary(), incremental.washCache.baseGasPrice) != nil {
+			incrementalWashes++
+		}
+		assertEqualPools(round)
+	}
+	assert.True(t, incrementalWashes > 40, "incremental washes %d", incrementalWashes)
+}
+
+func BenchmarkWash(b *testing.B) {
+	c := newWashTestChain(b)
+
+	var txs tx.Transactions
+	for i := 0; i < 2000; i++ {
+		txs = append(txs, newTx(c.repo.ChainTag(), []*tx.Clause{vetClause(devAccounts[i%len(devAccounts)].Address, 1)}, 21000, tx.BlockRef{}, 1000, nil, tx.Features(0), devAccounts[(i+1)%len(devAccounts)]))
+	}
+
+	for _, bm := range []struct {
+		name        string
+		incremental bool
+	}{{"full", false}, {"incremental", true}} {
+		b.Run(bm.name, func(b *testing.B) {
+			pool := c.newPool()
+			defer pool.Close()
+			fillPools(b, txs, pool)
+			_, _, err := pool.wash(c.repo.BestBlockSummary())
+			require.NoError(b, err)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if !bm.incremental {
+					pool.washCache = nil
+				}
+				if _, _, err := pool.wash(c.repo.BestBlockSummary()); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}